@@ -0,0 +1,50 @@
+package core_entities
+
+type CheckType string
+
+const (
+	CheckUnreachable   CheckType = "unreachable"
+	CheckSSH           CheckType = "ssh"
+	CheckMeta          CheckType = "meta"
+	CheckMemory        CheckType = "memory"
+	CheckDisk          CheckType = "disk"
+	CheckLink          CheckType = "link"
+	CheckBMC           CheckType = "bmc"
+	CheckCPU           CheckType = "cpu"
+	CheckGPU           CheckType = "gpu"
+	CheckReboots       CheckType = "reboots"
+	CheckTaintedKernel CheckType = "tainted_kernel"
+	CheckFSCheck       CheckType = "fs_check"
+	CheckOverheat      CheckType = "overheat"
+	CheckInfiniband    CheckType = "infiniband"
+)
+
+var checkTypes = []CheckType{
+	CheckUnreachable,
+	CheckSSH,
+	CheckMeta,
+	CheckMemory,
+	CheckDisk,
+	CheckLink,
+	CheckBMC,
+	CheckCPU,
+	CheckGPU,
+	CheckReboots,
+	CheckTaintedKernel,
+	CheckFSCheck,
+	CheckOverheat,
+	CheckInfiniband,
+}
+
+func CheckTypes() []CheckType {
+	return append([]CheckType(nil), checkTypes...)
+}
+
+func (t CheckType) IsValid() bool {
+	for _, known := range checkTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package core_entities_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCoreEntitiesSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Core Entities Suite")
+}
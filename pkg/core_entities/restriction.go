@@ -0,0 +1,73 @@
+package core_entities
+
+import (
+	"slices"
+)
+
+type Restriction string
+
+const (
+	RestrictionNoAutomation Restriction = "no-automation"
+	RestrictionNoReboot     Restriction = "no-reboot"
+	RestrictionNoRedeploy   Restriction = "no-redeploy"
+	RestrictionNoProfile    Restriction = "no-profile"
+	RestrictionNoVlanChange Restriction = "no-vlan-change"
+)
+
+var restrictions = []Restriction{
+	RestrictionNoAutomation,
+	RestrictionNoReboot,
+	RestrictionNoRedeploy,
+	RestrictionNoProfile,
+	RestrictionNoVlanChange,
+}
+
+var impliedRestrictions = map[Restriction][]Restriction{
+	RestrictionNoReboot:   {RestrictionNoRedeploy},
+	RestrictionNoRedeploy: {RestrictionNoProfile},
+}
+
+func Restrictions() []Restriction {
+	return append([]Restriction(nil), restrictions...)
+}
+
+func (r Restriction) IsValid() bool {
+	return slices.Contains(restrictions, r)
+}
+
+func (r Restriction) Implies() []Restriction {
+	result := []Restriction{r}
+	for i := 0; i < len(result); i++ {
+		for _, implied := range impliedRestrictions[result[i]] {
+			if !slices.Contains(result, implied) {
+				result = append(result, implied)
+			}
+		}
+	}
+	return result
+}
+
+func EffectiveRestrictions(sets ...[]Restriction) []Restriction {
+	result := []Restriction{}
+	for _, set := range sets {
+		for _, restriction := range set {
+			for _, implied := range restriction.Implies() {
+				if !slices.Contains(result, implied) {
+					result = append(result, implied)
+				}
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func ForbiddenBy(effective []Restriction, required Restriction, automated bool) (Restriction, bool) {
+	if automated && slices.Contains(effective, RestrictionNoAutomation) {
+		return RestrictionNoAutomation, true
+	}
+	if slices.Contains(effective, required) {
+		return required, true
+	}
+	return "", false
+}
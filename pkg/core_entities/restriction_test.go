@@ -0,0 +1,46 @@
+package core_entities_test
+
+import (
+	. "github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Restriction", func() {
+	It("should validate known restrictions", func() {
+		for _, restriction := range Restrictions() {
+			Expect(restriction.IsValid()).To(BeTrue())
+		}
+		Expect(Restriction("no-power").IsValid()).To(BeFalse())
+	})
+
+	DescribeTable("should imply narrower restrictions",
+		func(restriction Restriction, implied []Restriction) {
+			Expect(restriction.Implies()).To(Equal(implied))
+		},
+		Entry("reboot", RestrictionNoReboot, []Restriction{RestrictionNoReboot, RestrictionNoRedeploy, RestrictionNoProfile}),
+		Entry("redeploy", RestrictionNoRedeploy, []Restriction{RestrictionNoRedeploy, RestrictionNoProfile}),
+		Entry("profile", RestrictionNoProfile, []Restriction{RestrictionNoProfile}),
+		Entry("automation", RestrictionNoAutomation, []Restriction{RestrictionNoAutomation}),
+	)
+
+	It("should merge and expand restriction sets", func() {
+		Expect(EffectiveRestrictions(
+			[]Restriction{RestrictionNoRedeploy},
+			[]Restriction{RestrictionNoVlanChange, RestrictionNoProfile},
+		)).To(Equal([]Restriction{RestrictionNoProfile, RestrictionNoRedeploy, RestrictionNoVlanChange}))
+		Expect(EffectiveRestrictions()).To(BeEmpty())
+	})
+
+	DescribeTable("should name the forbidding restriction",
+		func(effective []Restriction, required Restriction, automated bool, expected Restriction) {
+			restriction, forbidden := ForbiddenBy(effective, required, automated)
+			Expect(forbidden).To(Equal(expected != ""))
+			Expect(restriction).To(Equal(expected))
+		},
+		Entry("allowed", []Restriction{RestrictionNoVlanChange}, RestrictionNoReboot, true, Restriction("")),
+		Entry("restricted", EffectiveRestrictions([]Restriction{RestrictionNoReboot}), RestrictionNoProfile, false, RestrictionNoProfile),
+		Entry("automated", []Restriction{RestrictionNoAutomation}, RestrictionNoReboot, true, RestrictionNoAutomation),
+		Entry("manual under no automation", []Restriction{RestrictionNoAutomation}, RestrictionNoReboot, false, Restriction("")),
+	)
+})
@@ -9,3 +9,11 @@ const (
 	TypeMac          UnitType = "mac"
 	TypeShadowServer UnitType = "shadow-server"
 )
+
+func (t UnitType) IsValid() bool {
+	switch t {
+	case TypeServer, TypeVM, TypeMac, TypeShadowServer:
+		return true
+	}
+	return false
+}
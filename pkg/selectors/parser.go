@@ -0,0 +1,240 @@
+package selectors
+
+import (
+	"fmt"
+	"unicode"
+)
+
+type ParseError struct {
+	Input    string
+	Position int
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid selector %q at position %d: %s", e.Input, e.Position, e.Message)
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenComma
+	tokenOpenParen
+	tokenCloseParen
+	tokenEquals
+	tokenNotEquals
+	tokenNot
+)
+
+type token struct {
+	kind     tokenKind
+	value    string
+	position int
+}
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEnd:
+		return "end of input"
+	case tokenWord:
+		return "word"
+	case tokenComma:
+		return "','"
+	case tokenOpenParen:
+		return "'('"
+	case tokenCloseParen:
+		return "')'"
+	case tokenEquals:
+		return "'='"
+	case tokenNotEquals:
+		return "'!='"
+	case tokenNot:
+		return "'!'"
+	}
+	return "unknown token"
+}
+
+func isWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-' || r == '/' || r == ':')
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, position: i})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, position: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, position: i})
+			i++
+		case r == '=':
+			tokens = append(tokens, token{kind: tokenEquals, position: i})
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+		case r == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokenNotEquals, position: i})
+				i += 2
+			} else {
+				tokens = append(tokens, token{kind: tokenNot, position: i})
+				i++
+			}
+		case isWordRune(r):
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i]), position: start})
+		default:
+			return nil, &ParseError{Input: input, Position: i, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, position: len(runes)}), nil
+}
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func Parse(input string) (Selector, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return Selector{}, err
+	}
+
+	p := &parser{input: input, tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return Everything(), nil
+	}
+
+	var selector Selector
+	for {
+		requirement, err := p.requirement()
+		if err != nil {
+			return Selector{}, err
+		}
+		selector.Requirements = append(selector.Requirements, requirement)
+
+		switch next := p.next(); next.kind {
+		case tokenEnd:
+			return selector, nil
+		case tokenComma:
+		default:
+			return Selector{}, p.errorAt(next, "expected ',' or end of input")
+		}
+	}
+}
+
+func MustParse(input string) Selector {
+	selector, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorAt(t token, message string) error {
+	found := t.kind.String()
+	if t.kind == tokenWord {
+		found = fmt.Sprintf("%q", t.value)
+	}
+	return &ParseError{Input: p.input, Position: t.position, Message: fmt.Sprintf("%s, found %s", message, found)}
+}
+
+func (p *parser) requirement() (Requirement, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		key, err := p.key()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: OperatorDoesNotExist}, nil
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return Requirement{}, err
+	}
+
+	switch op := p.peek(); {
+	case op.kind == tokenEquals || op.kind == tokenNotEquals:
+		p.next()
+		operator := OperatorEquals
+		if op.kind == tokenNotEquals {
+			operator = OperatorNotEquals
+		}
+		value := ""
+		if p.peek().kind == tokenWord {
+			value = p.next().value
+		}
+		return Requirement{Key: key, Operator: operator, Values: []string{value}}, nil
+	case op.kind == tokenWord && (op.value == string(OperatorIn) || op.value == string(OperatorNotIn)):
+		p.next()
+		values, err := p.values()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: Operator(op.value), Values: values}, nil
+	case op.kind == tokenComma || op.kind == tokenEnd:
+		return Requirement{Key: key, Operator: OperatorExists}, nil
+	default:
+		return Requirement{}, p.errorAt(op, "expected operator")
+	}
+}
+
+func (p *parser) key() (string, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return "", p.errorAt(t, "expected key")
+	}
+	return t.value, nil
+}
+
+func (p *parser) values() ([]string, error) {
+	if t := p.next(); t.kind != tokenOpenParen {
+		return nil, p.errorAt(t, "expected '('")
+	}
+
+	var values []string
+	for {
+		t := p.next()
+		if t.kind != tokenWord {
+			return nil, p.errorAt(t, "expected value")
+		}
+		values = append(values, t.value)
+
+		switch t := p.next(); t.kind {
+		case tokenCloseParen:
+			return values, nil
+		case tokenComma:
+		default:
+			return nil, p.errorAt(t, "expected ',' or ')'")
+		}
+	}
+}
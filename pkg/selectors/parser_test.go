@@ -0,0 +1,82 @@
+package selectors_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/gwall-e/pkg/selectors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parse", func() {
+	DescribeTable("should parse requirements",
+		func(input string, expected []Requirement) {
+			selector, err := Parse(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Requirements).To(Equal(expected))
+		},
+		Entry("empty", "  ", nil),
+		Entry("equality", "role=db", []Requirement{{Key: "role", Operator: OperatorEquals, Values: []string{"db"}}}),
+		Entry("double equality", "role == db", []Requirement{{Key: "role", Operator: OperatorEquals, Values: []string{"db"}}}),
+		Entry("empty value", "role=", []Requirement{{Key: "role", Operator: OperatorEquals, Values: []string{""}}}),
+		Entry("inequality", "role!=db", []Requirement{{Key: "role", Operator: OperatorNotEquals, Values: []string{"db"}}}),
+		Entry("in", "location.datacenter in (sas, vla)", []Requirement{
+			{Key: "location.datacenter", Operator: OperatorIn, Values: []string{"sas", "vla"}},
+		}),
+		Entry("notin", "unit_type notin (vm)", []Requirement{{Key: "unit_type", Operator: OperatorNotIn, Values: []string{"vm"}}}),
+		Entry("existence", "gpu, !canary", []Requirement{
+			{Key: "gpu", Operator: OperatorExists},
+			{Key: "canary", Operator: OperatorDoesNotExist},
+		}),
+		Entry("combination", "project=search,team/owner in (infra),!drain", []Requirement{
+			{Key: "project", Operator: OperatorEquals, Values: []string{"search"}},
+			{Key: "team/owner", Operator: OperatorIn, Values: []string{"infra"}},
+			{Key: "drain", Operator: OperatorDoesNotExist},
+		}),
+		Entry("keyword as key", "in=1", []Requirement{{Key: "in", Operator: OperatorEquals, Values: []string{"1"}}}),
+	)
+
+	DescribeTable("should report position of errors",
+		func(input string, position int, message string) {
+			_, err := Parse(input)
+			var parseErr *ParseError
+			Expect(errors.As(err, &parseErr)).To(BeTrue())
+			Expect(parseErr.Position).To(Equal(position))
+			Expect(parseErr.Message).To(ContainSubstring(message))
+		},
+		Entry("unexpected character", "role=db;", 7, "unexpected character"),
+		Entry("missing key", "=db", 0, "expected key"),
+		Entry("trailing comma", "role=db,", 8, "expected key"),
+		Entry("unknown operator", "role like db", 5, `expected operator, found "like"`),
+		Entry("missing parenthesis", "role in db", 8, "expected '('"),
+		Entry("empty set", "role in ()", 9, "expected value"),
+		Entry("unclosed set", "role in (a, b", 13, "expected ',' or ')'"),
+		Entry("missing comma", "role=db gpu", 8, "expected ',' or end of input"),
+		Entry("negated value", "!role=db", 5, "expected ',' or end of input"),
+	)
+
+	It("should print the canonical form that parses back", func() {
+		selector := MustParse(" project == search , role in (db,cache), !drain, gpu, env!=prod ")
+		Expect(selector.String()).To(Equal("project=search,role in (db,cache),!drain,gpu,env!=prod"))
+		Expect(Parse(selector.String())).To(Equal(selector))
+	})
+
+	It("should be usable in json config", func() {
+		var config struct {
+			Targets Selector `json:"targets"`
+		}
+		Expect(json.Unmarshal([]byte(`{"targets":"role in (db), !drain"}`), &config)).To(Succeed())
+		Expect(config.Targets.Keys()).To(Equal([]string{"role", "drain"}))
+
+		data, err := json.Marshal(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"targets":"role in (db),!drain"}`))
+
+		Expect(json.Unmarshal([]byte(`{"targets":"role in"}`), &config)).NotTo(Succeed())
+	})
+
+	It("should panic in MustParse on invalid selectors", func() {
+		Expect(func() { MustParse("(") }).To(Panic())
+	})
+})
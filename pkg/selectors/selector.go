@@ -0,0 +1,119 @@
+package selectors
+
+import (
+	"slices"
+	"strings"
+)
+
+type Operator string
+
+const (
+	OperatorEquals       Operator = "="
+	OperatorNotEquals    Operator = "!="
+	OperatorIn           Operator = "in"
+	OperatorNotIn        Operator = "notin"
+	OperatorExists       Operator = "exists"
+	OperatorDoesNotExist Operator = "!"
+)
+
+type Fields interface {
+	Get(key string) (value string, ok bool)
+}
+
+type Set map[string]string
+
+func (s Set) Get(key string) (string, bool) {
+	value, ok := s[key]
+	return value, ok
+}
+
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+func (r Requirement) Matches(fields Fields) bool {
+	value, ok := fields.Get(r.Key)
+	switch r.Operator {
+	case OperatorEquals:
+		return ok && value == r.Values[0]
+	case OperatorNotEquals:
+		return !ok || value != r.Values[0]
+	case OperatorIn:
+		return ok && slices.Contains(r.Values, value)
+	case OperatorNotIn:
+		return !ok || !slices.Contains(r.Values, value)
+	case OperatorExists:
+		return ok
+	case OperatorDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OperatorEquals, OperatorNotEquals:
+		return r.Key + string(r.Operator) + r.Values[0]
+	case OperatorIn, OperatorNotIn:
+		return r.Key + " " + string(r.Operator) + " (" + strings.Join(r.Values, ",") + ")"
+	case OperatorExists:
+		return r.Key
+	case OperatorDoesNotExist:
+		return "!" + r.Key
+	}
+	return ""
+}
+
+type Selector struct {
+	Requirements []Requirement
+}
+
+func Everything() Selector {
+	return Selector{}
+}
+
+func (s Selector) Empty() bool {
+	return len(s.Requirements) == 0
+}
+
+func (s Selector) Matches(fields Fields) bool {
+	for _, requirement := range s.Requirements {
+		if !requirement.Matches(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) Keys() []string {
+	var keys []string
+	for _, requirement := range s.Requirements {
+		if !slices.Contains(keys, requirement.Key) {
+			keys = append(keys, requirement.Key)
+		}
+	}
+	return keys
+}
+
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s.Requirements))
+	for _, requirement := range s.Requirements {
+		parts = append(parts, requirement.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+func (s Selector) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Selector) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
@@ -0,0 +1,36 @@
+package selectors_test
+
+import (
+	. "github.com/gwall-e/pkg/selectors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Selector", func() {
+	fields := Set{"role": "db", "env": "prod", "gpu": ""}
+
+	DescribeTable("should match fields",
+		func(input string, matches bool) {
+			Expect(MustParse(input).Matches(fields)).To(Equal(matches))
+		},
+		Entry("everything", "", true),
+		Entry("equal", "role=db", true),
+		Entry("not equal value", "role=cache", false),
+		Entry("equal on missing key", "zone=a", false),
+		Entry("inequality", "role!=cache", true),
+		Entry("inequality on missing key", "zone!=a", true),
+		Entry("in", "env in (prod, testing)", true),
+		Entry("in on missing key", "zone in (a)", false),
+		Entry("notin", "env notin (prod)", false),
+		Entry("notin on missing key", "zone notin (a)", true),
+		Entry("exists with empty value", "gpu", true),
+		Entry("does not exist", "!gpu", false),
+		Entry("all requirements", "role=db,env=prod,!zone", true),
+		Entry("one failed requirement", "role=db,env=testing", false),
+	)
+
+	It("should report emptiness", func() {
+		Expect(Everything().Empty()).To(BeTrue())
+		Expect(MustParse("a").Empty()).To(BeFalse())
+	})
+})
@@ -0,0 +1,13 @@
+package selectors_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSelectorsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Selectors Suite")
+}
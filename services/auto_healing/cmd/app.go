@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/application/healing"
+	"github.com/gwall-e/auto_healing/internal/domain/approvals"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch"
+	killSwitchContracts "github.com/gwall-e/auto_healing/internal/domain/killswitch/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/limits"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness"
+	"github.com/gwall-e/auto_healing/internal/domain/power"
+	"github.com/gwall-e/auto_healing/internal/domain/releases"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+	workflowContracts "github.com/gwall-e/auto_healing/internal/domain/workflows/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/cms"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/hypervisor"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/notifications"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/redfish"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	repositories "github.com/gwall-e/auto_healing/internal/infrastructure/repositories/mongo"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/vault"
+	"github.com/gwall-e/pkg/core_entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type config struct {
+	SilenceTimeout       time.Duration
+	WebhookURL           string
+	DatacenterRecipients []string
+	VaultAddr            string
+	VaultToken           string
+	VaultMount           string
+	CMSURL               string
+	CMSToken             string
+}
+
+func loadConfig() (*config, error) {
+	silenceTimeout, err := getDurationEnv("HEARTBEAT_SILENCE_TIMEOUT", liveness.DEFAULT_SILENCE_TIMEOUT)
+	if err != nil {
+		return nil, fmt.Errorf("parse HEARTBEAT_SILENCE_TIMEOUT: %w", err)
+	}
+	cfg := &config{
+		SilenceTimeout:       silenceTimeout,
+		WebhookURL:           os.Getenv("NOTIFICATION_WEBHOOK_URL"),
+		DatacenterRecipients: getListEnv("DATACENTER_RECIPIENTS"),
+		VaultAddr:            os.Getenv("VAULT_ADDR"),
+		VaultToken:           os.Getenv("VAULT_TOKEN"),
+		VaultMount:           getEnv("VAULT_KV_MOUNT", vault.DEFAULT_MOUNT),
+		CMSURL:               os.Getenv("CMS_URL"),
+		CMSToken:             os.Getenv("CMS_TOKEN"),
+	}
+	for _, required := range []struct{ key, value string }{
+		{"VAULT_ADDR", cfg.VaultAddr},
+		{"CMS_URL", cfg.CMSURL},
+	} {
+		if required.value == "" {
+			return nil, fmt.Errorf("%s is required", required.key)
+		}
+	}
+	return cfg, nil
+}
+
+type app struct {
+	checks    *checks.CheckService
+	liveness  *liveness.LivenessService
+	switches  *killswitch.KillSwitchService
+	decisions *decisions.DecisionService
+	workflows *workflows.WorkflowService
+	approvals *approvals.ApprovalService
+	healer    *healing.Healer
+	handler   http.Handler
+}
+
+func newApp(ctx context.Context, db *mongo.Database, cfg *config) (*app, error) {
+	checkRepository := repositories.NewCheckRepository(db)
+	if err := checkRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create checks indexes: %w", err)
+	}
+	decisionRepository := repositories.NewDecisionRepository(db)
+	if err := decisionRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create decisions indexes: %w", err)
+	}
+	workflowRepository := repositories.NewWorkflowRepository(db)
+	if err := workflowRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create workflows indexes: %w", err)
+	}
+	automationRepository := repositories.NewAutomationRepository(db)
+	if err := automationRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create automation indexes: %w", err)
+	}
+	actionHistory := repositories.NewActionHistory(db)
+	if err := actionHistory.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create action history indexes: %w", err)
+	}
+	hostInfo := repositories.NewHostInfoRepository(db)
+	if err := hostInfo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create hosts indexes: %w", err)
+	}
+	powerTargets := repositories.NewPowerTargetRepository(db)
+	if err := powerTargets.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create power targets indexes: %w", err)
+	}
+	killSwitchRepository := repositories.NewKillSwitchRepository(db)
+	if err := killSwitchRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create kill switches indexes: %w", err)
+	}
+	approvalRepository := repositories.NewApprovalRepository(db)
+	if err := approvalRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("create approvals indexes: %w", err)
+	}
+
+	inventoryService := inventory.NewDomainService(hostInfo, powerTargets)
+	eventLog := memory.NewEventLog()
+	checkService := checks.NewDomainService(checkRepository, memory.NewManualQueueRepository())
+	limitService := limits.NewDomainService(automationRepository, hostInfo)
+	livenessService := liveness.NewDomainService(memory.NewLivenessRepository(), memory.NewOutageRepository(),
+		hostInfo, checkService, eventLog, liveness.WithSilenceTimeout(cfg.SilenceTimeout))
+	sender := newNotificationSender(cfg.WebhookURL)
+	killSwitchService := killswitch.NewDomainService(killSwitchRepository, hostInfo, eventLog, sender)
+	decisionService := decisions.NewDomainService(hostInfo, checkService, actionHistory, decisionRepository,
+		repositories.NewDryRunRepository(db), decisions.WithOutageReader(livenessService), decisions.WithLimitsReader(limitService),
+		decisions.WithRuleRepository(repositories.NewRuleRepository(db)))
+	if err := decisionService.LoadRules(ctx); err != nil {
+		return nil, fmt.Errorf("load healing rules: %w", err)
+	}
+	timelineService := timeline.NewDomainService(checkService, decisionService, actionHistory)
+	secrets := vault.NewKVVault(pkgHttp.NewClient(cfg.VaultAddr), cfg.VaultToken, cfg.VaultMount)
+	powerService := power.NewDomainService(powerTargets,
+		power.WithDriver("redfish", redfish.NewDriver(secrets), 0, core_entities.TypeServer, core_entities.TypeShadowServer),
+		power.WithDriver("hypervisor", hypervisor.NewDriver(secrets), 0, core_entities.TypeVM))
+	actionRunner := healing.NewActionRunner(powerService, hostInfo,
+		notifications.NewDatacenterReporter(sender, cfg.DatacenterRecipients))
+	releaseService := releases.NewDomainService(repositories.NewReleaseRepository(db),
+		cms.NewClient(pkgHttp.NewClient(cfg.CMSURL), cfg.CMSToken), hostInfo)
+	workflowService := newWorkflowService(workflowRepository, actionRunner, hostInfo, releaseService,
+		checkService, actionHistory, eventLog, limitService, killSwitchService)
+	approvalService := approvals.NewDomainService(approvalRepository, decisionService, workflowService,
+		checkService, eventLog)
+
+	return &app{
+		checks:    checkService,
+		liveness:  livenessService,
+		switches:  killSwitchService,
+		decisions: decisionService,
+		workflows: workflowService,
+		approvals: approvalService,
+		healer:    healing.NewHealer(checkService, hostInfo, decisionService, workflowService, approvalService),
+		handler: api.NewServer(checkService, api.WithLimits(limitService), api.WithDecisions(decisionService),
+			api.WithLiveness(livenessService), api.WithKillSwitches(killSwitchService), api.WithTimeline(timelineService),
+			api.WithWorkflows(workflowService), api.WithApprovals(approvalService), api.WithInventory(inventoryService)),
+	}, nil
+}
+
+func newNotificationSender(webhookURL string) killSwitchContracts.NotificationSender {
+	if webhookURL == "" {
+		log.Printf("NOTIFICATION_WEBHOOK_URL is not set, notifications will only be logged")
+		return notifications.NewLogSender(log.Default())
+	}
+	return notifications.NewWebhookSender(pkgHttp.NewClient(""), webhookURL)
+}
+
+func (a *app) run(ctx context.Context) {
+	go func() {
+		_ = a.liveness.Run(ctx, func(err error) { log.Printf("detect unreachable hosts: %v", err) })
+	}()
+	go func() {
+		_ = a.switches.Run(ctx, func(err error) { log.Printf("expire kill switches: %v", err) })
+	}()
+	go func() {
+		_ = a.workflows.Run(ctx, func(err error) { log.Printf("process workflows: %v", err) })
+	}()
+	go func() {
+		_ = a.approvals.Run(ctx, func(err error) { log.Printf("process approvals: %v", err) })
+	}()
+	go func() {
+		_ = a.decisions.RunRulesReload(ctx, func(err error) { log.Printf("reload healing rules: %v", err) })
+	}()
+	go func() {
+		_ = a.healer.Run(ctx, func(err error) { log.Printf("heal hosts: %v", err) })
+	}()
+}
+
+func newWorkflowService(
+	repo workflowContracts.WorkflowRepository,
+	runner workflowContracts.ActionRunner,
+	hosts workflowContracts.HostInfoReader,
+	releaser workflowContracts.HostReleaser,
+	checks workflowContracts.CheckReader,
+	history workflowContracts.ActionRecorder,
+	publisher workflowContracts.EventPublisher,
+	limiter workflowContracts.AutomationLimiter,
+	guard workflowContracts.ActionGuard,
+	setup ...workflows.SetupFunc,
+) *workflows.WorkflowService {
+	setup = append([]workflows.SetupFunc{workflows.WithLimiter(limiter), workflows.WithGuard(guard)}, setup...)
+	return workflows.NewDomainService(repo, runner, hosts, releaser, checks, history, publisher, setup...)
+}
+
+func getEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/cms/cmstest"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/notifications"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/redfish/redfishtest"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/vault"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MONGO_TEST_URI_ENV = "MONGO_TEST_URI"
+
+var _ = Describe("App", func() {
+	var (
+		ctx    context.Context
+		db     *mongo.Database
+		bmc    *redfishtest.Server
+		cms    *cmstest.Server
+		secret *httptest.Server
+		hooks  *httptest.Server
+	)
+
+	BeforeEach(func() {
+		uri := os.Getenv(MONGO_TEST_URI_ENV)
+		if uri == "" {
+			Skip(MONGO_TEST_URI_ENV + " is not set")
+		}
+		ctx = context.Background()
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+		Expect(err).NotTo(HaveOccurred())
+		db = client.Database(fmt.Sprintf("auto_healing_test_%d", time.Now().UnixNano()))
+		DeferCleanup(func() {
+			Expect(db.Drop(context.Background())).To(Succeed())
+			Expect(client.Disconnect(context.Background())).To(Succeed())
+		})
+
+		bmc = redfishtest.NewServer("healer", "secret")
+		DeferCleanup(bmc.Close)
+		bmc.AddSystem("System.Embedded.1", "On")
+
+		cms = cmstest.NewServer("OAuth cms-token")
+		DeferCleanup(cms.Close)
+		cms.SetAutoApprove(true)
+
+		secret = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(vault.TOKEN_HEADER) != "vault-token" || r.URL.Path != "/v1/secret/data/bmc-dc1" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"data": map[string]string{"username": "healer", "password": "secret"}},
+			})
+		}))
+		DeferCleanup(secret.Close)
+
+		hooks = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		DeferCleanup(hooks.Close)
+	})
+
+	It("should heal a host reported by the hosts service", func() {
+		application, err := newApp(ctx, db, &config{
+			SilenceTimeout: liveness.DEFAULT_SILENCE_TIMEOUT,
+			WebhookURL:     hooks.URL,
+			VaultAddr:      secret.URL,
+			VaultToken:     "vault-token",
+			VaultMount:     vault.DEFAULT_MOUNT,
+			CMSURL:         cms.URL,
+			CMSToken:       "cms-token",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		server := httptest.NewServer(application.handler)
+		DeferCleanup(server.Close)
+
+		do := func(method string, path string, body string) map[string]interface{} {
+			request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			response, err := http.DefaultClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var decoded map[string]interface{}
+			Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+			return decoded
+		}
+		report := func(status string, at time.Time) {
+			do(http.MethodPost, "/api/v1/checks", fmt.Sprintf(`{"results": [
+				{"host_id": "host-1", "check": "ssh", "status": %q, "timestamp": %q}
+			]}`, status, at.UTC().Format(time.RFC3339Nano)))
+		}
+
+		do(http.MethodPost, "/api/v1/inventory/events", `{"type": "host_added", "event": {
+			"id": "host-1", "fqdn": "host-1.search.dc1", "unit_type": "server", "project_id": "search",
+			"state": "ready", "datacenter": "dc1", "rack": "r1"
+		}}`)
+		do(http.MethodPost, "/api/v1/inventory/events", fmt.Sprintf(`{"type": "host_labels_changed", "event": {
+			"id": "host-1", "labels": {"power/address": %q, "power/secret": "bmc-dc1", "power/resource": "System.Embedded.1"}
+		}}`, bmc.URL))
+
+		now := time.Now()
+		report("ok", now.Add(-time.Hour))
+		report("failed", now.Add(-30*time.Minute))
+		report("failed", now.Add(-time.Minute))
+
+		Expect(application.healer.HealHosts(ctx)).To(Succeed())
+		workflows := do(http.MethodGet, "/api/v1/hosts/host-1/workflows", "")["workflows"]
+		Expect(workflows).To(ConsistOf(HaveKeyWithValue("status", "running")))
+		workflowID := workflows.([]interface{})[0].(map[string]interface{})["id"].(string)
+
+		Expect(application.workflows.ProcessWorkflows(ctx)).To(Succeed())
+		Expect(cms.Tasks()).To(ConsistOf(SatisfyAll(
+			HaveField("Action", "reboot"),
+			HaveField("Hosts", []string{"host-1.search.dc1"}),
+		)))
+
+		Expect(application.workflows.ProcessWorkflows(ctx)).To(Succeed())
+		system, ok := bmc.System("System.Embedded.1")
+		Expect(ok).To(BeTrue())
+		Expect(system.Resets).To(HaveLen(1))
+
+		report("ok", time.Now())
+		Expect(application.workflows.ProcessWorkflows(ctx)).To(Succeed())
+		Expect(do(http.MethodGet, "/api/v1/workflows/"+workflowID, "")).To(HaveKeyWithValue("status", "succeeded"))
+	})
+})
+
+var _ = Describe("loadConfig", func() {
+	setEnv := func(values map[string]string) {
+		for key, value := range values {
+			previous, ok := os.LookupEnv(key)
+			Expect(os.Setenv(key, value)).To(Succeed())
+			DeferCleanup(func() {
+				if ok {
+					Expect(os.Setenv(key, previous)).To(Succeed())
+					return
+				}
+				Expect(os.Unsetenv(key)).To(Succeed())
+			})
+		}
+	}
+
+	It("should log notifications when no webhook is configured", func() {
+		setEnv(map[string]string{"NOTIFICATION_WEBHOOK_URL": "", "VAULT_ADDR": "http://vault:8200", "CMS_URL": "http://cms"})
+
+		cfg, err := loadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.WebhookURL).To(BeEmpty())
+		Expect(newNotificationSender(cfg.WebhookURL)).To(BeAssignableToTypeOf(&notifications.LogSender{}))
+		Expect(newNotificationSender("http://hooks/notifications")).To(BeAssignableToTypeOf(&notifications.WebhookSender{}))
+	})
+
+	It("should require vault and cms", func() {
+		setEnv(map[string]string{"VAULT_ADDR": "", "CMS_URL": "http://cms"})
+		_, err := loadConfig()
+		Expect(err).To(MatchError("VAULT_ADDR is required"))
+	})
+})
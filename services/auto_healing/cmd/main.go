@@ -1,7 +1,72 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DEFAULT_LISTEN_ADDR = ":8080"
+	SHUTDOWN_TIMEOUT    = 30 * time.Second
+	READ_HEADER_TIMEOUT = 10 * time.Second
+	STARTUP_TIMEOUT     = 30 * time.Second
+
+	DEFAULT_MONGO_URI      = "mongodb://localhost:27017"
+	DEFAULT_MONGO_DATABASE = "auto_healing"
+)
 
 func main() {
-    fmt.Println("Autohealing service starting...")
-}
\ No newline at end of file
+	fmt.Println("Autohealing service starting...")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	startupCtx, cancelStartup := context.WithTimeout(context.Background(), STARTUP_TIMEOUT)
+	defer cancelStartup()
+
+	client, err := mongo.Connect(startupCtx, options.Client().ApplyURI(getEnv("MONGO_URI", DEFAULT_MONGO_URI)))
+	if err != nil {
+		log.Fatalf("connect to mongo: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	application, err := newApp(startupCtx, client.Database(getEnv("MONGO_DATABASE", DEFAULT_MONGO_DATABASE)), cfg)
+	if err != nil {
+		log.Fatalf("start autohealing: %v", err)
+	}
+	server := &http.Server{
+		Addr:              getEnv("LISTEN_ADDR", DEFAULT_LISTEN_ADDR),
+		Handler:           application.handler,
+		ReadHeaderTimeout: READ_HEADER_TIMEOUT,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	application.run(ctx)
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("shutdown http server: %v", err)
+		}
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("serve http: %v", err)
+	}
+}
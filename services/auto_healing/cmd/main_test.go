@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch"
+	killSwitchEntities "github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMainSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Main Suite")
+}
+
+type recordingRunner struct {
+	mu    sync.Mutex
+	calls []decisionEntities.Action
+}
+
+func (r *recordingRunner) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, action)
+	return nil
+}
+
+func (r *recordingRunner) Calls() []decisionEntities.Action {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]decisionEntities.Action{}, r.calls...)
+}
+
+type noopSender struct{}
+
+func (noopSender) Send(ctx context.Context, notification killSwitchEntities.Notification) error {
+	return nil
+}
+
+var _ = Describe("newWorkflowService", func() {
+	It("should postpone steps while a kill switch is engaged", func() {
+		ctx := context.Background()
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		releases := memory.NewHostReleaseRepository()
+		releases.SetReleased("host-1", decisionEntities.ActionReboot)
+		eventLog := memory.NewEventLog()
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		limitService := limits.NewDomainService(memory.NewAutomationRepository(), hosts, limits.WithClock(clock))
+		killSwitchService := killswitch.NewDomainService(memory.NewKillSwitchRepository(), hosts, eventLog, noopSender{},
+			killswitch.WithClock(clock))
+		runner := &recordingRunner{}
+		service := newWorkflowService(memory.NewWorkflowRepository(), runner, hosts, releases, checkService,
+			memory.NewActionHistory(), eventLog, limitService, killSwitchService, workflows.WithClock(clock))
+
+		killSwitch, err := killSwitchService.EngageKillSwitch(ctx, killSwitchEntities.KillSwitchRequest{
+			Scope: killSwitchEntities.ScopeGlobal, Reason: "datacenter maintenance", Author: "alice", ExpiresAt: now.Add(time.Hour),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		workflow, err := service.StartWorkflow(ctx, entities.WorkflowRequest{
+			HostID: "host-1", ProjectID: "search", Check: core_entities.CheckSSH, Rule: "ssh",
+			Actions: []decisionEntities.Action{decisionEntities.ActionReboot},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.ProcessWorkflows(ctx)).To(Succeed())
+		workflow, err = service.GetWorkflow(ctx, workflow.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepPending))
+		Expect(workflow.Steps[0].Error).To(ContainSubstring("automation is stopped by a kill switch"))
+		Expect(runner.Calls()).To(BeEmpty())
+
+		_, err = killSwitchService.ReleaseKillSwitch(ctx, killSwitch.ID, "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service.ProcessWorkflows(ctx)).To(Succeed())
+		Expect(runner.Calls()).To(Equal([]decisionEntities.Action{decisionEntities.ActionReboot}))
+	})
+})
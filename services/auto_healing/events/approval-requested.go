@@ -0,0 +1,15 @@
+package events
+
+import "time"
+
+type ApprovalRequestedEvent struct {
+	ApprovalID string    `bson:"approval_id"`
+	HostID     string    `bson:"host_id"`
+	ProjectID  string    `bson:"project_id"`
+	Check      string    `bson:"check"`
+	Rule       string    `bson:"rule"`
+	Action     string    `bson:"action"`
+	Reason     string    `bson:"reason"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+	At         time.Time `bson:"at"`
+}
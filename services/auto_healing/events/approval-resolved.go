@@ -0,0 +1,15 @@
+package events
+
+import "time"
+
+type ApprovalResolvedEvent struct {
+	ApprovalID string    `bson:"approval_id"`
+	HostID     string    `bson:"host_id"`
+	ProjectID  string    `bson:"project_id"`
+	Action     string    `bson:"action"`
+	Status     string    `bson:"status"`
+	ResolvedBy string    `bson:"resolved_by"`
+	Comment    string    `bson:"comment"`
+	WorkflowID string    `bson:"workflow_id"`
+	At         time.Time `bson:"at"`
+}
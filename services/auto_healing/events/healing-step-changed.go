@@ -0,0 +1,17 @@
+package events
+
+import "time"
+
+type HealingStepChangedEvent struct {
+	WorkflowID     string    `bson:"workflow_id"`
+	HostID         string    `bson:"host_id"`
+	ProjectID      string    `bson:"project_id"`
+	Check          string    `bson:"check"`
+	Step           int       `bson:"step"`
+	Action         string    `bson:"action"`
+	Status         string    `bson:"status"`
+	WorkflowStatus string    `bson:"workflow_status"`
+	Attempts       int       `bson:"attempts"`
+	Error          string    `bson:"error"`
+	At             time.Time `bson:"at"`
+}
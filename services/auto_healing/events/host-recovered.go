@@ -0,0 +1,9 @@
+package events
+
+import "time"
+
+type HostRecoveredEvent struct {
+	HostID           string    `bson:"host_id"`
+	UnreachableSince time.Time `bson:"unreachable_since"`
+	At               time.Time `bson:"at"`
+}
@@ -0,0 +1,10 @@
+package events
+
+import "time"
+
+type HostUnreachableEvent struct {
+	HostID   string    `bson:"host_id"`
+	LastSeen time.Time `bson:"last_seen"`
+	OutageID string    `bson:"outage_id"`
+	At       time.Time `bson:"at"`
+}
@@ -0,0 +1,13 @@
+package events
+
+import "time"
+
+type KillSwitchEngagedEvent struct {
+	KillSwitchID string    `bson:"kill_switch_id"`
+	Scope        string    `bson:"scope"`
+	Target       string    `bson:"target"`
+	Reason       string    `bson:"reason"`
+	Author       string    `bson:"author"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+	At           time.Time `bson:"at"`
+}
@@ -0,0 +1,13 @@
+package events
+
+import "time"
+
+type KillSwitchReleasedEvent struct {
+	KillSwitchID string    `bson:"kill_switch_id"`
+	Scope        string    `bson:"scope"`
+	Target       string    `bson:"target"`
+	ReleasedBy   string    `bson:"released_by"`
+	Expired      bool      `bson:"expired"`
+	Notify       []string  `bson:"notify"`
+	At           time.Time `bson:"at"`
+}
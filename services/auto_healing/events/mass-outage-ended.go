@@ -0,0 +1,11 @@
+package events
+
+import "time"
+
+type MassOutageEndedEvent struct {
+	OutageID  string    `bson:"outage_id"`
+	Level     string    `bson:"level"`
+	Key       string    `bson:"key"`
+	StartedAt time.Time `bson:"started_at"`
+	At        time.Time `bson:"at"`
+}
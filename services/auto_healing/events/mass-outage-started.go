@@ -0,0 +1,12 @@
+package events
+
+import "time"
+
+type MassOutageStartedEvent struct {
+	OutageID   string    `bson:"outage_id"`
+	Level      string    `bson:"level"`
+	Key        string    `bson:"key"`
+	Hosts      []string  `bson:"hosts"`
+	TotalHosts int       `bson:"total_hosts"`
+	At         time.Time `bson:"at"`
+}
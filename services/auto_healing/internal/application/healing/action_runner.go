@@ -0,0 +1,41 @@
+package healing
+
+import (
+	"context"
+	"fmt"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	monitoringErrors "github.com/gwall-e/auto_healing/internal/domain/monitoring/errors"
+)
+
+type PowerActionRunner interface {
+	RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error
+}
+
+type DatacenterReporter interface {
+	ReportHost(ctx context.Context, host decisionEntities.HostInfo) error
+}
+
+type ActionRunner struct {
+	power    PowerActionRunner
+	hosts    HostInfoProvider
+	reporter DatacenterReporter
+}
+
+func NewActionRunner(power PowerActionRunner, hosts HostInfoProvider, reporter DatacenterReporter) *ActionRunner {
+	return &ActionRunner{power: power, hosts: hosts, reporter: reporter}
+}
+
+func (r *ActionRunner) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	if action != decisionEntities.ActionReportToDatacenter {
+		return r.power.RunAction(ctx, hostID, action)
+	}
+	host, err := r.hosts.GetHostInfo(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if host == nil {
+		return fmt.Errorf("%w: %s", monitoringErrors.ErrHostNotFound, hostID)
+	}
+	return r.reporter.ReportHost(ctx, *host)
+}
@@ -0,0 +1,64 @@
+package healing_test
+
+import (
+	"context"
+
+	. "github.com/gwall-e/auto_healing/internal/application/healing"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	monitoringErrors "github.com/gwall-e/auto_healing/internal/domain/monitoring/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type recordingRunner struct {
+	actions []decisionEntities.Action
+}
+
+func (r *recordingRunner) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+type recordingReporter struct {
+	reported []decisionEntities.HostInfo
+}
+
+func (r *recordingReporter) ReportHost(ctx context.Context, host decisionEntities.HostInfo) error {
+	r.reported = append(r.reported, host)
+	return nil
+}
+
+var _ = Describe("ActionRunner", func() {
+	var (
+		ctx      context.Context
+		power    *recordingRunner
+		reporter *recordingReporter
+		runner   *ActionRunner
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		power = &recordingRunner{}
+		reporter = &recordingReporter{}
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", Datacenter: "sas", Rack: "1a"})
+		runner = NewActionRunner(power, hosts, reporter)
+	})
+
+	It("should run power actions through the power service", func() {
+		Expect(runner.RunAction(ctx, "host-1", decisionEntities.ActionReboot)).To(Succeed())
+		Expect(runner.RunAction(ctx, "host-1", decisionEntities.ActionRedeploy)).To(Succeed())
+		Expect(power.actions).To(Equal([]decisionEntities.Action{decisionEntities.ActionReboot, decisionEntities.ActionRedeploy}))
+		Expect(reporter.reported).To(BeEmpty())
+	})
+
+	It("should report hosts to datacenter with their location", func() {
+		Expect(runner.RunAction(ctx, "host-1", decisionEntities.ActionReportToDatacenter)).To(Succeed())
+		Expect(reporter.reported).To(Equal([]decisionEntities.HostInfo{{HostID: "host-1", Datacenter: "sas", Rack: "1a"}}))
+		Expect(power.actions).To(BeEmpty())
+
+		Expect(runner.RunAction(ctx, "host-2", decisionEntities.ActionReportToDatacenter)).To(MatchError(monitoringErrors.ErrHostNotFound))
+		Expect(reporter.reported).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,159 @@
+package healing
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	approvalEntities "github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	workflowEntities "github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+const DEFAULT_HEAL_INTERVAL = time.Minute
+
+type FailingHostsReader interface {
+	ListFailingHosts(ctx context.Context) ([]string, error)
+}
+
+type HostInfoProvider interface {
+	GetHostInfo(ctx context.Context, hostID string) (*decisionEntities.HostInfo, error)
+}
+
+type Decider interface {
+	DecideHost(ctx context.Context, hostID string) (*decisionEntities.Decision, error)
+	Rules() []decisionEntities.Rule
+}
+
+type WorkflowStarter interface {
+	GetActiveWorkflow(ctx context.Context, hostID string) (*workflowEntities.Workflow, error)
+	StartFromDecision(ctx context.Context, projectID string, rule decisionEntities.Rule, decision decisionEntities.Decision) (*workflowEntities.Workflow, error)
+}
+
+type ApprovalRequester interface {
+	ListApprovals(ctx context.Context, filter approvalEntities.ApprovalFilter) ([]*approvalEntities.Approval, error)
+	RequestApproval(ctx context.Context, projectID string, decision decisionEntities.Decision) (*approvalEntities.Approval, error)
+}
+
+type SetupFunc func(*Healer)
+
+type Healer struct {
+	checks    FailingHostsReader
+	hosts     HostInfoProvider
+	decisions Decider
+	workflows WorkflowStarter
+	approvals ApprovalRequester
+	interval  time.Duration
+}
+
+func WithInterval(interval time.Duration) SetupFunc {
+	return func(h *Healer) {
+		h.interval = interval
+	}
+}
+
+func NewHealer(
+	checks FailingHostsReader,
+	hosts HostInfoProvider,
+	decisions Decider,
+	workflows WorkflowStarter,
+	approvals ApprovalRequester,
+	setup ...SetupFunc,
+) *Healer {
+	h := &Healer{
+		checks:    checks,
+		hosts:     hosts,
+		decisions: decisions,
+		workflows: workflows,
+		approvals: approvals,
+		interval:  DEFAULT_HEAL_INTERVAL,
+	}
+	for _, fn := range setup {
+		fn(h)
+	}
+	return h
+}
+
+func (h *Healer) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.HealHosts(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Healer) HealHosts(ctx context.Context) error {
+	hostIDs, err := h.checks.ListFailingHosts(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, hostID := range hostIDs {
+		if err := h.healHost(ctx, hostID); err != nil {
+			errs = append(errs, fmt.Errorf("heal host %s: %w", hostID, err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+func (h *Healer) healHost(ctx context.Context, hostID string) error {
+	active, err := h.workflows.GetActiveWorkflow(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		return nil
+	}
+	pending, err := h.approvals.ListApprovals(ctx, approvalEntities.ApprovalFilter{Status: approvalEntities.ApprovalPending, HostID: hostID})
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return nil
+	}
+
+	decision, err := h.decisions.DecideHost(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if decision.DryRun || decision.Action == decisionEntities.ActionNone || decision.Action == decisionEntities.ActionWait {
+		return nil
+	}
+	rule, ok := h.findRule(decision.Rule)
+	if !ok {
+		return fmt.Errorf("rule %q of the decision is not configured", decision.Rule)
+	}
+	info, err := h.hosts.GetHostInfo(ctx, hostID)
+	if err != nil {
+		return err
+	}
+
+	if decision.ApprovalRequired {
+		_, err = h.approvals.RequestApproval(ctx, info.ProjectID, *decision)
+		return err
+	}
+	_, err = h.workflows.StartFromDecision(ctx, info.ProjectID, rule, *decision)
+	if stdErrors.Is(err, workflowErrors.ErrWorkflowInProgress) {
+		return nil
+	}
+	return err
+}
+
+func (h *Healer) findRule(name string) (decisionEntities.Rule, bool) {
+	for _, rule := range h.decisions.Rules() {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return decisionEntities.Rule{}, false
+}
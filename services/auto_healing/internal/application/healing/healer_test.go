@@ -0,0 +1,131 @@
+package healing_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/gwall-e/auto_healing/internal/application/healing"
+	"github.com/gwall-e/auto_healing/internal/domain/approvals"
+	approvalEntities "github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+	workflowEntities "github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Healer", func() {
+	var (
+		ctx             context.Context
+		now             time.Time
+		hosts           *memory.HostInfoRepository
+		checkService    *checks.CheckService
+		decisionService *decisions.DecisionService
+		workflowService *workflows.WorkflowService
+		approvalService *approvals.ApprovalService
+		healer          *Healer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		hosts = memory.NewHostInfoRepository()
+		history := memory.NewActionHistory()
+		checkService = checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		decisionService = decisions.NewDomainService(hosts, checkService, history, memory.NewDecisionRepository(),
+			memory.NewDryRunRepository(), decisions.WithClock(clock))
+		Expect(decisionService.SetRules(ctx, []decisionEntities.Rule{{
+			Name:    "ssh",
+			Check:   core_entities.CheckSSH,
+			Actions: []decisionEntities.Action{decisionEntities.ActionReboot, decisionEntities.ActionRedeploy},
+		}})).To(Succeed())
+		workflowService = workflows.NewDomainService(memory.NewWorkflowRepository(), noopRunner{}, hosts,
+			memory.NewHostReleaseRepository(), checkService, history, memory.NewEventLog(), workflows.WithClock(clock))
+		approvalService = approvals.NewDomainService(memory.NewApprovalRepository(), decisionService, workflowService,
+			checkService, memory.NewEventLog(), approvals.WithClock(clock))
+		healer = NewHealer(checkService, hosts, decisionService, workflowService, approvalService)
+
+		for _, hostID := range []string{"host-1", "host-2", "host-3"} {
+			hosts.SetHostInfo(decisionEntities.HostInfo{HostID: hostID, ProjectID: "search", UnitType: core_entities.TypeServer})
+		}
+		_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now},
+			{HostID: "host-2", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusOK, Timestamp: now},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	decided := func(hostID string) []decisionEntities.DecisionRecord {
+		records, err := decisionService.ListDecisions(ctx, decisionEntities.DecisionFilter{HostID: hostID})
+		Expect(err).NotTo(HaveOccurred())
+		return records
+	}
+
+	It("should start workflows for decisions on hosts with failing checks", func() {
+		Expect(healer.HealHosts(ctx)).To(Succeed())
+
+		workflow, err := workflowService.GetActiveWorkflow(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.ProjectID).To(Equal("search"))
+		Expect(workflow.Rule).To(Equal("ssh"))
+		Expect(workflow.Steps).To(HaveLen(3))
+		Expect(workflow.Steps[0].Action).To(Equal(decisionEntities.ActionReboot))
+		Expect(decided("host-1")).To(HaveLen(1))
+		Expect(decided("host-2")).To(BeEmpty())
+		Expect(decided("host-3")).To(BeEmpty())
+	})
+
+	It("should not decide on hosts being healed", func() {
+		Expect(healer.HealHosts(ctx)).To(Succeed())
+		Expect(healer.HealHosts(ctx)).To(Succeed())
+
+		Expect(decided("host-1")).To(HaveLen(1))
+		Expect(workflowService.ListHostWorkflows(ctx, "host-1")).To(HaveLen(1))
+	})
+
+	It("should only record decisions of projects in dry run", func() {
+		Expect(decisionService.SetProjectDryRun(ctx, "search", true)).To(Succeed())
+		Expect(healer.HealHosts(ctx)).To(Succeed())
+
+		Expect(decided("host-1")).To(HaveLen(1))
+		Expect(workflowService.ListHostWorkflows(ctx, "host-1")).To(BeEmpty())
+	})
+
+	It("should queue decisions requiring approval instead of starting workflows", func() {
+		Expect(decisionService.SetRules(ctx, []decisionEntities.Rule{{
+			Name:     "ssh",
+			Check:    core_entities.CheckSSH,
+			Actions:  []decisionEntities.Action{decisionEntities.ActionRedeploy},
+			Approval: decisionEntities.ApprovalPolicy{Actions: []decisionEntities.Action{decisionEntities.ActionRedeploy}},
+		}})).To(Succeed())
+
+		Expect(healer.HealHosts(ctx)).To(Succeed())
+		Expect(healer.HealHosts(ctx)).To(Succeed())
+
+		pending, err := approvalService.ListApprovals(ctx, approvalEntities.ApprovalFilter{Status: approvalEntities.ApprovalPending})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+		Expect(pending[0].HostID).To(Equal("host-1"))
+		Expect(pending[0].Action).To(Equal(decisionEntities.ActionRedeploy))
+		Expect(decided("host-1")).To(HaveLen(1))
+		Expect(workflowService.ListHostWorkflows(ctx, "host-1")).To(BeEmpty())
+	})
+
+	It("should keep healing other hosts when one fails", func() {
+		_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-0", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(healer.HealHosts(ctx)).To(MatchError(ContainSubstring("heal host host-0")))
+		workflow, err := workflowService.GetActiveWorkflow(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.Status).To(Equal(workflowEntities.WorkflowRunning))
+	})
+})
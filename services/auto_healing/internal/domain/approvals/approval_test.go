@@ -0,0 +1,201 @@
+package approvals_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+	. "github.com/gwall-e/auto_healing/internal/domain/approvals"
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	approvalErrors "github.com/gwall-e/auto_healing/internal/domain/approvals/errors"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApprovalService", func() {
+	var (
+		ctx             context.Context
+		now             time.Time
+		hosts           *memory.HostInfoRepository
+		eventLog        *memory.EventLog
+		checkService    *checks.CheckService
+		decisionService *decisions.DecisionService
+		workflowService *workflows.WorkflowService
+		service         *ApprovalService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+
+		hosts = memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer, Tier: 0})
+		history := memory.NewActionHistory()
+		eventLog = memory.NewEventLog()
+		checkService = checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(),
+			checks.WithClock(clock), checks.WithStaleTimeout(24*time.Hour))
+		decisionService = decisions.NewDomainService(hosts, checkService, history,
+			memory.NewDecisionRepository(), memory.NewDryRunRepository(), decisions.WithClock(clock))
+		Expect(decisionService.SetRules(ctx, []decisionEntities.Rule{{
+			Name:     "ssh",
+			Check:    core_entities.CheckSSH,
+			Actions:  []decisionEntities.Action{decisionEntities.ActionRedeploy},
+			Approval: decisionEntities.ApprovalPolicy{Actions: []decisionEntities.Action{decisionEntities.ActionRedeploy}, Tiers: []byte{0}},
+		}})).To(Succeed())
+		workflowService = workflows.NewDomainService(memory.NewWorkflowRepository(), noopRunner{}, hosts,
+			memory.NewHostReleaseRepository(), checkService, history, eventLog, workflows.WithClock(clock))
+		service = NewDomainService(memory.NewApprovalRepository(), decisionService, workflowService, checkService, eventLog,
+			WithApprovalTTL(time.Hour), WithClock(clock))
+
+		report(checkService, now, checkEntities.CheckStatusFailed)
+	})
+
+	request := func() *entities.Approval {
+		decision, err := decisionService.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.ApprovalRequired).To(BeTrue())
+		approval, err := service.RequestApproval(ctx, "search", *decision)
+		Expect(err).NotTo(HaveOccurred())
+		return approval
+	}
+
+	It("should queue decisions requiring approval once per host", func() {
+		approval := request()
+		Expect(approval.Status).To(Equal(entities.ApprovalPending))
+		Expect(approval.Action).To(Equal(decisionEntities.ActionRedeploy))
+		Expect(approval.ExpiresAt).To(Equal(now.Add(time.Hour)))
+		Expect(request().ID).To(Equal(approval.ID))
+
+		pending, err := service.ListApprovals(ctx, entities.ApprovalFilter{Status: entities.ApprovalPending})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+		Expect(eventLog.Events()).To(ContainElement(BeAssignableToTypeOf(&events.ApprovalRequestedEvent{})))
+
+		_, err = service.RequestApproval(ctx, "search", decisionEntities.Decision{HostID: "host-1", Action: decisionEntities.ActionReboot})
+		Expect(err).To(MatchError(approvalErrors.ErrApprovalNotRequired))
+	})
+
+	It("should start the workflow of approved actions", func() {
+		approval := request()
+		_, err := service.ApproveAction(ctx, approval.ID, "", "")
+		Expect(err).To(BeAssignableToTypeOf(&approvalErrors.ApprovalValidationError{}))
+
+		approved, err := service.ApproveAction(ctx, approval.ID, "alice", "disk is already dead")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approved.Status).To(Equal(entities.ApprovalApproved))
+		Expect(approved.ResolvedBy).To(Equal("alice"))
+		Expect(approved.Comment).To(Equal("disk is already dead"))
+
+		workflow, err := workflowService.GetWorkflow(ctx, approved.WorkflowID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.ApprovedBy).To(Equal("alice"))
+		Expect(workflow.Steps[0].Action).To(Equal(decisionEntities.ActionRedeploy))
+
+		_, err = service.RejectAction(ctx, approval.ID, "bob", "")
+		Expect(err).To(MatchError(approvalErrors.ErrApprovalResolved))
+	})
+
+	It("should reject actions with the actor recorded", func() {
+		approval := request()
+		rejected, err := service.RejectAction(ctx, approval.ID, "bob", "host holds the last replica")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rejected.Status).To(Equal(entities.ApprovalRejected))
+		Expect(rejected.ResolvedBy).To(Equal("bob"))
+		Expect(eventLog.Events()).To(ContainElement(&events.ApprovalResolvedEvent{
+			ApprovalID: approval.ID, HostID: "host-1", ProjectID: "search", Action: "redeploy",
+			Status: "rejected", ResolvedBy: "bob", Comment: "host holds the last replica", At: now,
+		}))
+		Expect(workflowService.ListHostWorkflows(ctx, "host-1")).To(BeEmpty())
+	})
+
+	It("should cancel the approval when the host recovered before approval", func() {
+		approval := request()
+		now = now.Add(time.Minute)
+		report(checkService, now, checkEntities.CheckStatusOK)
+
+		_, err := service.ApproveAction(ctx, approval.ID, "alice", "")
+		Expect(err).To(MatchError(approvalErrors.ErrHostRecovered))
+		cancelled, err := service.GetApproval(ctx, approval.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cancelled.Status).To(Equal(entities.ApprovalCancelled))
+		Expect(cancelled.Comment).To(Equal("host recovered"))
+		Expect(workflowService.ListHostWorkflows(ctx, "host-1")).To(BeEmpty())
+	})
+
+	It("should cancel the approval when the decision changed", func() {
+		approval := request()
+		Expect(decisionService.SetProjectDryRun(ctx, "search", true)).To(Succeed())
+
+		_, err := service.ApproveAction(ctx, approval.ID, "alice", "")
+		Expect(err).To(MatchError(approvalErrors.ErrDecisionChanged))
+		Expect(service.GetApproval(ctx, approval.ID)).To(HaveField("Status", entities.ApprovalCancelled))
+	})
+
+	It("should expire and auto-cancel pending approvals", func() {
+		approval := request()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-2", ProjectID: "search", UnitType: core_entities.TypeServer})
+		_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-2", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		decision, err := decisionService.DecideHost(ctx, "host-2")
+		Expect(err).NotTo(HaveOccurred())
+		recovering, err := service.RequestApproval(ctx, "search", *decision)
+		Expect(err).NotTo(HaveOccurred())
+
+		now = now.Add(30 * time.Minute)
+		_, err = checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-2", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusOK, Timestamp: now},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service.ProcessApprovals(ctx)).To(Succeed())
+		Expect(service.GetApproval(ctx, recovering.ID)).To(HaveField("Status", entities.ApprovalCancelled))
+		Expect(service.GetApproval(ctx, approval.ID)).To(HaveField("Status", entities.ApprovalPending))
+
+		now = now.Add(30 * time.Minute)
+		Expect(service.ProcessApprovals(ctx)).To(Succeed())
+		Expect(service.GetApproval(ctx, approval.ID)).To(HaveField("Status", entities.ApprovalExpired))
+		_, err = service.ApproveAction(ctx, approval.ID, "alice", "")
+		Expect(err).To(MatchError(approvalErrors.ErrApprovalResolved))
+	})
+
+	It("should not approve expired approvals before they are processed", func() {
+		approval := request()
+		now = now.Add(time.Hour)
+		_, err := service.ApproveAction(ctx, approval.ID, "alice", "")
+		Expect(err).To(MatchError(approvalErrors.ErrApprovalResolved))
+		Expect(service.GetApproval(ctx, approval.ID)).To(HaveField("Status", entities.ApprovalExpired))
+	})
+
+	It("should replace the approval when another action is decided", func() {
+		approval := request()
+		replacement, err := service.RequestApproval(ctx, "search", decisionEntities.Decision{
+			HostID: "host-1", Action: decisionEntities.ActionReportToDatacenter, Rule: "ssh", Check: core_entities.CheckSSH, ApprovalRequired: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replacement.ID).NotTo(Equal(approval.ID))
+		Expect(service.GetApproval(ctx, approval.ID)).To(HaveField("Comment", "superseded by report-to-datacenter decided by rule ssh"))
+	})
+
+	It("should reject unknown approvals and statuses", func() {
+		_, err := service.ApproveAction(ctx, "unknown", "alice", "")
+		Expect(err).To(MatchError(approvalErrors.ErrApprovalNotFound))
+		_, err = service.ListApprovals(ctx, entities.ApprovalFilter{Status: "waiting"})
+		Expect(err).To(BeAssignableToTypeOf(&approvalErrors.ApprovalValidationError{}))
+	})
+})
+
+func report(checkService *checks.CheckService, at time.Time, status checkEntities.CheckStatus) {
+	_, err := checkService.IngestResults(context.Background(), []checkEntities.CheckResult{
+		{HostID: "host-1", Type: core_entities.CheckSSH, Status: status, Timestamp: at},
+	})
+	Expect(err).NotTo(HaveOccurred())
+}
@@ -0,0 +1,21 @@
+package approvals_test
+
+import (
+	"context"
+	"testing"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestApprovalsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Approvals Domain Suite")
+}
+
+type noopRunner struct{}
+
+func (noopRunner) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	return nil
+}
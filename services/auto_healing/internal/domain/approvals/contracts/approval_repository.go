@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+)
+
+type ApprovalRepository interface {
+	Save(ctx context.Context, approval *entities.Approval) error
+	Get(ctx context.Context, id string) (*entities.Approval, error)
+	FindPendingByHost(ctx context.Context, hostID string) (*entities.Approval, error)
+	List(ctx context.Context, filter entities.ApprovalFilter) ([]*entities.Approval, error)
+}
@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type DecisionMaker interface {
+	DecideHost(ctx context.Context, hostID string) (*decisionEntities.Decision, error)
+	Rules() []decisionEntities.Rule
+}
@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	workflowEntities "github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+)
+
+type WorkflowStarter interface {
+	StartApprovedDecision(ctx context.Context, projectID string, rule decisionEntities.Rule, decision decisionEntities.Decision, approvedBy string) (*workflowEntities.Workflow, error)
+}
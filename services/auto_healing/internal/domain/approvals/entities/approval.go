@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gwall-e/auto_healing/events"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending   ApprovalStatus = "pending"
+	ApprovalApproved  ApprovalStatus = "approved"
+	ApprovalRejected  ApprovalStatus = "rejected"
+	ApprovalExpired   ApprovalStatus = "expired"
+	ApprovalCancelled ApprovalStatus = "cancelled"
+)
+
+func (s ApprovalStatus) IsValid() bool {
+	switch s {
+	case ApprovalPending, ApprovalApproved, ApprovalRejected, ApprovalExpired, ApprovalCancelled:
+		return true
+	}
+	return false
+}
+
+type Approval struct {
+	ID          string                  `bson:"_id"`
+	HostID      string                  `bson:"host_id"`
+	ProjectID   string                  `bson:"project_id"`
+	Check       core_entities.CheckType `bson:"check"`
+	Rule        string                  `bson:"rule"`
+	Action      decisionEntities.Action `bson:"action"`
+	Reason      string                  `bson:"reason"`
+	Status      ApprovalStatus          `bson:"status"`
+	RequestedAt time.Time               `bson:"requested_at"`
+	ExpiresAt   time.Time               `bson:"expires_at"`
+	ResolvedAt  *time.Time              `bson:"resolved_at"`
+	ResolvedBy  string                  `bson:"resolved_by"`
+	Comment     string                  `bson:"comment"`
+	WorkflowID  string                  `bson:"workflow_id"`
+	events      []interface{}           `bson:"-"`
+}
+
+type ApprovalFilter struct {
+	Status ApprovalStatus
+	HostID string
+	Since  time.Time
+}
+
+func NewApproval(projectID string, decision decisionEntities.Decision, expiresAt time.Time, now time.Time) *Approval {
+	approval := &Approval{
+		ID:          uuid.NewString(),
+		HostID:      decision.HostID,
+		ProjectID:   projectID,
+		Check:       decision.Check,
+		Rule:        decision.Rule,
+		Action:      decision.Action,
+		Reason:      decision.Reason,
+		Status:      ApprovalPending,
+		RequestedAt: now,
+		ExpiresAt:   expiresAt,
+	}
+	approval.addEvent(&events.ApprovalRequestedEvent{
+		ApprovalID: approval.ID,
+		HostID:     approval.HostID,
+		ProjectID:  projectID,
+		Check:      string(approval.Check),
+		Rule:       approval.Rule,
+		Action:     string(approval.Action),
+		Reason:     approval.Reason,
+		ExpiresAt:  expiresAt,
+		At:         now,
+	})
+	return approval
+}
+
+func (a *Approval) Events() []interface{} {
+	return a.events
+}
+
+func (a *Approval) ClearEvents() {
+	a.events = nil
+}
+
+func (a *Approval) addEvent(event interface{}) {
+	a.events = append(a.events, event)
+}
+
+func (a *Approval) IsPending() bool {
+	return a.Status == ApprovalPending
+}
+
+func (a *Approval) IsExpired(now time.Time) bool {
+	return a.IsPending() && !now.Before(a.ExpiresAt)
+}
+
+func (a *Approval) Covers(decision decisionEntities.Decision) bool {
+	return a.HostID == decision.HostID && a.Rule == decision.Rule && a.Action == decision.Action
+}
+
+func (a *Approval) Approve(by string, comment string, workflowID string, now time.Time) {
+	a.WorkflowID = workflowID
+	a.resolve(ApprovalApproved, by, comment, now)
+}
+
+func (a *Approval) Reject(by string, comment string, now time.Time) {
+	a.resolve(ApprovalRejected, by, comment, now)
+}
+
+func (a *Approval) Expire(now time.Time) {
+	a.resolve(ApprovalExpired, "", "", now)
+}
+
+func (a *Approval) Cancel(reason string, now time.Time) {
+	a.resolve(ApprovalCancelled, "", reason, now)
+}
+
+func (a *Approval) resolve(status ApprovalStatus, by string, comment string, now time.Time) {
+	a.Status = status
+	a.ResolvedAt = &now
+	a.ResolvedBy = by
+	a.Comment = comment
+	a.addEvent(&events.ApprovalResolvedEvent{
+		ApprovalID: a.ID,
+		HostID:     a.HostID,
+		ProjectID:  a.ProjectID,
+		Action:     string(a.Action),
+		Status:     string(status),
+		ResolvedBy: by,
+		Comment:    comment,
+		WorkflowID: a.WorkflowID,
+		At:         now,
+	})
+}
+
+func (a *Approval) String() string {
+	return fmt.Sprintf("%s of host %s by rule %s", a.Action, a.HostID, a.Rule)
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrApprovalNotFound    = errors.New("approval not found")
+	ErrApprovalResolved    = errors.New("approval is already resolved")
+	ErrApprovalNotRequired = errors.New("decision does not require approval")
+	ErrHostRecovered       = errors.New("host recovered")
+	ErrDecisionChanged     = errors.New("decision changed since the approval was requested")
+	ErrUnknownRule         = errors.New("rule of the approval is not configured anymore")
+)
+
+type ApprovalValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ApprovalValidationError) Error() string {
+	return fmt.Sprintf("approval validation error, field: %s, err: %s", e.Field, e.Message)
+}
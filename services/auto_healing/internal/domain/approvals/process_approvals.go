@@ -0,0 +1,56 @@
+package approvals
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+)
+
+func (s *ApprovalService) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(s.processInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessApprovals(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ApprovalService) ProcessApprovals(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, err := s.repo.List(ctx, entities.ApprovalFilter{Status: entities.ApprovalPending})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, approval := range pending {
+		if err := s.processApproval(ctx, approval); err != nil {
+			errs = append(errs, fmt.Errorf("approval %s: %w", approval.ID, err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+func (s *ApprovalService) processApproval(ctx context.Context, approval *entities.Approval) error {
+	now := s.now()
+	if approval.IsExpired(now) {
+		approval.Expire(now)
+		return s.save(ctx, approval)
+	}
+	recovered, err := s.recovered(ctx, approval)
+	if err != nil || !recovered {
+		return err
+	}
+	return s.cancel(ctx, approval, "host recovered")
+}
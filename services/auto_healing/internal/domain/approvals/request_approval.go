@@ -0,0 +1,75 @@
+package approvals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/errors"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+func (s *ApprovalService) RequestApproval(ctx context.Context, projectID string, decision decisionEntities.Decision) (*entities.Approval, error) {
+	if !decision.ApprovalRequired || decision.DryRun {
+		return nil, fmt.Errorf("%w: %s of host %s", errors.ErrApprovalNotRequired, decision.Action, decision.HostID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	pending, err := s.repo.FindPendingByHost(ctx, decision.HostID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		if pending.Covers(decision) && !pending.IsExpired(now) {
+			return pending, nil
+		}
+		if pending.IsExpired(now) {
+			pending.Expire(now)
+		} else {
+			pending.Cancel(fmt.Sprintf("superseded by %s decided by rule %s", decision.Action, decision.Rule), now)
+		}
+		if err := s.save(ctx, pending); err != nil {
+			return nil, err
+		}
+	}
+
+	approval := entities.NewApproval(projectID, decision, now.Add(s.ttl), now)
+	if err := s.save(ctx, approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) GetApproval(ctx context.Context, id string) (*entities.Approval, error) {
+	approval, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrApprovalNotFound, id)
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) ListApprovals(ctx context.Context, filter entities.ApprovalFilter) ([]*entities.Approval, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, &errors.ApprovalValidationError{Field: "status", Message: fmt.Sprintf("unknown status %q", filter.Status)}
+	}
+	return s.repo.List(ctx, filter)
+}
+
+func (s *ApprovalService) save(ctx context.Context, approval *entities.Approval) error {
+	if err := s.repo.Save(ctx, approval); err != nil {
+		return err
+	}
+	for _, event := range approval.Events() {
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	approval.ClearEvents()
+	return nil
+}
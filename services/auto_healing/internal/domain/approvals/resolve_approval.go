@@ -0,0 +1,134 @@
+package approvals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/errors"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+func (s *ApprovalService) ApproveAction(ctx context.Context, id string, by string, comment string) (*entities.Approval, error) {
+	if by == "" {
+		return nil, &errors.ApprovalValidationError{Field: "actor", Message: "actor is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	approval, err := s.getPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recovered, err := s.recovered(ctx, approval)
+	if err != nil {
+		return nil, err
+	}
+	if recovered {
+		if err := s.cancel(ctx, approval, "host recovered"); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s check of host %s passes", errors.ErrHostRecovered, approval.Check, approval.HostID)
+	}
+
+	decision, err := s.decisions.DecideHost(ctx, approval.HostID)
+	if err != nil {
+		return nil, err
+	}
+	if reason, changed := changedDecision(approval, *decision); changed {
+		if err := s.cancel(ctx, approval, reason); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", errors.ErrDecisionChanged, reason)
+	}
+	rule, found := s.findRule(approval.Rule)
+	if !found {
+		return nil, fmt.Errorf("%w: %s", errors.ErrUnknownRule, approval.Rule)
+	}
+
+	workflow, err := s.workflows.StartApprovedDecision(ctx, approval.ProjectID, rule, *decision, by)
+	if err != nil {
+		return nil, err
+	}
+	approval.Approve(by, comment, workflow.ID, s.now())
+	if err := s.save(ctx, approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) RejectAction(ctx context.Context, id string, by string, comment string) (*entities.Approval, error) {
+	if by == "" {
+		return nil, &errors.ApprovalValidationError{Field: "actor", Message: "actor is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	approval, err := s.getPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	approval.Reject(by, comment, s.now())
+	if err := s.save(ctx, approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) getPending(ctx context.Context, id string) (*entities.Approval, error) {
+	approval, err := s.GetApproval(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !approval.IsPending() {
+		return nil, fmt.Errorf("%w: %s is %s", errors.ErrApprovalResolved, id, approval.Status)
+	}
+	if approval.IsExpired(s.now()) {
+		approval.Expire(s.now())
+		if err := s.save(ctx, approval); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s expired at %s", errors.ErrApprovalResolved, id, approval.ExpiresAt)
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) recovered(ctx context.Context, approval *entities.Approval) (bool, error) {
+	checks, err := s.checks.GetHostChecks(ctx, approval.HostID)
+	if err != nil {
+		return false, err
+	}
+	for _, check := range checks {
+		if check.Type == approval.Check {
+			return check.Status != checkEntities.CheckStatusFailed && !check.Stale, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *ApprovalService) cancel(ctx context.Context, approval *entities.Approval, reason string) error {
+	approval.Cancel(reason, s.now())
+	return s.save(ctx, approval)
+}
+
+func (s *ApprovalService) findRule(name string) (decisionEntities.Rule, bool) {
+	for _, rule := range s.decisions.Rules() {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return decisionEntities.Rule{}, false
+}
+
+func changedDecision(approval *entities.Approval, decision decisionEntities.Decision) (string, bool) {
+	if decision.DryRun {
+		return fmt.Sprintf("project %s is in dry run", approval.ProjectID), true
+	}
+	if !approval.Covers(decision) {
+		return fmt.Sprintf("decided %s: %s", decision.Action, decision.Reason), true
+	}
+	return "", false
+}
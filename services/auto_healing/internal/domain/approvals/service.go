@@ -0,0 +1,69 @@
+package approvals
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/contracts"
+)
+
+const (
+	DEFAULT_APPROVAL_TTL     = 4 * time.Hour
+	DEFAULT_PROCESS_INTERVAL = time.Minute
+)
+
+type SetupFunc func(*ApprovalService)
+
+type ApprovalService struct {
+	mu              sync.Mutex
+	repo            contracts.ApprovalRepository
+	decisions       contracts.DecisionMaker
+	workflows       contracts.WorkflowStarter
+	checks          contracts.CheckReader
+	publisher       contracts.EventPublisher
+	ttl             time.Duration
+	processInterval time.Duration
+	now             func() time.Time
+}
+
+func WithApprovalTTL(ttl time.Duration) SetupFunc {
+	return func(s *ApprovalService) {
+		s.ttl = ttl
+	}
+}
+
+func WithProcessInterval(interval time.Duration) SetupFunc {
+	return func(s *ApprovalService) {
+		s.processInterval = interval
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *ApprovalService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	repo contracts.ApprovalRepository,
+	decisions contracts.DecisionMaker,
+	workflows contracts.WorkflowStarter,
+	checks contracts.CheckReader,
+	publisher contracts.EventPublisher,
+	setup ...SetupFunc,
+) *ApprovalService {
+	s := &ApprovalService{
+		repo:            repo,
+		decisions:       decisions,
+		workflows:       workflows,
+		checks:          checks,
+		publisher:       publisher,
+		ttl:             DEFAULT_APPROVAL_TTL,
+		processInterval: DEFAULT_PROCESS_INTERVAL,
+		now:             time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
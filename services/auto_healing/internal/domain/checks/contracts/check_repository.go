@@ -0,0 +1,19 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type CheckRepository interface {
+	GetLatest(ctx context.Context, hostID string, checkType core_entities.CheckType) (*entities.HostCheck, error)
+	SaveLatest(ctx context.Context, check *entities.HostCheck) (bool, error)
+	ListLatest(ctx context.Context, hostID string) ([]*entities.HostCheck, error)
+	ListReportedBefore(ctx context.Context, before time.Time) ([]*entities.HostCheck, error)
+	ListByStatus(ctx context.Context, status entities.CheckStatus) ([]*entities.HostCheck, error)
+	AppendHistory(ctx context.Context, results []entities.CheckResult) (int, error)
+	ListHistory(ctx context.Context, hostID string, checkType core_entities.CheckType, since time.Time) ([]entities.CheckResult, error)
+}
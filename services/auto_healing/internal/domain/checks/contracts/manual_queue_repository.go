@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+)
+
+type ManualQueueRepository interface {
+	Add(ctx context.Context, item *entities.ManualQueueItem) error
+	Get(ctx context.Context, id string) (*entities.ManualQueueItem, error)
+	Save(ctx context.Context, item *entities.ManualQueueItem) error
+	ListOpen(ctx context.Context) ([]*entities.ManualQueueItem, error)
+}
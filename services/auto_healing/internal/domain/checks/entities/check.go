@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"maps"
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type CheckStatus string
+
+const (
+	CheckStatusOK      CheckStatus = "ok"
+	CheckStatusWarning CheckStatus = "warning"
+	CheckStatusFailed  CheckStatus = "failed"
+)
+
+func (s CheckStatus) IsValid() bool {
+	switch s {
+	case CheckStatusOK, CheckStatusWarning, CheckStatusFailed:
+		return true
+	}
+	return false
+}
+
+type CheckResult struct {
+	HostID     string                  `bson:"host_id"`
+	Type       core_entities.CheckType `bson:"type"`
+	Status     CheckStatus             `bson:"status"`
+	Timestamp  time.Time               `bson:"timestamp"`
+	Metadata   map[string]string       `bson:"metadata"`
+	ReceivedAt time.Time               `bson:"received_at"`
+	ExpiresAt  time.Time               `bson:"expires_at"`
+}
+
+type HostCheck struct {
+	HostID        string                  `bson:"host_id"`
+	Type          core_entities.CheckType `bson:"type"`
+	Status        CheckStatus             `bson:"status"`
+	Timestamp     time.Time               `bson:"timestamp"`
+	Metadata      map[string]string       `bson:"metadata"`
+	ReceivedAt    time.Time               `bson:"received_at"`
+	StatusSince   time.Time               `bson:"status_since"`
+	Transitions   []Transition            `bson:"transitions"`
+	FlapScore     float64                 `bson:"flap_score"`
+	Flapping      bool                    `bson:"flapping"`
+	FlappingSince time.Time               `bson:"flapping_since"`
+	Escalated     bool                    `bson:"escalated"`
+	Stale         bool                    `bson:"-"`
+}
+
+func NewHostCheck(result CheckResult) *HostCheck {
+	return &HostCheck{
+		HostID:      result.HostID,
+		Type:        result.Type,
+		Status:      result.Status,
+		Timestamp:   result.Timestamp,
+		Metadata:    maps.Clone(result.Metadata),
+		ReceivedAt:  result.ReceivedAt,
+		StatusSince: result.Timestamp,
+		Transitions: []Transition{},
+	}
+}
+
+func (c *HostCheck) Apply(result CheckResult) bool {
+	if !result.Timestamp.After(c.Timestamp) {
+		return false
+	}
+	if result.Status != c.Status {
+		c.Transitions = append(c.Transitions, Transition{From: c.Status, To: result.Status, At: result.Timestamp})
+		c.StatusSince = result.Timestamp
+	}
+	c.Status = result.Status
+	c.Timestamp = result.Timestamp
+	c.Metadata = maps.Clone(result.Metadata)
+	c.ReceivedAt = result.ReceivedAt
+	return true
+}
+
+func (c *HostCheck) IsStaleAt(now time.Time, timeout time.Duration) bool {
+	return now.Sub(c.Timestamp) > timeout
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+const MAX_TRANSITIONS = 200
+
+type Transition struct {
+	From CheckStatus `bson:"from"`
+	To   CheckStatus `bson:"to"`
+	At   time.Time   `bson:"at"`
+}
+
+type FlappingThresholds struct {
+	Window        time.Duration `bson:"window"`
+	StartScore    float64       `bson:"start_score"`
+	StopScore     float64       `bson:"stop_score"`
+	EscalateAfter time.Duration `bson:"escalate_after"`
+}
+
+var DEFAULT_FLAPPING_THRESHOLDS = FlappingThresholds{
+	Window:        time.Hour,
+	StartScore:    4,
+	StopScore:     1.5,
+	EscalateAfter: 2 * time.Hour,
+}
+
+func (t FlappingThresholds) Score(transitions []Transition, now time.Time) float64 {
+	score := 0.0
+	for _, transition := range transitions {
+		age := now.Sub(transition.At)
+		if age < 0 || age > t.Window {
+			continue
+		}
+		score += 1 - 0.5*float64(age)/float64(t.Window)
+	}
+	return score
+}
+
+func (c *HostCheck) UpdateFlapping(thresholds FlappingThresholds) (escalate bool) {
+	now := c.Timestamp
+	c.FlapScore = thresholds.Score(c.Transitions, now)
+	switch {
+	case !c.Flapping && c.FlapScore >= thresholds.StartScore:
+		c.Flapping = true
+		c.FlappingSince = now
+	case c.Flapping && c.FlapScore <= thresholds.StopScore:
+		c.Flapping = false
+		c.FlappingSince = time.Time{}
+		c.Escalated = false
+	}
+
+	keepSince := now.Add(-thresholds.Window)
+	if c.Flapping && c.FlappingSince.Add(-thresholds.Window).Before(keepSince) {
+		keepSince = c.FlappingSince.Add(-thresholds.Window)
+	}
+	c.Transitions = slices.DeleteFunc(c.Transitions, func(transition Transition) bool {
+		return transition.At.Before(keepSince)
+	})
+	if len(c.Transitions) > MAX_TRANSITIONS {
+		c.Transitions = c.Transitions[len(c.Transitions)-MAX_TRANSITIONS:]
+	}
+
+	return c.Flapping && !c.Escalated && now.Sub(c.FlappingSince) >= thresholds.EscalateAfter
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type ManualQueueItem struct {
+	ID            string                  `bson:"_id"`
+	HostID        string                  `bson:"host_id"`
+	Type          core_entities.CheckType `bson:"type"`
+	Reason        string                  `bson:"reason"`
+	FlapScore     float64                 `bson:"flap_score"`
+	FlappingSince time.Time               `bson:"flapping_since"`
+	Transitions   []Transition            `bson:"transitions"`
+	CreatedAt     time.Time               `bson:"created_at"`
+	ResolvedAt    *time.Time              `bson:"resolved_at"`
+	ResolvedBy    string                  `bson:"resolved_by"`
+}
+
+func NewFlappingItem(check *HostCheck, reason string, now time.Time) *ManualQueueItem {
+	return &ManualQueueItem{
+		ID:            uuid.NewString(),
+		HostID:        check.HostID,
+		Type:          check.Type,
+		Reason:        reason,
+		FlapScore:     check.FlapScore,
+		FlappingSince: check.FlappingSince,
+		Transitions:   slices.Clone(check.Transitions),
+		CreatedAt:     now,
+	}
+}
+
+func (i *ManualQueueItem) IsOpen() bool {
+	return i.ResolvedAt == nil
+}
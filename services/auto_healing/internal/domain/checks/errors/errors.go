@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBatchTooLarge = errors.New("batch of check results is too large")
+	ErrEmptyBatch    = errors.New("batch of check results is empty")
+	ErrCheckConflict = errors.New("check was concurrently updated too many times")
+
+	ErrManualQueueItemNotFound = errors.New("manual queue item not found")
+)
+
+type CheckResultValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e CheckResultValidationError) Error() string {
+	return fmt.Sprintf("check result validation error, field: %s, err: %s", e.Field, e.Message)
+}
@@ -0,0 +1,18 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+)
+
+func (s *CheckService) FindStaleChecks(ctx context.Context) ([]*entities.HostCheck, error) {
+	checks, err := s.repo.ListReportedBefore(ctx, s.now().Add(-s.staleTimeout))
+	if err != nil {
+		return nil, err
+	}
+	for _, check := range checks {
+		check.Stale = true
+	}
+	return checks, nil
+}
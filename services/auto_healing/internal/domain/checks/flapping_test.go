@@ -0,0 +1,159 @@
+package checks_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flapping", func() {
+	var (
+		ctx     context.Context
+		start   time.Time
+		now     time.Time
+		service *CheckService
+	)
+
+	newService := func(escalateAfter time.Duration) *CheckService {
+		return NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(),
+			WithClock(func() time.Time { return now }),
+			WithFlappingThresholds(core_entities.CheckSSH, entities.FlappingThresholds{
+				Window:        30 * time.Minute,
+				StartScore:    3,
+				StopScore:     1,
+				EscalateAfter: escalateAfter,
+			}),
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		start = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	report := func(minute int, status entities.CheckStatus) *entities.HostCheck {
+		timestamp := start.Add(time.Duration(minute) * time.Minute)
+		now = timestamp.Add(30 * time.Second)
+		_, err := service.IngestResults(ctx, []entities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: status, Timestamp: timestamp},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		return checks[0]
+	}
+
+	oscillate := func(from int, to int) *entities.HostCheck {
+		var check *entities.HostCheck
+		for minute := from; minute <= to; minute += 5 {
+			status := entities.CheckStatusOK
+			if minute%10 == 5 {
+				status = entities.CheckStatusFailed
+			}
+			check = report(minute, status)
+		}
+		return check
+	}
+
+	It("should start and stop flapping with hysteresis", func() {
+		service = newService(time.Hour)
+
+		check := oscillate(0, 15)
+		Expect(check.Flapping).To(BeFalse())
+		Expect(check.FlapScore).To(BeNumerically("~", 2.75, 0.01))
+
+		check = oscillate(20, 20)
+		Expect(check.Flapping).To(BeTrue())
+		Expect(check.FlappingSince).To(Equal(start.Add(20 * time.Minute)))
+		Expect(check.Transitions).To(HaveLen(4))
+		Expect(check.Transitions[0]).To(Equal(entities.Transition{
+			From: entities.CheckStatusOK,
+			To:   entities.CheckStatusFailed,
+			At:   start.Add(5 * time.Minute),
+		}))
+
+		report(25, entities.CheckStatusFailed)
+		check = report(45, entities.CheckStatusFailed)
+		Expect(check.FlapScore).To(BeNumerically("~", 1.75, 0.01))
+		Expect(check.Flapping).To(BeTrue())
+
+		check = report(55, entities.CheckStatusFailed)
+		Expect(check.Flapping).To(BeFalse())
+		Expect(check.FlappingSince).To(BeZero())
+		Expect(check.Transitions).To(HaveLen(1))
+
+		Expect(service.ListManualQueue(ctx)).To(BeEmpty())
+	})
+
+	It("should detect transitions within a single batch", func() {
+		service = newService(time.Hour)
+		results := []entities.CheckResult{}
+		for minute := 20; minute >= 0; minute -= 5 {
+			status := entities.CheckStatusOK
+			if minute%10 == 5 {
+				status = entities.CheckStatusFailed
+			}
+			results = append(results, entities.CheckResult{HostID: "host-1", Type: core_entities.CheckSSH, Status: status, Timestamp: start.Add(time.Duration(minute) * time.Minute)})
+		}
+		now = start.Add(21 * time.Minute)
+		_, err := service.IngestResults(ctx, results)
+		Expect(err).NotTo(HaveOccurred())
+
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks[0].Flapping).To(BeTrue())
+	})
+
+	It("should escalate persistently flapping checks to the manual queue once", func() {
+		service = newService(20 * time.Minute)
+
+		check := oscillate(0, 35)
+		Expect(check.Escalated).To(BeFalse())
+		check = oscillate(40, 50)
+		Expect(check.Escalated).To(BeTrue())
+
+		items, err := service.ListManualQueue(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(HaveLen(1))
+		item := items[0]
+		Expect(item.HostID).To(Equal("host-1"))
+		Expect(item.Type).To(Equal(core_entities.CheckSSH))
+		Expect(item.FlappingSince).To(Equal(start.Add(20 * time.Minute)))
+		Expect(item.CreatedAt).To(Equal(start.Add(40*time.Minute + 30*time.Second)))
+		Expect(item.Reason).To(HavePrefix("ssh check has been flapping for 20m0s, score"))
+		Expect(item.Transitions).To(HaveLen(8))
+
+		resolved, err := service.ResolveManualQueueItem(ctx, item.ID, "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved.ResolvedBy).To(Equal("alice"))
+		Expect(service.ListManualQueue(ctx)).To(BeEmpty())
+
+		_, err = service.ResolveManualQueueItem(ctx, "unknown", "alice")
+		Expect(err).To(MatchError(errors.ErrManualQueueItemNotFound))
+	})
+
+	It("should use default thresholds for other check types", func() {
+		service = newService(time.Hour)
+		for minute := 0; minute <= 20; minute += 5 {
+			status := entities.CheckStatusOK
+			if minute%10 == 5 {
+				status = entities.CheckStatusFailed
+			}
+			now = start.Add(time.Duration(minute)*time.Minute + 30*time.Second)
+			_, err := service.IngestResults(ctx, []entities.CheckResult{
+				{HostID: "host-1", Type: core_entities.CheckDisk, Status: status, Timestamp: start.Add(time.Duration(minute) * time.Minute)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks[0].Flapping).To(BeFalse())
+	})
+})
@@ -0,0 +1,21 @@
+package checks
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func (s *CheckService) GetCheckHistory(ctx context.Context, hostID string, checkType core_entities.CheckType, since time.Time) ([]entities.CheckResult, error) {
+	history, err := s.repo.ListHistory(ctx, hostID, checkType, since)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	return slices.DeleteFunc(history, func(result entities.CheckResult) bool {
+		return !result.ExpiresAt.After(now)
+	}), nil
+}
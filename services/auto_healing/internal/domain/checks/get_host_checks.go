@@ -0,0 +1,33 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+)
+
+func (s *CheckService) GetHostChecks(ctx context.Context, hostID string) ([]*entities.HostCheck, error) {
+	checks, err := s.repo.ListLatest(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	for _, check := range checks {
+		check.Stale = check.IsStaleAt(now, s.staleTimeout)
+	}
+	return checks, nil
+}
+
+func (s *CheckService) ListFailingHosts(ctx context.Context) ([]string, error) {
+	checks, err := s.repo.ListByStatus(ctx, entities.CheckStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0)
+	for _, check := range checks {
+		if len(hosts) == 0 || hosts[len(hosts)-1] != check.HostID {
+			hosts = append(hosts, check.HostID)
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,128 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/validators"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type RejectedResult struct {
+	Index   int
+	HostID  string
+	Message string
+}
+
+type IngestReport struct {
+	Accepted   int
+	Duplicates int
+	Rejected   []RejectedResult
+}
+
+type checkKey struct {
+	hostID    string
+	checkType core_entities.CheckType
+}
+
+func (s *CheckService) IngestResults(ctx context.Context, results []entities.CheckResult) (*IngestReport, error) {
+	if len(results) == 0 {
+		return nil, errors.ErrEmptyBatch
+	}
+	if len(results) > MAX_BATCH_SIZE {
+		return nil, fmt.Errorf("%w: %d results, limit is %d", errors.ErrBatchTooLarge, len(results), MAX_BATCH_SIZE)
+	}
+
+	now := s.now()
+	report := &IngestReport{Rejected: []RejectedResult{}}
+	valid := make([]entities.CheckResult, 0, len(results))
+	for i, result := range results {
+		if err := validators.ValidateCheckResult(result, now); err != nil {
+			report.Rejected = append(report.Rejected, RejectedResult{Index: i, HostID: result.HostID, Message: err.Error()})
+			continue
+		}
+		result.ReceivedAt = now
+		result.ExpiresAt = now.Add(s.historyTTL)
+		valid = append(valid, result)
+	}
+	if len(valid) == 0 {
+		return report, nil
+	}
+
+	stored, err := s.repo.AppendHistory(ctx, valid)
+	if err != nil {
+		return nil, err
+	}
+	report.Accepted = stored
+	report.Duplicates = len(valid) - stored
+
+	for key, checkResults := range groupResults(valid) {
+		if err := s.applyResults(ctx, key, checkResults); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func groupResults(results []entities.CheckResult) map[checkKey][]entities.CheckResult {
+	grouped := map[checkKey][]entities.CheckResult{}
+	for _, result := range results {
+		key := checkKey{hostID: result.HostID, checkType: result.Type}
+		grouped[key] = append(grouped[key], result)
+	}
+	for _, checkResults := range grouped {
+		sort.SliceStable(checkResults, func(i, j int) bool { return checkResults[i].Timestamp.Before(checkResults[j].Timestamp) })
+	}
+	return grouped
+}
+
+func (s *CheckService) applyResults(ctx context.Context, key checkKey, results []entities.CheckResult) error {
+	for attempt := 0; attempt < MAX_SAVE_ATTEMPTS; attempt++ {
+		check, escalate, err := s.mergeResults(ctx, key, results)
+		if err != nil || check == nil {
+			return err
+		}
+		saved, err := s.repo.SaveLatest(ctx, check)
+		if err != nil {
+			return err
+		}
+		if !saved {
+			continue
+		}
+		if !escalate {
+			return nil
+		}
+		reason := fmt.Sprintf("%s check has been flapping for %s, score %.1f", check.Type,
+			check.Timestamp.Sub(check.FlappingSince), check.FlapScore)
+		return s.queue.Add(ctx, entities.NewFlappingItem(check, reason, s.now()))
+	}
+	return fmt.Errorf("%w: %s check of host %s", errors.ErrCheckConflict, key.checkType, key.hostID)
+}
+
+func (s *CheckService) mergeResults(ctx context.Context, key checkKey, results []entities.CheckResult) (*entities.HostCheck, bool, error) {
+	check, err := s.repo.GetLatest(ctx, key.hostID, key.checkType)
+	if err != nil {
+		return nil, false, err
+	}
+	changed := false
+	for _, result := range results {
+		if check == nil {
+			check = entities.NewHostCheck(result)
+			changed = true
+		} else if check.Apply(result) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, false, nil
+	}
+
+	escalate := check.UpdateFlapping(s.flappingThresholds(key.checkType))
+	if escalate {
+		check.Escalated = true
+	}
+	return check, escalate, nil
+}
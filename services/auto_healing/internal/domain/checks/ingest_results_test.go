@@ -0,0 +1,247 @@
+package checks_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type racingCheckRepository struct {
+	*memory.CheckRepository
+	race   *entities.HostCheck
+	reject bool
+}
+
+func (r *racingCheckRepository) SaveLatest(ctx context.Context, check *entities.HostCheck) (bool, error) {
+	if r.reject {
+		return false, nil
+	}
+	if r.race != nil {
+		race := r.race
+		r.race = nil
+		if _, err := r.CheckRepository.SaveLatest(ctx, race); err != nil {
+			return false, err
+		}
+	}
+	return r.CheckRepository.SaveLatest(ctx, check)
+}
+
+var _ = Describe("CheckService", func() {
+	var (
+		ctx     context.Context
+		now     time.Time
+		service *CheckService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		service = NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(),
+			WithClock(func() time.Time { return now }),
+			WithHistoryTTL(24*time.Hour),
+			WithStaleTimeout(10*time.Minute),
+		)
+	})
+
+	result := func(hostID string, checkType core_entities.CheckType, status entities.CheckStatus, age time.Duration) entities.CheckResult {
+		return entities.CheckResult{HostID: hostID, Type: checkType, Status: status, Timestamp: now.Add(-age)}
+	}
+
+	It("should store the latest state and history of every check", func() {
+		report, err := service.IngestResults(ctx, []entities.CheckResult{
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 3*time.Minute),
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, time.Minute),
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, 2*time.Minute),
+			result("host-1", core_entities.CheckDisk, entities.CheckStatusWarning, time.Minute),
+			result("host-2", core_entities.CheckSSH, entities.CheckStatusOK, time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(Equal(&IngestReport{Accepted: 5, Rejected: []RejectedResult{}}))
+
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks).To(HaveLen(2))
+		Expect(checks[0].Type).To(Equal(core_entities.CheckDisk))
+		Expect(checks[1].Type).To(Equal(core_entities.CheckSSH))
+		Expect(checks[1].Status).To(Equal(entities.CheckStatusFailed))
+		Expect(checks[1].Timestamp).To(Equal(now.Add(-time.Minute)))
+		Expect(checks[1].ReceivedAt).To(Equal(now))
+
+		history, err := service.GetCheckHistory(ctx, "host-1", core_entities.CheckSSH, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(3))
+		Expect(history[0].Status).To(Equal(entities.CheckStatusOK))
+		Expect(history[2].Timestamp).To(Equal(now.Add(-time.Minute)))
+
+		history, err = service.GetCheckHistory(ctx, "host-1", core_entities.CheckSSH, now.Add(-2*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(2))
+	})
+
+	It("should track since when the check has its status", func() {
+		_, err := service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 5*time.Minute)})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, 4*time.Minute)})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, 2*time.Minute)})
+		Expect(err).NotTo(HaveOccurred())
+
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks[0].StatusSince).To(Equal(now.Add(-4 * time.Minute)))
+	})
+
+	It("should deduplicate repeated and late results", func() {
+		batch := []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, time.Minute)}
+		_, err := service.IngestResults(ctx, batch)
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := service.IngestResults(ctx, append(batch, batch[0], result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 5*time.Minute)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Accepted).To(Equal(1))
+		Expect(report.Duplicates).To(Equal(2))
+
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks[0].Status).To(Equal(entities.CheckStatusFailed))
+
+		history, err := service.GetCheckHistory(ctx, "host-1", core_entities.CheckSSH, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(2))
+	})
+
+	It("should reject invalid results and keep the rest of the batch", func() {
+		report, err := service.IngestResults(ctx, []entities.CheckResult{
+			result("", core_entities.CheckSSH, entities.CheckStatusOK, 0),
+			result("host-1", "dns", entities.CheckStatusOK, 0),
+			result("host-1", core_entities.CheckSSH, "broken", 0),
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, -time.Hour),
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: entities.CheckStatusOK},
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 0),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Accepted).To(Equal(1))
+		Expect(report.Rejected).To(HaveLen(5))
+		Expect(report.Rejected[1]).To(Equal(RejectedResult{
+			Index:   1,
+			HostID:  "host-1",
+			Message: `check result validation error, field: check, err: unknown check type "dns"`,
+		}))
+		Expect(report.Rejected[3].Message).To(ContainSubstring("future"))
+		Expect(report.Rejected[4].Message).To(ContainSubstring("timestamp is required"))
+	})
+
+	It("should reject empty and too large batches", func() {
+		_, err := service.IngestResults(ctx, nil)
+		Expect(err).To(MatchError(errors.ErrEmptyBatch))
+
+		batch := make([]entities.CheckResult, MAX_BATCH_SIZE+1)
+		_, err = service.IngestResults(ctx, batch)
+		Expect(err).To(MatchError(errors.ErrBatchTooLarge))
+	})
+
+	It("should detect checks that stopped reporting", func() {
+		_, err := service.IngestResults(ctx, []entities.CheckResult{
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 20*time.Minute),
+			result("host-1", core_entities.CheckDisk, entities.CheckStatusOK, time.Minute),
+			result("host-2", core_entities.CheckMemory, entities.CheckStatusOK, 11*time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		stale, err := service.FindStaleChecks(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stale).To(HaveLen(2))
+		Expect(stale[0].HostID).To(Equal("host-1"))
+		Expect(stale[0].Type).To(Equal(core_entities.CheckSSH))
+		Expect(stale[1].HostID).To(Equal("host-2"))
+
+		checks, err := service.GetHostChecks(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks[0].Stale).To(BeFalse())
+		Expect(checks[1].Stale).To(BeTrue())
+	})
+
+	It("should list hosts with failing checks once", func() {
+		_, err := service.IngestResults(ctx, []entities.CheckResult{
+			result("host-2", core_entities.CheckSSH, entities.CheckStatusFailed, time.Minute),
+			result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, time.Minute),
+			result("host-3", core_entities.CheckSSH, entities.CheckStatusFailed, time.Minute),
+			result("host-3", core_entities.CheckDisk, entities.CheckStatusFailed, time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		hosts, err := service.ListFailingHosts(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hosts).To(Equal([]string{"host-2", "host-3"}))
+	})
+
+	It("should expire history after ttl", func() {
+		_, err := service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, time.Minute)})
+		Expect(err).NotTo(HaveOccurred())
+
+		now = now.Add(25 * time.Hour)
+		history, err := service.GetCheckHistory(ctx, "host-1", core_entities.CheckSSH, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(BeEmpty())
+
+		_, err = service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, time.Minute)})
+		Expect(err).NotTo(HaveOccurred())
+		history, err = service.GetCheckHistory(ctx, "host-1", core_entities.CheckSSH, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(1))
+		Expect(history[0].Status).To(Equal(entities.CheckStatusOK))
+	})
+
+	Describe("concurrent updates", func() {
+		var repo *racingCheckRepository
+
+		BeforeEach(func() {
+			repo = &racingCheckRepository{CheckRepository: memory.NewCheckRepository()}
+			service = NewDomainService(repo, memory.NewManualQueueRepository(),
+				WithClock(func() time.Time { return now }),
+				WithFlappingThresholds(core_entities.CheckSSH, entities.FlappingThresholds{Window: time.Hour, StartScore: 1, StopScore: 0.5}),
+			)
+		})
+
+		It("should keep a newer check saved concurrently", func() {
+			repo.race = entities.NewHostCheck(result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 0))
+			report, err := service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, time.Second)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Accepted).To(Equal(1))
+
+			checks, err := service.GetHostChecks(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks[0].Status).To(Equal(entities.CheckStatusOK))
+			Expect(checks[0].Timestamp).To(Equal(now))
+		})
+
+		It("should fail when the check can not be saved", func() {
+			repo.reject = true
+			_, err := service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, time.Second)})
+			Expect(err).To(MatchError(errors.ErrCheckConflict))
+		})
+
+		It("should put flapping checks into the manual queue only once they are saved", func() {
+			_, err := service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusOK, 2*time.Second)})
+			Expect(err).NotTo(HaveOccurred())
+
+			repo.reject = true
+			flap := []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, time.Second)}
+			_, err = service.IngestResults(ctx, flap)
+			Expect(err).To(MatchError(errors.ErrCheckConflict))
+			Expect(service.ListManualQueue(ctx)).To(BeEmpty())
+
+			repo.reject = false
+			_, err = service.IngestResults(ctx, []entities.CheckResult{result("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, 0)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.ListManualQueue(ctx)).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,33 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+)
+
+func (s *CheckService) ListManualQueue(ctx context.Context) ([]*entities.ManualQueueItem, error) {
+	return s.queue.ListOpen(ctx)
+}
+
+func (s *CheckService) ResolveManualQueueItem(ctx context.Context, id string, by string) (*entities.ManualQueueItem, error) {
+	item, err := s.queue.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrManualQueueItemNotFound, id)
+	}
+	if !item.IsOpen() {
+		return item, nil
+	}
+	now := s.now()
+	item.ResolvedAt = &now
+	item.ResolvedBy = by
+	if err := s.queue.Save(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
@@ -0,0 +1,73 @@
+package checks
+
+import (
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+const (
+	MAX_BATCH_SIZE        = 1000
+	DEFAULT_HISTORY_TTL   = 7 * 24 * time.Hour
+	DEFAULT_STALE_TIMEOUT = 15 * time.Minute
+	MAX_SAVE_ATTEMPTS     = 5
+)
+
+type SetupFunc func(*CheckService)
+
+type CheckService struct {
+	repo         contracts.CheckRepository
+	queue        contracts.ManualQueueRepository
+	historyTTL   time.Duration
+	staleTimeout time.Duration
+	flapping     map[core_entities.CheckType]entities.FlappingThresholds
+	now          func() time.Time
+}
+
+func WithHistoryTTL(ttl time.Duration) SetupFunc {
+	return func(s *CheckService) {
+		s.historyTTL = ttl
+	}
+}
+
+func WithStaleTimeout(timeout time.Duration) SetupFunc {
+	return func(s *CheckService) {
+		s.staleTimeout = timeout
+	}
+}
+
+func WithFlappingThresholds(checkType core_entities.CheckType, thresholds entities.FlappingThresholds) SetupFunc {
+	return func(s *CheckService) {
+		s.flapping[checkType] = thresholds
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *CheckService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(repo contracts.CheckRepository, queue contracts.ManualQueueRepository, setup ...SetupFunc) *CheckService {
+	s := &CheckService{
+		repo:         repo,
+		queue:        queue,
+		historyTTL:   DEFAULT_HISTORY_TTL,
+		staleTimeout: DEFAULT_STALE_TIMEOUT,
+		flapping:     map[core_entities.CheckType]entities.FlappingThresholds{},
+		now:          time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
+
+func (s *CheckService) flappingThresholds(checkType core_entities.CheckType) entities.FlappingThresholds {
+	if thresholds, ok := s.flapping[checkType]; ok {
+		return thresholds
+	}
+	return entities.DEFAULT_FLAPPING_THRESHOLDS
+}
@@ -0,0 +1,10 @@
+package validators
+
+import "time"
+
+const (
+	MAX_HOST_ID_LENGTH     = 64
+	MAX_METADATA_KEYS      = 32
+	MAX_METADATA_VALUE_LEN = 1024
+	MAX_CLOCK_SKEW         = 5 * time.Minute
+)
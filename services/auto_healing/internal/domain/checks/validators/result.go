@@ -0,0 +1,57 @@
+package validators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+)
+
+func ValidateCheckResult(result entities.CheckResult, now time.Time) error {
+	if result.HostID == "" || len(result.HostID) > MAX_HOST_ID_LENGTH {
+		return &errors.CheckResultValidationError{
+			Field:   "host_id",
+			Message: fmt.Sprintf("host id is required and must be at most %d characters", MAX_HOST_ID_LENGTH),
+		}
+	}
+	if !result.Type.IsValid() {
+		return &errors.CheckResultValidationError{
+			Field:   "check",
+			Message: fmt.Sprintf("unknown check type %q", result.Type),
+		}
+	}
+	if !result.Status.IsValid() {
+		return &errors.CheckResultValidationError{
+			Field:   "status",
+			Message: fmt.Sprintf("unknown status %q", result.Status),
+		}
+	}
+	if result.Timestamp.IsZero() {
+		return &errors.CheckResultValidationError{
+			Field:   "timestamp",
+			Message: "timestamp is required",
+		}
+	}
+	if result.Timestamp.After(now.Add(MAX_CLOCK_SKEW)) {
+		return &errors.CheckResultValidationError{
+			Field:   "timestamp",
+			Message: "timestamp is in the future",
+		}
+	}
+	if len(result.Metadata) > MAX_METADATA_KEYS {
+		return &errors.CheckResultValidationError{
+			Field:   "metadata",
+			Message: fmt.Sprintf("at most %d metadata keys are allowed", MAX_METADATA_KEYS),
+		}
+	}
+	for key, value := range result.Metadata {
+		if key == "" || len(value) > MAX_METADATA_VALUE_LEN {
+			return &errors.CheckResultValidationError{
+				Field:   "metadata",
+				Message: fmt.Sprintf("metadata %q is invalid, values are limited to %d characters", key, MAX_METADATA_VALUE_LEN),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+)
+
+type CheckReader interface {
+	GetHostChecks(ctx context.Context, hostID string) ([]*checkEntities.HostCheck, error)
+}
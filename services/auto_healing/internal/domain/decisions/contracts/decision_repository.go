@@ -0,0 +1,13 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type DecisionRepository interface {
+	SaveDecision(ctx context.Context, record *entities.DecisionRecord) error
+	GetDecision(ctx context.Context, id string) (*entities.DecisionRecord, error)
+	ListDecisions(ctx context.Context, filter entities.DecisionFilter) ([]entities.DecisionRecord, error)
+}
@@ -0,0 +1,9 @@
+package contracts
+
+import "context"
+
+type DryRunRepository interface {
+	IsDryRun(ctx context.Context, projectID string) (bool, error)
+	SetDryRun(ctx context.Context, projectID string, enabled bool) error
+	ListDryRunProjects(ctx context.Context) ([]string, error)
+}
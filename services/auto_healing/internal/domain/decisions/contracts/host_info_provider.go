@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type HostInfoProvider interface {
+	GetHostInfo(ctx context.Context, hostID string) (*entities.HostInfo, error)
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	limitEntities "github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+)
+
+type LimitsReader interface {
+	GetProjectStatus(ctx context.Context, projectID string) (*limitEntities.ProjectStatus, error)
+}
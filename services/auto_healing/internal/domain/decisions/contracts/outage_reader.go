@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	livenessEntities "github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+type OutageReader interface {
+	GetHostOutage(ctx context.Context, hostID string) (*livenessEntities.Outage, error)
+}
@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type RuleRepository interface {
+	GetRuleSet(ctx context.Context, kind entities.RuleSetKind) (*entities.RuleSet, error)
+	SaveRuleSet(ctx context.Context, set *entities.RuleSet) error
+}
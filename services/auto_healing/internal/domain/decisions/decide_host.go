@@ -0,0 +1,137 @@
+package decisions
+
+import (
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	limitErrors "github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+)
+
+func (s *DecisionService) DecideHost(ctx context.Context, hostID string) (*entities.Decision, error) {
+	rules, shadowRules := s.ruleSnapshot()
+	snapshot, err := s.hostSnapshot(ctx, hostID, rules)
+	if err != nil {
+		return nil, err
+	}
+	decision, evaluations := entities.Explain(rules, *snapshot)
+	decision.DryRun, err = s.dryRun.IsDryRun(ctx, snapshot.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := s.limitsState(ctx, snapshot.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entities.DecisionRecord{
+		ID:        uuid.NewString(),
+		HostID:    hostID,
+		ProjectID: snapshot.ProjectID,
+		Decision:  decision,
+		Explanation: entities.Explanation{
+			Snapshot: *snapshot,
+			Rules:    evaluations,
+			Limits:   limits,
+		},
+		DecidedAt: snapshot.Now,
+		ExpiresAt: snapshot.Now.Add(s.decisionTTL),
+	}
+	if len(shadowRules) > 0 {
+		shadow := entities.Decide(shadowRules, *snapshot)
+		shadow.DryRun = true
+		record.Shadow = &shadow
+	}
+	if err := s.decisions.SaveDecision(ctx, record); err != nil {
+		return nil, err
+	}
+	return &decision, nil
+}
+
+func (s *DecisionService) limitsState(ctx context.Context, projectID string) (*entities.LimitsState, error) {
+	if s.limits == nil {
+		return nil, nil
+	}
+	status, err := s.limits.GetProjectStatus(ctx, projectID)
+	if stdErrors.Is(err, limitErrors.ErrProjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entities.LimitsState{
+		Disabled:    status.Disabled,
+		Reason:      status.Reason,
+		Actions:     status.Actions,
+		MaxActions:  status.Limits.MaxActions,
+		Window:      status.Limits.Window,
+		InFlight:    status.InFlight,
+		MaxInFlight: status.MaxInFlight,
+	}, nil
+}
+
+func (s *DecisionService) GetHostSnapshot(ctx context.Context, hostID string) (*entities.HostSnapshot, error) {
+	return s.hostSnapshot(ctx, hostID, s.Rules())
+}
+
+func (s *DecisionService) hostSnapshot(ctx context.Context, hostID string, rules []entities.Rule) (*entities.HostSnapshot, error) {
+	info, err := s.hosts.GetHostInfo(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	hostChecks, err := s.checks.GetHostChecks(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	history, err := s.history.ListActions(ctx, hostID, now.Add(-historyWindow(rules)))
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]entities.CheckState, 0, len(hostChecks))
+	for _, check := range hostChecks {
+		checks = append(checks, entities.CheckState{
+			Type:     check.Type,
+			Failing:  check.Status == checkEntities.CheckStatusFailed,
+			Since:    check.StatusSince,
+			Stale:    check.Stale,
+			Flapping: check.Flapping,
+		})
+	}
+
+	outage := ""
+	if s.outages != nil {
+		hostOutage, err := s.outages.GetHostOutage(ctx, hostID)
+		if err != nil {
+			return nil, err
+		}
+		if hostOutage != nil {
+			outage = hostOutage.String()
+		}
+	}
+
+	return &entities.HostSnapshot{
+		HostID:       hostID,
+		ProjectID:    info.ProjectID,
+		UnitType:     info.UnitType,
+		Tier:         info.Tier,
+		Restrictions: info.Restrictions,
+		Checks:       checks,
+		History:      history,
+		Outage:       outage,
+		Now:          now,
+	}, nil
+}
+
+func historyWindow(rules []entities.Rule) time.Duration {
+	window := entities.DEFAULT_HISTORY_WINDOW
+	for _, rule := range rules {
+		window = max(window, rule.HistoryWindow)
+	}
+	return window
+}
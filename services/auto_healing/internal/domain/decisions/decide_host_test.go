@@ -0,0 +1,195 @@
+package decisions_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/decisions"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	"github.com/gwall-e/auto_healing/internal/domain/limits"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DecideHost", func() {
+	var (
+		ctx          context.Context
+		now          time.Time
+		hosts        *memory.HostInfoRepository
+		history      *memory.ActionHistory
+		decisions    *memory.DecisionRepository
+		dryRun       *memory.DryRunRepository
+		checkService *checks.CheckService
+		service      *DecisionService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+
+		hosts = memory.NewHostInfoRepository()
+		hosts.SetHostInfo(entities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer, Tier: 1})
+		history = memory.NewActionHistory()
+		decisions = memory.NewDecisionRepository()
+		dryRun = memory.NewDryRunRepository()
+
+		checkService = checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		for _, result := range []checkEntities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusOK, Timestamp: now.Add(-time.Hour)},
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now.Add(-20 * time.Minute)},
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now.Add(-time.Minute)},
+		} {
+			_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{result})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		service = NewDomainService(hosts, checkService, history, decisions, dryRun, WithClock(clock))
+	})
+
+	It("should decide from the collected host state", func() {
+		decision, err := service.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision).To(Equal(&entities.Decision{
+			HostID:    "host-1",
+			Action:    entities.ActionReboot,
+			Rule:      "ssh",
+			Check:     core_entities.CheckSSH,
+			Reason:    "ssh check has been failing for 20m0s",
+			DecidedAt: now,
+		}))
+	})
+
+	It("should escalate with action history", func() {
+		Expect(history.AddAction(ctx, "host-1", entities.ActionRecord{Action: entities.ActionReboot, Check: core_entities.CheckSSH, At: now.Add(-10 * time.Minute)})).To(Succeed())
+		Expect(history.AddAction(ctx, "host-1", entities.ActionRecord{Action: entities.ActionReboot, Check: core_entities.CheckSSH, At: now.Add(-48 * time.Hour)})).To(Succeed())
+
+		snapshot, err := service.GetHostSnapshot(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.History).To(HaveLen(1))
+		Expect(snapshot.Tier).To(Equal(byte(1)))
+
+		decision, err := service.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionRedeploy))
+	})
+
+	It("should use configured rules", func() {
+		Expect(service.SetRules(ctx, []entities.Rule{
+			{Name: "ssh-tier-1", Check: core_entities.CheckSSH, Tiers: []byte{1}, FailingFor: time.Hour, Actions: []entities.Action{entities.ActionRedeploy}},
+		})).To(Succeed())
+
+		decision, err := service.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionWait))
+		Expect(decision.Reason).To(Equal("ssh check has been failing for 20m0s, rule ssh-tier-1 acts after 1h0m0s"))
+	})
+
+	It("should reject invalid and duplicated rules keeping the current ones", func() {
+		rule := entities.Rule{Name: "ssh", Check: core_entities.CheckSSH, Actions: []entities.Action{entities.ActionReboot}}
+		Expect(service.SetRules(ctx, []entities.Rule{rule, rule})).To(MatchError(ContainSubstring("duplicated rule ssh")))
+		Expect(service.SetRules(ctx, []entities.Rule{{Name: "broken"}})).NotTo(Succeed())
+		Expect(service.Rules()).To(Equal(entities.DefaultRules()))
+	})
+
+	It("should persist rules and load them in other instances", func() {
+		ruleSets := memory.NewRuleRepository()
+		service = NewDomainService(hosts, checkService, history, decisions, dryRun, WithClock(func() time.Time { return now }), WithRuleRepository(ruleSets))
+		replica := NewDomainService(hosts, checkService, history, decisions, dryRun, WithClock(func() time.Time { return now }), WithRuleRepository(ruleSets))
+
+		rules := []entities.Rule{{Name: "ssh-redeploy", Check: core_entities.CheckSSH, Actions: []entities.Action{entities.ActionRedeploy}}}
+		Expect(service.SetRules(ctx, rules)).To(Succeed())
+		Expect(ruleSets.GetRuleSet(ctx, entities.RuleSetActive)).To(Equal(&entities.RuleSet{Kind: entities.RuleSetActive, Rules: rules, UpdatedAt: now}))
+
+		Expect(replica.Rules()).To(Equal(entities.DefaultRules()))
+		Expect(replica.LoadRules(ctx)).To(Succeed())
+		Expect(replica.Rules()).To(Equal(rules))
+
+		decision, err := replica.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionRedeploy))
+		Expect(decision.Rule).To(Equal("ssh-redeploy"))
+	})
+
+	It("should keep the current rules when stored ones are invalid", func() {
+		ruleSets := memory.NewRuleRepository()
+		Expect(ruleSets.SaveRuleSet(ctx, &entities.RuleSet{Kind: entities.RuleSetActive, Rules: []entities.Rule{{Name: "broken"}}})).To(Succeed())
+		service = NewDomainService(hosts, checkService, history, decisions, dryRun, WithRuleRepository(ruleSets))
+
+		Expect(service.LoadRules(ctx)).To(MatchError(ContainSubstring("stored active rules")))
+		Expect(service.Rules()).To(Equal(entities.DefaultRules()))
+	})
+
+	It("should record decisions not to be executed for projects in dry run", func() {
+		Expect(service.SetProjectDryRun(ctx, "search", true)).To(Succeed())
+		Expect(service.ListDryRunProjects(ctx)).To(Equal([]string{"search"}))
+
+		decision, err := service.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionReboot))
+		Expect(decision.DryRun).To(BeTrue())
+
+		records, err := service.ListDecisions(ctx, entities.DecisionFilter{HostID: "host-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].ProjectID).To(Equal("search"))
+		Expect(records[0].Decision).To(Equal(*decision))
+		Expect(records[0].Shadow).To(BeNil())
+		Expect(records[0].ExpiresAt).To(Equal(now.Add(DEFAULT_DECISION_TTL)))
+
+		Expect(service.SetProjectDryRun(ctx, "search", false)).To(Succeed())
+		decision, err = service.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.DryRun).To(BeFalse())
+	})
+
+	It("should record decisions for healthy hosts", func() {
+		hosts.SetHostInfo(entities.HostInfo{HostID: "host-2", ProjectID: "search", UnitType: core_entities.TypeServer})
+		decision, err := service.DecideHost(ctx, "host-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionNone))
+
+		records, err := service.ListDecisions(ctx, entities.DecisionFilter{HostID: "host-2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Explanation.Rules).To(HaveEach(HaveField("Applied", false)))
+	})
+
+	It("should explain decisions with rules, input checks and limits", func() {
+		limitService := limits.NewDomainService(memory.NewAutomationRepository(), hosts, limits.WithClock(func() time.Time { return now }))
+		service = NewDomainService(hosts, checkService, history, decisions, dryRun,
+			WithClock(func() time.Time { return now }), WithLimitsReader(limitService))
+
+		decision, err := service.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		records, err := service.ListDecisions(ctx, entities.DecisionFilter{HostID: "host-1"})
+		Expect(err).NotTo(HaveOccurred())
+		record, err := service.GetDecision(ctx, records[0].ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		explanation := record.Explanation
+		Expect(explanation.Snapshot.Checks).To(Equal([]entities.CheckState{
+			{Type: core_entities.CheckSSH, Failing: true, Since: now.Add(-20 * time.Minute)},
+		}))
+		Expect(explanation.Rules).To(ContainElements(
+			entities.RuleEvaluation{Rule: "ssh", Check: core_entities.CheckSSH, Applied: true, Action: decision.Action,
+				Reason: decision.Reason, Selected: true},
+			entities.RuleEvaluation{Rule: "unreachable-vm", Check: core_entities.CheckUnreachable, Reason: "host does not match the rule"},
+			entities.RuleEvaluation{Rule: "disk", Check: core_entities.CheckDisk, Reason: "no disk check reported"},
+		))
+		Expect(explanation.Limits).To(Equal(&entities.LimitsState{Actions: 0, MaxActions: 5, Window: time.Hour, MaxInFlight: 1}))
+
+		_, err = service.GetDecision(ctx, "unknown")
+		Expect(err).To(MatchError(errors.ErrDecisionNotFound))
+	})
+
+	It("should fail for unknown hosts", func() {
+		_, err := service.DecideHost(ctx, "unknown")
+		Expect(err).To(MatchError(errors.ErrHostNotFound))
+	})
+})
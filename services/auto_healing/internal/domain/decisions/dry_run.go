@@ -0,0 +1,15 @@
+package decisions
+
+import "context"
+
+func (s *DecisionService) SetProjectDryRun(ctx context.Context, projectID string, enabled bool) error {
+	return s.dryRun.SetDryRun(ctx, projectID, enabled)
+}
+
+func (s *DecisionService) IsProjectDryRun(ctx context.Context, projectID string) (bool, error) {
+	return s.dryRun.IsDryRun(ctx, projectID)
+}
+
+func (s *DecisionService) ListDryRunProjects(ctx context.Context) ([]string, error) {
+	return s.dryRun.ListDryRunProjects(ctx)
+}
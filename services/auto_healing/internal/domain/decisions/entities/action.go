@@ -0,0 +1,47 @@
+package entities
+
+import "github.com/gwall-e/pkg/core_entities"
+
+type Action string
+
+const (
+	ActionNone               Action = "none"
+	ActionWait               Action = "wait"
+	ActionReboot             Action = "reboot"
+	ActionRedeploy           Action = "redeploy"
+	ActionProfile            Action = "profile"
+	ActionReportToDatacenter Action = "report-to-datacenter"
+)
+
+var actions = []Action{
+	ActionNone,
+	ActionWait,
+	ActionReboot,
+	ActionRedeploy,
+	ActionProfile,
+	ActionReportToDatacenter,
+}
+
+var actionRestrictions = map[Action]core_entities.Restriction{
+	ActionReboot:   core_entities.RestrictionNoReboot,
+	ActionRedeploy: core_entities.RestrictionNoRedeploy,
+	ActionProfile:  core_entities.RestrictionNoProfile,
+}
+
+func (a Action) IsValid() bool {
+	return a.Severity() >= 0
+}
+
+func (a Action) Severity() int {
+	for i, known := range actions {
+		if known == a {
+			return i
+		}
+	}
+	return -1
+}
+
+func (a Action) Restriction() (core_entities.Restriction, bool) {
+	restriction, ok := actionRestrictions[a]
+	return restriction, ok
+}
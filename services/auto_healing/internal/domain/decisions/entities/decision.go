@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type Decision struct {
+	HostID           string                  `bson:"host_id"`
+	Action           Action                  `bson:"action"`
+	Rule             string                  `bson:"rule"`
+	Check            core_entities.CheckType `bson:"check"`
+	Reason           string                  `bson:"reason"`
+	DecidedAt        time.Time               `bson:"decided_at"`
+	DryRun           bool                    `bson:"dry_run"`
+	ApprovalRequired bool                    `bson:"approval_required"`
+}
+
+func Decide(rules []Rule, snapshot HostSnapshot) Decision {
+	decision, _ := Explain(rules, snapshot)
+	return decision
+}
+
+func noDecision(snapshot HostSnapshot) Decision {
+	failing := []string{}
+	for _, check := range snapshot.Checks {
+		if check.Failing {
+			failing = append(failing, string(check.Type))
+		}
+	}
+	reason := "no failing checks"
+	if len(failing) > 0 {
+		reason = fmt.Sprintf("no rule applies to failing checks: %s", strings.Join(failing, ", "))
+	}
+	return Decision{HostID: snapshot.HostID, Action: ActionNone, Reason: reason, DecidedAt: snapshot.Now}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func highTierApproval() ApprovalPolicy {
+	return ApprovalPolicy{Actions: []Action{ActionRedeploy, ActionReportToDatacenter}, Tiers: []byte{0}}
+}
+
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name:       "unreachable-server",
+			Check:      core_entities.CheckUnreachable,
+			FailingFor: 10 * time.Minute,
+			UnitTypes:  []core_entities.UnitType{core_entities.TypeServer, core_entities.TypeShadowServer},
+			Actions:    []Action{ActionReboot, ActionRedeploy},
+			Approval:   highTierApproval(),
+		},
+		{
+			Name:       "unreachable-vm",
+			Check:      core_entities.CheckUnreachable,
+			FailingFor: 5 * time.Minute,
+			UnitTypes:  []core_entities.UnitType{core_entities.TypeVM},
+			Actions:    []Action{ActionReboot, ActionRedeploy},
+			Approval:   highTierApproval(),
+		},
+		{
+			Name:       "ssh",
+			Check:      core_entities.CheckSSH,
+			FailingFor: 15 * time.Minute,
+			Actions:    []Action{ActionReboot, ActionRedeploy},
+			Approval:   highTierApproval(),
+		},
+		{
+			Name:       "memory",
+			Check:      core_entities.CheckMemory,
+			FailingFor: 30 * time.Minute,
+			UnitTypes:  []core_entities.UnitType{core_entities.TypeServer},
+			Actions:    []Action{ActionReportToDatacenter},
+			Approval:   highTierApproval(),
+		},
+		{
+			Name:       "disk",
+			Check:      core_entities.CheckDisk,
+			FailingFor: 30 * time.Minute,
+			UnitTypes:  []core_entities.UnitType{core_entities.TypeServer},
+			Actions:    []Action{ActionReportToDatacenter},
+			Approval:   highTierApproval(),
+		},
+		{
+			Name:       "bmc",
+			Check:      core_entities.CheckBMC,
+			FailingFor: time.Hour,
+			Actions:    []Action{ActionReportToDatacenter},
+			Approval:   highTierApproval(),
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package entities_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEntitiesSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Decision Entities Suite")
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type RuleEvaluation struct {
+	Rule     string                  `bson:"rule"`
+	Check    core_entities.CheckType `bson:"check"`
+	Applied  bool                    `bson:"applied"`
+	Action   Action                  `bson:"action"`
+	Reason   string                  `bson:"reason"`
+	Selected bool                    `bson:"selected"`
+}
+
+type LimitsState struct {
+	Disabled    bool          `bson:"disabled"`
+	Reason      string        `bson:"reason"`
+	Actions     int           `bson:"actions"`
+	MaxActions  int           `bson:"max_actions"`
+	Window      time.Duration `bson:"window"`
+	InFlight    int           `bson:"in_flight"`
+	MaxInFlight int           `bson:"max_in_flight"`
+}
+
+type Explanation struct {
+	Snapshot HostSnapshot     `bson:"snapshot"`
+	Rules    []RuleEvaluation `bson:"rules"`
+	Limits   *LimitsState     `bson:"limits"`
+}
+
+func Explain(rules []Rule, snapshot HostSnapshot) (Decision, []RuleEvaluation) {
+	evaluations := make([]RuleEvaluation, 0, len(rules))
+	selected := -1
+	var decided Decision
+	for i, rule := range rules {
+		evaluation := RuleEvaluation{Rule: rule.Name, Check: rule.Check}
+		decision, ok := rule.Evaluate(snapshot)
+		if ok {
+			evaluation.Applied = true
+			evaluation.Action = decision.Action
+			evaluation.Reason = decision.Reason
+			if selected < 0 || decision.Action.Severity() > decided.Action.Severity() {
+				decided, selected = decision, i
+			}
+		} else {
+			evaluation.Reason = rule.skipReason(snapshot)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	if selected >= 0 {
+		evaluations[selected].Selected = true
+		return decided, evaluations
+	}
+	return noDecision(snapshot), evaluations
+}
+
+func (r Rule) skipReason(snapshot HostSnapshot) string {
+	if !r.Matches(snapshot) {
+		return "host does not match the rule"
+	}
+	if _, found := snapshot.Check(r.Check); !found {
+		return fmt.Sprintf("no %s check reported", r.Check)
+	}
+	return fmt.Sprintf("%s check is not failing", r.Check)
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "github.com/gwall-e/pkg/core_entities"
+
+type HostInfo struct {
+	HostID       string                      `bson:"_id"`
+	FQDN         string                      `bson:"fqdn"`
+	ProjectID    string                      `bson:"project_id"`
+	UnitType     core_entities.UnitType      `bson:"unit_type"`
+	Tier         byte                        `bson:"tier"`
+	Restrictions []core_entities.Restriction `bson:"restrictions"`
+	Datacenter   string                      `bson:"datacenter"`
+	Rack         string                      `bson:"rack"`
+	Switch       string                      `bson:"switch"`
+}
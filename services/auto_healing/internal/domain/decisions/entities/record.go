@@ -0,0 +1,81 @@
+package entities
+
+import "time"
+
+type DecisionRecord struct {
+	ID          string      `bson:"_id"`
+	HostID      string      `bson:"host_id"`
+	ProjectID   string      `bson:"project_id"`
+	Decision    Decision    `bson:"decision"`
+	Explanation Explanation `bson:"explanation"`
+	Shadow      *Decision   `bson:"shadow"`
+	DecidedAt   time.Time   `bson:"decided_at"`
+	ExpiresAt   time.Time   `bson:"expires_at"`
+}
+
+func (r *DecisionRecord) Differs() bool {
+	return r.Shadow != nil && r.Shadow.Action != r.Decision.Action
+}
+
+type DecisionFilter struct {
+	ProjectID string
+	HostID    string
+	Since     time.Time
+	Until     time.Time
+}
+
+type ActionTransition struct {
+	Active Action `bson:"active"`
+	Shadow Action `bson:"shadow"`
+	Count  int    `bson:"count"`
+}
+
+type ShadowReport struct {
+	Since       time.Time          `bson:"since"`
+	Until       time.Time          `bson:"until"`
+	ProjectID   string             `bson:"project_id"`
+	Compared    int                `bson:"compared"`
+	Matching    int                `bson:"matching"`
+	Differing   int                `bson:"differing"`
+	Transitions []ActionTransition `bson:"transitions"`
+	Differences []DecisionRecord   `bson:"differences"`
+}
+
+const MAX_REPORT_DIFFERENCES = 100
+
+func NewShadowReport(filter DecisionFilter, records []DecisionRecord) *ShadowReport {
+	report := &ShadowReport{
+		Since:       filter.Since,
+		Until:       filter.Until,
+		ProjectID:   filter.ProjectID,
+		Transitions: []ActionTransition{},
+		Differences: []DecisionRecord{},
+	}
+	counts := map[[2]Action]int{}
+	for _, record := range records {
+		if record.Shadow == nil {
+			continue
+		}
+		report.Compared++
+		key := [2]Action{record.Decision.Action, record.Shadow.Action}
+		if counts[key] == 0 {
+			report.Transitions = append(report.Transitions, ActionTransition{Active: key[0], Shadow: key[1]})
+		}
+		counts[key]++
+		if !record.Differs() {
+			report.Matching++
+			continue
+		}
+		report.Differing++
+		report.Differences = append(report.Differences, record)
+	}
+
+	for i := range report.Transitions {
+		transition := &report.Transitions[i]
+		transition.Count = counts[[2]Action{transition.Active, transition.Shadow}]
+	}
+	if len(report.Differences) > MAX_REPORT_DIFFERENCES {
+		report.Differences = report.Differences[len(report.Differences)-MAX_REPORT_DIFFERENCES:]
+	}
+	return report
+}
@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+const DEFAULT_HISTORY_WINDOW = 24 * time.Hour
+
+type Rule struct {
+	Name          string                   `bson:"name"`
+	Check         core_entities.CheckType  `bson:"check"`
+	FailingFor    time.Duration            `bson:"failing_for"`
+	UnitTypes     []core_entities.UnitType `bson:"unit_types"`
+	Tiers         []byte                   `bson:"tiers"`
+	Actions       []Action                 `bson:"actions"`
+	MaxAttempts   int                      `bson:"max_attempts"`
+	HistoryWindow time.Duration            `bson:"history_window"`
+	Approval      ApprovalPolicy           `bson:"approval"`
+}
+
+type ApprovalPolicy struct {
+	Actions []Action `bson:"actions"`
+	Tiers   []byte   `bson:"tiers"`
+}
+
+func (p ApprovalPolicy) Requires(action Action, tier byte) bool {
+	if !slices.Contains(p.Actions, action) {
+		return false
+	}
+	return len(p.Tiers) == 0 || slices.Contains(p.Tiers, tier)
+}
+
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return &errors.RuleValidationError{Rule: r.Name, Field: "name", Message: "name is required"}
+	}
+	if !r.Check.IsValid() {
+		return &errors.RuleValidationError{Rule: r.Name, Field: "check", Message: fmt.Sprintf("unknown check type %q", r.Check)}
+	}
+	if r.FailingFor < 0 || r.HistoryWindow < 0 || r.MaxAttempts < 0 {
+		return &errors.RuleValidationError{Rule: r.Name, Field: "limits", Message: "durations and attempts can not be negative"}
+	}
+	for _, unitType := range r.UnitTypes {
+		if !unitType.IsValid() {
+			return &errors.RuleValidationError{Rule: r.Name, Field: "unit_types", Message: fmt.Sprintf("unknown unit type %q", unitType)}
+		}
+	}
+	if len(r.Actions) == 0 {
+		return &errors.RuleValidationError{Rule: r.Name, Field: "actions", Message: "at least one action is required"}
+	}
+	for _, action := range r.Actions {
+		if !action.IsValid() || action == ActionNone {
+			return &errors.RuleValidationError{Rule: r.Name, Field: "actions", Message: fmt.Sprintf("unknown action %q", action)}
+		}
+	}
+	for _, action := range r.Approval.Actions {
+		if !action.IsValid() || action == ActionNone || action == ActionWait {
+			return &errors.RuleValidationError{Rule: r.Name, Field: "approval", Message: fmt.Sprintf("action %q can not be approved", action)}
+		}
+	}
+	return nil
+}
+
+func (r Rule) maxAttempts() int {
+	if r.MaxAttempts <= 0 {
+		return 1
+	}
+	return r.MaxAttempts
+}
+
+func (r Rule) historyWindow() time.Duration {
+	if r.HistoryWindow <= 0 {
+		return DEFAULT_HISTORY_WINDOW
+	}
+	return r.HistoryWindow
+}
+
+func (r Rule) Matches(snapshot HostSnapshot) bool {
+	if len(r.UnitTypes) > 0 && !slices.Contains(r.UnitTypes, snapshot.UnitType) {
+		return false
+	}
+	if len(r.Tiers) > 0 && !slices.Contains(r.Tiers, snapshot.Tier) {
+		return false
+	}
+	return true
+}
+
+func (r Rule) Evaluate(snapshot HostSnapshot) (Decision, bool) {
+	check, found := snapshot.Check(r.Check)
+	if !found || !check.Failing || !r.Matches(snapshot) {
+		return Decision{}, false
+	}
+
+	decision := Decision{HostID: snapshot.HostID, Rule: r.Name, Check: r.Check, DecidedAt: snapshot.Now}
+	failingFor := snapshot.Now.Sub(check.Since)
+	reason := fmt.Sprintf("%s check has been failing for %s", r.Check, failingFor.Round(time.Second))
+
+	if check.Stale {
+		decision.Action = ActionWait
+		decision.Reason = fmt.Sprintf("%s, but its result is stale", reason)
+		return decision, true
+	}
+	if check.Flapping {
+		decision.Action = ActionWait
+		decision.Reason = fmt.Sprintf("%s, but it is flapping", reason)
+		return decision, true
+	}
+	if snapshot.Outage != "" {
+		decision.Action = ActionWait
+		decision.Reason = fmt.Sprintf("%s, but the host is in a %s", reason, snapshot.Outage)
+		return decision, true
+	}
+	if failingFor < r.FailingFor {
+		decision.Action = ActionWait
+		decision.Reason = fmt.Sprintf("%s, rule %s acts after %s", reason, r.Name, r.FailingFor)
+		return decision, true
+	}
+
+	action, taken := r.nextAction(snapshot)
+	decision.Action = action
+	if len(taken) > 0 {
+		reason = fmt.Sprintf("%s, already taken within %s: %s", reason, r.historyWindow(), strings.Join(taken, ", "))
+	}
+	decision.Reason = reason
+
+	if restriction, ok := action.Restriction(); ok {
+		effective := core_entities.EffectiveRestrictions(snapshot.Restrictions)
+		if forbidden, ok := core_entities.ForbiddenBy(effective, restriction, true); ok {
+			decision.Action = ActionWait
+			decision.Reason = fmt.Sprintf("%s, %s is forbidden by restriction %s", reason, action, forbidden)
+			return decision, true
+		}
+	}
+	decision.ApprovalRequired = r.Approval.Requires(action, snapshot.Tier)
+	return decision, true
+}
+
+func (r Rule) nextAction(snapshot HostSnapshot) (Action, []string) {
+	taken := []string{}
+	for _, action := range r.Actions {
+		count := snapshot.ActionsTaken(action, r.Check, r.historyWindow())
+		if count < r.maxAttempts() {
+			return action, taken
+		}
+		taken = append(taken, fmt.Sprintf("%s %d time(s)", action, count))
+	}
+	return ActionReportToDatacenter, taken
+}
+
+func (r Rule) ChainFrom(action Action, tier byte) []Action {
+	chain := []Action{}
+	if index := slices.Index(r.Actions, action); index >= 0 {
+		chain = append(chain, r.Actions[index:]...)
+	} else if action != ActionReportToDatacenter {
+		chain = append(chain, action)
+	}
+	if len(chain) == 0 || chain[len(chain)-1] != ActionReportToDatacenter {
+		chain = append(chain, ActionReportToDatacenter)
+	}
+	for i := 1; i < len(chain); i++ {
+		if r.Approval.Requires(chain[i], tier) {
+			return chain[:i]
+		}
+	}
+	return chain
+}
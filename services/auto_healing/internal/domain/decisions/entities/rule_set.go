@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type RuleSetKind string
+
+const (
+	RuleSetActive RuleSetKind = "active"
+	RuleSetShadow RuleSetKind = "shadow"
+)
+
+type RuleSet struct {
+	Kind      RuleSetKind `bson:"_id"`
+	Rules     []Rule      `bson:"rules"`
+	UpdatedAt time.Time   `bson:"updated_at"`
+}
@@ -0,0 +1,249 @@
+package entities_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	decisionErrors "github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rule", func() {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	rule := Rule{
+		Name:          "ssh",
+		Check:         core_entities.CheckSSH,
+		FailingFor:    15 * time.Minute,
+		Actions:       []Action{ActionReboot, ActionRedeploy},
+		MaxAttempts:   2,
+		HistoryWindow: 12 * time.Hour,
+	}
+
+	snapshot := func(failingFor time.Duration, history ...ActionRecord) HostSnapshot {
+		return HostSnapshot{
+			HostID:   "host-1",
+			UnitType: core_entities.TypeServer,
+			Tier:     1,
+			Checks: []CheckState{
+				{Type: core_entities.CheckSSH, Failing: true, Since: now.Add(-failingFor)},
+				{Type: core_entities.CheckDisk, Since: now.Add(-time.Hour)},
+			},
+			History: history,
+			Now:     now,
+		}
+	}
+
+	reboot := func(ago time.Duration) ActionRecord {
+		return ActionRecord{Action: ActionReboot, Check: core_entities.CheckSSH, At: now.Add(-ago)}
+	}
+
+	DescribeTable("should decide by failure duration and history",
+		func(state HostSnapshot, action Action, reason string) {
+			decision, ok := rule.Evaluate(state)
+			Expect(ok).To(BeTrue())
+			Expect(decision.Action).To(Equal(action))
+			Expect(decision.Reason).To(Equal(reason))
+			Expect(decision.Rule).To(Equal("ssh"))
+			Expect(decision.Check).To(Equal(core_entities.CheckSSH))
+			Expect(decision.DecidedAt).To(Equal(now))
+		},
+		Entry("failing shortly", snapshot(5*time.Minute), ActionWait,
+			"ssh check has been failing for 5m0s, rule ssh acts after 15m0s"),
+		Entry("failing long enough", snapshot(20*time.Minute), ActionReboot,
+			"ssh check has been failing for 20m0s"),
+		Entry("one attempt left", snapshot(20*time.Minute, reboot(time.Hour)), ActionReboot,
+			"ssh check has been failing for 20m0s"),
+		Entry("escalation", snapshot(20*time.Minute, reboot(time.Hour), reboot(2*time.Hour)), ActionRedeploy,
+			"ssh check has been failing for 20m0s, already taken within 12h0m0s: reboot 2 time(s)"),
+		Entry("history outside the window", snapshot(20*time.Minute, reboot(time.Hour), reboot(13*time.Hour)), ActionReboot,
+			"ssh check has been failing for 20m0s"),
+		Entry("exhausted ladder", snapshot(20*time.Minute, reboot(time.Hour), reboot(2*time.Hour),
+			ActionRecord{Action: ActionRedeploy, Check: core_entities.CheckSSH, At: now.Add(-time.Hour)},
+			ActionRecord{Action: ActionRedeploy, Check: core_entities.CheckSSH, At: now.Add(-time.Hour)},
+		), ActionReportToDatacenter,
+			"ssh check has been failing for 20m0s, already taken within 12h0m0s: reboot 2 time(s), redeploy 2 time(s)"),
+		Entry("history of another check", snapshot(20*time.Minute,
+			ActionRecord{Action: ActionReboot, Check: core_entities.CheckDisk, At: now},
+			ActionRecord{Action: ActionReboot, Check: core_entities.CheckDisk, At: now},
+		), ActionReboot, "ssh check has been failing for 20m0s"),
+	)
+
+	It("should wait on stale results", func() {
+		state := snapshot(time.Hour)
+		state.Checks[0].Stale = true
+		decision, ok := rule.Evaluate(state)
+		Expect(ok).To(BeTrue())
+		Expect(decision.Action).To(Equal(ActionWait))
+		Expect(decision.Reason).To(HaveSuffix("but its result is stale"))
+	})
+
+	It("should suppress actions on flapping checks", func() {
+		state := snapshot(time.Hour)
+		state.Checks[0].Flapping = true
+		decision, ok := rule.Evaluate(state)
+		Expect(ok).To(BeTrue())
+		Expect(decision.Action).To(Equal(ActionWait))
+		Expect(decision.Reason).To(Equal("ssh check has been failing for 1h0m0s, but it is flapping"))
+	})
+
+	It("should suppress actions on hosts in a mass outage", func() {
+		state := snapshot(time.Hour)
+		state.Outage = "mass outage of switch sw-1"
+		decision, ok := rule.Evaluate(state)
+		Expect(ok).To(BeTrue())
+		Expect(decision.Action).To(Equal(ActionWait))
+		Expect(decision.Reason).To(Equal("ssh check has been failing for 1h0m0s, but the host is in a mass outage of switch sw-1"))
+	})
+
+	DescribeTable("should respect restrictions for automated actions",
+		func(restrictions []core_entities.Restriction, action Action, reason string) {
+			state := snapshot(20 * time.Minute)
+			state.Restrictions = restrictions
+			decision, _ := rule.Evaluate(state)
+			Expect(decision.Action).To(Equal(action))
+			Expect(decision.Reason).To(HaveSuffix(reason))
+		},
+		Entry("no automation", []core_entities.Restriction{core_entities.RestrictionNoAutomation}, ActionWait,
+			"reboot is forbidden by restriction no-automation"),
+		Entry("implied restriction", []core_entities.Restriction{core_entities.RestrictionNoReboot}, ActionWait,
+			"reboot is forbidden by restriction no-reboot"),
+		Entry("unrelated restriction", []core_entities.Restriction{core_entities.RestrictionNoVlanChange}, ActionReboot,
+			"failing for 20m0s"),
+	)
+
+	DescribeTable("should require approval by action and project tier",
+		func(policy ApprovalPolicy, tier byte, history []ActionRecord, required bool) {
+			approved := rule
+			approved.Approval = policy
+			state := snapshot(20*time.Minute, history...)
+			state.Tier = tier
+			decision, _ := approved.Evaluate(state)
+			Expect(decision.ApprovalRequired).To(Equal(required))
+		},
+		Entry("no policy", ApprovalPolicy{}, byte(0), nil, false),
+		Entry("action without approval", ApprovalPolicy{Actions: []Action{ActionRedeploy}, Tiers: []byte{0}}, byte(0), nil, false),
+		Entry("action of the tier", ApprovalPolicy{Actions: []Action{ActionRedeploy}, Tiers: []byte{0}}, byte(0),
+			[]ActionRecord{reboot(time.Hour), reboot(2 * time.Hour)}, true),
+		Entry("action of another tier", ApprovalPolicy{Actions: []Action{ActionRedeploy}, Tiers: []byte{0}}, byte(1),
+			[]ActionRecord{reboot(time.Hour), reboot(2 * time.Hour)}, false),
+		Entry("any tier", ApprovalPolicy{Actions: []Action{ActionReboot}}, byte(2), nil, true),
+	)
+
+	It("should not require approval of waiting", func() {
+		approved := rule
+		approved.Approval = ApprovalPolicy{Actions: []Action{ActionReboot}}
+		state := snapshot(20 * time.Minute)
+		state.Restrictions = []core_entities.Restriction{core_entities.RestrictionNoReboot}
+		decision, _ := approved.Evaluate(state)
+		Expect(decision.Action).To(Equal(ActionWait))
+		Expect(decision.ApprovalRequired).To(BeFalse())
+	})
+
+	It("should skip hosts not matching the rule", func() {
+		limited := rule
+		limited.UnitTypes = []core_entities.UnitType{core_entities.TypeVM}
+		_, ok := limited.Evaluate(snapshot(time.Hour))
+		Expect(ok).To(BeFalse())
+
+		limited = rule
+		limited.Tiers = []byte{0}
+		_, ok = limited.Evaluate(snapshot(time.Hour))
+		Expect(ok).To(BeFalse())
+
+		state := snapshot(time.Hour)
+		state.Checks[0].Failing = false
+		_, ok = rule.Evaluate(state)
+		Expect(ok).To(BeFalse())
+	})
+
+	DescribeTable("should validate rules",
+		func(mutate func(r *Rule), field string) {
+			invalid := rule
+			mutate(&invalid)
+			var validationErr *decisionErrors.RuleValidationError
+			Expect(errors.As(invalid.Validate(), &validationErr)).To(BeTrue())
+			Expect(validationErr.Field).To(Equal(field))
+		},
+		Entry("name", func(r *Rule) { r.Name = "" }, "name"),
+		Entry("check", func(r *Rule) { r.Check = "dns" }, "check"),
+		Entry("negative duration", func(r *Rule) { r.FailingFor = -time.Minute }, "limits"),
+		Entry("unit type", func(r *Rule) { r.UnitTypes = []core_entities.UnitType{"rack"} }, "unit_types"),
+		Entry("no actions", func(r *Rule) { r.Actions = nil }, "actions"),
+		Entry("unknown action", func(r *Rule) { r.Actions = []Action{"power-off"} }, "actions"),
+		Entry("none action", func(r *Rule) { r.Actions = []Action{ActionNone} }, "actions"),
+		Entry("approval of waiting", func(r *Rule) { r.Approval.Actions = []Action{ActionWait} }, "approval"),
+	)
+
+	DescribeTable("should chain the rest of the ladder",
+		func(action Action, expected []Action) {
+			Expect(rule.ChainFrom(action, 0)).To(Equal(expected))
+		},
+		Entry("first action", ActionReboot, []Action{ActionReboot, ActionRedeploy, ActionReportToDatacenter}),
+		Entry("last action", ActionRedeploy, []Action{ActionRedeploy, ActionReportToDatacenter}),
+		Entry("action out of the ladder", ActionProfile, []Action{ActionProfile, ActionReportToDatacenter}),
+		Entry("hand over", ActionReportToDatacenter, []Action{ActionReportToDatacenter}),
+	)
+
+	DescribeTable("should stop the chain before actions requiring approval",
+		func(action Action, tier byte, expected []Action) {
+			approved := rule
+			approved.Approval = ApprovalPolicy{Actions: []Action{ActionRedeploy}, Tiers: []byte{0}}
+			Expect(approved.ChainFrom(action, tier)).To(Equal(expected))
+		},
+		Entry("escalation into an approved action", ActionReboot, byte(0), []Action{ActionReboot}),
+		Entry("approved action itself", ActionRedeploy, byte(0), []Action{ActionRedeploy, ActionReportToDatacenter}),
+		Entry("tier without approval", ActionReboot, byte(1), []Action{ActionReboot, ActionRedeploy, ActionReportToDatacenter}),
+	)
+
+	It("should provide valid default rules", func() {
+		for _, rule := range DefaultRules() {
+			Expect(rule.Validate()).To(Succeed())
+		}
+	})
+})
+
+var _ = Describe("Decide", func() {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	state := HostSnapshot{
+		HostID:   "host-1",
+		UnitType: core_entities.TypeServer,
+		Checks: []CheckState{
+			{Type: core_entities.CheckSSH, Failing: true, Since: now.Add(-time.Hour)},
+			{Type: core_entities.CheckMemory, Failing: true, Since: now.Add(-time.Hour)},
+			{Type: core_entities.CheckGPU, Failing: true, Since: now.Add(-time.Hour)},
+		},
+		Now: now,
+	}
+
+	It("should take the most severe decision", func() {
+		decision := Decide(DefaultRules(), state)
+		Expect(decision.Action).To(Equal(ActionReportToDatacenter))
+		Expect(decision.Rule).To(Equal("memory"))
+	})
+
+	It("should prefer earlier rules of the same severity", func() {
+		rules := []Rule{
+			{Name: "first", Check: core_entities.CheckSSH, Actions: []Action{ActionReboot}},
+			{Name: "second", Check: core_entities.CheckMemory, Actions: []Action{ActionReboot}},
+		}
+		Expect(Decide(rules, state).Rule).To(Equal("first"))
+	})
+
+	It("should explain when no rule applies", func() {
+		decision := Decide(nil, state)
+		Expect(decision).To(Equal(Decision{
+			HostID:    "host-1",
+			Action:    ActionNone,
+			Reason:    "no rule applies to failing checks: ssh, memory, gpu",
+			DecidedAt: now,
+		}))
+
+		healthy := HostSnapshot{HostID: "host-2", Now: now}
+		Expect(Decide(DefaultRules(), healthy).Reason).To(Equal("no failing checks"))
+	})
+})
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type CheckState struct {
+	Type     core_entities.CheckType `bson:"type"`
+	Failing  bool                    `bson:"failing"`
+	Since    time.Time               `bson:"since"`
+	Stale    bool                    `bson:"stale"`
+	Flapping bool                    `bson:"flapping"`
+}
+
+type ActionRecord struct {
+	Action Action                  `bson:"action"`
+	Check  core_entities.CheckType `bson:"check"`
+	At     time.Time               `bson:"at"`
+}
+
+type HostSnapshot struct {
+	HostID       string                      `bson:"host_id"`
+	ProjectID    string                      `bson:"project_id"`
+	UnitType     core_entities.UnitType      `bson:"unit_type"`
+	Tier         byte                        `bson:"tier"`
+	Restrictions []core_entities.Restriction `bson:"restrictions"`
+	Checks       []CheckState                `bson:"checks"`
+	History      []ActionRecord              `bson:"history"`
+	Outage       string                      `bson:"outage"`
+	Now          time.Time                   `bson:"now"`
+}
+
+func (s HostSnapshot) Check(checkType core_entities.CheckType) (CheckState, bool) {
+	for _, check := range s.Checks {
+		if check.Type == checkType {
+			return check, true
+		}
+	}
+	return CheckState{}, false
+}
+
+func (s HostSnapshot) ActionsTaken(action Action, checkType core_entities.CheckType, window time.Duration) int {
+	count := 0
+	for _, record := range s.History {
+		if record.Action == action && record.Check == checkType && s.Now.Sub(record.At) <= window {
+			count++
+		}
+	}
+	return count
+}
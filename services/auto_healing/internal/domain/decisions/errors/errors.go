@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrHostNotFound     = errors.New("host not found")
+	ErrInvalidPeriod    = errors.New("invalid period")
+	ErrDecisionNotFound = errors.New("decision not found")
+)
+
+type RuleValidationError struct {
+	Rule    string
+	Field   string
+	Message string
+}
+
+func (e RuleValidationError) Error() string {
+	return fmt.Sprintf("rule %q validation error, field: %s, err: %s", e.Rule, e.Field, e.Message)
+}
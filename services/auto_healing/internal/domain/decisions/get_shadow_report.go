@@ -0,0 +1,40 @@
+package decisions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+)
+
+func (s *DecisionService) GetShadowReport(ctx context.Context, filter entities.DecisionFilter) (*entities.ShadowReport, error) {
+	until := filter.Until
+	if until.IsZero() {
+		until = s.now()
+	}
+	if filter.Since.After(until) || (!filter.Until.IsZero() && !filter.Since.Before(filter.Until)) {
+		return nil, fmt.Errorf("%w: since %s is not before until %s", errors.ErrInvalidPeriod, filter.Since, until)
+	}
+	records, err := s.decisions.ListDecisions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	filter.Until = until
+	return entities.NewShadowReport(filter, records), nil
+}
+
+func (s *DecisionService) ListDecisions(ctx context.Context, filter entities.DecisionFilter) ([]entities.DecisionRecord, error) {
+	return s.decisions.ListDecisions(ctx, filter)
+}
+
+func (s *DecisionService) GetDecision(ctx context.Context, id string) (*entities.DecisionRecord, error) {
+	record, err := s.decisions.GetDecision(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrDecisionNotFound, id)
+	}
+	return record, nil
+}
@@ -0,0 +1,122 @@
+package decisions_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/decisions"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetShadowReport", func() {
+	var (
+		ctx     context.Context
+		now     time.Time
+		service *DecisionService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+
+		hosts := memory.NewHostInfoRepository()
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock), checks.WithStaleTimeout(24*time.Hour))
+		for n, checkType := range []core_entities.CheckType{core_entities.CheckSSH, core_entities.CheckGPU, core_entities.CheckDisk} {
+			hostID := fmt.Sprintf("host-%d", n+1)
+			hosts.SetHostInfo(entities.HostInfo{HostID: hostID, ProjectID: "search", UnitType: core_entities.TypeServer})
+			_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+				{HostID: hostID, Type: checkType, Status: checkEntities.CheckStatusFailed, Timestamp: now.Add(-time.Hour)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		hosts.SetHostInfo(entities.HostInfo{HostID: "host-4", ProjectID: "web", UnitType: core_entities.TypeServer})
+
+		service = NewDomainService(hosts, checkService, memory.NewActionHistory(),
+			memory.NewDecisionRepository(), memory.NewDryRunRepository(), WithClock(clock))
+	})
+
+	decideAll := func() {
+		for n := 1; n <= 4; n++ {
+			_, err := service.DecideHost(ctx, fmt.Sprintf("host-%d", n))
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	It("should compare shadow decisions with active ones", func() {
+		gpu := entities.Rule{Name: "gpu", Check: core_entities.CheckGPU, FailingFor: 30 * time.Minute, Actions: []entities.Action{entities.ActionReportToDatacenter}}
+		Expect(service.SetShadowRules(ctx, append(entities.DefaultRules(), gpu))).To(Succeed())
+
+		decideAll()
+		now = now.Add(time.Hour)
+		decideAll()
+
+		report, err := service.GetShadowReport(ctx, entities.DecisionFilter{Since: now.Add(-2 * time.Hour)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Until).To(Equal(now))
+		Expect(report.Compared).To(Equal(8))
+		Expect(report.Matching).To(Equal(6))
+		Expect(report.Differing).To(Equal(2))
+		Expect(report.Transitions).To(Equal([]entities.ActionTransition{
+			{Active: entities.ActionReboot, Shadow: entities.ActionReboot, Count: 2},
+			{Active: entities.ActionNone, Shadow: entities.ActionReportToDatacenter, Count: 2},
+			{Active: entities.ActionReportToDatacenter, Shadow: entities.ActionReportToDatacenter, Count: 2},
+			{Active: entities.ActionNone, Shadow: entities.ActionNone, Count: 2},
+		}))
+		Expect(report.Differences).To(HaveLen(2))
+		difference := report.Differences[1]
+		Expect(difference.HostID).To(Equal("host-2"))
+		Expect(difference.Decision.Action).To(Equal(entities.ActionNone))
+		Expect(difference.Shadow.Rule).To(Equal("gpu"))
+		Expect(difference.Shadow.DryRun).To(BeTrue())
+
+		report, err = service.GetShadowReport(ctx, entities.DecisionFilter{Since: now.Add(-time.Minute), ProjectID: "search"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Compared).To(Equal(3))
+	})
+
+	It("should not compare decisions without shadow rules", func() {
+		decideAll()
+		report, err := service.GetShadowReport(ctx, entities.DecisionFilter{Since: now.Add(-time.Hour)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Compared).To(BeZero())
+		Expect(report.Transitions).To(BeEmpty())
+		Expect(service.ListDecisions(ctx, entities.DecisionFilter{})).To(HaveLen(4))
+	})
+
+	It("should persist shadow rules and load them in other instances", func() {
+		ruleSets := memory.NewRuleRepository()
+		newService := func() *DecisionService {
+			checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository())
+			return NewDomainService(memory.NewHostInfoRepository(), checkService, memory.NewActionHistory(),
+				memory.NewDecisionRepository(), memory.NewDryRunRepository(), WithRuleRepository(ruleSets))
+		}
+		service = newService()
+		replica := newService()
+
+		gpu := entities.Rule{Name: "gpu", Check: core_entities.CheckGPU, Actions: []entities.Action{entities.ActionReportToDatacenter}}
+		Expect(service.SetShadowRules(ctx, []entities.Rule{gpu})).To(Succeed())
+		Expect(ruleSets.GetRuleSet(ctx, entities.RuleSetShadow)).To(HaveField("Rules", []entities.Rule{gpu}))
+		Expect(ruleSets.GetRuleSet(ctx, entities.RuleSetActive)).To(BeNil())
+
+		Expect(replica.LoadRules(ctx)).To(Succeed())
+		Expect(replica.ShadowRules()).To(Equal([]entities.Rule{gpu}))
+		Expect(replica.Rules()).To(Equal(entities.DefaultRules()))
+	})
+
+	It("should reject invalid shadow rules and periods", func() {
+		Expect(service.SetShadowRules(ctx, []entities.Rule{{Name: "broken"}})).NotTo(Succeed())
+		Expect(service.ShadowRules()).To(BeEmpty())
+
+		_, err := service.GetShadowReport(ctx, entities.DecisionFilter{Since: now.Add(time.Hour)})
+		Expect(err).To(MatchError(errors.ErrInvalidPeriod))
+	})
+})
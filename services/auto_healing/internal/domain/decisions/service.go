@@ -0,0 +1,94 @@
+package decisions
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+const (
+	DEFAULT_DECISION_TTL          = 30 * 24 * time.Hour
+	DEFAULT_RULES_RELOAD_INTERVAL = time.Minute
+)
+
+type SetupFunc func(*DecisionService)
+
+type DecisionService struct {
+	hosts       contracts.HostInfoProvider
+	checks      contracts.CheckReader
+	history     contracts.ActionHistory
+	decisions   contracts.DecisionRepository
+	dryRun      contracts.DryRunRepository
+	outages     contracts.OutageReader
+	limits      contracts.LimitsReader
+	ruleSets    contracts.RuleRepository
+	mu          sync.RWMutex
+	rules       []entities.Rule
+	shadowRules []entities.Rule
+	decisionTTL time.Duration
+	reload      time.Duration
+	now         func() time.Time
+}
+
+func WithOutageReader(outages contracts.OutageReader) SetupFunc {
+	return func(s *DecisionService) {
+		s.outages = outages
+	}
+}
+
+func WithLimitsReader(limits contracts.LimitsReader) SetupFunc {
+	return func(s *DecisionService) {
+		s.limits = limits
+	}
+}
+
+func WithRuleRepository(ruleSets contracts.RuleRepository) SetupFunc {
+	return func(s *DecisionService) {
+		s.ruleSets = ruleSets
+	}
+}
+
+func WithRulesReloadInterval(interval time.Duration) SetupFunc {
+	return func(s *DecisionService) {
+		s.reload = interval
+	}
+}
+
+func WithDecisionTTL(ttl time.Duration) SetupFunc {
+	return func(s *DecisionService) {
+		s.decisionTTL = ttl
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *DecisionService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	hosts contracts.HostInfoProvider,
+	checks contracts.CheckReader,
+	history contracts.ActionHistory,
+	decisions contracts.DecisionRepository,
+	dryRun contracts.DryRunRepository,
+	setup ...SetupFunc,
+) *DecisionService {
+	s := &DecisionService{
+		hosts:       hosts,
+		checks:      checks,
+		history:     history,
+		decisions:   decisions,
+		dryRun:      dryRun,
+		rules:       entities.DefaultRules(),
+		decisionTTL: DEFAULT_DECISION_TTL,
+		reload:      DEFAULT_RULES_RELOAD_INTERVAL,
+		now:         time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
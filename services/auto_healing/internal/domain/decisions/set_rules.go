@@ -0,0 +1,126 @@
+package decisions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+)
+
+func (s *DecisionService) SetRules(ctx context.Context, rules []entities.Rule) error {
+	if err := s.saveRules(ctx, entities.RuleSetActive, rules); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = slices.Clone(rules)
+	return nil
+}
+
+func (s *DecisionService) Rules() []entities.Rule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.rules)
+}
+
+func (s *DecisionService) SetShadowRules(ctx context.Context, rules []entities.Rule) error {
+	if err := s.saveRules(ctx, entities.RuleSetShadow, rules); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shadowRules = slices.Clone(rules)
+	return nil
+}
+
+func (s *DecisionService) ShadowRules() []entities.Rule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.shadowRules)
+}
+
+func (s *DecisionService) ruleSnapshot() ([]entities.Rule, []entities.Rule) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rules, s.shadowRules
+}
+
+func (s *DecisionService) saveRules(ctx context.Context, kind entities.RuleSetKind, rules []entities.Rule) error {
+	if err := validateRules(rules); err != nil {
+		return err
+	}
+	if s.ruleSets == nil {
+		return nil
+	}
+	return s.ruleSets.SaveRuleSet(ctx, &entities.RuleSet{Kind: kind, Rules: rules, UpdatedAt: s.now()})
+}
+
+func (s *DecisionService) LoadRules(ctx context.Context) error {
+	if s.ruleSets == nil {
+		return nil
+	}
+	active, err := s.loadRuleSet(ctx, entities.RuleSetActive)
+	if err != nil {
+		return err
+	}
+	shadow, err := s.loadRuleSet(ctx, entities.RuleSetShadow)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if active != nil {
+		s.rules = active.Rules
+	}
+	if shadow != nil {
+		s.shadowRules = shadow.Rules
+	}
+	return nil
+}
+
+func (s *DecisionService) loadRuleSet(ctx context.Context, kind entities.RuleSetKind) (*entities.RuleSet, error) {
+	set, err := s.ruleSets.GetRuleSet(ctx, kind)
+	if err != nil || set == nil {
+		return nil, err
+	}
+	if err := validateRules(set.Rules); err != nil {
+		return nil, fmt.Errorf("stored %s rules: %w", kind, err)
+	}
+	return set, nil
+}
+
+func (s *DecisionService) RunRulesReload(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(s.reload)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := s.LoadRules(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+func validateRules(rules []entities.Rule) error {
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if names[rule.Name] {
+			return &errors.RuleValidationError{Rule: rule.Name, Field: "name", Message: fmt.Sprintf("duplicated rule %s", rule.Name)}
+		}
+		names[rule.Name] = true
+	}
+	return nil
+}
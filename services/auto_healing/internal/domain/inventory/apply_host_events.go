@@ -0,0 +1,111 @@
+package inventory
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"slices"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	decisionErrors "github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory/errors"
+)
+
+func (s *InventoryService) ApplyHostAdded(ctx context.Context, event *entities.HostAdded) error {
+	if event.State == entities.HOST_STATE_DECOMMISSIONED {
+		return s.removeHost(ctx, event.ID)
+	}
+	info, err := s.hosts.GetHostInfo(ctx, event.ID)
+	if err != nil && !stdErrors.Is(err, decisionErrors.ErrHostNotFound) {
+		return err
+	}
+	if info == nil {
+		info = &decisionEntities.HostInfo{HostID: event.ID}
+	}
+	info.FQDN = event.FQDN
+	info.UnitType = event.UnitType
+	info.ProjectID = event.ProjectID
+	info.Datacenter = event.Datacenter
+	info.Rack = event.Rack
+	if err := s.hosts.SaveHostInfo(ctx, info); err != nil {
+		return err
+	}
+
+	target, err := s.targets.GetPowerTarget(ctx, event.ID)
+	if err != nil || target == nil || target.UnitType == event.UnitType {
+		return err
+	}
+	target.UnitType = event.UnitType
+	return s.targets.SavePowerTarget(ctx, target)
+}
+
+func (s *InventoryService) ApplyHostProjectChanged(ctx context.Context, event *entities.HostProjectChanged) error {
+	return s.updateHost(ctx, event.ID, event.FQDN, func(info *decisionEntities.HostInfo) {
+		info.ProjectID = event.To
+	})
+}
+
+func (s *InventoryService) ApplyHostLocationChanged(ctx context.Context, event *entities.HostLocationChanged) error {
+	return s.updateHost(ctx, event.ID, event.FQDN, func(info *decisionEntities.HostInfo) {
+		info.Datacenter = event.Datacenter
+		info.Rack = event.Rack
+		info.Switch = event.Switch
+	})
+}
+
+func (s *InventoryService) ApplyHostRestrictionsChanged(ctx context.Context, event *entities.HostRestrictionsChanged) error {
+	return s.updateHost(ctx, event.ID, event.FQDN, func(info *decisionEntities.HostInfo) {
+		info.Restrictions = slices.Clone(event.Restrictions)
+	})
+}
+
+func (s *InventoryService) ApplyHostStateChanged(ctx context.Context, event *entities.HostStateChanged) error {
+	if event.To == entities.HOST_STATE_DECOMMISSIONED {
+		return s.removeHost(ctx, event.ID)
+	}
+	return s.updateHost(ctx, event.ID, event.FQDN, func(info *decisionEntities.HostInfo) {
+		if event.ProjectID != "" {
+			info.ProjectID = event.ProjectID
+		}
+	})
+}
+
+func (s *InventoryService) ApplyHostLabelsChanged(ctx context.Context, event *entities.HostLabelsChanged) error {
+	info, err := s.getHost(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	target, ok := entities.PowerTarget(info.HostID, info.UnitType, event.Labels)
+	if !ok {
+		return s.targets.DeletePowerTarget(ctx, info.HostID)
+	}
+	return s.targets.SavePowerTarget(ctx, target)
+}
+
+func (s *InventoryService) updateHost(ctx context.Context, hostID string, fqdn string, update func(*decisionEntities.HostInfo)) error {
+	info, err := s.getHost(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if fqdn != "" {
+		info.FQDN = fqdn
+	}
+	update(info)
+	return s.hosts.SaveHostInfo(ctx, info)
+}
+
+func (s *InventoryService) getHost(ctx context.Context, hostID string) (*decisionEntities.HostInfo, error) {
+	info, err := s.hosts.GetHostInfo(ctx, hostID)
+	if stdErrors.Is(err, decisionErrors.ErrHostNotFound) {
+		return nil, fmt.Errorf("%w: %s", errors.ErrHostNotFound, hostID)
+	}
+	return info, err
+}
+
+func (s *InventoryService) removeHost(ctx context.Context, hostID string) error {
+	if err := s.targets.DeletePowerTarget(ctx, hostID); err != nil {
+		return err
+	}
+	return s.hosts.DeleteHostInfo(ctx, hostID)
+}
@@ -0,0 +1,88 @@
+package inventory_test
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	decisionErrors "github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	. "github.com/gwall-e/auto_healing/internal/domain/inventory"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory/errors"
+	powerEntities "github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApplyHostEvents", func() {
+	var (
+		ctx     context.Context
+		hosts   *memory.HostInfoRepository
+		targets *memory.PowerTargetRepository
+		service *InventoryService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		hosts = memory.NewHostInfoRepository()
+		targets = memory.NewPowerTargetRepository()
+		service = NewDomainService(hosts, targets)
+
+		Expect(service.ApplyHostAdded(ctx, &entities.HostAdded{
+			ID: "host-1", FQDN: "host-1.search.dc1", UnitType: core_entities.TypeServer, ProjectID: "search",
+			State: "free", Datacenter: "dc1", Rack: "r1",
+		})).To(Succeed())
+	})
+
+	It("should keep host info up to date", func() {
+		Expect(service.ApplyHostProjectChanged(ctx, &entities.HostProjectChanged{ID: "host-1", From: "search", To: "web"})).To(Succeed())
+		Expect(service.ApplyHostLocationChanged(ctx, &entities.HostLocationChanged{ID: "host-1", FQDN: "host-1.web.dc2",
+			Datacenter: "dc2", Rack: "r7", Switch: "sw-7"})).To(Succeed())
+		Expect(service.ApplyHostRestrictionsChanged(ctx, &entities.HostRestrictionsChanged{ID: "host-1",
+			Restrictions: []core_entities.Restriction{core_entities.RestrictionNoRedeploy}})).To(Succeed())
+		Expect(service.ApplyHostStateChanged(ctx, &entities.HostStateChanged{ID: "host-1", ProjectID: "web", From: "free", To: "ready"})).To(Succeed())
+
+		Expect(hosts.GetHostInfo(ctx, "host-1")).To(Equal(&decisionEntities.HostInfo{
+			HostID:       "host-1",
+			FQDN:         "host-1.web.dc2",
+			ProjectID:    "web",
+			UnitType:     core_entities.TypeServer,
+			Restrictions: []core_entities.Restriction{core_entities.RestrictionNoRedeploy},
+			Datacenter:   "dc2",
+			Rack:         "r7",
+			Switch:       "sw-7",
+		}))
+	})
+
+	It("should configure power targets from host labels", func() {
+		Expect(service.ApplyHostLabelsChanged(ctx, &entities.HostLabelsChanged{ID: "host-1", Labels: map[string]string{
+			entities.LABEL_POWER_ADDRESS:  "bmc-host-1.dc1",
+			entities.LABEL_POWER_SECRET:   "bmc-dc1",
+			entities.LABEL_POWER_RESOURCE: "System.Embedded.1",
+			"team":                        "search",
+		}})).To(Succeed())
+		Expect(targets.GetPowerTarget(ctx, "host-1")).To(Equal(&powerEntities.PowerTarget{
+			HostID: "host-1", UnitType: core_entities.TypeServer, Address: "bmc-host-1.dc1", ResourceID: "System.Embedded.1", SecretID: "bmc-dc1",
+		}))
+
+		Expect(service.ApplyHostLabelsChanged(ctx, &entities.HostLabelsChanged{ID: "host-1", Labels: map[string]string{"team": "search"}})).To(Succeed())
+		Expect(targets.GetPowerTarget(ctx, "host-1")).To(BeNil())
+	})
+
+	It("should forget decommissioned hosts", func() {
+		Expect(service.ApplyHostLabelsChanged(ctx, &entities.HostLabelsChanged{ID: "host-1", Labels: map[string]string{
+			entities.LABEL_POWER_ADDRESS: "bmc-host-1.dc1",
+		}})).To(Succeed())
+		Expect(service.ApplyHostStateChanged(ctx, &entities.HostStateChanged{ID: "host-1", From: "dead", To: entities.HOST_STATE_DECOMMISSIONED})).To(Succeed())
+
+		_, err := hosts.GetHostInfo(ctx, "host-1")
+		Expect(err).To(MatchError(decisionErrors.ErrHostNotFound))
+		Expect(targets.GetPowerTarget(ctx, "host-1")).To(BeNil())
+	})
+
+	It("should reject changes of unknown hosts", func() {
+		Expect(service.ApplyHostProjectChanged(ctx, &entities.HostProjectChanged{ID: "host-2", To: "web"})).To(MatchError(errors.ErrHostNotFound))
+		Expect(service.ApplyHostLabelsChanged(ctx, &entities.HostLabelsChanged{ID: "host-2"})).To(MatchError(errors.ErrHostNotFound))
+	})
+})
@@ -0,0 +1,13 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type HostInfoRepository interface {
+	GetHostInfo(ctx context.Context, hostID string) (*entities.HostInfo, error)
+	SaveHostInfo(ctx context.Context, info *entities.HostInfo) error
+	DeleteHostInfo(ctx context.Context, hostID string) error
+}
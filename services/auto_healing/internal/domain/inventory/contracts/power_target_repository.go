@@ -0,0 +1,13 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+type PowerTargetRepository interface {
+	GetPowerTarget(ctx context.Context, hostID string) (*entities.PowerTarget, error)
+	SavePowerTarget(ctx context.Context, target *entities.PowerTarget) error
+	DeletePowerTarget(ctx context.Context, hostID string) error
+}
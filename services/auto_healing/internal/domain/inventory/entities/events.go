@@ -0,0 +1,55 @@
+package entities
+
+import "github.com/gwall-e/pkg/core_entities"
+
+type HostAdded struct {
+	ID              string                 `bson:"id"`
+	InventoryNumber string                 `bson:"inventory_number"`
+	FQDN            string                 `bson:"fqdn"`
+	UnitType        core_entities.UnitType `bson:"unit_type"`
+	ProjectID       string                 `bson:"project_id"`
+	State           string                 `bson:"state"`
+	Datacenter      string                 `bson:"datacenter"`
+	Rack            string                 `bson:"rack"`
+}
+
+type HostProjectChanged struct {
+	ID     string `bson:"id"`
+	FQDN   string `bson:"fqdn"`
+	From   string `bson:"from"`
+	To     string `bson:"to"`
+	Reason string `bson:"reason"`
+}
+
+type HostLocationChanged struct {
+	ID         string `bson:"id"`
+	FQDN       string `bson:"fqdn"`
+	Datacenter string `bson:"datacenter"`
+	Hall       string `bson:"hall"`
+	Row        string `bson:"row"`
+	Rack       string `bson:"rack"`
+	Unit       int    `bson:"unit"`
+	Switch     string `bson:"switch"`
+	Port       string `bson:"port"`
+}
+
+type HostRestrictionsChanged struct {
+	ID           string                      `bson:"id"`
+	FQDN         string                      `bson:"fqdn"`
+	Restrictions []core_entities.Restriction `bson:"restrictions"`
+}
+
+type HostLabelsChanged struct {
+	ID     string            `bson:"id"`
+	FQDN   string            `bson:"fqdn"`
+	Labels map[string]string `bson:"labels"`
+}
+
+type HostStateChanged struct {
+	ID        string `bson:"id"`
+	FQDN      string `bson:"fqdn"`
+	ProjectID string `bson:"project_id"`
+	From      string `bson:"from"`
+	To        string `bson:"to"`
+	Reason    string `bson:"reason"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	powerEntities "github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+const (
+	LABEL_POWER_ADDRESS  = "power/address"
+	LABEL_POWER_RESOURCE = "power/resource"
+	LABEL_POWER_SECRET   = "power/secret"
+
+	HOST_STATE_DECOMMISSIONED = "decommissioned"
+)
+
+func PowerTarget(hostID string, unitType core_entities.UnitType, labels map[string]string) (*powerEntities.PowerTarget, bool) {
+	address := labels[LABEL_POWER_ADDRESS]
+	if address == "" {
+		return nil, false
+	}
+	return &powerEntities.PowerTarget{
+		HostID:     hostID,
+		UnitType:   unitType,
+		Address:    address,
+		ResourceID: labels[LABEL_POWER_RESOURCE],
+		SecretID:   labels[LABEL_POWER_SECRET],
+	}, true
+}
@@ -0,0 +1,7 @@
+package errors
+
+import "errors"
+
+var (
+	ErrHostNotFound = errors.New("host is not in the inventory")
+)
@@ -0,0 +1,13 @@
+package inventory_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInventorySuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Inventory Domain Suite")
+}
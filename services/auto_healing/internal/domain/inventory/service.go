@@ -0,0 +1,12 @@
+package inventory
+
+import "github.com/gwall-e/auto_healing/internal/domain/inventory/contracts"
+
+type InventoryService struct {
+	hosts   contracts.HostInfoRepository
+	targets contracts.PowerTargetRepository
+}
+
+func NewDomainService(hosts contracts.HostInfoRepository, targets contracts.PowerTargetRepository) *InventoryService {
+	return &InventoryService{hosts: hosts, targets: targets}
+}
@@ -0,0 +1,23 @@
+package killswitch
+
+import (
+	"context"
+	"fmt"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/errors"
+)
+
+func (s *KillSwitchService) CheckAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error {
+	switches, err := s.repo.ListUnreleased(ctx)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, killSwitch := range switches {
+		if killSwitch.IsActive(now) && killSwitch.Matches(projectID, action) {
+			return fmt.Errorf("%w: %s", errors.ErrAutomationStopped, killSwitch)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+)
+
+type KillSwitchRepository interface {
+	Save(ctx context.Context, killSwitch *entities.KillSwitch) error
+	Get(ctx context.Context, id string) (*entities.KillSwitch, error)
+	ListUnreleased(ctx context.Context) ([]*entities.KillSwitch, error)
+	List(ctx context.Context, since time.Time) ([]*entities.KillSwitch, error)
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+)
+
+type NotificationSender interface {
+	Send(ctx context.Context, notification entities.Notification) error
+}
@@ -0,0 +1,7 @@
+package contracts
+
+import "context"
+
+type OwnerProvider interface {
+	GetProjectOwners(ctx context.Context, projectID string) ([]string, error)
+}
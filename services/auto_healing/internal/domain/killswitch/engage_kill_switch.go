@@ -0,0 +1,51 @@
+package killswitch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/errors"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/validators"
+)
+
+func (s *KillSwitchService) EngageKillSwitch(ctx context.Context, request entities.KillSwitchRequest) (*entities.KillSwitch, error) {
+	if err := validators.ValidateKillSwitchRequest(request, s.now()); err != nil {
+		return nil, err
+	}
+	killSwitch := entities.NewKillSwitch(uuid.NewString(), request, s.now())
+	if err := s.save(ctx, killSwitch); err != nil {
+		return nil, err
+	}
+	return killSwitch, nil
+}
+
+func (s *KillSwitchService) GetKillSwitch(ctx context.Context, id string) (*entities.KillSwitch, error) {
+	killSwitch, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if killSwitch == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrKillSwitchNotFound, id)
+	}
+	return killSwitch, nil
+}
+
+func (s *KillSwitchService) ListKillSwitches(ctx context.Context, since time.Time) ([]*entities.KillSwitch, error) {
+	return s.repo.List(ctx, since)
+}
+
+func (s *KillSwitchService) save(ctx context.Context, killSwitch *entities.KillSwitch) error {
+	if err := s.repo.Save(ctx, killSwitch); err != nil {
+		return err
+	}
+	for _, event := range killSwitch.Events() {
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	killSwitch.ClearEvents()
+	return nil
+}
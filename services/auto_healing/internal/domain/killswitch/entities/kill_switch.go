@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type Scope string
+
+const (
+	ScopeGlobal  Scope = "global"
+	ScopeProject Scope = "project"
+	ScopeAction  Scope = "action"
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeGlobal, ScopeProject, ScopeAction:
+		return true
+	}
+	return false
+}
+
+type KillSwitchRequest struct {
+	Scope     Scope     `bson:"scope"`
+	Target    string    `bson:"target"`
+	Reason    string    `bson:"reason"`
+	Author    string    `bson:"author"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+type KillSwitch struct {
+	ID         string        `bson:"_id"`
+	Scope      Scope         `bson:"scope"`
+	Target     string        `bson:"target"`
+	Reason     string        `bson:"reason"`
+	Author     string        `bson:"author"`
+	CreatedAt  time.Time     `bson:"created_at"`
+	ExpiresAt  time.Time     `bson:"expires_at"`
+	ReleasedAt *time.Time    `bson:"released_at"`
+	ReleasedBy string        `bson:"released_by"`
+	Expired    bool          `bson:"expired"`
+	events     []interface{} `bson:"-"`
+}
+
+func NewKillSwitch(id string, request KillSwitchRequest, now time.Time) *KillSwitch {
+	killSwitch := &KillSwitch{
+		ID:        id,
+		Scope:     request.Scope,
+		Target:    request.Target,
+		Reason:    request.Reason,
+		Author:    request.Author,
+		CreatedAt: now,
+		ExpiresAt: request.ExpiresAt,
+	}
+	killSwitch.addEvent(&events.KillSwitchEngagedEvent{
+		KillSwitchID: id,
+		Scope:        string(request.Scope),
+		Target:       request.Target,
+		Reason:       request.Reason,
+		Author:       request.Author,
+		ExpiresAt:    request.ExpiresAt,
+		At:           now,
+	})
+	return killSwitch
+}
+
+func (k *KillSwitch) Events() []interface{} {
+	return k.events
+}
+
+func (k *KillSwitch) ClearEvents() {
+	k.events = nil
+}
+
+func (k *KillSwitch) addEvent(event interface{}) {
+	k.events = append(k.events, event)
+}
+
+func (k *KillSwitch) IsActive(now time.Time) bool {
+	return k.ReleasedAt == nil && now.Before(k.ExpiresAt)
+}
+
+func (k *KillSwitch) Matches(projectID string, action decisionEntities.Action) bool {
+	switch k.Scope {
+	case ScopeGlobal:
+		return true
+	case ScopeProject:
+		return k.Target == projectID
+	case ScopeAction:
+		return k.Target == string(action)
+	}
+	return false
+}
+
+func (k *KillSwitch) Release(by string, now time.Time) {
+	k.release(by, false, nil, now)
+}
+
+func (k *KillSwitch) Expire(notify []string, now time.Time) {
+	k.release("", true, notify, now)
+}
+
+func (k *KillSwitch) release(by string, expired bool, notify []string, now time.Time) {
+	k.ReleasedAt = &now
+	k.ReleasedBy = by
+	k.Expired = expired
+	k.addEvent(&events.KillSwitchReleasedEvent{
+		KillSwitchID: k.ID,
+		Scope:        string(k.Scope),
+		Target:       k.Target,
+		ReleasedBy:   by,
+		Expired:      expired,
+		Notify:       slices.Clone(notify),
+		At:           now,
+	})
+}
+
+func (k *KillSwitch) ExpiryNotification(recipients []string) Notification {
+	return Notification{
+		Recipients: slices.Clone(recipients),
+		Subject:    fmt.Sprintf("Automation resumed: %s kill switch expired", k.scope()),
+		Body:       fmt.Sprintf("The %s expired at %s, automated actions run again.", k, k.ExpiresAt.Format(time.RFC3339)),
+		DedupKey:   "kill-switch-expired:" + k.ID,
+	}
+}
+
+func (k *KillSwitch) String() string {
+	return fmt.Sprintf("%s kill switch by %s until %s: %s", k.scope(), k.Author, k.ExpiresAt.Format(time.RFC3339), k.Reason)
+}
+
+func (k *KillSwitch) scope() string {
+	if k.Target == "" {
+		return string(k.Scope)
+	}
+	return fmt.Sprintf("%s %s", k.Scope, k.Target)
+}
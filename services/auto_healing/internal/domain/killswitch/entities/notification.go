@@ -0,0 +1,8 @@
+package entities
+
+type Notification struct {
+	Recipients []string
+	Subject    string
+	Body       string
+	DedupKey   string
+}
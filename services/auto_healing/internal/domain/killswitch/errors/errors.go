@@ -0,0 +1,21 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrKillSwitchNotFound = errors.New("kill switch not found")
+	ErrKillSwitchReleased = errors.New("kill switch is already released")
+	ErrAutomationStopped  = errors.New("automation is stopped by a kill switch")
+)
+
+type KillSwitchValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e KillSwitchValidationError) Error() string {
+	return fmt.Sprintf("kill switch validation error, field: %s, err: %s", e.Field, e.Message)
+}
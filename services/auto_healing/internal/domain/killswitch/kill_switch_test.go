@@ -0,0 +1,168 @@
+package killswitch_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/killswitch"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	killSwitchErrors "github.com/gwall-e/auto_healing/internal/domain/killswitch/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KillSwitchService", func() {
+	var (
+		ctx      context.Context
+		now      time.Time
+		eventLog *memory.EventLog
+		sender   *fakeSender
+		service  *KillSwitchService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		owners := memory.NewHostInfoRepository()
+		owners.SetProjectOwners("search", []string{"alice", "bob"})
+		eventLog = memory.NewEventLog()
+		sender = &fakeSender{}
+		service = NewDomainService(memory.NewKillSwitchRepository(), owners, eventLog, sender, WithClock(func() time.Time { return now }))
+	})
+
+	engage := func(scope entities.Scope, target string) *entities.KillSwitch {
+		killSwitch, err := service.EngageKillSwitch(ctx, entities.KillSwitchRequest{
+			Scope: scope, Target: target, Reason: "incident INC-42", Author: "alice", ExpiresAt: now.Add(time.Hour),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return killSwitch
+	}
+
+	DescribeTable("should stop actions within the scope of the switch",
+		func(scope entities.Scope, target string, projectID string, action decisionEntities.Action, stopped bool) {
+			engage(scope, target)
+			err := service.CheckAction(ctx, "host-1", projectID, action)
+			if stopped {
+				Expect(err).To(MatchError(killSwitchErrors.ErrAutomationStopped))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("global", entities.ScopeGlobal, "", "search", decisionEntities.ActionReboot, true),
+		Entry("same project", entities.ScopeProject, "search", "search", decisionEntities.ActionReboot, true),
+		Entry("another project", entities.ScopeProject, "search", "mail", decisionEntities.ActionReboot, false),
+		Entry("same action", entities.ScopeAction, "redeploy", "search", decisionEntities.ActionRedeploy, true),
+		Entry("another action", entities.ScopeAction, "redeploy", "search", decisionEntities.ActionReboot, false),
+	)
+
+	It("should describe the switch stopping the action", func() {
+		engage(entities.ScopeProject, "search")
+		err := service.CheckAction(ctx, "host-1", "search", decisionEntities.ActionReboot)
+		Expect(err.Error()).To(Equal("automation is stopped by a kill switch: " +
+			"project search kill switch by alice until 2026-10-01T13:00:00Z: incident INC-42"))
+	})
+
+	It("should resume automation when the switch is released", func() {
+		killSwitch := engage(entities.ScopeGlobal, "")
+		now = now.Add(time.Minute)
+
+		_, err := service.ReleaseKillSwitch(ctx, killSwitch.ID, "")
+		Expect(err).To(BeAssignableToTypeOf(&killSwitchErrors.KillSwitchValidationError{}))
+
+		released, err := service.ReleaseKillSwitch(ctx, killSwitch.ID, "bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(released.ReleasedAt).To(Equal(&now))
+		Expect(released.ReleasedBy).To(Equal("bob"))
+		Expect(service.CheckAction(ctx, "host-1", "search", decisionEntities.ActionReboot)).To(Succeed())
+
+		_, err = service.ReleaseKillSwitch(ctx, killSwitch.ID, "bob")
+		Expect(err).To(MatchError(killSwitchErrors.ErrKillSwitchReleased))
+		_, err = service.ReleaseKillSwitch(ctx, "unknown", "bob")
+		Expect(err).To(MatchError(killSwitchErrors.ErrKillSwitchNotFound))
+
+		Expect(eventLog.Events()).To(Equal([]interface{}{
+			&events.KillSwitchEngagedEvent{
+				KillSwitchID: killSwitch.ID, Scope: "global", Reason: "incident INC-42", Author: "alice",
+				ExpiresAt: killSwitch.ExpiresAt, At: killSwitch.CreatedAt,
+			},
+			&events.KillSwitchReleasedEvent{KillSwitchID: killSwitch.ID, Scope: "global", ReleasedBy: "bob", At: now},
+		}))
+	})
+
+	It("should re-enable automation on expiry and notify owners", func() {
+		project := engage(entities.ScopeProject, "search")
+		global := engage(entities.ScopeGlobal, "")
+		Expect(service.ReleaseKillSwitch(ctx, global.ID, "alice")).NotTo(BeNil())
+
+		now = now.Add(time.Hour)
+		Expect(service.CheckAction(ctx, "host-1", "search", decisionEntities.ActionReboot)).To(Succeed())
+		Expect(service.ExpireKillSwitches(ctx)).To(Succeed())
+
+		expired, err := service.GetKillSwitch(ctx, project.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired.Expired).To(BeTrue())
+		Expect(expired.ReleasedAt).To(Equal(&now))
+		Expect(eventLog.Events()).To(HaveLen(4))
+		Expect(eventLog.Events()[3]).To(Equal(&events.KillSwitchReleasedEvent{
+			KillSwitchID: project.ID, Scope: "project", Target: "search", Expired: true, Notify: []string{"alice", "bob"}, At: now,
+		}))
+
+		Expect(sender.Sent()).To(Equal([]entities.Notification{{
+			Recipients: []string{"alice", "bob"},
+			Subject:    "Automation resumed: project search kill switch expired",
+			Body: "The project search kill switch by alice until 2026-10-01T13:00:00Z: incident INC-42 expired at " +
+				"2026-10-01T13:00:00Z, automated actions run again.",
+			DedupKey: "kill-switch-expired:" + project.ID,
+		}}))
+
+		Expect(service.ExpireKillSwitches(ctx)).To(Succeed())
+		Expect(eventLog.Events()).To(HaveLen(4))
+		Expect(sender.Sent()).To(HaveLen(1))
+		Expect(service.ListKillSwitches(ctx, now)).To(HaveLen(1))
+	})
+
+	It("should notify on expiry again when sending failed", func() {
+		killSwitch := engage(entities.ScopeGlobal, "")
+		now = now.Add(time.Hour)
+		sender.err = errSendFailed
+
+		Expect(service.ExpireKillSwitches(ctx)).To(MatchError(errSendFailed))
+		stored, err := service.GetKillSwitch(ctx, killSwitch.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.ReleasedAt).To(BeNil())
+		Expect(service.CheckAction(ctx, "host-1", "search", decisionEntities.ActionReboot)).To(Succeed())
+
+		sender.err = nil
+		Expect(service.ExpireKillSwitches(ctx)).To(Succeed())
+		Expect(sender.Sent()).To(HaveLen(1))
+		Expect(sender.Sent()[0].Recipients).To(Equal([]string{"alice"}))
+		stored, err = service.GetKillSwitch(ctx, killSwitch.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Expired).To(BeTrue())
+	})
+
+	DescribeTable("should validate kill switches",
+		func(mutate func(request *entities.KillSwitchRequest), field string) {
+			request := entities.KillSwitchRequest{
+				Scope: entities.ScopeProject, Target: "search", Reason: "incident", Author: "alice", ExpiresAt: now.Add(time.Hour),
+			}
+			mutate(&request)
+			_, err := service.EngageKillSwitch(ctx, request)
+			var validationErr *killSwitchErrors.KillSwitchValidationError
+			Expect(err).To(BeAssignableToTypeOf(validationErr))
+			Expect(err.(*killSwitchErrors.KillSwitchValidationError).Field).To(Equal(field))
+		},
+		Entry("unknown scope", func(r *entities.KillSwitchRequest) { r.Scope = "datacenter" }, "scope"),
+		Entry("global with target", func(r *entities.KillSwitchRequest) { r.Scope = entities.ScopeGlobal }, "target"),
+		Entry("project without target", func(r *entities.KillSwitchRequest) { r.Target = "" }, "target"),
+		Entry("unknown action", func(r *entities.KillSwitchRequest) { r.Scope = entities.ScopeAction }, "target"),
+		Entry("wait action", func(r *entities.KillSwitchRequest) { r.Scope, r.Target = entities.ScopeAction, "wait" }, "target"),
+		Entry("no reason", func(r *entities.KillSwitchRequest) { r.Reason = "" }, "reason"),
+		Entry("no author", func(r *entities.KillSwitchRequest) { r.Author = "" }, "author"),
+		Entry("expired", func(r *entities.KillSwitchRequest) { r.ExpiresAt = now }, "expires_at"),
+		Entry("too long", func(r *entities.KillSwitchRequest) { r.ExpiresAt = now.Add(8 * 24 * time.Hour) }, "expires_at"),
+	)
+})
@@ -0,0 +1,41 @@
+package killswitch_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestKillSwitchSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kill Switch Domain Suite")
+}
+
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []entities.Notification
+	err  error
+}
+
+func (s *fakeSender) Send(ctx context.Context, notification entities.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, notification)
+	return nil
+}
+
+func (s *fakeSender) Sent() []entities.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entities.Notification{}, s.sent...)
+}
+
+var errSendFailed = errors.New("webhook unavailable")
@@ -0,0 +1,89 @@
+package killswitch
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/errors"
+)
+
+func (s *KillSwitchService) ReleaseKillSwitch(ctx context.Context, id string, by string) (*entities.KillSwitch, error) {
+	if by == "" {
+		return nil, &errors.KillSwitchValidationError{Field: "released_by", Message: "released_by is required"}
+	}
+	killSwitch, err := s.GetKillSwitch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if killSwitch.ReleasedAt != nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrKillSwitchReleased, id)
+	}
+	killSwitch.Release(by, s.now())
+	if err := s.save(ctx, killSwitch); err != nil {
+		return nil, err
+	}
+	return killSwitch, nil
+}
+
+func (s *KillSwitchService) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(s.expireInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ExpireKillSwitches(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *KillSwitchService) ExpireKillSwitches(ctx context.Context) error {
+	switches, err := s.repo.ListUnreleased(ctx)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	var errs []error
+	for _, killSwitch := range switches {
+		if killSwitch.IsActive(now) {
+			continue
+		}
+		notify, err := s.notifyOnExpiry(ctx, killSwitch)
+		if err == nil {
+			err = s.notifier.Send(ctx, killSwitch.ExpiryNotification(notify))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("kill switch %s: %w", killSwitch.ID, err))
+			continue
+		}
+		killSwitch.Expire(notify, now)
+		if err := s.save(ctx, killSwitch); err != nil {
+			errs = append(errs, fmt.Errorf("kill switch %s: %w", killSwitch.ID, err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+func (s *KillSwitchService) notifyOnExpiry(ctx context.Context, killSwitch *entities.KillSwitch) ([]string, error) {
+	notify := []string{killSwitch.Author}
+	if killSwitch.Scope == entities.ScopeProject {
+		owners, err := s.owners.GetProjectOwners(ctx, killSwitch.Target)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range owners {
+			if !slices.Contains(notify, owner) {
+				notify = append(notify, owner)
+			}
+		}
+	}
+	return notify, nil
+}
@@ -0,0 +1,53 @@
+package killswitch
+
+import (
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/contracts"
+)
+
+const DEFAULT_EXPIRE_INTERVAL = time.Minute
+
+type SetupFunc func(*KillSwitchService)
+
+type KillSwitchService struct {
+	repo           contracts.KillSwitchRepository
+	owners         contracts.OwnerProvider
+	publisher      contracts.EventPublisher
+	notifier       contracts.NotificationSender
+	expireInterval time.Duration
+	now            func() time.Time
+}
+
+func WithExpireInterval(interval time.Duration) SetupFunc {
+	return func(s *KillSwitchService) {
+		s.expireInterval = interval
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *KillSwitchService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	repo contracts.KillSwitchRepository,
+	owners contracts.OwnerProvider,
+	publisher contracts.EventPublisher,
+	notifier contracts.NotificationSender,
+	setup ...SetupFunc,
+) *KillSwitchService {
+	s := &KillSwitchService{
+		repo:           repo,
+		owners:         owners,
+		publisher:      publisher,
+		notifier:       notifier,
+		expireInterval: DEFAULT_EXPIRE_INTERVAL,
+		now:            time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package validators
+
+import (
+	"fmt"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/errors"
+)
+
+const (
+	MAX_REASON_LENGTH = 1024
+	MAX_DURATION      = 7 * 24 * time.Hour
+)
+
+func ValidateKillSwitchRequest(request entities.KillSwitchRequest, now time.Time) error {
+	if !request.Scope.IsValid() {
+		return &errors.KillSwitchValidationError{Field: "scope", Message: fmt.Sprintf("unknown scope %q", request.Scope)}
+	}
+	switch request.Scope {
+	case entities.ScopeGlobal:
+		if request.Target != "" {
+			return &errors.KillSwitchValidationError{Field: "target", Message: "global kill switch has no target"}
+		}
+	case entities.ScopeProject:
+		if request.Target == "" {
+			return &errors.KillSwitchValidationError{Field: "target", Message: "project is required"}
+		}
+	case entities.ScopeAction:
+		action := decisionEntities.Action(request.Target)
+		if !action.IsValid() || action == decisionEntities.ActionNone || action == decisionEntities.ActionWait {
+			return &errors.KillSwitchValidationError{Field: "target", Message: fmt.Sprintf("unknown action %q", request.Target)}
+		}
+	}
+	if request.Reason == "" || len(request.Reason) > MAX_REASON_LENGTH {
+		return &errors.KillSwitchValidationError{
+			Field:   "reason",
+			Message: fmt.Sprintf("reason is required and must be at most %d characters", MAX_REASON_LENGTH),
+		}
+	}
+	if request.Author == "" {
+		return &errors.KillSwitchValidationError{Field: "author", Message: "author is required"}
+	}
+	if !request.ExpiresAt.After(now) || request.ExpiresAt.Sub(now) > MAX_DURATION {
+		return &errors.KillSwitchValidationError{
+			Field:   "expires_at",
+			Message: fmt.Sprintf("must be in the future and at most %s ahead", MAX_DURATION),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+)
+
+type AutomationRepository interface {
+	GetProject(ctx context.Context, projectID string) (*entities.ProjectAutomation, error)
+	SaveProject(ctx context.Context, project *entities.ProjectAutomation) error
+	ListDisabledProjects(ctx context.Context) ([]*entities.ProjectAutomation, error)
+	CreateAction(ctx context.Context, action *entities.AutomatedAction, quotas []entities.ActionQuota) (bool, error)
+	GetAction(ctx context.Context, id string) (*entities.AutomatedAction, error)
+	FinishAction(ctx context.Context, id string, at time.Time) error
+	GetInFlightAction(ctx context.Context, hostID string) (*entities.AutomatedAction, error)
+	CountActions(ctx context.Context, projectID string, since time.Time) (int, error)
+	CountInFlight(ctx context.Context, projectID string) (int, error)
+}
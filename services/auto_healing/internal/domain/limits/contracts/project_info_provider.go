@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+)
+
+type ProjectInfoProvider interface {
+	GetProjectInfo(ctx context.Context, projectID string) (*entities.ProjectInfo, error)
+}
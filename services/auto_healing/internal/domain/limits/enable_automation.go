@@ -0,0 +1,20 @@
+package limits
+
+import (
+	"context"
+)
+
+func (s *LimitService) EnableAutomation(ctx context.Context, projectID string, by string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.projects.GetProjectInfo(ctx, projectID); err != nil {
+		return err
+	}
+	project, err := s.getProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	project.Enable(by, s.now())
+	return s.repo.SaveProject(ctx, project)
+}
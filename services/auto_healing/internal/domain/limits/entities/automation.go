@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type ProjectAutomation struct {
+	ProjectID  string    `bson:"_id"`
+	Disabled   bool      `bson:"disabled"`
+	Reason     string    `bson:"reason"`
+	DisabledAt time.Time `bson:"disabled_at"`
+	EnabledAt  time.Time `bson:"enabled_at"`
+	EnabledBy  string    `bson:"enabled_by"`
+	Limits     *Limits   `bson:"limits"`
+}
+
+func NewProjectAutomation(projectID string) *ProjectAutomation {
+	return &ProjectAutomation{ProjectID: projectID}
+}
+
+func (p *ProjectAutomation) Disable(reason string, now time.Time) {
+	p.Disabled = true
+	p.Reason = reason
+	p.DisabledAt = now
+}
+
+func (p *ProjectAutomation) Enable(by string, now time.Time) {
+	p.Disabled = false
+	p.Reason = ""
+	p.EnabledAt = now
+	p.EnabledBy = by
+}
+
+func (p *ProjectAutomation) EffectiveLimits(tier byte) Limits {
+	if p.Limits != nil {
+		return *p.Limits
+	}
+	return DefaultLimits(tier)
+}
+
+func (p *ProjectAutomation) WindowStart(window time.Duration, now time.Time) time.Time {
+	start := now.Add(-window)
+	if p.EnabledAt.After(start) {
+		return p.EnabledAt
+	}
+	return start
+}
+
+type AutomatedAction struct {
+	ID         string                  `bson:"_id"`
+	HostID     string                  `bson:"host_id"`
+	ProjectID  string                  `bson:"project_id"`
+	Action     decisionEntities.Action `bson:"action"`
+	StartedAt  time.Time               `bson:"started_at"`
+	FinishedAt *time.Time              `bson:"finished_at"`
+}
+
+func (a *AutomatedAction) InFlight() bool {
+	return a.FinishedAt == nil
+}
+
+type ProjectInfo struct {
+	ProjectID string `bson:"_id"`
+	Tier      byte   `bson:"tier"`
+	Hosts     int    `bson:"hosts"`
+}
+
+type ProjectStatus struct {
+	ProjectID   string    `bson:"project_id"`
+	Disabled    bool      `bson:"disabled"`
+	Reason      string    `bson:"reason"`
+	DisabledAt  time.Time `bson:"disabled_at"`
+	EnabledAt   time.Time `bson:"enabled_at"`
+	EnabledBy   string    `bson:"enabled_by"`
+	Limits      Limits    `bson:"limits"`
+	Overridden  bool      `bson:"overridden"`
+	Hosts       int       `bson:"hosts"`
+	MaxInFlight int       `bson:"max_in_flight"`
+	InFlight    int       `bson:"in_flight"`
+	Actions     int       `bson:"actions"`
+}
@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+type Limits struct {
+	MaxActions         int           `bson:"max_actions"`
+	Window             time.Duration `bson:"window"`
+	MaxInFlightPercent int           `bson:"max_in_flight_percent"`
+}
+
+func (l Limits) MaxInFlight(hosts int) int {
+	return max(1, hosts*l.MaxInFlightPercent/100)
+}
+
+type GlobalLimits struct {
+	MaxActions  int           `bson:"max_actions"`
+	Window      time.Duration `bson:"window"`
+	MaxInFlight int           `bson:"max_in_flight"`
+}
+
+type ActionQuota struct {
+	ProjectID   string
+	Since       time.Time
+	MaxActions  int
+	MaxInFlight int
+}
+
+var TIER_LIMITS = []Limits{
+	{MaxActions: 2, Window: time.Hour, MaxInFlightPercent: 5},
+	{MaxActions: 5, Window: time.Hour, MaxInFlightPercent: 10},
+	{MaxActions: 10, Window: time.Hour, MaxInFlightPercent: 20},
+	{MaxActions: 20, Window: time.Hour, MaxInFlightPercent: 30},
+}
+
+var DEFAULT_GLOBAL_LIMITS = GlobalLimits{MaxActions: 200, Window: time.Hour, MaxInFlight: 50}
+
+func DefaultLimits(tier byte) Limits {
+	return TIER_LIMITS[min(int(tier), len(TIER_LIMITS)-1)]
+}
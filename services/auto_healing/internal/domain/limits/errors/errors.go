@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrActionNotFound       = errors.New("automated action not found")
+	ErrAutomationDisabled   = errors.New("automation is disabled for project")
+	ErrProjectLimitExceeded = errors.New("automation limit of project exceeded")
+	ErrGlobalLimitExceeded  = errors.New("global automation limit exceeded")
+	ErrProjectInFlightLimit = errors.New("too many hosts of project in automation")
+	ErrHostInAutomation     = errors.New("host is already in automation")
+	ErrActionConflict       = errors.New("automation limits were concurrently used too many times")
+)
+
+type LimitsValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e LimitsValidationError) Error() string {
+	return fmt.Sprintf("limits validation error, field: %s, err: %s", e.Field, e.Message)
+}
@@ -0,0 +1,73 @@
+package limits
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+)
+
+func (s *LimitService) GetProjectStatus(ctx context.Context, projectID string) (*entities.ProjectStatus, error) {
+	project, err := s.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.projectStatus(ctx, project)
+}
+
+func (s *LimitService) ListDisabledProjects(ctx context.Context) ([]*entities.ProjectStatus, error) {
+	projects, err := s.repo.ListDisabledProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*entities.ProjectStatus, 0, len(projects))
+	for _, project := range projects {
+		status, err := s.projectStatus(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *LimitService) getProject(ctx context.Context, projectID string) (*entities.ProjectAutomation, error) {
+	project, err := s.repo.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		project = entities.NewProjectAutomation(projectID)
+	}
+	return project, nil
+}
+
+func (s *LimitService) projectStatus(ctx context.Context, project *entities.ProjectAutomation) (*entities.ProjectStatus, error) {
+	info, err := s.projects.GetProjectInfo(ctx, project.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	limits := project.EffectiveLimits(info.Tier)
+	actions, err := s.repo.CountActions(ctx, project.ProjectID, project.WindowStart(limits.Window, s.now()))
+	if err != nil {
+		return nil, err
+	}
+	inFlight, err := s.repo.CountInFlight(ctx, project.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ProjectStatus{
+		ProjectID:   project.ProjectID,
+		Disabled:    project.Disabled,
+		Reason:      project.Reason,
+		DisabledAt:  project.DisabledAt,
+		EnabledAt:   project.EnabledAt,
+		EnabledBy:   project.EnabledBy,
+		Limits:      limits,
+		Overridden:  project.Limits != nil,
+		Hosts:       info.Hosts,
+		MaxInFlight: limits.MaxInFlight(info.Hosts),
+		InFlight:    inFlight,
+		Actions:     actions,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package limits_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLimitsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Limits Domain Suite")
+}
@@ -0,0 +1,50 @@
+package limits
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+)
+
+const MAX_START_ATTEMPTS = 5
+
+type SetupFunc func(*LimitService)
+
+type LimitService struct {
+	repo     contracts.AutomationRepository
+	projects contracts.ProjectInfoProvider
+	global   entities.GlobalLimits
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+func WithGlobalLimits(limits entities.GlobalLimits) SetupFunc {
+	return func(s *LimitService) {
+		s.global = limits
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *LimitService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(repo contracts.AutomationRepository, projects contracts.ProjectInfoProvider, setup ...SetupFunc) *LimitService {
+	s := &LimitService{
+		repo:     repo,
+		projects: projects,
+		global:   entities.DEFAULT_GLOBAL_LIMITS,
+		now:      time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
+
+func (s *LimitService) GlobalLimits() entities.GlobalLimits {
+	return s.global
+}
@@ -0,0 +1,29 @@
+package limits
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/validators"
+)
+
+func (s *LimitService) SetProjectLimits(ctx context.Context, projectID string, limits *entities.Limits) error {
+	if limits != nil {
+		if err := validators.ValidateLimits(*limits); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.projects.GetProjectInfo(ctx, projectID); err != nil {
+		return err
+	}
+	project, err := s.getProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	project.Limits = limits
+	return s.repo.SaveProject(ctx, project)
+}
@@ -0,0 +1,115 @@
+package limits
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+)
+
+func (s *LimitService) StartAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) (*entities.AutomatedAction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for attempt := 0; attempt < MAX_START_ATTEMPTS; attempt++ {
+		automated, quotas, err := s.admitAction(ctx, hostID, projectID, action)
+		if err != nil {
+			return nil, err
+		}
+		created, err := s.repo.CreateAction(ctx, automated, quotas)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return automated, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s of host %s", errors.ErrActionConflict, action, hostID)
+}
+
+func (s *LimitService) admitAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) (*entities.AutomatedAction, []entities.ActionQuota, error) {
+	project, err := s.getProject(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if project.Disabled {
+		return nil, nil, fmt.Errorf("%w %s: %s", errors.ErrAutomationDisabled, projectID, project.Reason)
+	}
+
+	inFlight, err := s.repo.GetInFlightAction(ctx, hostID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inFlight != nil {
+		return nil, nil, fmt.Errorf("%w: %s of host %s started at %s", errors.ErrHostInAutomation, inFlight.Action, hostID, inFlight.StartedAt)
+	}
+
+	now := s.now()
+	if err := s.checkGlobalLimits(ctx); err != nil {
+		return nil, nil, err
+	}
+	status, err := s.projectStatus(ctx, project)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status.InFlight >= status.MaxInFlight {
+		return nil, nil, fmt.Errorf("%w %s: %d of %d hosts in automation reached the limit of %d%%", errors.ErrProjectInFlightLimit,
+			projectID, status.InFlight, status.Hosts, status.Limits.MaxInFlightPercent)
+	}
+	if status.Actions >= status.Limits.MaxActions {
+		reason := fmt.Sprintf("%d actions within %s reached the limit of %d", status.Actions, status.Limits.Window, status.Limits.MaxActions)
+		project.Disable(reason, now)
+		if err := s.repo.SaveProject(ctx, project); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%w %s: %s", errors.ErrProjectLimitExceeded, projectID, reason)
+	}
+
+	automated := &entities.AutomatedAction{
+		ID:        uuid.NewString(),
+		HostID:    hostID,
+		ProjectID: projectID,
+		Action:    action,
+		StartedAt: now,
+	}
+	quotas := []entities.ActionQuota{
+		{Since: now.Add(-s.global.Window), MaxActions: s.global.MaxActions, MaxInFlight: s.global.MaxInFlight},
+		{ProjectID: projectID, Since: project.WindowStart(status.Limits.Window, now), MaxActions: status.Limits.MaxActions, MaxInFlight: status.MaxInFlight},
+	}
+	return automated, quotas, nil
+}
+
+func (s *LimitService) FinishAction(ctx context.Context, id string) error {
+	action, err := s.repo.GetAction(ctx, id)
+	if err != nil {
+		return err
+	}
+	if action == nil {
+		return fmt.Errorf("%w: %s", errors.ErrActionNotFound, id)
+	}
+	if !action.InFlight() {
+		return nil
+	}
+	return s.repo.FinishAction(ctx, id, s.now())
+}
+
+func (s *LimitService) checkGlobalLimits(ctx context.Context) error {
+	inFlight, err := s.repo.CountInFlight(ctx, "")
+	if err != nil {
+		return err
+	}
+	if inFlight >= s.global.MaxInFlight {
+		return fmt.Errorf("%w: %d hosts in automation, max %d", errors.ErrGlobalLimitExceeded, inFlight, s.global.MaxInFlight)
+	}
+	actions, err := s.repo.CountActions(ctx, "", s.now().Add(-s.global.Window))
+	if err != nil {
+		return err
+	}
+	if actions >= s.global.MaxActions {
+		return fmt.Errorf("%w: %d actions within %s, max %d", errors.ErrGlobalLimitExceeded, actions, s.global.Window, s.global.MaxActions)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package limits_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/limits"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	limitErrors "github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type racingRepository struct {
+	*memory.AutomationRepository
+	beforeCreate func()
+}
+
+func (r *racingRepository) CreateAction(ctx context.Context, action *entities.AutomatedAction, quotas []entities.ActionQuota) (bool, error) {
+	if fn := r.beforeCreate; fn != nil {
+		r.beforeCreate = nil
+		fn()
+	}
+	return r.AutomationRepository.CreateAction(ctx, action, quotas)
+}
+
+var _ = Describe("LimitService", func() {
+	var (
+		ctx     context.Context
+		now     time.Time
+		hosts   *memory.HostInfoRepository
+		service *LimitService
+	)
+
+	addHosts := func(projectID string, tier byte, count int) {
+		for n := 1; n <= count; n++ {
+			hosts.SetHostInfo(decisionEntities.HostInfo{
+				HostID:    fmt.Sprintf("%s-%02d", projectID, n),
+				ProjectID: projectID,
+				UnitType:  core_entities.TypeServer,
+				Tier:      tier,
+			})
+		}
+	}
+
+	start := func(projectID string, n int) (*entities.AutomatedAction, error) {
+		return service.StartAction(ctx, fmt.Sprintf("%s-%02d", projectID, n), projectID, decisionEntities.ActionReboot)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		hosts = memory.NewHostInfoRepository()
+		addHosts("search", 1, 20)
+		addHosts("web", 3, 40)
+		service = NewDomainService(memory.NewAutomationRepository(), hosts,
+			WithClock(func() time.Time { return now }),
+			WithGlobalLimits(entities.GlobalLimits{MaxActions: 10, Window: time.Hour, MaxInFlight: 8}),
+		)
+	})
+
+	It("should default limits by project tier", func() {
+		status, err := service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(&entities.ProjectStatus{
+			ProjectID:   "search",
+			Limits:      entities.Limits{MaxActions: 5, Window: time.Hour, MaxInFlightPercent: 10},
+			Hosts:       20,
+			MaxInFlight: 2,
+		}))
+
+		Expect(entities.DefaultLimits(0)).To(Equal(entities.TIER_LIMITS[0]))
+		Expect(entities.DefaultLimits(200)).To(Equal(entities.TIER_LIMITS[3]))
+		Expect(entities.TIER_LIMITS[0].MaxInFlight(3)).To(Equal(1))
+	})
+
+	It("should postpone actions when too many hosts are in automation", func() {
+		first, err := start("search", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.StartedAt).To(Equal(now))
+		_, err = start("search", 2)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = start("search", 3)
+		Expect(err).To(MatchError(limitErrors.ErrProjectInFlightLimit))
+		Expect(err).To(MatchError(ContainSubstring("2 of 20 hosts in automation reached the limit of 10%")))
+
+		status, err := service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Disabled).To(BeFalse())
+		Expect(status.InFlight).To(Equal(2))
+
+		Expect(service.FinishAction(ctx, first.ID)).To(Succeed())
+		_, err = start("search", 3)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should disable automation when too many actions are started within the window", func() {
+		for n := 1; n <= 5; n++ {
+			action, err := start("search", n)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.FinishAction(ctx, action.ID)).To(Succeed())
+			now = now.Add(10 * time.Minute)
+		}
+		_, err := start("search", 6)
+		Expect(err).To(MatchError(ContainSubstring("5 actions within 1h0m0s reached the limit of 5")))
+
+		disabled, err := service.ListDisabledProjects(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(disabled).To(HaveLen(1))
+		Expect(disabled[0].ProjectID).To(Equal("search"))
+	})
+
+	It("should forgive actions started before automation is enabled again", func() {
+		Expect(service.SetProjectLimits(ctx, "search", &entities.Limits{MaxActions: 1, Window: 24 * time.Hour, MaxInFlightPercent: 50})).To(Succeed())
+		action, err := start("search", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service.FinishAction(ctx, action.ID)).To(Succeed())
+		_, err = start("search", 2)
+		Expect(err).To(MatchError(limitErrors.ErrProjectLimitExceeded))
+
+		now = now.Add(time.Minute)
+		Expect(service.EnableAutomation(ctx, "search", "alice")).To(Succeed())
+		status, err := service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Disabled).To(BeFalse())
+		Expect(status.EnabledBy).To(Equal("alice"))
+		Expect(status.Actions).To(Equal(0))
+
+		_, err = start("search", 2)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should postpone actions over global limits without disabling projects", func() {
+		for n := 1; n <= 8; n++ {
+			_, err := start("web", n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := start("search", 1)
+		Expect(err).To(MatchError(limitErrors.ErrGlobalLimitExceeded))
+
+		status, err := service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Disabled).To(BeFalse())
+	})
+
+	It("should recheck limits when another replica took the quota first", func() {
+		repo := &racingRepository{AutomationRepository: memory.NewAutomationRepository()}
+		clock := WithClock(func() time.Time { return now })
+		service = NewDomainService(repo, hosts, clock)
+		replica := NewDomainService(repo, hosts, clock)
+
+		_, err := start("search", 1)
+		Expect(err).NotTo(HaveOccurred())
+		repo.beforeCreate = func() {
+			_, err := replica.StartAction(ctx, "search-02", "search", decisionEntities.ActionReboot)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err = start("search", 3)
+		Expect(err).To(MatchError(limitErrors.ErrProjectInFlightLimit))
+
+		status, err := service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.InFlight).To(Equal(2))
+	})
+
+	It("should not start a second action on a host in automation", func() {
+		_, err := start("web", 1)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = start("web", 1)
+		Expect(err).To(MatchError(limitErrors.ErrHostInAutomation))
+	})
+
+	It("should override and restore project limits", func() {
+		var validationErr *limitErrors.LimitsValidationError
+		err := service.SetProjectLimits(ctx, "search", &entities.Limits{MaxActions: 1, Window: time.Hour, MaxInFlightPercent: 101})
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		Expect(validationErr.Field).To(Equal("max_in_flight_percent"))
+
+		limits := entities.Limits{MaxActions: 1, Window: time.Hour, MaxInFlightPercent: 50}
+		Expect(service.SetProjectLimits(ctx, "search", &limits)).To(Succeed())
+		status, err := service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Overridden).To(BeTrue())
+		Expect(status.MaxInFlight).To(Equal(10))
+
+		Expect(service.SetProjectLimits(ctx, "search", nil)).To(Succeed())
+		status, err = service.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Overridden).To(BeFalse())
+		Expect(status.Limits).To(Equal(entities.DefaultLimits(1)))
+	})
+
+	It("should report unknown projects and actions", func() {
+		_, err := service.GetProjectStatus(ctx, "unknown")
+		Expect(err).To(MatchError(limitErrors.ErrProjectNotFound))
+		Expect(service.EnableAutomation(ctx, "unknown", "alice")).To(MatchError(limitErrors.ErrProjectNotFound))
+		Expect(service.FinishAction(ctx, "unknown")).To(MatchError(limitErrors.ErrActionNotFound))
+	})
+})
@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+)
+
+func ValidateLimits(limits entities.Limits) error {
+	if limits.MaxActions < 1 {
+		return &errors.LimitsValidationError{Field: "max_actions", Message: "must be positive"}
+	}
+	if limits.Window <= 0 {
+		return &errors.LimitsValidationError{Field: "window", Message: "must be positive"}
+	}
+	if limits.MaxInFlightPercent < 1 || limits.MaxInFlightPercent > 100 {
+		return &errors.LimitsValidationError{Field: "max_in_flight_percent", Message: "must be between 1 and 100"}
+	}
+	return nil
+}
+
+func ValidateGlobalLimits(limits entities.GlobalLimits) error {
+	if limits.MaxActions < 1 {
+		return &errors.LimitsValidationError{Field: "max_actions", Message: "must be positive"}
+	}
+	if limits.Window <= 0 {
+		return &errors.LimitsValidationError{Field: "window", Message: "must be positive"}
+	}
+	if limits.MaxInFlight < 1 {
+		return &errors.LimitsValidationError{Field: "max_in_flight", Message: "must be positive"}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+)
+
+type CheckReporter interface {
+	IngestResults(ctx context.Context, results []checkEntities.CheckResult) (*checks.IngestReport, error)
+}
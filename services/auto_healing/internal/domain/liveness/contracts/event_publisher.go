@@ -0,0 +1,7 @@
+package contracts
+
+import "context"
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event interface{}) error
+}
@@ -0,0 +1,15 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+type LivenessRepository interface {
+	GetHost(ctx context.Context, hostID string) (*entities.HostLiveness, error)
+	SaveHost(ctx context.Context, host *entities.HostLiveness) error
+	ListSilent(ctx context.Context, before time.Time) ([]*entities.HostLiveness, error)
+	ListUnreachable(ctx context.Context) ([]*entities.HostLiveness, error)
+}
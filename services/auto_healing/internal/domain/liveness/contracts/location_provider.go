@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+type LocationProvider interface {
+	GetHostLocation(ctx context.Context, hostID string) (*entities.HostLocation, error)
+	CountHosts(ctx context.Context, domain entities.FailureDomain) (int, error)
+}
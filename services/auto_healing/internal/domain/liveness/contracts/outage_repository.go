@@ -0,0 +1,15 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+type OutageRepository interface {
+	SaveOutage(ctx context.Context, outage *entities.Outage) error
+	GetOutage(ctx context.Context, id string) (*entities.Outage, error)
+	ListOutages(ctx context.Context, since time.Time) ([]*entities.Outage, error)
+	ListActiveOutages(ctx context.Context) ([]*entities.Outage, error)
+}
@@ -0,0 +1,189 @@
+package liveness
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+func (s *LivenessService) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(s.detectInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.DetectUnreachable(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *LivenessService) DetectUnreachable(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	silent, err := s.repo.ListSilent(ctx, now.Add(-s.silenceTimeout))
+	if err != nil {
+		return err
+	}
+	unreachable, err := s.repo.ListUnreachable(ctx)
+	if err != nil {
+		return err
+	}
+	for _, host := range silent {
+		host.UnreachableSince = &now
+	}
+
+	hostOutages, err := s.updateOutages(ctx, append(unreachable, silent...), now)
+	if err != nil {
+		return err
+	}
+
+	results := []checkEntities.CheckResult{}
+	for _, host := range silent {
+		host.MarkUnreachable(now, hostOutages[host.HostID])
+		host.ReportedAt = now
+		results = append(results, unreachableResult(host, checkEntities.CheckStatusFailed, now))
+		if err := s.saveHost(ctx, host); err != nil {
+			return err
+		}
+	}
+	for _, host := range unreachable {
+		changed := host.SetOutage(hostOutages[host.HostID])
+		if now.Sub(host.ReportedAt) >= s.reportInterval {
+			host.ReportedAt = now
+			results = append(results, unreachableResult(host, checkEntities.CheckStatusFailed, now))
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if err := s.saveHost(ctx, host); err != nil {
+			return err
+		}
+	}
+	return s.reportChecks(ctx, results)
+}
+
+func (s *LivenessService) updateOutages(ctx context.Context, unreachable []*entities.HostLiveness, now time.Time) (map[string]string, error) {
+	byDomain := map[entities.FailureDomain][]*entities.HostLiveness{}
+	for _, host := range unreachable {
+		location, err := s.locations.GetHostLocation(ctx, host.HostID)
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			continue
+		}
+		for _, domain := range location.FailureDomains() {
+			byDomain[domain] = append(byDomain[domain], host)
+		}
+	}
+
+	hostOutages := map[string]string{}
+	active, err := s.outages.ListActiveOutages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(active, func(i, j int) bool { return domainBefore(active[i].Domain, active[j].Domain) })
+	ongoing := map[entities.FailureDomain]bool{}
+	for _, outage := range active {
+		hosts := byDomain[outage.Domain]
+		total, err := s.locations.CountHosts(ctx, outage.Domain)
+		if err != nil {
+			return nil, err
+		}
+		if !s.thresholds.Reached(len(hosts), total) {
+			outage.End(now)
+		} else {
+			ongoing[outage.Domain] = true
+			assignOutage(hostOutages, outage.ID, hosts)
+			if !outage.SetHosts(hostIDs(hosts), total) {
+				continue
+			}
+		}
+		if err := s.saveOutage(ctx, outage); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, domain := range sortedDomains(byDomain) {
+		if ongoing[domain] {
+			continue
+		}
+		candidates := []*entities.HostLiveness{}
+		for _, host := range byDomain[domain] {
+			if hostOutages[host.HostID] == "" {
+				candidates = append(candidates, host)
+			}
+		}
+		hosts := s.thresholds.SilentTogether(candidates)
+		total, err := s.locations.CountHosts(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		if !s.thresholds.Reached(len(hosts), total) {
+			continue
+		}
+		outage := entities.NewOutage(uuid.NewString(), domain, hostIDs(hosts), total, now)
+		if err := s.saveOutage(ctx, outage); err != nil {
+			return nil, err
+		}
+		assignOutage(hostOutages, outage.ID, hosts)
+	}
+	return hostOutages, nil
+}
+
+func (s *LivenessService) saveOutage(ctx context.Context, outage *entities.Outage) error {
+	if err := s.outages.SaveOutage(ctx, outage); err != nil {
+		return err
+	}
+	for _, event := range outage.Events() {
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	outage.ClearEvents()
+	return nil
+}
+
+func assignOutage(hostOutages map[string]string, outageID string, hosts []*entities.HostLiveness) {
+	for _, host := range hosts {
+		if hostOutages[host.HostID] == "" {
+			hostOutages[host.HostID] = outageID
+		}
+	}
+}
+
+func sortedDomains(byDomain map[entities.FailureDomain][]*entities.HostLiveness) []entities.FailureDomain {
+	domains := make([]entities.FailureDomain, 0, len(byDomain))
+	for domain := range byDomain {
+		domains = append(domains, domain)
+	}
+	sort.Slice(domains, func(i, j int) bool { return domainBefore(domains[i], domains[j]) })
+	return domains
+}
+
+func domainBefore(a entities.FailureDomain, b entities.FailureDomain) bool {
+	if a.Level != b.Level {
+		return a.Level == entities.LocationLevelSwitch
+	}
+	return a.Key < b.Key
+}
+
+func hostIDs(hosts []*entities.HostLiveness) []string {
+	ids := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		ids = append(ids, host.HostID)
+	}
+	return ids
+}
@@ -0,0 +1,191 @@
+package liveness_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/liveness"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	livenessErrors "github.com/gwall-e/auto_healing/internal/domain/liveness/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LivenessService", func() {
+	var (
+		ctx          context.Context
+		now          time.Time
+		checkService *checks.CheckService
+		eventLog     *memory.EventLog
+		service      *LivenessService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+
+		hosts := memory.NewHostInfoRepository()
+		for i := 1; i <= 4; i++ {
+			hosts.SetHostInfo(decisionEntities.HostInfo{
+				HostID: fmt.Sprintf("host-%d", i), ProjectID: "search", Datacenter: "dc-1", Rack: "r-1", Switch: "sw-1",
+			})
+		}
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-5", ProjectID: "search", Datacenter: "dc-1", Rack: "r-2", Switch: "sw-2"})
+
+		checkService = checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		eventLog = memory.NewEventLog()
+		service = NewDomainService(memory.NewLivenessRepository(), memory.NewOutageRepository(), hosts, checkService, eventLog,
+			WithClock(clock), WithSilenceTimeout(3*time.Minute))
+	})
+
+	beat := func(at time.Time, hostIDs ...string) *HeartbeatReport {
+		heartbeats := []entities.Heartbeat{}
+		for _, hostID := range hostIDs {
+			heartbeats = append(heartbeats, entities.Heartbeat{HostID: hostID, AgentVersion: "1.0.0", Timestamp: at})
+		}
+		report, err := service.RecordHeartbeats(ctx, heartbeats)
+		Expect(err).NotTo(HaveOccurred())
+		return report
+	}
+
+	unreachableCheck := func(hostID string) *checkEntities.HostCheck {
+		hostChecks, err := checkService.GetHostChecks(ctx, hostID)
+		Expect(err).NotTo(HaveOccurred())
+		for _, check := range hostChecks {
+			if check.Type == core_entities.CheckUnreachable {
+				return check
+			}
+		}
+		return nil
+	}
+
+	It("should mark a silent host unreachable and recover it on a heartbeat", func() {
+		beat(now, "host-1", "host-2", "host-5")
+		now = now.Add(2 * time.Minute)
+		beat(now, "host-2", "host-5")
+
+		now = now.Add(2 * time.Minute)
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+		host, err := service.GetHostLiveness(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.Status).To(Equal(entities.LivenessUnreachable))
+		Expect(host.UnreachableSince).To(Equal(&now))
+		Expect(host.OutageID).To(BeEmpty())
+		Expect(service.ListUnreachable(ctx)).To(HaveLen(1))
+		Expect(unreachableCheck("host-1").Status).To(Equal(checkEntities.CheckStatusFailed))
+		Expect(unreachableCheck("host-2")).To(BeNil())
+		Expect(service.CheckAction(ctx, "host-1", "search", decisionEntities.ActionReboot)).To(Succeed())
+
+		now = now.Add(time.Minute)
+		report := beat(now, "host-1")
+		Expect(report.Recovered).To(Equal([]string{"host-1"}))
+		host, err = service.GetHostLiveness(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.Status).To(Equal(entities.LivenessAlive))
+		Expect(unreachableCheck("host-1").Status).To(Equal(checkEntities.CheckStatusOK))
+
+		Expect(eventLog.Events()).To(Equal([]interface{}{
+			&events.HostUnreachableEvent{HostID: "host-1", LastSeen: now.Add(-5 * time.Minute), At: now.Add(-time.Minute)},
+			&events.HostRecoveredEvent{HostID: "host-1", UnreachableSince: now.Add(-time.Minute), At: now},
+		}))
+	})
+
+	It("should keep the unreachable check of silent hosts fresh", func() {
+		beat(now, "host-1")
+		now = now.Add(5 * time.Minute)
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+		reported := unreachableCheck("host-1").Timestamp
+
+		now = now.Add(time.Minute)
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+		Expect(unreachableCheck("host-1").Timestamp).To(Equal(reported))
+
+		now = now.Add(DEFAULT_REPORT_INTERVAL)
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+		Expect(unreachableCheck("host-1").Timestamp).To(Equal(now))
+		Expect(unreachableCheck("host-1").StatusSince).To(Equal(reported))
+	})
+
+	It("should suppress automation during a mass outage of a switch", func() {
+		beat(now, "host-1", "host-2", "host-3", "host-4", "host-5")
+		now = now.Add(time.Minute)
+		beat(now, "host-4", "host-5")
+
+		now = now.Add(3 * time.Minute)
+		beat(now, "host-4", "host-5")
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+
+		outages, err := service.ListOutages(ctx, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outages).To(HaveLen(1))
+		outage := outages[0]
+		Expect(outage.Domain).To(Equal(entities.FailureDomain{Level: entities.LocationLevelSwitch, Key: "sw-1"}))
+		Expect(outage.Hosts).To(Equal([]string{"host-1", "host-2", "host-3"}))
+		Expect(outage.TotalHosts).To(Equal(4))
+
+		hostOutage, err := service.GetHostOutage(ctx, "host-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostOutage.ID).To(Equal(outage.ID))
+		err = service.CheckAction(ctx, "host-2", "search", decisionEntities.ActionReboot)
+		Expect(err).To(MatchError(livenessErrors.ErrMassOutage))
+		Expect(err.Error()).To(ContainSubstring("mass outage of switch sw-1, 3 of 4 hosts unreachable"))
+		Expect(unreachableCheck("host-2").Metadata).To(HaveKeyWithValue("outage_id", outage.ID))
+
+		Expect(eventLog.Events()).To(HaveLen(4))
+		Expect(eventLog.Events()[0]).To(Equal(&events.MassOutageStartedEvent{
+			OutageID: outage.ID, Level: "switch", Key: "sw-1", Hosts: []string{"host-1", "host-2", "host-3"}, TotalHosts: 4, At: now,
+		}))
+		Expect(eventLog.Events()[1]).To(HaveField("OutageID", outage.ID))
+
+		now = now.Add(time.Minute)
+		beat(now, "host-1", "host-2", "host-4", "host-5")
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+
+		Expect(service.GetHostOutage(ctx, "host-3")).To(BeNil())
+		Expect(service.CheckAction(ctx, "host-3", "search", decisionEntities.ActionReboot)).To(Succeed())
+		outages, err = service.ListOutages(ctx, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outages[0].EndedAt).To(Equal(&now))
+		Expect(eventLog.Events()).To(ContainElement(&events.MassOutageEndedEvent{
+			OutageID: outage.ID, Level: "switch", Key: "sw-1", StartedAt: now.Add(-time.Minute), At: now,
+		}))
+	})
+
+	It("should not take hosts going silent one by one for a mass outage", func() {
+		beat(now, "host-1", "host-2", "host-3")
+		now = now.Add(10 * time.Minute)
+		beat(now, "host-2", "host-3")
+		now = now.Add(10 * time.Minute)
+		beat(now, "host-3")
+		now = now.Add(10 * time.Minute)
+
+		Expect(service.DetectUnreachable(ctx)).To(Succeed())
+		Expect(service.ListUnreachable(ctx)).To(HaveLen(3))
+		Expect(service.ListOutages(ctx, time.Time{})).To(BeEmpty())
+	})
+
+	It("should reject invalid heartbeats", func() {
+		report, err := service.RecordHeartbeats(ctx, []entities.Heartbeat{
+			{HostID: "host-1", Timestamp: now},
+			{Timestamp: now},
+			{HostID: "host-2", Timestamp: now.Add(time.Hour)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Accepted).To(Equal(1))
+		Expect(report.Rejected).To(HaveLen(2))
+		Expect(report.Rejected[1].Index).To(Equal(2))
+
+		_, err = service.RecordHeartbeats(ctx, nil)
+		Expect(err).To(MatchError(livenessErrors.ErrEmptyBatch))
+		_, err = service.GetHostLiveness(ctx, "host-2")
+		Expect(err).To(MatchError(livenessErrors.ErrHostNotFound))
+	})
+})
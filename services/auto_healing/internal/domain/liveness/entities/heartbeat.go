@@ -0,0 +1,9 @@
+package entities
+
+import "time"
+
+type Heartbeat struct {
+	HostID       string    `bson:"host_id"`
+	AgentVersion string    `bson:"agent_version"`
+	Timestamp    time.Time `bson:"timestamp"`
+}
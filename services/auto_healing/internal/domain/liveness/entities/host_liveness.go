@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+)
+
+type LivenessStatus string
+
+const (
+	LivenessAlive       LivenessStatus = "alive"
+	LivenessUnreachable LivenessStatus = "unreachable"
+)
+
+type HostLiveness struct {
+	HostID           string         `bson:"_id"`
+	AgentVersion     string         `bson:"agent_version"`
+	LastSeen         time.Time      `bson:"last_seen"`
+	Status           LivenessStatus `bson:"status"`
+	UnreachableSince *time.Time     `bson:"unreachable_since"`
+	OutageID         string         `bson:"outage_id"`
+	ReportedAt       time.Time      `bson:"reported_at"`
+	events           []interface{}  `bson:"-"`
+}
+
+func NewHostLiveness(heartbeat Heartbeat) *HostLiveness {
+	return &HostLiveness{
+		HostID:       heartbeat.HostID,
+		AgentVersion: heartbeat.AgentVersion,
+		LastSeen:     heartbeat.Timestamp,
+		Status:       LivenessAlive,
+	}
+}
+
+func (h *HostLiveness) Events() []interface{} {
+	return h.events
+}
+
+func (h *HostLiveness) ClearEvents() {
+	h.events = nil
+}
+
+func (h *HostLiveness) addEvent(event interface{}) {
+	h.events = append(h.events, event)
+}
+
+func (h *HostLiveness) IsUnreachable() bool {
+	return h.Status == LivenessUnreachable
+}
+
+func (h *HostLiveness) Silent(now time.Time, timeout time.Duration) bool {
+	return h.Status == LivenessAlive && now.Sub(h.LastSeen) > timeout
+}
+
+func (h *HostLiveness) Beat(heartbeat Heartbeat, now time.Time) bool {
+	if !heartbeat.Timestamp.After(h.LastSeen) {
+		return false
+	}
+	h.LastSeen = heartbeat.Timestamp
+	h.AgentVersion = heartbeat.AgentVersion
+	if !h.IsUnreachable() {
+		return false
+	}
+
+	h.addEvent(&events.HostRecoveredEvent{HostID: h.HostID, UnreachableSince: *h.UnreachableSince, At: now})
+	h.Status = LivenessAlive
+	h.UnreachableSince = nil
+	h.OutageID = ""
+	return true
+}
+
+func (h *HostLiveness) MarkUnreachable(now time.Time, outageID string) {
+	h.Status = LivenessUnreachable
+	h.UnreachableSince = &now
+	h.OutageID = outageID
+	h.addEvent(&events.HostUnreachableEvent{HostID: h.HostID, LastSeen: h.LastSeen, OutageID: outageID, At: now})
+}
+
+func (h *HostLiveness) SetOutage(outageID string) bool {
+	if h.OutageID == outageID {
+		return false
+	}
+	h.OutageID = outageID
+	return true
+}
@@ -0,0 +1,49 @@
+package entities
+
+import "fmt"
+
+type LocationLevel string
+
+const (
+	LocationLevelRack   LocationLevel = "rack"
+	LocationLevelSwitch LocationLevel = "switch"
+)
+
+func (l LocationLevel) IsValid() bool {
+	switch l {
+	case LocationLevelRack, LocationLevelSwitch:
+		return true
+	}
+	return false
+}
+
+type HostLocation struct {
+	HostID     string `bson:"_id"`
+	Datacenter string `bson:"datacenter"`
+	Rack       string `bson:"rack"`
+	Switch     string `bson:"switch"`
+}
+
+type FailureDomain struct {
+	Level LocationLevel `bson:"level"`
+	Key   string        `bson:"key"`
+}
+
+func (d FailureDomain) String() string {
+	return fmt.Sprintf("%s %s", d.Level, d.Key)
+}
+
+func (l HostLocation) FailureDomains() []FailureDomain {
+	domains := []FailureDomain{}
+	if l.Switch != "" {
+		domains = append(domains, FailureDomain{Level: LocationLevelSwitch, Key: l.Switch})
+	}
+	if l.Rack != "" && l.Datacenter != "" {
+		domains = append(domains, FailureDomain{Level: LocationLevelRack, Key: RackKey(l.Datacenter, l.Rack)})
+	}
+	return domains
+}
+
+func RackKey(datacenter string, rack string) string {
+	return datacenter + "/" + rack
+}
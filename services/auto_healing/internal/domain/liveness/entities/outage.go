@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+)
+
+type MassOutageThresholds struct {
+	MinHosts    int           `bson:"min_hosts"`
+	MinFraction float64       `bson:"min_fraction"`
+	Window      time.Duration `bson:"window"`
+}
+
+var DEFAULT_MASS_OUTAGE_THRESHOLDS = MassOutageThresholds{
+	MinHosts:    3,
+	MinFraction: 0.5,
+	Window:      5 * time.Minute,
+}
+
+func (t MassOutageThresholds) Reached(unreachable int, total int) bool {
+	return unreachable >= t.MinHosts && float64(unreachable) >= t.MinFraction*float64(total)
+}
+
+func (t MassOutageThresholds) SilentTogether(hosts []*HostLiveness) []*HostLiveness {
+	sorted := slices.Clone(hosts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LastSeen.Before(sorted[j].LastSeen) })
+
+	var best []*HostLiveness
+	first := 0
+	for last := range sorted {
+		for sorted[last].LastSeen.Sub(sorted[first].LastSeen) > t.Window {
+			first++
+		}
+		if last-first+1 > len(best) {
+			best = sorted[first : last+1]
+		}
+	}
+	return best
+}
+
+type Outage struct {
+	ID         string        `bson:"_id"`
+	Domain     FailureDomain `bson:"domain"`
+	Hosts      []string      `bson:"hosts"`
+	TotalHosts int           `bson:"total_hosts"`
+	StartedAt  time.Time     `bson:"started_at"`
+	EndedAt    *time.Time    `bson:"ended_at"`
+	events     []interface{} `bson:"-"`
+}
+
+func NewOutage(id string, domain FailureDomain, hosts []string, totalHosts int, now time.Time) *Outage {
+	outage := &Outage{
+		ID:         id,
+		Domain:     domain,
+		Hosts:      slices.Sorted(slices.Values(hosts)),
+		TotalHosts: totalHosts,
+		StartedAt:  now,
+	}
+	outage.addEvent(&events.MassOutageStartedEvent{
+		OutageID:   id,
+		Level:      string(domain.Level),
+		Key:        domain.Key,
+		Hosts:      slices.Clone(outage.Hosts),
+		TotalHosts: totalHosts,
+		At:         now,
+	})
+	return outage
+}
+
+func (o *Outage) Events() []interface{} {
+	return o.events
+}
+
+func (o *Outage) ClearEvents() {
+	o.events = nil
+}
+
+func (o *Outage) addEvent(event interface{}) {
+	o.events = append(o.events, event)
+}
+
+func (o *Outage) IsActive() bool {
+	return o.EndedAt == nil
+}
+
+func (o *Outage) SetHosts(hosts []string, totalHosts int) bool {
+	hosts = slices.Sorted(slices.Values(hosts))
+	if slices.Equal(o.Hosts, hosts) && o.TotalHosts == totalHosts {
+		return false
+	}
+	o.Hosts = hosts
+	o.TotalHosts = totalHosts
+	return true
+}
+
+func (o *Outage) End(now time.Time) {
+	o.EndedAt = &now
+	o.addEvent(&events.MassOutageEndedEvent{
+		OutageID:  o.ID,
+		Level:     string(o.Domain.Level),
+		Key:       o.Domain.Key,
+		StartedAt: o.StartedAt,
+		At:        now,
+	})
+}
+
+func (o *Outage) String() string {
+	return fmt.Sprintf("mass outage of %s, %d of %d hosts unreachable since %s",
+		o.Domain, len(o.Hosts), o.TotalHosts, o.StartedAt.Format(time.RFC3339))
+}
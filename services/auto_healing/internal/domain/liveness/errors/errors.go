@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBatchTooLarge = errors.New("batch of heartbeats is too large")
+	ErrEmptyBatch    = errors.New("batch of heartbeats is empty")
+	ErrHostNotFound  = errors.New("host has never sent a heartbeat")
+	ErrMassOutage    = errors.New("host is in a mass outage")
+)
+
+type HeartbeatValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e HeartbeatValidationError) Error() string {
+	return fmt.Sprintf("heartbeat validation error, field: %s, err: %s", e.Field, e.Message)
+}
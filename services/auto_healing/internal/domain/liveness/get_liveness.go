@@ -0,0 +1,53 @@
+package liveness
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/errors"
+)
+
+func (s *LivenessService) GetHostLiveness(ctx context.Context, hostID string) (*entities.HostLiveness, error) {
+	host, err := s.repo.GetHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if host == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrHostNotFound, hostID)
+	}
+	return host, nil
+}
+
+func (s *LivenessService) ListUnreachable(ctx context.Context) ([]*entities.HostLiveness, error) {
+	return s.repo.ListUnreachable(ctx)
+}
+
+func (s *LivenessService) ListOutages(ctx context.Context, since time.Time) ([]*entities.Outage, error) {
+	return s.outages.ListOutages(ctx, since)
+}
+
+func (s *LivenessService) GetHostOutage(ctx context.Context, hostID string) (*entities.Outage, error) {
+	host, err := s.repo.GetHost(ctx, hostID)
+	if err != nil || host == nil || host.OutageID == "" {
+		return nil, err
+	}
+	outage, err := s.outages.GetOutage(ctx, host.OutageID)
+	if err != nil || outage == nil || !outage.IsActive() {
+		return nil, err
+	}
+	return outage, nil
+}
+
+func (s *LivenessService) CheckAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error {
+	outage, err := s.GetHostOutage(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if outage != nil {
+		return fmt.Errorf("%w: %s", errors.ErrMassOutage, outage)
+	}
+	return nil
+}
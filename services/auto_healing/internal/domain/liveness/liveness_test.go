@@ -0,0 +1,13 @@
+package liveness_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLivenessSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Liveness Domain Suite")
+}
@@ -0,0 +1,121 @@
+package liveness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/errors"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/validators"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type RejectedHeartbeat struct {
+	Index   int
+	HostID  string
+	Message string
+}
+
+type HeartbeatReport struct {
+	Accepted  int
+	Recovered []string
+	Rejected  []RejectedHeartbeat
+}
+
+func (s *LivenessService) RecordHeartbeats(ctx context.Context, heartbeats []entities.Heartbeat) (*HeartbeatReport, error) {
+	if len(heartbeats) == 0 {
+		return nil, errors.ErrEmptyBatch
+	}
+	if len(heartbeats) > MAX_BATCH_SIZE {
+		return nil, fmt.Errorf("%w: %d heartbeats, limit is %d", errors.ErrBatchTooLarge, len(heartbeats), MAX_BATCH_SIZE)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	report := &HeartbeatReport{Recovered: []string{}, Rejected: []RejectedHeartbeat{}}
+	valid := make([]entities.Heartbeat, 0, len(heartbeats))
+	for i, heartbeat := range heartbeats {
+		if err := validators.ValidateHeartbeat(heartbeat, now); err != nil {
+			report.Rejected = append(report.Rejected, RejectedHeartbeat{Index: i, HostID: heartbeat.HostID, Message: err.Error()})
+			continue
+		}
+		valid = append(valid, heartbeat)
+	}
+	report.Accepted = len(valid)
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Timestamp.Before(valid[j].Timestamp) })
+
+	hosts := map[string]*entities.HostLiveness{}
+	order := []string{}
+	results := []checkEntities.CheckResult{}
+	for _, heartbeat := range valid {
+		host, ok := hosts[heartbeat.HostID]
+		if !ok {
+			var err error
+			if host, err = s.repo.GetHost(ctx, heartbeat.HostID); err != nil {
+				return nil, err
+			}
+			if host == nil {
+				host = entities.NewHostLiveness(heartbeat)
+			}
+			hosts[heartbeat.HostID] = host
+			order = append(order, heartbeat.HostID)
+		}
+		if host.Beat(heartbeat, now) {
+			report.Recovered = append(report.Recovered, host.HostID)
+			results = append(results, unreachableResult(host, checkEntities.CheckStatusOK, now))
+		}
+	}
+
+	for _, hostID := range order {
+		if err := s.saveHost(ctx, hosts[hostID]); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.reportChecks(ctx, results); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *LivenessService) saveHost(ctx context.Context, host *entities.HostLiveness) error {
+	if err := s.repo.SaveHost(ctx, host); err != nil {
+		return err
+	}
+	for _, event := range host.Events() {
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	host.ClearEvents()
+	return nil
+}
+
+func (s *LivenessService) reportChecks(ctx context.Context, results []checkEntities.CheckResult) error {
+	for len(results) > 0 {
+		batch := results[:min(len(results), MAX_BATCH_SIZE)]
+		if _, err := s.checks.IngestResults(ctx, batch); err != nil {
+			return fmt.Errorf("report unreachable checks: %w", err)
+		}
+		results = results[len(batch):]
+	}
+	return nil
+}
+
+func unreachableResult(host *entities.HostLiveness, status checkEntities.CheckStatus, at time.Time) checkEntities.CheckResult {
+	metadata := map[string]string{"last_seen": host.LastSeen.Format(time.RFC3339)}
+	if host.OutageID != "" {
+		metadata["outage_id"] = host.OutageID
+	}
+	return checkEntities.CheckResult{
+		HostID:    host.HostID,
+		Type:      core_entities.CheckUnreachable,
+		Status:    status,
+		Timestamp: at,
+		Metadata:  metadata,
+	}
+}
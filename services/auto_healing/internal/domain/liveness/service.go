@@ -0,0 +1,88 @@
+package liveness
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+const (
+	MAX_BATCH_SIZE          = 1000
+	DEFAULT_SILENCE_TIMEOUT = 3 * time.Minute
+	DEFAULT_DETECT_INTERVAL = time.Minute
+	DEFAULT_REPORT_INTERVAL = 5 * time.Minute
+)
+
+type SetupFunc func(*LivenessService)
+
+type LivenessService struct {
+	repo           contracts.LivenessRepository
+	outages        contracts.OutageRepository
+	locations      contracts.LocationProvider
+	checks         contracts.CheckReporter
+	publisher      contracts.EventPublisher
+	silenceTimeout time.Duration
+	thresholds     entities.MassOutageThresholds
+	detectInterval time.Duration
+	reportInterval time.Duration
+	now            func() time.Time
+	mu             sync.Mutex
+}
+
+func WithSilenceTimeout(timeout time.Duration) SetupFunc {
+	return func(s *LivenessService) {
+		s.silenceTimeout = timeout
+	}
+}
+
+func WithMassOutageThresholds(thresholds entities.MassOutageThresholds) SetupFunc {
+	return func(s *LivenessService) {
+		s.thresholds = thresholds
+	}
+}
+
+func WithDetectInterval(interval time.Duration) SetupFunc {
+	return func(s *LivenessService) {
+		s.detectInterval = interval
+	}
+}
+
+func WithReportInterval(interval time.Duration) SetupFunc {
+	return func(s *LivenessService) {
+		s.reportInterval = interval
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *LivenessService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	repo contracts.LivenessRepository,
+	outages contracts.OutageRepository,
+	locations contracts.LocationProvider,
+	checks contracts.CheckReporter,
+	publisher contracts.EventPublisher,
+	setup ...SetupFunc,
+) *LivenessService {
+	s := &LivenessService{
+		repo:           repo,
+		outages:        outages,
+		locations:      locations,
+		checks:         checks,
+		publisher:      publisher,
+		silenceTimeout: DEFAULT_SILENCE_TIMEOUT,
+		thresholds:     entities.DEFAULT_MASS_OUTAGE_THRESHOLDS,
+		detectInterval: DEFAULT_DETECT_INTERVAL,
+		reportInterval: DEFAULT_REPORT_INTERVAL,
+		now:            time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
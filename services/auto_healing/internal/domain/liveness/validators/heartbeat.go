@@ -0,0 +1,43 @@
+package validators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/errors"
+)
+
+const (
+	MAX_HOST_ID_LENGTH       = 64
+	MAX_AGENT_VERSION_LENGTH = 64
+	MAX_CLOCK_SKEW           = 5 * time.Minute
+)
+
+func ValidateHeartbeat(heartbeat entities.Heartbeat, now time.Time) error {
+	if heartbeat.HostID == "" || len(heartbeat.HostID) > MAX_HOST_ID_LENGTH {
+		return &errors.HeartbeatValidationError{
+			Field:   "host_id",
+			Message: fmt.Sprintf("host id is required and must be at most %d characters", MAX_HOST_ID_LENGTH),
+		}
+	}
+	if len(heartbeat.AgentVersion) > MAX_AGENT_VERSION_LENGTH {
+		return &errors.HeartbeatValidationError{
+			Field:   "agent_version",
+			Message: fmt.Sprintf("agent version must be at most %d characters", MAX_AGENT_VERSION_LENGTH),
+		}
+	}
+	if heartbeat.Timestamp.IsZero() {
+		return &errors.HeartbeatValidationError{
+			Field:   "timestamp",
+			Message: "timestamp is required",
+		}
+	}
+	if heartbeat.Timestamp.After(now.Add(MAX_CLOCK_SKEW)) {
+		return &errors.HeartbeatValidationError{
+			Field:   "timestamp",
+			Message: "timestamp is in the future",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package monitoring
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/entities"
+)
+
+func (s *MonitoringService) ApplyProjectMonitoringChanged(ctx context.Context, event *entities.ProjectMonitoringChanged) error {
+	checks := make([]entities.MonitoringCheck, len(event.Checks))
+	copy(checks, event.Checks)
+	return s.repo.Save(ctx, &entities.MonitoringConfig{ProjectID: event.ID, Checks: checks})
+}
@@ -0,0 +1,7 @@
+package contracts
+
+import "context"
+
+type HostProjectResolver interface {
+	GetHostProject(ctx context.Context, hostID string) (string, error)
+}
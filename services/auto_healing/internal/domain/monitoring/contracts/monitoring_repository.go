@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/entities"
+)
+
+type MonitoringRepository interface {
+	Get(ctx context.Context, projectID string) (*entities.MonitoringConfig, error)
+	Save(ctx context.Context, config *entities.MonitoringConfig) error
+}
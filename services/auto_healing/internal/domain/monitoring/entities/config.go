@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type MonitoringCheck struct {
+	Type             core_entities.CheckType `bson:"type"`
+	Enabled          bool                    `bson:"enabled"`
+	FailureThreshold int                     `bson:"failure_threshold"`
+	GracePeriod      time.Duration           `bson:"grace_period"`
+	AllowAutomation  bool                    `bson:"allow_automation"`
+}
+
+type ProjectMonitoringChanged struct {
+	ID     string            `bson:"id"`
+	Checks []MonitoringCheck `bson:"checks"`
+}
+
+type MonitoringConfig struct {
+	ProjectID string            `bson:"_id"`
+	Checks    []MonitoringCheck `bson:"checks"`
+}
+
+func (c *MonitoringConfig) Check(checkType core_entities.CheckType) (MonitoringCheck, bool) {
+	for _, check := range c.Checks {
+		if check.Type == checkType {
+			return check, true
+		}
+	}
+	return MonitoringCheck{}, false
+}
+
+func (c *MonitoringConfig) AutomationAllowed(checkType core_entities.CheckType) bool {
+	check, ok := c.Check(checkType)
+	return ok && check.Enabled && check.AllowAutomation
+}
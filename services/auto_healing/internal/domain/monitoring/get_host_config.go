@@ -0,0 +1,23 @@
+package monitoring
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/entities"
+)
+
+func (s *MonitoringService) GetHostConfig(ctx context.Context, hostID string) (*entities.MonitoringConfig, error) {
+	projectID, err := s.resolver.GetHostProject(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.repo.Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return &entities.MonitoringConfig{ProjectID: projectID, Checks: []entities.MonitoringCheck{}}, nil
+	}
+	return config, nil
+}
@@ -0,0 +1,69 @@
+package monitoring_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/gwall-e/auto_healing/internal/domain/monitoring"
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHostConfig", func() {
+	var (
+		resolver *memory.HostProjectResolver
+		service  *MonitoringService
+	)
+
+	BeforeEach(func() {
+		resolver = memory.NewHostProjectResolver()
+		resolver.SetHostProject("host-1", "search")
+		resolver.SetHostProject("host-2", "mail")
+		service = NewDomainService(memory.NewMonitoringRepository(), resolver)
+
+		Expect(service.ApplyProjectMonitoringChanged(context.Background(), &entities.ProjectMonitoringChanged{
+			ID: "search",
+			Checks: []entities.MonitoringCheck{
+				{Type: core_entities.CheckSSH, Enabled: true, FailureThreshold: 3, GracePeriod: 10 * time.Minute, AllowAutomation: true},
+				{Type: core_entities.CheckMeta, Enabled: true, FailureThreshold: 1},
+			},
+		})).To(Succeed())
+	})
+
+	It("should return effective config of the host project", func() {
+		config, err := service.GetHostConfig(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.ProjectID).To(Equal("search"))
+		Expect(config.Checks).To(HaveLen(2))
+		Expect(config.AutomationAllowed(core_entities.CheckSSH)).To(BeTrue())
+		Expect(config.AutomationAllowed(core_entities.CheckMeta)).To(BeFalse())
+		Expect(config.AutomationAllowed(core_entities.CheckDisk)).To(BeFalse())
+	})
+
+	It("should replace config on subsequent events", func() {
+		Expect(service.ApplyProjectMonitoringChanged(context.Background(), &entities.ProjectMonitoringChanged{
+			ID:     "search",
+			Checks: []entities.MonitoringCheck{{Type: core_entities.CheckSSH, Enabled: false, FailureThreshold: 3}},
+		})).To(Succeed())
+
+		config, err := service.GetHostConfig(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.AutomationAllowed(core_entities.CheckSSH)).To(BeFalse())
+	})
+
+	It("should return empty config for project without configuration", func() {
+		config, err := service.GetHostConfig(context.Background(), "host-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.ProjectID).To(Equal("mail"))
+		Expect(config.Checks).To(BeEmpty())
+	})
+
+	It("should fail for unknown host", func() {
+		_, err := service.GetHostConfig(context.Background(), "host-3")
+		Expect(err).To(MatchError(errors.ErrHostNotFound))
+	})
+})
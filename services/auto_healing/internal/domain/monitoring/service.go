@@ -0,0 +1,12 @@
+package monitoring
+
+import "github.com/gwall-e/auto_healing/internal/domain/monitoring/contracts"
+
+type MonitoringService struct {
+	repo     contracts.MonitoringRepository
+	resolver contracts.HostProjectResolver
+}
+
+func NewDomainService(repo contracts.MonitoringRepository, resolver contracts.HostProjectResolver) *MonitoringService {
+	return &MonitoringService{repo: repo, resolver: resolver}
+}
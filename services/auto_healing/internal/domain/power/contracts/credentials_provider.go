@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+type CredentialsProvider interface {
+	GetCredentials(ctx context.Context, secretID string) (*entities.Credentials, error)
+}
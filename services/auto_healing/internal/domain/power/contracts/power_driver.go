@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+type PowerDriver interface {
+	PowerOn(ctx context.Context, target entities.PowerTarget) error
+	PowerOff(ctx context.Context, target entities.PowerTarget) error
+	PowerCycle(ctx context.Context, target entities.PowerTarget) error
+	Status(ctx context.Context, target entities.PowerTarget) (entities.PowerState, error)
+	SetBootDevice(ctx context.Context, target entities.PowerTarget, device entities.BootDevice, persistent bool) error
+	ReadSEL(ctx context.Context, target entities.PowerTarget, since time.Time) ([]entities.SELEntry, error)
+}
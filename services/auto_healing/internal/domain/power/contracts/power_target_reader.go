@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+type PowerTargetReader interface {
+	GetPowerTarget(ctx context.Context, hostID string) (*entities.PowerTarget, error)
+}
@@ -0,0 +1,41 @@
+package power
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+)
+
+func (s *PowerService) DriverName(target entities.PowerTarget) (string, error) {
+	registered, err := s.driverFor(target)
+	if err != nil {
+		return "", err
+	}
+	return registered.name, nil
+}
+
+func (s *PowerService) driverFor(target entities.PowerTarget) (registeredDriver, error) {
+	registered, ok := s.drivers[target.UnitType]
+	if !ok {
+		return registeredDriver{}, fmt.Errorf("%w: %s", errors.ErrUnsupportedUnitType, target.UnitType)
+	}
+	return registered, nil
+}
+
+func (s *PowerService) call(ctx context.Context, target entities.PowerTarget, operation string, fn func(ctx context.Context, driver contracts.PowerDriver) error) error {
+	registered, err := s.driverFor(target)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, registered.timeout)
+	defer cancel()
+
+	if err := fn(ctx, registered.driver); err != nil {
+		return &errors.DriverError{Driver: registered.name, Operation: operation, HostID: target.HostID, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type PowerState string
+
+const (
+	PowerStateOn      PowerState = "on"
+	PowerStateOff     PowerState = "off"
+	PowerStateUnknown PowerState = "unknown"
+)
+
+type BootDevice string
+
+const (
+	BootDevicePXE  BootDevice = "pxe"
+	BootDeviceDisk BootDevice = "disk"
+	BootDeviceBIOS BootDevice = "bios"
+)
+
+func (d BootDevice) IsValid() bool {
+	switch d {
+	case BootDevicePXE, BootDeviceDisk, BootDeviceBIOS:
+		return true
+	}
+	return false
+}
+
+type PowerTarget struct {
+	HostID     string                 `bson:"host_id"`
+	UnitType   core_entities.UnitType `bson:"unit_type"`
+	Address    string                 `bson:"address"`
+	ResourceID string                 `bson:"resource_id"`
+	SecretID   string                 `bson:"secret_id"`
+}
+
+type SELEntry struct {
+	ID       string    `bson:"id"`
+	Created  time.Time `bson:"created"`
+	Severity string    `bson:"severity"`
+	Message  string    `bson:"message"`
+}
+
+type Credentials struct {
+	Username string
+	Password string
+}
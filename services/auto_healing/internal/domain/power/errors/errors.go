@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnsupportedUnitType   = errors.New("no power driver for unit type")
+	ErrUnsupportedBootDevice = errors.New("unsupported boot device")
+	ErrSecretNotFound        = errors.New("secret not found")
+	ErrMachineNotFound       = errors.New("machine not found on management interface")
+	ErrAccessDenied          = errors.New("management interface denied access")
+	ErrTargetNotFound        = errors.New("management interface of host is unknown")
+	ErrUnsupportedAction     = errors.New("action can not be done through the management interface")
+	ErrNoEventLog            = errors.New("management interface has no event log")
+)
+
+type DriverError struct {
+	Driver    string
+	Operation string
+	HostID    string
+	Err       error
+}
+
+func (e *DriverError) Error() string {
+	return fmt.Sprintf("%s driver failed to %s host %s: %v", e.Driver, e.Operation, e.HostID, e.Err)
+}
+
+func (e *DriverError) Unwrap() error {
+	return e.Err
+}
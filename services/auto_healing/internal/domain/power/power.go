@@ -0,0 +1,39 @@
+package power
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+func (s *PowerService) PowerOn(ctx context.Context, target entities.PowerTarget) error {
+	return s.call(ctx, target, "power on", func(ctx context.Context, driver contracts.PowerDriver) error {
+		return driver.PowerOn(ctx, target)
+	})
+}
+
+func (s *PowerService) PowerOff(ctx context.Context, target entities.PowerTarget) error {
+	return s.call(ctx, target, "power off", func(ctx context.Context, driver contracts.PowerDriver) error {
+		return driver.PowerOff(ctx, target)
+	})
+}
+
+func (s *PowerService) PowerCycle(ctx context.Context, target entities.PowerTarget) error {
+	return s.call(ctx, target, "power cycle", func(ctx context.Context, driver contracts.PowerDriver) error {
+		return driver.PowerCycle(ctx, target)
+	})
+}
+
+func (s *PowerService) PowerStatus(ctx context.Context, target entities.PowerTarget) (entities.PowerState, error) {
+	state := entities.PowerStateUnknown
+	err := s.call(ctx, target, "read power status", func(ctx context.Context, driver contracts.PowerDriver) error {
+		var err error
+		state, err = driver.Status(ctx, target)
+		return err
+	})
+	if err != nil {
+		return entities.PowerStateUnknown, err
+	}
+	return state, nil
+}
@@ -0,0 +1,114 @@
+package power_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/power"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PowerService", func() {
+	var (
+		ctx        context.Context
+		bmc        *fakeDriver
+		hypervisor *fakeDriver
+		targets    *memory.PowerTargetRepository
+		service    *PowerService
+		server     entities.PowerTarget
+		vm         entities.PowerTarget
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		bmc = &fakeDriver{state: entities.PowerStateOn}
+		hypervisor = &fakeDriver{state: entities.PowerStateOff}
+		targets = memory.NewPowerTargetRepository()
+		service = NewDomainService(targets,
+			WithDriver("redfish", bmc, 0, core_entities.TypeServer, core_entities.TypeShadowServer),
+			WithDriver("hypervisor", hypervisor, 20*time.Millisecond, core_entities.TypeVM),
+		)
+		server = entities.PowerTarget{HostID: "server-1", UnitType: core_entities.TypeServer}
+		vm = entities.PowerTarget{HostID: "vm-1", UnitType: core_entities.TypeVM}
+	})
+
+	It("should choose the driver by unit type", func() {
+		Expect(service.PowerCycle(ctx, server)).To(Succeed())
+		Expect(service.PowerOff(ctx, vm)).To(Succeed())
+		Expect(service.PowerOn(ctx, vm)).To(Succeed())
+		Expect(service.PowerStatus(ctx, server)).To(Equal(entities.PowerStateOn))
+		Expect(service.PowerStatus(ctx, vm)).To(Equal(entities.PowerStateOff))
+
+		Expect(bmc.calls).To(Equal([]string{"cycle server-1", "status server-1"}))
+		Expect(hypervisor.calls).To(Equal([]string{"off vm-1", "on vm-1", "status vm-1"}))
+		Expect(service.DriverName(vm)).To(Equal("hypervisor"))
+	})
+
+	It("should reject unit types without a driver", func() {
+		mac := entities.PowerTarget{HostID: "mac-1", UnitType: core_entities.TypeMac}
+		Expect(service.PowerCycle(ctx, mac)).To(MatchError(errors.ErrUnsupportedUnitType))
+		_, err := service.DriverName(mac)
+		Expect(err).To(MatchError(errors.ErrUnsupportedUnitType))
+	})
+
+	It("should limit driver calls by the driver timeout", func() {
+		hypervisor.delay = time.Second
+		err := service.PowerCycle(ctx, vm)
+
+		var driverErr *errors.DriverError
+		Expect(stdErrors.As(err, &driverErr)).To(BeTrue())
+		Expect(driverErr.Driver).To(Equal("hypervisor"))
+		Expect(driverErr.Operation).To(Equal("power cycle"))
+		Expect(driverErr.HostID).To(Equal("vm-1"))
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should report unknown state when status can not be read", func() {
+		bmc.err = stdErrors.New("bmc unreachable")
+		state, err := service.PowerStatus(ctx, server)
+		Expect(err).To(MatchError(ContainSubstring("redfish driver failed to read power status host server-1: bmc unreachable")))
+		Expect(state).To(Equal(entities.PowerStateUnknown))
+	})
+
+	It("should validate the boot device before calling the driver", func() {
+		Expect(service.SetBootDevice(ctx, server, entities.BootDevicePXE, false)).To(Succeed())
+		Expect(service.SetBootDevice(ctx, server, "usb", false)).To(MatchError(errors.ErrUnsupportedBootDevice))
+		Expect(bmc.calls).To(Equal([]string{"boot pxe server-1"}))
+	})
+
+	It("should run reboots of workflows as power cycles", func() {
+		targets.SetPowerTarget(server)
+		Expect(service.RunAction(ctx, "server-1", decisionEntities.ActionReboot)).To(Succeed())
+		Expect(bmc.calls).To(Equal([]string{"cycle server-1"}))
+
+		Expect(service.RunAction(ctx, "server-1", decisionEntities.ActionProfile)).To(MatchError(errors.ErrUnsupportedAction))
+		Expect(service.RunAction(ctx, "server-2", decisionEntities.ActionReboot)).To(MatchError(errors.ErrTargetNotFound))
+		Expect(bmc.calls).To(HaveLen(1))
+	})
+
+	It("should run redeploys as a network boot of servers and vms", func() {
+		targets.SetPowerTarget(server)
+		targets.SetPowerTarget(vm)
+		Expect(service.RunAction(ctx, "server-1", decisionEntities.ActionRedeploy)).To(Succeed())
+		Expect(service.RunAction(ctx, "vm-1", decisionEntities.ActionRedeploy)).To(Succeed())
+
+		Expect(bmc.calls).To(Equal([]string{"boot pxe server-1", "cycle server-1"}))
+		Expect(hypervisor.calls).To(Equal([]string{"boot pxe vm-1", "cycle vm-1"}))
+	})
+
+	It("should read sel entries", func() {
+		entries, err := service.ReadSEL(ctx, server, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+
+		bmc.sel = []entities.SELEntry{{ID: "1", Severity: "Critical", Message: "memory ecc error"}}
+		Expect(service.ReadSEL(ctx, server, time.Time{})).To(Equal(bmc.sel))
+	})
+})
@@ -0,0 +1,60 @@
+package power_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPowerSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Power Domain Suite")
+}
+
+type fakeDriver struct {
+	calls []string
+	state entities.PowerState
+	sel   []entities.SELEntry
+	delay time.Duration
+	err   error
+}
+
+func (f *fakeDriver) call(ctx context.Context, name string) error {
+	f.calls = append(f.calls, name)
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return f.err
+}
+
+func (f *fakeDriver) PowerOn(ctx context.Context, target entities.PowerTarget) error {
+	return f.call(ctx, "on "+target.HostID)
+}
+
+func (f *fakeDriver) PowerOff(ctx context.Context, target entities.PowerTarget) error {
+	return f.call(ctx, "off "+target.HostID)
+}
+
+func (f *fakeDriver) PowerCycle(ctx context.Context, target entities.PowerTarget) error {
+	return f.call(ctx, "cycle "+target.HostID)
+}
+
+func (f *fakeDriver) Status(ctx context.Context, target entities.PowerTarget) (entities.PowerState, error) {
+	return f.state, f.call(ctx, "status "+target.HostID)
+}
+
+func (f *fakeDriver) SetBootDevice(ctx context.Context, target entities.PowerTarget, device entities.BootDevice, persistent bool) error {
+	return f.call(ctx, "boot "+string(device)+" "+target.HostID)
+}
+
+func (f *fakeDriver) ReadSEL(ctx context.Context, target entities.PowerTarget, since time.Time) ([]entities.SELEntry, error) {
+	return f.sel, f.call(ctx, "sel "+target.HostID)
+}
@@ -0,0 +1,25 @@
+package power
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+func (s *PowerService) ReadSEL(ctx context.Context, target entities.PowerTarget, since time.Time) ([]entities.SELEntry, error) {
+	var entries []entities.SELEntry
+	err := s.call(ctx, target, "read sel", func(ctx context.Context, driver contracts.PowerDriver) error {
+		var err error
+		entries, err = driver.ReadSEL(ctx, target, since)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []entities.SELEntry{}
+	}
+	return entries, nil
+}
@@ -0,0 +1,29 @@
+package power
+
+import (
+	"context"
+	"fmt"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+)
+
+func (s *PowerService) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	if action != decisionEntities.ActionReboot && action != decisionEntities.ActionRedeploy {
+		return fmt.Errorf("%w: %s", errors.ErrUnsupportedAction, action)
+	}
+	target, err := s.targets.GetPowerTarget(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return fmt.Errorf("%w: %s", errors.ErrTargetNotFound, hostID)
+	}
+	if action == decisionEntities.ActionRedeploy {
+		if err := s.SetBootDevice(ctx, *target, entities.BootDevicePXE, false); err != nil {
+			return err
+		}
+	}
+	return s.PowerCycle(ctx, *target)
+}
@@ -0,0 +1,42 @@
+package power
+
+import (
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+const DEFAULT_DRIVER_TIMEOUT = 30 * time.Second
+
+type SetupFunc func(*PowerService)
+
+type registeredDriver struct {
+	name    string
+	driver  contracts.PowerDriver
+	timeout time.Duration
+}
+
+type PowerService struct {
+	targets contracts.PowerTargetReader
+	drivers map[core_entities.UnitType]registeredDriver
+}
+
+func WithDriver(name string, driver contracts.PowerDriver, timeout time.Duration, unitTypes ...core_entities.UnitType) SetupFunc {
+	if timeout <= 0 {
+		timeout = DEFAULT_DRIVER_TIMEOUT
+	}
+	return func(s *PowerService) {
+		for _, unitType := range unitTypes {
+			s.drivers[unitType] = registeredDriver{name: name, driver: driver, timeout: timeout}
+		}
+	}
+}
+
+func NewDomainService(targets contracts.PowerTargetReader, setup ...SetupFunc) *PowerService {
+	s := &PowerService{targets: targets, drivers: map[core_entities.UnitType]registeredDriver{}}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
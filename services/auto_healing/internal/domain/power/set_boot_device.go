@@ -0,0 +1,19 @@
+package power
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+)
+
+func (s *PowerService) SetBootDevice(ctx context.Context, target entities.PowerTarget, device entities.BootDevice, persistent bool) error {
+	if !device.IsValid() {
+		return fmt.Errorf("%w: %s", errors.ErrUnsupportedBootDevice, device)
+	}
+	return s.call(ctx, target, "set boot device", func(ctx context.Context, driver contracts.PowerDriver) error {
+		return driver.SetBootDevice(ctx, target, device, persistent)
+	})
+}
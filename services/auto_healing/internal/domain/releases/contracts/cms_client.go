@@ -0,0 +1,13 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+)
+
+type CMSClient interface {
+	CreateTask(ctx context.Context, task *entities.Task) (*entities.Task, error)
+	GetTask(ctx context.Context, id string) (*entities.Task, error)
+	DeleteTask(ctx context.Context, id string) error
+}
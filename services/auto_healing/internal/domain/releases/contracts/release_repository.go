@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+)
+
+type ReleaseRepository interface {
+	GetRelease(ctx context.Context, id string) (*entities.Release, error)
+	CreateRelease(ctx context.Context, release *entities.Release) (bool, error)
+	SaveRelease(ctx context.Context, release *entities.Release) error
+	DeleteRelease(ctx context.Context, id string) error
+}
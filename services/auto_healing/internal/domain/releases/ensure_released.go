@@ -0,0 +1,112 @@
+package releases
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/releases/errors"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+func (s *ReleaseService) EnsureReleased(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error {
+	release, err := s.repo.GetRelease(ctx, entities.ReleaseID(hostID, action))
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		return s.request(ctx, hostID, projectID, action)
+	}
+	if release.ValidAt(s.now(), s.ttl) {
+		return nil
+	}
+	if release.ApprovedAt != nil {
+		if err := s.drop(ctx, release); err != nil {
+			return err
+		}
+		return s.request(ctx, hostID, projectID, action)
+	}
+
+	task, err := s.cms.GetTask(ctx, release.TaskID)
+	if stdErrors.Is(err, errors.ErrTaskNotFound) {
+		if err := s.repo.DeleteRelease(ctx, release.ID); err != nil {
+			return err
+		}
+		return s.request(ctx, hostID, projectID, action)
+	}
+	if err != nil {
+		return err
+	}
+
+	host, err := s.hostName(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case task.Status == entities.TaskRejected:
+		if err := s.drop(ctx, release); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: cms rejected task %s: %s", workflowErrors.ErrHostNotReleased, task.ID, task.Message)
+	case task.Status != entities.TaskApproved || (len(task.Hosts) > 0 && !task.HasHost(host)):
+		return fmt.Errorf("%w: cms task %s of host %s is %s", workflowErrors.ErrHostNotReleased, task.ID, hostID, task.Status)
+	}
+
+	approvedAt := s.now()
+	release.ApprovedAt = &approvedAt
+	return s.repo.SaveRelease(ctx, release)
+}
+
+func (s *ReleaseService) request(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error {
+	host, err := s.hostName(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	release := &entities.Release{
+		ID:          entities.ReleaseID(hostID, action),
+		HostID:      hostID,
+		ProjectID:   projectID,
+		Action:      action,
+		TaskID:      uuid.NewString(),
+		RequestedAt: s.now(),
+	}
+	created, err := s.repo.CreateRelease(ctx, release)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return fmt.Errorf("%w: %s of host %s is already requested", workflowErrors.ErrHostNotReleased, action, hostID)
+	}
+
+	_, err = s.cms.CreateTask(ctx, &entities.Task{
+		ID:     release.TaskID,
+		Action: string(action),
+		Hosts:  []string{host},
+		Issuer: ISSUER,
+	})
+	if err != nil {
+		return stdErrors.Join(err, s.repo.DeleteRelease(ctx, release.ID))
+	}
+	return fmt.Errorf("%w: requested %s of host %s with cms task %s", workflowErrors.ErrHostNotReleased, action, hostID, release.TaskID)
+}
+
+func (s *ReleaseService) drop(ctx context.Context, release *entities.Release) error {
+	if err := s.cms.DeleteTask(ctx, release.TaskID); err != nil && !stdErrors.Is(err, errors.ErrTaskNotFound) {
+		return err
+	}
+	return s.repo.DeleteRelease(ctx, release.ID)
+}
+
+func (s *ReleaseService) hostName(ctx context.Context, hostID string) (string, error) {
+	info, err := s.hosts.GetHostInfo(ctx, hostID)
+	if err != nil {
+		return "", err
+	}
+	if info.FQDN == "" {
+		return hostID, nil
+	}
+	return info.FQDN, nil
+}
@@ -0,0 +1,91 @@
+package releases_test
+
+import (
+	"context"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/releases"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/cms"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/cms/cmstest"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnsureReleased", func() {
+	var (
+		ctx     context.Context
+		now     time.Time
+		server  *cmstest.Server
+		service *ReleaseService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		server = cmstest.NewServer("OAuth cms-token")
+		DeferCleanup(server.Close)
+
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", FQDN: "host-1.search.dc1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		service = NewDomainService(memory.NewReleaseRepository(), cms.NewClient(pkgHttp.NewClient(server.URL), "cms-token"), hosts,
+			WithClock(func() time.Time { return now }))
+	})
+
+	ensure := func() error {
+		return service.EnsureReleased(ctx, "host-1", "search", decisionEntities.ActionReboot)
+	}
+
+	It("should request the host from cms and wait for the approval", func() {
+		Expect(ensure()).To(MatchError(workflowErrors.ErrHostNotReleased))
+		tasks := server.Tasks()
+		Expect(tasks).To(HaveLen(1))
+		Expect(tasks[0]).To(SatisfyAll(
+			HaveField("Action", "reboot"),
+			HaveField("Hosts", []string{"host-1.search.dc1"}),
+			HaveField("Issuer", ISSUER),
+		))
+
+		Expect(ensure()).To(MatchError(ContainSubstring("is in-process")))
+		Expect(server.Tasks()).To(HaveLen(1))
+
+		server.Approve(tasks[0].ID)
+		Expect(ensure()).To(Succeed())
+		Expect(ensure()).To(Succeed())
+		Expect(service.EnsureReleased(ctx, "host-1", "search", decisionEntities.ActionRedeploy)).To(MatchError(workflowErrors.ErrHostNotReleased))
+	})
+
+	It("should request the host again after a rejection", func() {
+		Expect(ensure()).To(MatchError(workflowErrors.ErrHostNotReleased))
+		rejected := server.Tasks()[0].ID
+		server.Reject(rejected, "host is busy")
+
+		Expect(ensure()).To(MatchError(ContainSubstring("host is busy")))
+		Expect(server.Tasks()).To(BeEmpty())
+
+		Expect(ensure()).To(MatchError(workflowErrors.ErrHostNotReleased))
+		Expect(server.Tasks()).To(ConsistOf(HaveField("ID", Not(Equal(rejected)))))
+	})
+
+	It("should return expired releases to cms", func() {
+		server.SetAutoApprove(true)
+		Expect(ensure()).To(MatchError(workflowErrors.ErrHostNotReleased))
+		approved := server.Tasks()[0].ID
+		Expect(ensure()).To(Succeed())
+
+		now = now.Add(DEFAULT_RELEASE_TTL)
+		Expect(ensure()).To(MatchError(workflowErrors.ErrHostNotReleased))
+		Expect(server.Tasks()).To(ConsistOf(HaveField("ID", Not(Equal(approved)))))
+		Expect(ensure()).To(Succeed())
+	})
+
+	It("should fail when cms is unavailable", func() {
+		server.Close()
+		Expect(ensure()).NotTo(MatchError(workflowErrors.ErrHostNotReleased))
+		Expect(ensure()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type TaskStatus string
+
+const (
+	TaskInProcess TaskStatus = "in-process"
+	TaskApproved  TaskStatus = "ok"
+	TaskRejected  TaskStatus = "rejected"
+)
+
+type Task struct {
+	ID      string     `bson:"id"`
+	Action  string     `bson:"action"`
+	Hosts   []string   `bson:"hosts"`
+	Issuer  string     `bson:"issuer"`
+	Status  TaskStatus `bson:"status"`
+	Message string     `bson:"message"`
+}
+
+func (t *Task) HasHost(host string) bool {
+	for _, h := range t.Hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+type Release struct {
+	ID          string                  `bson:"_id"`
+	HostID      string                  `bson:"host_id"`
+	ProjectID   string                  `bson:"project_id"`
+	Action      decisionEntities.Action `bson:"action"`
+	TaskID      string                  `bson:"task_id"`
+	RequestedAt time.Time               `bson:"requested_at"`
+	ApprovedAt  *time.Time              `bson:"approved_at"`
+}
+
+func ReleaseID(hostID string, action decisionEntities.Action) string {
+	return hostID + "/" + string(action)
+}
+
+func (r *Release) ValidAt(now time.Time, ttl time.Duration) bool {
+	return r.ApprovedAt != nil && now.Before(r.ApprovedAt.Add(ttl))
+}
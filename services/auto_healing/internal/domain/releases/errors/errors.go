@@ -0,0 +1,7 @@
+package errors
+
+import "errors"
+
+var (
+	ErrTaskNotFound = errors.New("cms task not found")
+)
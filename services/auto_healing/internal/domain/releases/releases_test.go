@@ -0,0 +1,13 @@
+package releases_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReleasesSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Releases Domain Suite")
+}
@@ -0,0 +1,53 @@
+package releases
+
+import (
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/releases/contracts"
+)
+
+const (
+	DEFAULT_RELEASE_TTL = time.Hour
+	ISSUER              = "auto_healing"
+)
+
+type SetupFunc func(*ReleaseService)
+
+type ReleaseService struct {
+	repo  contracts.ReleaseRepository
+	cms   contracts.CMSClient
+	hosts contracts.HostInfoProvider
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+func WithReleaseTTL(ttl time.Duration) SetupFunc {
+	return func(s *ReleaseService) {
+		s.ttl = ttl
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *ReleaseService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	repo contracts.ReleaseRepository,
+	cms contracts.CMSClient,
+	hosts contracts.HostInfoProvider,
+	setup ...SetupFunc,
+) *ReleaseService {
+	s := &ReleaseService{
+		repo:  repo,
+		cms:   cms,
+		hosts: hosts,
+		ttl:   DEFAULT_RELEASE_TTL,
+		now:   time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type ActionHistory interface {
+	ListActions(ctx context.Context, hostID string, since time.Time) ([]decisionEntities.ActionRecord, error)
+}
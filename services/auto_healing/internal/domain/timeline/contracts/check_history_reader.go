@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type CheckHistoryReader interface {
+	GetHostChecks(ctx context.Context, hostID string) ([]*checkEntities.HostCheck, error)
+	GetCheckHistory(ctx context.Context, hostID string, checkType core_entities.CheckType, since time.Time) ([]checkEntities.CheckResult, error)
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type DecisionReader interface {
+	ListDecisions(ctx context.Context, filter decisionEntities.DecisionFilter) ([]decisionEntities.DecisionRecord, error)
+}
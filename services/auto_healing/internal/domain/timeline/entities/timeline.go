@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type EntryKind string
+
+const (
+	EntryCheck    EntryKind = "check"
+	EntryDecision EntryKind = "decision"
+	EntryAction   EntryKind = "action"
+)
+
+func (k EntryKind) order() int {
+	switch k {
+	case EntryCheck:
+		return 0
+	case EntryDecision:
+		return 1
+	default:
+		return 2
+	}
+}
+
+type Entry struct {
+	At         time.Time                 `bson:"at"`
+	Kind       EntryKind                 `bson:"kind"`
+	Check      core_entities.CheckType   `bson:"check"`
+	Status     checkEntities.CheckStatus `bson:"status"`
+	Metadata   map[string]string         `bson:"metadata"`
+	Action     decisionEntities.Action   `bson:"action"`
+	Rule       string                    `bson:"rule"`
+	Reason     string                    `bson:"reason"`
+	DryRun     bool                      `bson:"dry_run"`
+	DecisionID string                    `bson:"decision_id"`
+}
+
+type Timeline struct {
+	HostID    string                            `bson:"host_id"`
+	Since     time.Time                         `bson:"since"`
+	Until     time.Time                         `bson:"until"`
+	Entries   []Entry                           `bson:"entries"`
+	Decisions []decisionEntities.DecisionRecord `bson:"decisions"`
+}
+
+func NewTimeline(
+	hostID string,
+	since, until time.Time,
+	results []checkEntities.CheckResult,
+	decisions []decisionEntities.DecisionRecord,
+	actions []decisionEntities.ActionRecord,
+) *Timeline {
+	timeline := &Timeline{
+		HostID:    hostID,
+		Since:     since,
+		Until:     until,
+		Entries:   []Entry{},
+		Decisions: []decisionEntities.DecisionRecord{},
+	}
+	within := func(at time.Time) bool {
+		return !at.Before(since) && at.Before(until)
+	}
+
+	statuses := map[core_entities.CheckType]checkEntities.CheckStatus{}
+	for _, result := range results {
+		if !within(result.Timestamp) {
+			continue
+		}
+		if status, found := statuses[result.Type]; found && status == result.Status {
+			continue
+		}
+		statuses[result.Type] = result.Status
+		timeline.Entries = append(timeline.Entries, Entry{
+			At:       result.Timestamp,
+			Kind:     EntryCheck,
+			Check:    result.Type,
+			Status:   result.Status,
+			Metadata: result.Metadata,
+		})
+	}
+
+	var previous *decisionEntities.Decision
+	for _, record := range decisions {
+		if !within(record.DecidedAt) {
+			continue
+		}
+		timeline.Decisions = append(timeline.Decisions, record)
+		decision := record.Decision
+		if previous != nil && previous.Action == decision.Action && previous.Rule == decision.Rule {
+			continue
+		}
+		previous = &decision
+		timeline.Entries = append(timeline.Entries, Entry{
+			At:         record.DecidedAt,
+			Kind:       EntryDecision,
+			Check:      decision.Check,
+			Action:     decision.Action,
+			Rule:       decision.Rule,
+			Reason:     decision.Reason,
+			DryRun:     decision.DryRun,
+			DecisionID: record.ID,
+		})
+	}
+
+	for _, action := range actions {
+		if !within(action.At) {
+			continue
+		}
+		timeline.Entries = append(timeline.Entries, Entry{At: action.At, Kind: EntryAction, Check: action.Check, Action: action.Action})
+	}
+
+	sort.SliceStable(timeline.Entries, func(i, j int) bool {
+		a, b := timeline.Entries[i], timeline.Entries[j]
+		if !a.At.Equal(b.At) {
+			return a.At.Before(b.At)
+		}
+		return a.Kind.order() < b.Kind.order()
+	})
+	return timeline
+}
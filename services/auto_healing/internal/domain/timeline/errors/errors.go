@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidPeriod = errors.New("invalid period")
+	ErrPeriodTooLong = errors.New("period is too long")
+)
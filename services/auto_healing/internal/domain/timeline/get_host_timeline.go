@@ -0,0 +1,50 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline/errors"
+)
+
+func (s *TimelineService) GetHostTimeline(ctx context.Context, hostID string, since, until time.Time) (*entities.Timeline, error) {
+	if until.IsZero() {
+		until = s.now()
+	}
+	if since.IsZero() {
+		since = until.Add(-DEFAULT_PERIOD)
+	}
+	if !since.Before(until) {
+		return nil, fmt.Errorf("%w: since %s is not before until %s", errors.ErrInvalidPeriod, since, until)
+	}
+	if until.Sub(since) > MAX_PERIOD {
+		return nil, fmt.Errorf("%w: %s is longer than %s", errors.ErrPeriodTooLong, until.Sub(since), MAX_PERIOD)
+	}
+
+	hostChecks, err := s.checks.GetHostChecks(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	results := []checkEntities.CheckResult{}
+	for _, check := range hostChecks {
+		history, err := s.checks.GetCheckHistory(ctx, hostID, check.Type, since)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, history...)
+	}
+
+	records, err := s.decisions.ListDecisions(ctx, decisionEntities.DecisionFilter{HostID: hostID, Since: since, Until: until})
+	if err != nil {
+		return nil, err
+	}
+	actions, err := s.actions.ListActions(ctx, hostID, since)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewTimeline(hostID, since, until, results, records, actions), nil
+}
@@ -0,0 +1,135 @@
+package timeline_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/domain/timeline"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHostTimeline", func() {
+	var (
+		ctx             context.Context
+		now             time.Time
+		start           time.Time
+		checkService    *checks.CheckService
+		decisionService *decisions.DecisionService
+		history         *memory.ActionHistory
+		service         *TimelineService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		start = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		now = start
+		clock := func() time.Time { return now }
+
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		history = memory.NewActionHistory()
+		checkService = checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(),
+			checks.WithClock(clock), checks.WithStaleTimeout(24*time.Hour))
+		decisionService = decisions.NewDomainService(hosts, checkService, history,
+			memory.NewDecisionRepository(), memory.NewDryRunRepository(), decisions.WithClock(clock))
+		service = NewDomainService(checkService, decisionService, history, WithClock(clock))
+	})
+
+	report := func(status checkEntities.CheckStatus) {
+		_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: status, Timestamp: now},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	decide := func() {
+		_, err := decisionService.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should combine check changes, decisions and actions in order", func() {
+		report(checkEntities.CheckStatusOK)
+		decide()
+		now = now.Add(10 * time.Minute)
+		report(checkEntities.CheckStatusFailed)
+		decide()
+		now = now.Add(20 * time.Minute)
+		report(checkEntities.CheckStatusFailed)
+		decide()
+		Expect(history.AddAction(ctx, "host-1", decisionEntities.ActionRecord{Action: decisionEntities.ActionReboot, Check: core_entities.CheckSSH, At: now})).To(Succeed())
+		now = now.Add(10 * time.Minute)
+		report(checkEntities.CheckStatusOK)
+		decide()
+		now = now.Add(time.Minute)
+
+		timeline, err := service.GetHostTimeline(ctx, "host-1", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timeline.Since).To(Equal(now.Add(-DEFAULT_PERIOD)))
+		Expect(timeline.Until).To(Equal(now))
+		Expect(timeline.Decisions).To(HaveLen(4))
+
+		type step struct {
+			Kind   entities.EntryKind
+			Status checkEntities.CheckStatus
+			Action decisionEntities.Action
+		}
+		steps := []step{}
+		for _, entry := range timeline.Entries {
+			steps = append(steps, step{Kind: entry.Kind, Status: entry.Status, Action: entry.Action})
+		}
+		Expect(steps).To(Equal([]step{
+			{Kind: entities.EntryCheck, Status: checkEntities.CheckStatusOK},
+			{Kind: entities.EntryDecision, Action: decisionEntities.ActionNone},
+			{Kind: entities.EntryCheck, Status: checkEntities.CheckStatusFailed},
+			{Kind: entities.EntryDecision, Action: decisionEntities.ActionWait},
+			{Kind: entities.EntryDecision, Action: decisionEntities.ActionReboot},
+			{Kind: entities.EntryAction, Action: decisionEntities.ActionReboot},
+			{Kind: entities.EntryCheck, Status: checkEntities.CheckStatusOK},
+			{Kind: entities.EntryDecision, Action: decisionEntities.ActionNone},
+		}))
+		Expect(timeline.Entries[4].DecisionID).To(Equal(timeline.Decisions[2].ID))
+		Expect(timeline.Entries[4].Rule).To(Equal("ssh"))
+	})
+
+	It("should collapse repeated decisions keeping their records", func() {
+		report(checkEntities.CheckStatusFailed)
+		decide()
+		now = now.Add(time.Minute)
+		decide()
+		now = now.Add(time.Minute)
+
+		timeline, err := service.GetHostTimeline(ctx, "host-1", start, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timeline.Entries).To(HaveLen(2))
+		Expect(timeline.Decisions).To(HaveLen(2))
+	})
+
+	It("should limit the timeline to the period", func() {
+		report(checkEntities.CheckStatusFailed)
+		decide()
+		now = now.Add(time.Hour)
+		report(checkEntities.CheckStatusOK)
+		decide()
+
+		timeline, err := service.GetHostTimeline(ctx, "host-1", start.Add(time.Minute), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timeline.Entries).To(BeEmpty())
+		Expect(timeline.Decisions).To(BeEmpty())
+	})
+
+	It("should reject invalid periods", func() {
+		_, err := service.GetHostTimeline(ctx, "host-1", now, now)
+		Expect(err).To(MatchError(errors.ErrInvalidPeriod))
+		_, err = service.GetHostTimeline(ctx, "host-1", now.Add(-MAX_PERIOD-time.Hour), now)
+		Expect(err).To(MatchError(errors.ErrPeriodTooLong))
+	})
+})
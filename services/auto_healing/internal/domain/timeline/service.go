@@ -0,0 +1,45 @@
+package timeline
+
+import (
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/timeline/contracts"
+)
+
+const (
+	DEFAULT_PERIOD = 24 * time.Hour
+	MAX_PERIOD     = 31 * 24 * time.Hour
+)
+
+type SetupFunc func(*TimelineService)
+
+type TimelineService struct {
+	checks    contracts.CheckHistoryReader
+	decisions contracts.DecisionReader
+	actions   contracts.ActionHistory
+	now       func() time.Time
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *TimelineService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	checks contracts.CheckHistoryReader,
+	decisions contracts.DecisionReader,
+	actions contracts.ActionHistory,
+	setup ...SetupFunc,
+) *TimelineService {
+	s := &TimelineService{
+		checks:    checks,
+		decisions: decisions,
+		actions:   actions,
+		now:       time.Now,
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
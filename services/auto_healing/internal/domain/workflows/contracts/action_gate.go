@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	limitEntities "github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+)
+
+type AutomationLimiter interface {
+	StartAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) (*limitEntities.AutomatedAction, error)
+	FinishAction(ctx context.Context, id string) error
+}
+
+type ActionGuard interface {
+	CheckAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type ActionRunner interface {
+	RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error
+}
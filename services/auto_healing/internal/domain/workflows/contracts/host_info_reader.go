@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type HostInfoReader interface {
+	GetHostInfo(ctx context.Context, hostID string) (*decisionEntities.HostInfo, error)
+}
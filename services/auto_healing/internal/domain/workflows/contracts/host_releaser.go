@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type HostReleaser interface {
+	EnsureReleased(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error
+}
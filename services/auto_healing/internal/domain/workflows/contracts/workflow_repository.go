@@ -0,0 +1,16 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+)
+
+type WorkflowRepository interface {
+	Create(ctx context.Context, workflow *entities.Workflow) error
+	Update(ctx context.Context, workflow *entities.Workflow) error
+	Get(ctx context.Context, id string) (*entities.Workflow, error)
+	FindActiveByHost(ctx context.Context, hostID string) (*entities.Workflow, error)
+	ListActive(ctx context.Context) ([]*entities.Workflow, error)
+	ListByHost(ctx context.Context, hostID string) ([]*entities.Workflow, error)
+}
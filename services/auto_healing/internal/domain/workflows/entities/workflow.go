@@ -0,0 +1,239 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gwall-e/auto_healing/events"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type WorkflowStatus string
+
+const (
+	WorkflowRunning     WorkflowStatus = "running"
+	WorkflowSucceeded   WorkflowStatus = "succeeded"
+	WorkflowHandedOver  WorkflowStatus = "handed-over"
+	WorkflowFailed      WorkflowStatus = "failed"
+	WorkflowCancelled   WorkflowStatus = "cancelled"
+	WorkflowNeedsReview WorkflowStatus = "needs-review"
+)
+
+type StepStatus string
+
+const (
+	StepPending     StepStatus = "pending"
+	StepRunning     StepStatus = "running"
+	StepVerifying   StepStatus = "verifying"
+	StepSucceeded   StepStatus = "succeeded"
+	StepFailed      StepStatus = "failed"
+	StepNeedsReview StepStatus = "needs-review"
+)
+
+type WorkflowStep struct {
+	Action            decisionEntities.Action `bson:"action"`
+	Status            StepStatus              `bson:"status"`
+	Attempts          int                     `bson:"attempts"`
+	Error             string                  `bson:"error"`
+	Timeout           time.Duration           `bson:"timeout"`
+	StartedAt         *time.Time              `bson:"started_at"`
+	FinishedAt        *time.Time              `bson:"finished_at"`
+	AutomatedActionID string                  `bson:"automated_action_id"`
+}
+
+func (s *WorkflowStep) Deadline() time.Time {
+	if s.StartedAt == nil {
+		return time.Time{}
+	}
+	return s.StartedAt.Add(s.Timeout)
+}
+
+func (s *WorkflowStep) Verifiable() bool {
+	return s.Action != decisionEntities.ActionReportToDatacenter
+}
+
+type Workflow struct {
+	ID         string                  `bson:"_id"`
+	HostID     string                  `bson:"host_id"`
+	ProjectID  string                  `bson:"project_id"`
+	Check      core_entities.CheckType `bson:"check"`
+	Rule       string                  `bson:"rule"`
+	ApprovedBy string                  `bson:"approved_by"`
+	Steps      []WorkflowStep          `bson:"steps"`
+	Status     WorkflowStatus          `bson:"status"`
+	CreatedAt  time.Time               `bson:"created_at"`
+	UpdatedAt  time.Time               `bson:"updated_at"`
+	Owner      string                  `bson:"owner"`
+	LeaseUntil *time.Time              `bson:"lease_until"`
+	Version    int64                   `bson:"version"`
+	events     []interface{}           `bson:"-"`
+}
+
+type WorkflowRequest struct {
+	HostID     string
+	ProjectID  string
+	Check      core_entities.CheckType
+	Rule       string
+	Actions    []decisionEntities.Action
+	ApprovedBy string
+}
+
+func NewWorkflow(request WorkflowRequest, timeouts map[decisionEntities.Action]time.Duration, now time.Time) *Workflow {
+	workflow := &Workflow{
+		ID:         uuid.NewString(),
+		HostID:     request.HostID,
+		ProjectID:  request.ProjectID,
+		Check:      request.Check,
+		Rule:       request.Rule,
+		ApprovedBy: request.ApprovedBy,
+		Steps:      make([]WorkflowStep, 0, len(request.Actions)),
+		Status:     WorkflowRunning,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	for _, action := range request.Actions {
+		workflow.Steps = append(workflow.Steps, WorkflowStep{Action: action, Status: StepPending, Timeout: timeouts[action]})
+	}
+	workflow.addStepEvent(0)
+	return workflow
+}
+
+func (w *Workflow) Events() []interface{} {
+	return w.events
+}
+
+func (w *Workflow) ClearEvents() {
+	w.events = nil
+}
+
+func (w *Workflow) addEvent(event interface{}) {
+	w.events = append(w.events, event)
+}
+
+func (w *Workflow) IsActive() bool {
+	return w.Status == WorkflowRunning
+}
+
+func (w *Workflow) CurrentStep() int {
+	if !w.IsActive() {
+		return -1
+	}
+	for i := range w.Steps {
+		switch w.Steps[i].Status {
+		case StepPending, StepRunning, StepVerifying:
+			return i
+		}
+	}
+	return -1
+}
+
+func (w *Workflow) StartStep(index int, now time.Time) {
+	step := &w.Steps[index]
+	step.Status = StepRunning
+	step.Attempts++
+	step.Error = ""
+	step.StartedAt = &now
+	step.FinishedAt = nil
+	w.UpdatedAt = now
+	w.addStepEvent(index)
+}
+
+func (w *Workflow) VerifyStep(index int, now time.Time) {
+	w.Steps[index].Status = StepVerifying
+	w.UpdatedAt = now
+	w.addStepEvent(index)
+}
+
+func (w *Workflow) SucceedStep(index int, now time.Time) {
+	step := &w.Steps[index]
+	step.Status = StepSucceeded
+	step.FinishedAt = &now
+	w.Status = WorkflowSucceeded
+	if !step.Verifiable() {
+		w.Status = WorkflowHandedOver
+	}
+	w.UpdatedAt = now
+	w.addStepEvent(index)
+}
+
+func (w *Workflow) FailStep(index int, now time.Time, reason string) {
+	step := &w.Steps[index]
+	step.Status = StepFailed
+	step.Error = reason
+	step.FinishedAt = &now
+	if index == len(w.Steps)-1 {
+		w.Status = WorkflowFailed
+	}
+	w.UpdatedAt = now
+	w.addStepEvent(index)
+	if w.IsActive() {
+		w.addStepEvent(index + 1)
+	}
+}
+
+func (w *Workflow) RecoverStep(index int, now time.Time) {
+	step := &w.Steps[index]
+	step.Error = "interrupted while running"
+	if step.Verifiable() {
+		step.Status = StepVerifying
+	} else {
+		step.Status = StepNeedsReview
+		step.FinishedAt = &now
+		w.Status = WorkflowNeedsReview
+	}
+	w.UpdatedAt = now
+	w.addStepEvent(index)
+}
+
+func (w *Workflow) Claim(owner string, now time.Time, until time.Time) bool {
+	if w.Owner != owner && w.LeaseUntil != nil && now.Before(*w.LeaseUntil) {
+		return false
+	}
+	w.Owner = owner
+	w.LeaseUntil = &until
+	return true
+}
+
+func (w *Workflow) RenewLease(until time.Time) {
+	w.LeaseUntil = &until
+}
+
+func (w *Workflow) PostponeStep(index int, now time.Time, reason string) bool {
+	step := &w.Steps[index]
+	if step.Error == reason {
+		return false
+	}
+	step.Error = reason
+	w.UpdatedAt = now
+	return true
+}
+
+func (w *Workflow) Cancel(now time.Time) {
+	index := w.CurrentStep()
+	w.Status = WorkflowCancelled
+	w.UpdatedAt = now
+	if index >= 0 {
+		w.addStepEvent(index)
+	}
+}
+
+func (w *Workflow) addStepEvent(index int) {
+	if index >= len(w.Steps) {
+		return
+	}
+	step := w.Steps[index]
+	w.addEvent(&events.HealingStepChangedEvent{
+		WorkflowID:     w.ID,
+		HostID:         w.HostID,
+		ProjectID:      w.ProjectID,
+		Check:          string(w.Check),
+		Step:           index,
+		Action:         string(step.Action),
+		Status:         string(step.Status),
+		WorkflowStatus: string(w.Status),
+		Attempts:       step.Attempts,
+		Error:          step.Error,
+		At:             w.UpdatedAt,
+	})
+}
@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	ErrWorkflowNotFound        = errors.New("workflow not found")
+	ErrWorkflowInProgress      = errors.New("host already has a running workflow")
+	ErrWorkflowFinished        = errors.New("workflow is finished")
+	ErrInvalidWorkflow         = errors.New("invalid workflow")
+	ErrApprovalRequired        = errors.New("decision requires approval")
+	ErrWorkflowVersionConflict = errors.New("workflow was changed concurrently")
+	ErrWorkflowLeaseLost       = errors.New("workflow lease was taken over")
+	ErrHostNotReleased         = errors.New("host is not released by cms")
+)
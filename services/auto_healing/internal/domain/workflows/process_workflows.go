@@ -0,0 +1,265 @@
+package workflows
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func (s *WorkflowService) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessWorkflows(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *WorkflowService) ProcessWorkflows(ctx context.Context) error {
+	workflows, err := s.repo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, workflow := range workflows {
+		claimed, err := s.claim(ctx, workflow)
+		if err == nil && claimed {
+			err = s.advance(ctx, workflow)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("workflow %s of host %s: %w", workflow.ID, workflow.HostID, err))
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+func (s *WorkflowService) claim(ctx context.Context, workflow *entities.Workflow) (bool, error) {
+	now := s.now()
+	if !workflow.Claim(s.owner, now, now.Add(s.lease)) {
+		return false, nil
+	}
+	err := s.repo.Update(ctx, workflow)
+	if stdErrors.Is(err, errors.ErrWorkflowVersionConflict) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *WorkflowService) advance(ctx context.Context, workflow *entities.Workflow) error {
+	for {
+		index := workflow.CurrentStep()
+		if index < 0 {
+			return nil
+		}
+		step := &workflow.Steps[index]
+
+		switch step.Status {
+		case entities.StepRunning:
+			workflow.RecoverStep(index, s.now())
+			if !workflow.IsActive() {
+				if err := s.releaseStep(ctx, workflow, index); err != nil {
+					return err
+				}
+			}
+			if err := s.save(ctx, workflow); err != nil {
+				return err
+			}
+			continue
+
+		case entities.StepPending:
+			forbidden, err := s.forbiddenBy(ctx, workflow, index)
+			if err != nil {
+				return err
+			}
+			if forbidden != "" {
+				if err := s.failStep(ctx, workflow, index, forbidden); err != nil {
+					return err
+				}
+				continue
+			}
+			if reason := s.admitStep(ctx, workflow, index); reason != "" {
+				if workflow.PostponeStep(index, s.now(), reason) {
+					return s.save(ctx, workflow)
+				}
+				return nil
+			}
+			workflow.StartStep(index, s.now())
+			if err := s.save(ctx, workflow); err != nil {
+				return err
+			}
+			if err := s.runStep(ctx, workflow, index); err != nil {
+				if stdErrors.Is(err, errors.ErrWorkflowLeaseLost) {
+					return err
+				}
+				if err := s.failStep(ctx, workflow, index, fmt.Sprintf("%s failed: %v", step.Action, err)); err != nil {
+					return err
+				}
+				continue
+			}
+			if !step.Verifiable() {
+				return s.succeedStep(ctx, workflow, index)
+			}
+			workflow.VerifyStep(index, s.now())
+			return s.save(ctx, workflow)
+
+		case entities.StepVerifying:
+			healed, err := s.verifyStep(ctx, workflow, index)
+			if err != nil {
+				return err
+			}
+			if healed {
+				return s.succeedStep(ctx, workflow, index)
+			}
+			if s.now().Before(step.Deadline()) {
+				return nil
+			}
+			reason := fmt.Sprintf("no fresh passing %s check within %s after %s", workflow.Check, step.Timeout, step.Action)
+			if err := s.failStep(ctx, workflow, index, reason); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *WorkflowService) forbiddenBy(ctx context.Context, workflow *entities.Workflow, index int) (string, error) {
+	action := workflow.Steps[index].Action
+	restriction, ok := action.Restriction()
+	if !ok {
+		return "", nil
+	}
+	info, err := s.hosts.GetHostInfo(ctx, workflow.HostID)
+	if err != nil {
+		return "", err
+	}
+	effective := core_entities.EffectiveRestrictions(info.Restrictions)
+	if forbidden, ok := core_entities.ForbiddenBy(effective, restriction, true); ok {
+		return fmt.Sprintf("%s is forbidden by restriction %s", action, forbidden), nil
+	}
+	return "", nil
+}
+
+func (s *WorkflowService) admitStep(ctx context.Context, workflow *entities.Workflow, index int) string {
+	action := workflow.Steps[index].Action
+	for _, guard := range s.guards {
+		if err := guard.CheckAction(ctx, workflow.HostID, workflow.ProjectID, action); err != nil {
+			return fmt.Sprintf("postponed: %v", err)
+		}
+	}
+	if _, touchesHost := action.Restriction(); touchesHost {
+		if err := s.releaser.EnsureReleased(ctx, workflow.HostID, workflow.ProjectID, action); err != nil {
+			return fmt.Sprintf("postponed: %v", err)
+		}
+	}
+	if s.limiter == nil {
+		return ""
+	}
+	automated, err := s.limiter.StartAction(ctx, workflow.HostID, workflow.ProjectID, action)
+	if err != nil {
+		return fmt.Sprintf("postponed: %v", err)
+	}
+	workflow.Steps[index].AutomatedActionID = automated.ID
+	return ""
+}
+
+func (s *WorkflowService) runStep(ctx context.Context, workflow *entities.Workflow, index int) error {
+	step := workflow.Steps[index]
+	leaseCtx, release := s.holdLease(ctx, workflow)
+	runCtx, cancel := context.WithTimeout(leaseCtx, step.Timeout)
+	err := s.runner.RunAction(runCtx, workflow.HostID, step.Action)
+	cancel()
+	release()
+	if cause := context.Cause(leaseCtx); stdErrors.Is(cause, errors.ErrWorkflowLeaseLost) {
+		return cause
+	}
+	if err != nil {
+		return err
+	}
+	return s.history.AddAction(ctx, workflow.HostID, decisionEntities.ActionRecord{
+		Action: step.Action,
+		Check:  workflow.Check,
+		At:     *step.StartedAt,
+	})
+}
+
+func (s *WorkflowService) holdLease(ctx context.Context, workflow *entities.Workflow) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				workflow.RenewLease(s.now().Add(s.lease))
+				if err := s.repo.Update(ctx, workflow); err != nil {
+					cancel(fmt.Errorf("%w: %v", errors.ErrWorkflowLeaseLost, err))
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		wg.Wait()
+		cancel(nil)
+	}
+}
+
+func (s *WorkflowService) verifyStep(ctx context.Context, workflow *entities.Workflow, index int) (bool, error) {
+	checks, err := s.checks.GetHostChecks(ctx, workflow.HostID)
+	if err != nil {
+		return false, err
+	}
+	startedAt := *workflow.Steps[index].StartedAt
+	for _, check := range checks {
+		if check.Type == workflow.Check {
+			return !check.Stale && check.Timestamp.After(startedAt) && check.Status != checkEntities.CheckStatusFailed, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *WorkflowService) succeedStep(ctx context.Context, workflow *entities.Workflow, index int) error {
+	workflow.SucceedStep(index, s.now())
+	if err := s.releaseStep(ctx, workflow, index); err != nil {
+		return err
+	}
+	return s.save(ctx, workflow)
+}
+
+func (s *WorkflowService) failStep(ctx context.Context, workflow *entities.Workflow, index int, reason string) error {
+	workflow.FailStep(index, s.now(), reason)
+	if err := s.releaseStep(ctx, workflow, index); err != nil {
+		return err
+	}
+	return s.save(ctx, workflow)
+}
+
+func (s *WorkflowService) releaseStep(ctx context.Context, workflow *entities.Workflow, index int) error {
+	if index < 0 || s.limiter == nil || workflow.Steps[index].AutomatedActionID == "" {
+		return nil
+	}
+	return s.limiter.FinishAction(ctx, workflow.Steps[index].AutomatedActionID)
+}
@@ -0,0 +1,427 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gwall-e/auto_healing/events"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits"
+	. "github.com/gwall-e/auto_healing/internal/domain/workflows"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkflowService", func() {
+	var (
+		ctx          context.Context
+		now          time.Time
+		clock        func() time.Time
+		repo         *memory.WorkflowRepository
+		runner       *fakeRunner
+		hosts        *memory.HostInfoRepository
+		releases     *memory.HostReleaseRepository
+		checkService *checks.CheckService
+		history      *memory.ActionHistory
+		eventLog     *memory.EventLog
+		service      *WorkflowService
+	)
+
+	chain := []decisionEntities.Action{
+		decisionEntities.ActionReboot,
+		decisionEntities.ActionRedeploy,
+		decisionEntities.ActionReportToDatacenter,
+	}
+
+	newService := func(setup ...SetupFunc) *WorkflowService {
+		setup = append([]SetupFunc{WithClock(clock)}, setup...)
+		return NewDomainService(repo, runner, hosts, releases, checkService, history, eventLog, setup...)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock = func() time.Time { return now }
+		repo = memory.NewWorkflowRepository()
+		runner = newFakeRunner()
+		hosts = memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		releases = memory.NewHostReleaseRepository()
+		releases.SetReleased("host-1", decisionEntities.ActionReboot, decisionEntities.ActionRedeploy, decisionEntities.ActionProfile)
+		checkService = checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		history = memory.NewActionHistory()
+		eventLog = memory.NewEventLog()
+		service = newService()
+	})
+
+	report := func(status checkEntities.CheckStatus) {
+		_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: status, Timestamp: now},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	start := func() *entities.Workflow {
+		workflow, err := service.StartWorkflow(ctx, entities.WorkflowRequest{
+			HostID:    "host-1",
+			ProjectID: "search",
+			Check:     core_entities.CheckSSH,
+			Rule:      "ssh",
+			Actions:   chain,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return workflow
+	}
+
+	process := func(id string) *entities.Workflow {
+		Expect(service.ProcessWorkflows(ctx)).To(Succeed())
+		workflow, err := service.GetWorkflow(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		return workflow
+	}
+
+	stepEvents := func() []string {
+		transitions := []string{}
+		for _, event := range eventLog.Events() {
+			changed := event.(*events.HealingStepChangedEvent)
+			transitions = append(transitions, changed.Action+":"+changed.Status+":"+changed.WorkflowStatus)
+		}
+		return transitions
+	}
+
+	It("should verify the action by a fresh passing check", func() {
+		report(checkEntities.CheckStatusFailed)
+		workflow := start()
+
+		now = now.Add(time.Minute)
+		workflow = process(workflow.ID)
+		Expect(runner.Calls()).To(Equal([]decisionEntities.Action{decisionEntities.ActionReboot}))
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepVerifying))
+		Expect(workflow.Steps[0].Attempts).To(Equal(1))
+		Expect(workflow.Steps[0].Deadline()).To(Equal(now.Add(30 * time.Minute)))
+		Expect(history.ListActions(ctx, "host-1", time.Time{})).To(Equal([]decisionEntities.ActionRecord{
+			{Action: decisionEntities.ActionReboot, Check: core_entities.CheckSSH, At: now},
+		}))
+
+		now = now.Add(5 * time.Minute)
+		report(checkEntities.CheckStatusFailed)
+		workflow = process(workflow.ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepVerifying))
+
+		now = now.Add(5 * time.Minute)
+		report(checkEntities.CheckStatusOK)
+		workflow = process(workflow.ID)
+		Expect(workflow.Status).To(Equal(entities.WorkflowSucceeded))
+		Expect(workflow.Steps[0].FinishedAt).To(Equal(&now))
+		Expect(workflow.Steps[1].Status).To(Equal(entities.StepPending))
+
+		Expect(stepEvents()).To(Equal([]string{
+			"reboot:pending:running",
+			"reboot:running:running",
+			"reboot:verifying:running",
+			"reboot:succeeded:succeeded",
+		}))
+		Expect(eventLog.Events()[1]).To(Equal(&events.HealingStepChangedEvent{
+			WorkflowID:     workflow.ID,
+			HostID:         "host-1",
+			ProjectID:      "search",
+			Check:          "ssh",
+			Step:           0,
+			Action:         "reboot",
+			Status:         "running",
+			WorkflowStatus: "running",
+			Attempts:       1,
+			At:             now.Add(-10 * time.Minute),
+		}))
+	})
+
+	It("should escalate to the next step when verification times out", func() {
+		service = newService(WithStepTimeout(decisionEntities.ActionReboot, 10*time.Minute))
+		workflow := start()
+		workflow = process(workflow.ID)
+
+		now = now.Add(10 * time.Minute)
+		workflow = process(workflow.ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepFailed))
+		Expect(workflow.Steps[0].Error).To(Equal("no fresh passing ssh check within 10m0s after reboot"))
+		Expect(workflow.Steps[1].Status).To(Equal(entities.StepVerifying))
+		Expect(runner.Calls()).To(Equal([]decisionEntities.Action{decisionEntities.ActionReboot, decisionEntities.ActionRedeploy}))
+	})
+
+	It("should escalate failed actions right away and hand the host over at the end", func() {
+		runner.errors[decisionEntities.ActionReboot] = errors.New("bmc unreachable")
+		runner.errors[decisionEntities.ActionRedeploy] = errors.New("no deploy config")
+		workflow := process(start().ID)
+
+		Expect(workflow.Status).To(Equal(entities.WorkflowHandedOver))
+		Expect(workflow.Steps[0].Error).To(Equal("reboot failed: bmc unreachable"))
+		Expect(workflow.Steps[1].Status).To(Equal(entities.StepFailed))
+		Expect(workflow.Steps[2].Status).To(Equal(entities.StepSucceeded))
+		Expect(stepEvents()).To(Equal([]string{
+			"reboot:pending:running",
+			"reboot:running:running",
+			"reboot:failed:running",
+			"redeploy:pending:running",
+			"redeploy:running:running",
+			"redeploy:failed:running",
+			"report-to-datacenter:pending:running",
+			"report-to-datacenter:running:running",
+			"report-to-datacenter:succeeded:handed-over",
+		}))
+		Expect(history.ListActions(ctx, "host-1", time.Time{})).To(HaveLen(1))
+	})
+
+	It("should fail the workflow when its last step fails", func() {
+		workflow, err := service.StartWorkflow(ctx, entities.WorkflowRequest{
+			HostID: "host-1", Check: core_entities.CheckSSH, Actions: []decisionEntities.Action{decisionEntities.ActionReboot},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		runner.errors[decisionEntities.ActionReboot] = errors.New("bmc unreachable")
+		Expect(process(workflow.ID).Status).To(Equal(entities.WorkflowFailed))
+	})
+
+	crash := func(workflow *entities.Workflow, index int) {
+		Expect(workflow.Claim("crashed", now, now.Add(time.Minute))).To(BeTrue())
+		for i := 0; i < index; i++ {
+			workflow.FailStep(i, now, "failed")
+		}
+		workflow.StartStep(index, now)
+		Expect(repo.Update(ctx, workflow)).To(Succeed())
+	}
+
+	It("should verify a step interrupted by a crash instead of running it again", func() {
+		workflow := start()
+		crash(workflow, 0)
+
+		workflow = process(workflow.ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepRunning))
+		Expect(runner.Calls()).To(BeEmpty())
+
+		now = now.Add(2 * time.Minute)
+		workflow = process(workflow.ID)
+		Expect(workflow.Owner).NotTo(Equal("crashed"))
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepVerifying))
+		Expect(workflow.Steps[0].Error).To(Equal("interrupted while running"))
+		Expect(workflow.Steps[0].Attempts).To(Equal(1))
+		Expect(runner.Calls()).To(BeEmpty())
+
+		report(checkEntities.CheckStatusOK)
+		Expect(process(workflow.ID).Status).To(Equal(entities.WorkflowSucceeded))
+		Expect(runner.Calls()).To(BeEmpty())
+	})
+
+	It("should leave a hand over interrupted by a crash for review", func() {
+		workflow := start()
+		crash(workflow, 2)
+
+		now = now.Add(2 * time.Minute)
+		workflow = process(workflow.ID)
+		Expect(workflow.Status).To(Equal(entities.WorkflowNeedsReview))
+		Expect(workflow.Steps[2].Status).To(Equal(entities.StepNeedsReview))
+		Expect(runner.Calls()).To(BeEmpty())
+	})
+
+	It("should leave workflows leased by another executor alone", func() {
+		other := newService(WithOwner("other"))
+		workflow := start()
+
+		runner.onRun = func(context.Context) {
+			Expect(other.ProcessWorkflows(ctx)).To(Succeed())
+		}
+		workflow = process(workflow.ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepVerifying))
+		Expect(runner.Calls()).To(HaveLen(1))
+
+		runner.onRun = nil
+		now = now.Add(DEFAULT_LEASE)
+		Expect(other.ProcessWorkflows(ctx)).To(Succeed())
+		workflow, err := service.GetWorkflow(ctx, workflow.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.Owner).To(Equal("other"))
+		Expect(service.ProcessWorkflows(ctx)).To(Succeed())
+		Expect(runner.Calls()).To(HaveLen(1))
+	})
+
+	It("should stop the action when another executor takes the workflow over", func() {
+		service = newService(WithLease(30 * time.Millisecond))
+		workflow := start()
+
+		runner.onRun = func(runCtx context.Context) {
+			stolen, err := repo.Get(ctx, workflow.ID)
+			Expect(err).NotTo(HaveOccurred())
+			stolen.Owner = "other"
+			Expect(repo.Update(ctx, stolen)).To(Succeed())
+			Eventually(runCtx.Done()).Should(BeClosed())
+		}
+		err := service.ProcessWorkflows(ctx)
+		Expect(err).To(MatchError(workflowErrors.ErrWorkflowLeaseLost))
+
+		workflow, err = service.GetWorkflow(ctx, workflow.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.Owner).To(Equal("other"))
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepRunning))
+		Expect(history.ListActions(ctx, "host-1", time.Time{})).To(BeEmpty())
+	})
+
+	It("should check restrictions of the host again before every step", func() {
+		runner.errors[decisionEntities.ActionReboot] = errors.New("bmc unreachable")
+		runner.onRun = func(context.Context) {
+			hosts.SetHostInfo(decisionEntities.HostInfo{
+				HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer,
+				Restrictions: []core_entities.Restriction{core_entities.RestrictionNoRedeploy},
+			})
+		}
+
+		workflow := process(start().ID)
+		Expect(runner.Calls()).To(Equal([]decisionEntities.Action{
+			decisionEntities.ActionReboot,
+			decisionEntities.ActionReportToDatacenter,
+		}))
+		Expect(workflow.Steps[1].Status).To(Equal(entities.StepFailed))
+		Expect(workflow.Steps[1].Error).To(Equal("redeploy is forbidden by restriction no-redeploy"))
+		Expect(workflow.Status).To(Equal(entities.WorkflowHandedOver))
+	})
+
+	It("should postpone steps until the cms releases the host", func() {
+		releases.SetReleased("host-1")
+
+		workflow := process(start().ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepPending))
+		Expect(workflow.Steps[0].Error).To(Equal("postponed: host is not released by cms: reboot of host host-1"))
+		Expect(runner.Calls()).To(BeEmpty())
+		action, requested := releases.Requested("host-1")
+		Expect(requested).To(BeTrue())
+		Expect(action).To(Equal(decisionEntities.ActionReboot))
+
+		releases.SetReleased("host-1", decisionEntities.ActionReboot)
+		workflow = process(workflow.ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepVerifying))
+		Expect(runner.Calls()).To(HaveLen(1))
+	})
+
+	It("should postpone steps not admitted by guards and limits", func() {
+		limitService := limits.NewDomainService(memory.NewAutomationRepository(), hosts, limits.WithClock(clock))
+		guard := &fakeGuard{err: errors.New("automation is switched off")}
+		service = newService(WithLimiter(limitService), WithGuard(guard))
+
+		workflow := process(start().ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepPending))
+		Expect(workflow.Steps[0].Error).To(Equal("postponed: automation is switched off"))
+		Expect(runner.Calls()).To(BeEmpty())
+
+		guard.err = nil
+		workflow = process(workflow.ID)
+		Expect(workflow.Steps[0].Status).To(Equal(entities.StepVerifying))
+		Expect(workflow.Steps[0].Error).To(BeEmpty())
+		status, err := limitService.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.InFlight).To(Equal(1))
+
+		now = now.Add(time.Minute)
+		report(checkEntities.CheckStatusOK)
+		Expect(process(workflow.ID).Status).To(Equal(entities.WorkflowSucceeded))
+		status, err = limitService.GetProjectStatus(ctx, "search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.InFlight).To(BeZero())
+	})
+
+	actionsOf := func(workflow *entities.Workflow) []decisionEntities.Action {
+		actions := []decisionEntities.Action{}
+		for _, step := range workflow.Steps {
+			actions = append(actions, step.Action)
+		}
+		return actions
+	}
+
+	It("should start the rest of the rule ladder from a decision", func() {
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer, Tier: 1})
+		rule := decisionEntities.DefaultRules()[0]
+		workflow, err := service.StartFromDecision(ctx, "search", rule, decisionEntities.Decision{
+			HostID: "host-1", Action: decisionEntities.ActionRedeploy, Rule: rule.Name, Check: rule.Check,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actionsOf(workflow)).To(Equal([]decisionEntities.Action{
+			decisionEntities.ActionRedeploy,
+			decisionEntities.ActionReportToDatacenter,
+		}))
+
+		_, err = service.StartFromDecision(ctx, "search", rule, decisionEntities.Decision{HostID: "host-2", Action: decisionEntities.ActionReboot, Check: rule.Check, DryRun: true})
+		Expect(err).To(MatchError(workflowErrors.ErrInvalidWorkflow))
+	})
+
+	It("should not escalate a reboot into a redeploy requiring approval", func() {
+		rule := decisionEntities.DefaultRules()[0]
+		workflow, err := service.StartFromDecision(ctx, "search", rule, decisionEntities.Decision{
+			HostID: "host-1", Action: decisionEntities.ActionReboot, Rule: rule.Name, Check: rule.Check,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actionsOf(workflow)).To(Equal([]decisionEntities.Action{decisionEntities.ActionReboot}))
+
+		runner.errors[decisionEntities.ActionReboot] = errors.New("bmc is not responding")
+		workflow = process(workflow.ID)
+		Expect(workflow.Status).To(Equal(entities.WorkflowFailed))
+		Expect(runner.Calls()).To(Equal([]decisionEntities.Action{decisionEntities.ActionReboot}))
+
+		approved, err := service.StartApprovedDecision(ctx, "search", rule, decisionEntities.Decision{
+			HostID: "host-1", Action: decisionEntities.ActionRedeploy, Rule: rule.Name, Check: rule.Check, ApprovalRequired: true,
+		}, "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actionsOf(approved)).To(Equal([]decisionEntities.Action{decisionEntities.ActionRedeploy}))
+	})
+
+	It("should start decisions requiring approval only once approved", func() {
+		rule := decisionEntities.DefaultRules()[0]
+		decision := decisionEntities.Decision{
+			HostID: "host-1", Action: decisionEntities.ActionRedeploy, Rule: rule.Name, Check: rule.Check, ApprovalRequired: true,
+		}
+		_, err := service.StartFromDecision(ctx, "search", rule, decision)
+		Expect(err).To(MatchError(workflowErrors.ErrApprovalRequired))
+		_, err = service.StartApprovedDecision(ctx, "search", rule, decision, "")
+		Expect(err).To(MatchError(workflowErrors.ErrInvalidWorkflow))
+
+		workflow, err := service.StartApprovedDecision(ctx, "search", rule, decision, "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflow.ApprovedBy).To(Equal("alice"))
+		Expect(workflow.Steps[0].Action).To(Equal(decisionEntities.ActionRedeploy))
+	})
+
+	It("should allow a single running workflow per host and cancel it", func() {
+		workflow := start()
+		_, err := service.StartWorkflow(ctx, entities.WorkflowRequest{HostID: "host-1", Check: core_entities.CheckSSH, Actions: chain})
+		Expect(err).To(MatchError(workflowErrors.ErrWorkflowInProgress))
+
+		cancelled, err := service.CancelWorkflow(ctx, workflow.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cancelled.Status).To(Equal(entities.WorkflowCancelled))
+		_, err = service.CancelWorkflow(ctx, workflow.ID)
+		Expect(err).To(MatchError(workflowErrors.ErrWorkflowFinished))
+
+		Expect(process(workflow.ID).Status).To(Equal(entities.WorkflowCancelled))
+		Expect(runner.Calls()).To(BeEmpty())
+		Expect(service.ListHostWorkflows(ctx, "host-1")).To(HaveLen(1))
+	})
+
+	DescribeTable("should reject invalid workflows",
+		func(request entities.WorkflowRequest) {
+			_, err := service.StartWorkflow(ctx, request)
+			Expect(err).To(MatchError(workflowErrors.ErrInvalidWorkflow))
+		},
+		Entry("without host", entities.WorkflowRequest{Check: core_entities.CheckSSH, Actions: chain}),
+		Entry("unknown check", entities.WorkflowRequest{HostID: "host-1", Check: "dns", Actions: chain}),
+		Entry("without actions", entities.WorkflowRequest{HostID: "host-1", Check: core_entities.CheckSSH}),
+		Entry("wait action", entities.WorkflowRequest{HostID: "host-1", Check: core_entities.CheckSSH,
+			Actions: []decisionEntities.Action{decisionEntities.ActionWait}}),
+	)
+
+	It("should report unknown workflows", func() {
+		_, err := service.GetWorkflow(ctx, "unknown")
+		Expect(err).To(MatchError(workflowErrors.ErrWorkflowNotFound))
+	})
+})
@@ -0,0 +1,115 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/contracts"
+)
+
+const (
+	DEFAULT_POLL_INTERVAL = 30 * time.Second
+	DEFAULT_LEASE         = 2 * time.Minute
+)
+
+var DEFAULT_STEP_TIMEOUTS = map[decisionEntities.Action]time.Duration{
+	decisionEntities.ActionReboot:             30 * time.Minute,
+	decisionEntities.ActionRedeploy:           3 * time.Hour,
+	decisionEntities.ActionProfile:            6 * time.Hour,
+	decisionEntities.ActionReportToDatacenter: 5 * time.Minute,
+}
+
+type SetupFunc func(*WorkflowService)
+
+type WorkflowService struct {
+	repo         contracts.WorkflowRepository
+	runner       contracts.ActionRunner
+	hosts        contracts.HostInfoReader
+	releaser     contracts.HostReleaser
+	checks       contracts.CheckReader
+	history      contracts.ActionRecorder
+	publisher    contracts.EventPublisher
+	limiter      contracts.AutomationLimiter
+	guards       []contracts.ActionGuard
+	timeouts     map[decisionEntities.Action]time.Duration
+	pollInterval time.Duration
+	owner        string
+	lease        time.Duration
+	now          func() time.Time
+}
+
+func WithLimiter(limiter contracts.AutomationLimiter) SetupFunc {
+	return func(s *WorkflowService) {
+		s.limiter = limiter
+	}
+}
+
+func WithGuard(guard contracts.ActionGuard) SetupFunc {
+	return func(s *WorkflowService) {
+		s.guards = append(s.guards, guard)
+	}
+}
+
+func WithStepTimeout(action decisionEntities.Action, timeout time.Duration) SetupFunc {
+	return func(s *WorkflowService) {
+		s.timeouts[action] = timeout
+	}
+}
+
+func WithPollInterval(interval time.Duration) SetupFunc {
+	return func(s *WorkflowService) {
+		s.pollInterval = interval
+	}
+}
+
+func WithOwner(owner string) SetupFunc {
+	return func(s *WorkflowService) {
+		s.owner = owner
+	}
+}
+
+func WithLease(lease time.Duration) SetupFunc {
+	return func(s *WorkflowService) {
+		s.lease = lease
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(s *WorkflowService) {
+		s.now = now
+	}
+}
+
+func NewDomainService(
+	repo contracts.WorkflowRepository,
+	runner contracts.ActionRunner,
+	hosts contracts.HostInfoReader,
+	releaser contracts.HostReleaser,
+	checks contracts.CheckReader,
+	history contracts.ActionRecorder,
+	publisher contracts.EventPublisher,
+	setup ...SetupFunc,
+) *WorkflowService {
+	s := &WorkflowService{
+		repo:         repo,
+		runner:       runner,
+		hosts:        hosts,
+		releaser:     releaser,
+		checks:       checks,
+		history:      history,
+		publisher:    publisher,
+		timeouts:     map[decisionEntities.Action]time.Duration{},
+		pollInterval: DEFAULT_POLL_INTERVAL,
+		owner:        uuid.NewString(),
+		lease:        DEFAULT_LEASE,
+		now:          time.Now,
+	}
+	for action, timeout := range DEFAULT_STEP_TIMEOUTS {
+		s.timeouts[action] = timeout
+	}
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+func (s *WorkflowService) StartWorkflow(ctx context.Context, request entities.WorkflowRequest) (*entities.Workflow, error) {
+	if request.HostID == "" {
+		return nil, fmt.Errorf("%w: host is required", errors.ErrInvalidWorkflow)
+	}
+	if !request.Check.IsValid() {
+		return nil, fmt.Errorf("%w: unknown check type %q", errors.ErrInvalidWorkflow, request.Check)
+	}
+	if len(request.Actions) == 0 {
+		return nil, fmt.Errorf("%w: at least one action is required", errors.ErrInvalidWorkflow)
+	}
+	for _, action := range request.Actions {
+		if _, ok := s.timeouts[action]; !ok {
+			return nil, fmt.Errorf("%w: action %q can not be executed", errors.ErrInvalidWorkflow, action)
+		}
+	}
+
+	active, err := s.repo.FindActiveByHost(ctx, request.HostID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("%w: workflow %s", errors.ErrWorkflowInProgress, active.ID)
+	}
+
+	workflow := entities.NewWorkflow(request, s.timeouts, s.now())
+	if err := s.repo.Create(ctx, workflow); err != nil {
+		return nil, err
+	}
+	if err := s.publish(ctx, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+func (s *WorkflowService) StartFromDecision(ctx context.Context, projectID string, rule decisionEntities.Rule, decision decisionEntities.Decision) (*entities.Workflow, error) {
+	if decision.ApprovalRequired {
+		return nil, fmt.Errorf("%w: %s of host %s", errors.ErrApprovalRequired, decision.Action, decision.HostID)
+	}
+	return s.startFromDecision(ctx, projectID, rule, decision, "")
+}
+
+func (s *WorkflowService) StartApprovedDecision(ctx context.Context, projectID string, rule decisionEntities.Rule, decision decisionEntities.Decision, approvedBy string) (*entities.Workflow, error) {
+	if approvedBy == "" {
+		return nil, fmt.Errorf("%w: approver is required", errors.ErrInvalidWorkflow)
+	}
+	return s.startFromDecision(ctx, projectID, rule, decision, approvedBy)
+}
+
+func (s *WorkflowService) startFromDecision(ctx context.Context, projectID string, rule decisionEntities.Rule, decision decisionEntities.Decision, approvedBy string) (*entities.Workflow, error) {
+	if decision.DryRun {
+		return nil, fmt.Errorf("%w: decision for host %s is a dry run", errors.ErrInvalidWorkflow, decision.HostID)
+	}
+	info, err := s.hosts.GetHostInfo(ctx, decision.HostID)
+	if err != nil {
+		return nil, err
+	}
+	return s.StartWorkflow(ctx, entities.WorkflowRequest{
+		HostID:     decision.HostID,
+		ProjectID:  projectID,
+		Check:      decision.Check,
+		Rule:       rule.Name,
+		Actions:    rule.ChainFrom(decision.Action, info.Tier),
+		ApprovedBy: approvedBy,
+	})
+}
+
+func (s *WorkflowService) GetWorkflow(ctx context.Context, id string) (*entities.Workflow, error) {
+	workflow, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrWorkflowNotFound, id)
+	}
+	return workflow, nil
+}
+
+func (s *WorkflowService) GetActiveWorkflow(ctx context.Context, hostID string) (*entities.Workflow, error) {
+	return s.repo.FindActiveByHost(ctx, hostID)
+}
+
+func (s *WorkflowService) ListHostWorkflows(ctx context.Context, hostID string) ([]*entities.Workflow, error) {
+	return s.repo.ListByHost(ctx, hostID)
+}
+
+func (s *WorkflowService) CancelWorkflow(ctx context.Context, id string) (*entities.Workflow, error) {
+	workflow, err := s.GetWorkflow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !workflow.IsActive() {
+		return nil, fmt.Errorf("%w: %s is %s", errors.ErrWorkflowFinished, id, workflow.Status)
+	}
+	index := workflow.CurrentStep()
+	workflow.Cancel(s.now())
+	if err := s.releaseStep(ctx, workflow, index); err != nil {
+		return nil, err
+	}
+	if err := s.save(ctx, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+func (s *WorkflowService) save(ctx context.Context, workflow *entities.Workflow) error {
+	if err := s.repo.Update(ctx, workflow); err != nil {
+		return err
+	}
+	return s.publish(ctx, workflow)
+}
+
+func (s *WorkflowService) publish(ctx context.Context, workflow *entities.Workflow) error {
+	for _, event := range workflow.Events() {
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	workflow.ClearEvents()
+	return nil
+}
@@ -0,0 +1,51 @@
+package workflows_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkflowsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workflows Domain Suite")
+}
+
+type fakeRunner struct {
+	mu     sync.Mutex
+	calls  []decisionEntities.Action
+	errors map[decisionEntities.Action]error
+	onRun  func(ctx context.Context)
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{errors: map[decisionEntities.Action]error{}}
+}
+
+func (r *fakeRunner) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	if r.onRun != nil {
+		r.onRun(ctx)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, action)
+	return r.errors[action]
+}
+
+func (r *fakeRunner) Calls() []decisionEntities.Action {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]decisionEntities.Action{}, r.calls...)
+}
+
+type fakeGuard struct {
+	err error
+}
+
+func (g *fakeGuard) CheckAction(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error {
+	return g.err
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	approvalErrors "github.com/gwall-e/auto_healing/internal/domain/approvals/errors"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+type resolveApprovalRequest struct {
+	Actor   string `json:"actor"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type approvalResponse struct {
+	ID          string     `json:"id"`
+	HostID      string     `json:"host_id"`
+	ProjectID   string     `json:"project_id"`
+	Check       string     `json:"check"`
+	Rule        string     `json:"rule"`
+	Action      string     `json:"action"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy  string     `json:"resolved_by,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	WorkflowID  string     `json:"workflow_id,omitempty"`
+}
+
+type approvalsResponse struct {
+	Approvals []approvalResponse `json:"approvals"`
+}
+
+func (s *Server) listApprovals(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	since, err := parseTimeParam(query, "since")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+
+	approvals, err := s.approvals.ListApprovals(r.Context(), entities.ApprovalFilter{
+		Status: entities.ApprovalStatus(query.Get("status")),
+		HostID: query.Get("host"),
+		Since:  since,
+	})
+	if err != nil {
+		writeApprovalError(w, err)
+		return
+	}
+	response := approvalsResponse{Approvals: make([]approvalResponse, 0, len(approvals))}
+	for _, approval := range approvals {
+		response.Approvals = append(response.Approvals, newApprovalResponse(approval))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getApproval(w http.ResponseWriter, r *http.Request) {
+	approval, err := s.approvals.GetApproval(r.Context(), r.PathValue("approval_id"))
+	if err != nil {
+		writeApprovalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newApprovalResponse(approval))
+}
+
+func (s *Server) approveAction(w http.ResponseWriter, r *http.Request) {
+	var request resolveApprovalRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	approval, err := s.approvals.ApproveAction(r.Context(), r.PathValue("approval_id"), request.Actor, request.Comment)
+	if err != nil {
+		writeApprovalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newApprovalResponse(approval))
+}
+
+func (s *Server) rejectAction(w http.ResponseWriter, r *http.Request) {
+	var request resolveApprovalRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	approval, err := s.approvals.RejectAction(r.Context(), r.PathValue("approval_id"), request.Actor, request.Comment)
+	if err != nil {
+		writeApprovalError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newApprovalResponse(approval))
+}
+
+func writeApprovalError(w http.ResponseWriter, err error) {
+	var validationErr *approvalErrors.ApprovalValidationError
+	switch {
+	case errors.Is(err, approvalErrors.ErrApprovalNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, approvalErrors.ErrApprovalResolved),
+		errors.Is(err, approvalErrors.ErrHostRecovered),
+		errors.Is(err, approvalErrors.ErrDecisionChanged),
+		errors.Is(err, workflowErrors.ErrWorkflowInProgress):
+		writeError(w, http.StatusConflict, err)
+	case errors.As(err, &validationErr):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func newApprovalResponse(approval *entities.Approval) approvalResponse {
+	return approvalResponse{
+		ID:          approval.ID,
+		HostID:      approval.HostID,
+		ProjectID:   approval.ProjectID,
+		Check:       string(approval.Check),
+		Rule:        approval.Rule,
+		Action:      string(approval.Action),
+		Reason:      approval.Reason,
+		Status:      string(approval.Status),
+		RequestedAt: approval.RequestedAt,
+		ExpiresAt:   approval.ExpiresAt,
+		ResolvedAt:  approval.ResolvedAt,
+		ResolvedBy:  approval.ResolvedBy,
+		Comment:     approval.Comment,
+		WorkflowID:  approval.WorkflowID,
+	}
+}
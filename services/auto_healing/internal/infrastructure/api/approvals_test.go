@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Approvals API", func() {
+	var (
+		server      *httptest.Server
+		approvalIDs []string
+	)
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		hosts := memory.NewHostInfoRepository()
+		history := memory.NewActionHistory()
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(),
+			checks.WithClock(clock), checks.WithStaleTimeout(24*time.Hour))
+		decisionService := decisions.NewDomainService(hosts, checkService, history,
+			memory.NewDecisionRepository(), memory.NewDryRunRepository(), decisions.WithClock(clock))
+		Expect(decisionService.SetRules(ctx, []decisionEntities.Rule{{
+			Name:     "ssh",
+			Check:    core_entities.CheckSSH,
+			Actions:  []decisionEntities.Action{decisionEntities.ActionRedeploy},
+			Approval: decisionEntities.ApprovalPolicy{Actions: []decisionEntities.Action{decisionEntities.ActionRedeploy}},
+		}})).To(Succeed())
+		workflowService := workflows.NewDomainService(memory.NewWorkflowRepository(), noopRunner{}, hosts,
+			memory.NewHostReleaseRepository(), checkService, history, memory.NewEventLog(), workflows.WithClock(clock))
+		approvalService := approvals.NewDomainService(memory.NewApprovalRepository(), decisionService, workflowService,
+			checkService, memory.NewEventLog(), approvals.WithClock(clock))
+
+		approvalIDs = nil
+		for _, hostID := range []string{"host-1", "host-2"} {
+			hosts.SetHostInfo(decisionEntities.HostInfo{HostID: hostID, ProjectID: "search", UnitType: core_entities.TypeServer})
+			_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+				{HostID: hostID, Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			decision, err := decisionService.DecideHost(ctx, hostID)
+			Expect(err).NotTo(HaveOccurred())
+			approval, err := approvalService.RequestApproval(ctx, "search", *decision)
+			Expect(err).NotTo(HaveOccurred())
+			approvalIDs = append(approvalIDs, approval.ID)
+		}
+
+		server = httptest.NewServer(NewServer(checkService, WithApprovals(approvalService)))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should list pending approvals", func() {
+		status, body := do(http.MethodGet, "/api/v1/approvals?status=pending&since=2026-10-01T00:00:00Z", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["approvals"]).To(HaveLen(2))
+
+		status, body = do(http.MethodGet, "/api/v1/approvals/"+approvalIDs[0], "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("action", "redeploy"))
+		Expect(body).To(HaveKeyWithValue("status", "pending"))
+
+		status, _ = do(http.MethodGet, "/api/v1/approvals?status=waiting", "")
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, _ = do(http.MethodGet, "/api/v1/approvals/unknown", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+
+	It("should approve and reject actions recording the actor", func() {
+		status, _ := do(http.MethodPost, "/api/v1/approvals/"+approvalIDs[0]+"/approve", `{}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+
+		status, body := do(http.MethodPost, "/api/v1/approvals/"+approvalIDs[0]+"/approve", `{"actor": "alice"}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("status", "approved"))
+		Expect(body).To(HaveKeyWithValue("resolved_by", "alice"))
+		Expect(body).To(HaveKey("workflow_id"))
+
+		status, body = do(http.MethodPost, "/api/v1/approvals/"+approvalIDs[1]+"/reject", `{"actor": "bob", "comment": "not now"}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("status", "rejected"))
+		Expect(body).To(HaveKeyWithValue("comment", "not now"))
+
+		status, _ = do(http.MethodPost, "/api/v1/approvals/"+approvalIDs[1]+"/approve", `{"actor": "alice"}`)
+		Expect(status).To(Equal(http.StatusConflict))
+	})
+})
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	limitErrors "github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+)
+
+type limitsBody struct {
+	MaxActions         int    `json:"max_actions"`
+	Window             string `json:"window"`
+	MaxInFlightPercent int    `json:"max_in_flight_percent"`
+}
+
+type enableRequest struct {
+	EnabledBy string `json:"enabled_by"`
+}
+
+type projectAutomationResponse struct {
+	ProjectID   string     `json:"project_id"`
+	Disabled    bool       `json:"disabled"`
+	Reason      string     `json:"reason,omitempty"`
+	DisabledAt  *time.Time `json:"disabled_at,omitempty"`
+	EnabledAt   *time.Time `json:"enabled_at,omitempty"`
+	EnabledBy   string     `json:"enabled_by,omitempty"`
+	Limits      limitsBody `json:"limits"`
+	Overridden  bool       `json:"overridden"`
+	Hosts       int        `json:"hosts"`
+	MaxInFlight int        `json:"max_in_flight"`
+	InFlight    int        `json:"in_flight"`
+	Actions     int        `json:"actions"`
+}
+
+type disabledProjectsResponse struct {
+	Projects []projectAutomationResponse `json:"projects"`
+}
+
+func (s *Server) getProjectAutomation(w http.ResponseWriter, r *http.Request) {
+	status, err := s.limits.GetProjectStatus(r.Context(), r.PathValue("project_id"))
+	if err != nil {
+		writeLimitsError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newProjectAutomationResponse(status))
+}
+
+func (s *Server) listDisabledProjects(w http.ResponseWriter, r *http.Request) {
+	statuses, err := s.limits.ListDisabledProjects(r.Context())
+	if err != nil {
+		writeLimitsError(w, err)
+		return
+	}
+	response := disabledProjectsResponse{Projects: make([]projectAutomationResponse, 0, len(statuses))}
+	for _, status := range statuses {
+		response.Projects = append(response.Projects, newProjectAutomationResponse(status))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) enableProjectAutomation(w http.ResponseWriter, r *http.Request) {
+	var request enableRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if request.EnabledBy == "" {
+		writeError(w, http.StatusBadRequest, errors.New("enabled_by is required"))
+		return
+	}
+	projectID := r.PathValue("project_id")
+	if err := s.limits.EnableAutomation(r.Context(), projectID, request.EnabledBy); err != nil {
+		writeLimitsError(w, err)
+		return
+	}
+	s.getProjectAutomation(w, r)
+}
+
+func (s *Server) setProjectLimits(w http.ResponseWriter, r *http.Request) {
+	var request limitsBody
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	window, err := time.ParseDuration(request.Window)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid window: %w", err))
+		return
+	}
+	limits := &entities.Limits{MaxActions: request.MaxActions, Window: window, MaxInFlightPercent: request.MaxInFlightPercent}
+	if err := s.limits.SetProjectLimits(r.Context(), r.PathValue("project_id"), limits); err != nil {
+		writeLimitsError(w, err)
+		return
+	}
+	s.getProjectAutomation(w, r)
+}
+
+func (s *Server) resetProjectLimits(w http.ResponseWriter, r *http.Request) {
+	if err := s.limits.SetProjectLimits(r.Context(), r.PathValue("project_id"), nil); err != nil {
+		writeLimitsError(w, err)
+		return
+	}
+	s.getProjectAutomation(w, r)
+}
+
+func writeLimitsError(w http.ResponseWriter, err error) {
+	var validationErr *limitErrors.LimitsValidationError
+	switch {
+	case errors.Is(err, limitErrors.ErrProjectNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.As(err, &validationErr):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func newProjectAutomationResponse(status *entities.ProjectStatus) projectAutomationResponse {
+	return projectAutomationResponse{
+		ProjectID:  status.ProjectID,
+		Disabled:   status.Disabled,
+		Reason:     status.Reason,
+		DisabledAt: optionalTime(status.DisabledAt),
+		EnabledAt:  optionalTime(status.EnabledAt),
+		EnabledBy:  status.EnabledBy,
+		Limits: limitsBody{
+			MaxActions:         status.Limits.MaxActions,
+			Window:             status.Limits.Window.String(),
+			MaxInFlightPercent: status.Limits.MaxInFlightPercent,
+		},
+		Overridden:  status.Overridden,
+		Hosts:       status.Hosts,
+		MaxInFlight: status.MaxInFlight,
+		InFlight:    status.InFlight,
+		Actions:     status.Actions,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,104 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits"
+	limitEntities "github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Automation API", func() {
+	var (
+		server       *httptest.Server
+		limitService *limits.LimitService
+	)
+
+	BeforeEach(func() {
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		hosts := memory.NewHostInfoRepository()
+		for n := 1; n <= 10; n++ {
+			hosts.SetHostInfo(decisionEntities.HostInfo{HostID: fmt.Sprintf("host-%d", n), ProjectID: "search", UnitType: core_entities.TypeServer})
+		}
+		limitService = limits.NewDomainService(memory.NewAutomationRepository(), hosts, limits.WithClock(func() time.Time { return now }))
+		server = httptest.NewServer(NewServer(checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository()), WithLimits(limitService)))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should show and re-enable disabled automation", func() {
+		Expect(limitService.SetProjectLimits(context.Background(), "search",
+			&limitEntities.Limits{MaxActions: 1, Window: time.Hour, MaxInFlightPercent: 50})).To(Succeed())
+		_, err := limitService.StartAction(context.Background(), "host-1", "search", decisionEntities.ActionReboot)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = limitService.StartAction(context.Background(), "host-2", "search", decisionEntities.ActionReboot)
+		Expect(err).To(HaveOccurred())
+
+		status, body := do(http.MethodGet, "/api/v1/automation/disabled", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["projects"]).To(ConsistOf(And(
+			HaveKeyWithValue("project_id", "search"),
+			HaveKeyWithValue("disabled", true),
+			HaveKeyWithValue("reason", "1 actions within 1h0m0s reached the limit of 1"),
+			HaveKeyWithValue("in_flight", BeEquivalentTo(1)),
+		)))
+
+		status, _ = do(http.MethodPost, "/api/v1/projects/search/automation/enable", `{}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+
+		status, body = do(http.MethodPost, "/api/v1/projects/search/automation/enable", `{"enabled_by": "alice"}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("disabled", false))
+		Expect(body).To(HaveKeyWithValue("enabled_by", "alice"))
+		Expect(body).NotTo(HaveKey("reason"))
+	})
+
+	It("should override and reset project limits", func() {
+		status, body := do(http.MethodPut, "/api/v1/projects/search/automation/limits",
+			`{"max_actions": 3, "window": "30m", "max_in_flight_percent": 20}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("overridden", true))
+		Expect(body).To(HaveKeyWithValue("max_in_flight", BeEquivalentTo(2)))
+		Expect(body["limits"]).To(HaveKeyWithValue("window", "30m0s"))
+
+		status, _ = do(http.MethodPut, "/api/v1/projects/search/automation/limits",
+			`{"max_actions": 0, "window": "30m", "max_in_flight_percent": 20}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, _ = do(http.MethodPut, "/api/v1/projects/search/automation/limits",
+			`{"max_actions": 1, "window": "soon", "max_in_flight_percent": 20}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+
+		status, body = do(http.MethodDelete, "/api/v1/projects/search/automation/limits", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("overridden", false))
+		Expect(body["limits"]).To(HaveKeyWithValue("max_actions", BeEquivalentTo(2)))
+	})
+
+	It("should report unknown projects", func() {
+		status, _ := do(http.MethodGet, "/api/v1/projects/unknown/automation", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	checkErrors "github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type checkResultRequest struct {
+	HostID    string            `json:"host_id"`
+	Check     string            `json:"check"`
+	Status    string            `json:"status"`
+	Timestamp time.Time         `json:"timestamp"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type ingestRequest struct {
+	Results []checkResultRequest `json:"results"`
+}
+
+type rejectedResponse struct {
+	Index   int    `json:"index"`
+	HostID  string `json:"host_id,omitempty"`
+	Message string `json:"message"`
+}
+
+type ingestResponse struct {
+	Accepted   int                `json:"accepted"`
+	Duplicates int                `json:"duplicates"`
+	Rejected   []rejectedResponse `json:"rejected"`
+}
+
+type hostCheckResponse struct {
+	HostID        string            `json:"host_id"`
+	Check         string            `json:"check"`
+	Status        string            `json:"status"`
+	Timestamp     time.Time         `json:"timestamp"`
+	StatusSince   time.Time         `json:"status_since"`
+	ReceivedAt    time.Time         `json:"received_at"`
+	Stale         bool              `json:"stale"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	FlapScore     float64           `json:"flap_score"`
+	Flapping      bool              `json:"flapping"`
+	FlappingSince *time.Time        `json:"flapping_since,omitempty"`
+}
+
+type checksResponse struct {
+	Checks []hostCheckResponse `json:"checks"`
+}
+
+type checkResultResponse struct {
+	Status     string            `json:"status"`
+	Timestamp  time.Time         `json:"timestamp"`
+	ReceivedAt time.Time         `json:"received_at"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+type historyResponse struct {
+	HostID  string                `json:"host_id"`
+	Check   string                `json:"check"`
+	Results []checkResultResponse `json:"results"`
+}
+
+func (s *Server) ingestChecks(w http.ResponseWriter, r *http.Request) {
+	var request ingestRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	results := make([]entities.CheckResult, 0, len(request.Results))
+	for _, result := range request.Results {
+		results = append(results, entities.CheckResult{
+			HostID:    result.HostID,
+			Type:      core_entities.CheckType(result.Check),
+			Status:    entities.CheckStatus(result.Status),
+			Timestamp: result.Timestamp,
+			Metadata:  result.Metadata,
+		})
+	}
+
+	report, err := s.checks.IngestResults(r.Context(), results)
+	switch {
+	case errors.Is(err, checkErrors.ErrBatchTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	case errors.Is(err, checkErrors.ErrEmptyBatch):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := ingestResponse{
+		Accepted:   report.Accepted,
+		Duplicates: report.Duplicates,
+		Rejected:   make([]rejectedResponse, 0, len(report.Rejected)),
+	}
+	for _, rejected := range report.Rejected {
+		response.Rejected = append(response.Rejected, rejectedResponse(rejected))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) listHostChecks(w http.ResponseWriter, r *http.Request) {
+	hostChecks, err := s.checks.GetHostChecks(r.Context(), r.PathValue("host_id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newChecksResponse(hostChecks))
+}
+
+func (s *Server) listStaleChecks(w http.ResponseWriter, r *http.Request) {
+	staleChecks, err := s.checks.FindStaleChecks(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newChecksResponse(staleChecks))
+}
+
+func (s *Server) getCheckHistory(w http.ResponseWriter, r *http.Request) {
+	checkType := core_entities.CheckType(r.PathValue("check"))
+	if !checkType.IsValid() {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown check type %q", checkType))
+		return
+	}
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid since: %w", err))
+			return
+		}
+		since = parsed
+	}
+
+	hostID := r.PathValue("host_id")
+	history, err := s.checks.GetCheckHistory(r.Context(), hostID, checkType, since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := historyResponse{HostID: hostID, Check: string(checkType), Results: make([]checkResultResponse, 0, len(history))}
+	for _, result := range history {
+		response.Results = append(response.Results, checkResultResponse{
+			Status:     string(result.Status),
+			Timestamp:  result.Timestamp,
+			ReceivedAt: result.ReceivedAt,
+			Metadata:   result.Metadata,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newChecksResponse(hostChecks []*entities.HostCheck) checksResponse {
+	response := checksResponse{Checks: make([]hostCheckResponse, 0, len(hostChecks))}
+	for _, check := range hostChecks {
+		response.Checks = append(response.Checks, hostCheckResponse{
+			HostID:        check.HostID,
+			Check:         string(check.Type),
+			Status:        string(check.Status),
+			Timestamp:     check.Timestamp,
+			StatusSince:   check.StatusSince,
+			ReceivedAt:    check.ReceivedAt,
+			Stale:         check.Stale,
+			Metadata:      check.Metadata,
+			FlapScore:     check.FlapScore,
+			Flapping:      check.Flapping,
+			FlappingSince: optionalTime(check.FlappingSince),
+		})
+	}
+	return response
+}
@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checks API", func() {
+	var (
+		now    time.Time
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		service := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(func() time.Time { return now }))
+		server = httptest.NewServer(NewServer(service))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	ingest := `{"results": [
+		{"host_id": "host-1", "check": "ssh", "status": "failed", "timestamp": "2026-10-01T11:59:00Z", "metadata": {"reason": "connection refused"}},
+		{"host_id": "host-1", "check": "ssh", "status": "ok", "timestamp": "2026-10-01T11:40:00Z"},
+		{"host_id": "host-1", "check": "disk", "status": "ok", "timestamp": "2026-10-01T11:30:00Z"},
+		{"host_id": "host-1", "check": "dns", "status": "ok", "timestamp": "2026-10-01T11:59:00Z"}
+	]}`
+
+	It("should ingest results and show why a host is broken", func() {
+		status, body := do(http.MethodPost, "/api/v1/checks", ingest)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["accepted"]).To(BeEquivalentTo(3))
+		Expect(body["duplicates"]).To(BeEquivalentTo(0))
+		Expect(body["rejected"]).To(ConsistOf(HaveKeyWithValue("index", BeEquivalentTo(3))))
+
+		status, body = do(http.MethodGet, "/api/v1/hosts/host-1/checks", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["checks"]).To(HaveLen(2))
+		ssh := body["checks"].([]interface{})[1].(map[string]interface{})
+		Expect(ssh).To(HaveKeyWithValue("check", "ssh"))
+		Expect(ssh).To(HaveKeyWithValue("status", "failed"))
+		Expect(ssh).To(HaveKeyWithValue("status_since", "2026-10-01T11:59:00Z"))
+		Expect(ssh).To(HaveKeyWithValue("stale", false))
+		Expect(ssh).To(HaveKeyWithValue("metadata", HaveKeyWithValue("reason", "connection refused")))
+	})
+
+	It("should return check history and stale checks", func() {
+		status, _ := do(http.MethodPost, "/api/v1/checks", ingest)
+		Expect(status).To(Equal(http.StatusOK))
+
+		status, body := do(http.MethodGet, "/api/v1/hosts/host-1/checks/ssh/history?since=2026-10-01T11:45:00Z", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["results"]).To(ConsistOf(HaveKeyWithValue("status", "failed")))
+
+		status, body = do(http.MethodGet, "/api/v1/checks/stale", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["checks"]).To(ConsistOf(SatisfyAll(HaveKeyWithValue("check", "disk"), HaveKeyWithValue("stale", true))))
+	})
+
+	It("should show flapping checks and the manual queue", func() {
+		status, _ := do(http.MethodPost, "/api/v1/checks", `{"results": [
+			{"host_id": "host-2", "check": "ssh", "status": "ok", "timestamp": "2026-10-01T11:30:00Z"},
+			{"host_id": "host-2", "check": "ssh", "status": "failed", "timestamp": "2026-10-01T11:35:00Z"},
+			{"host_id": "host-2", "check": "ssh", "status": "ok", "timestamp": "2026-10-01T11:40:00Z"},
+			{"host_id": "host-2", "check": "ssh", "status": "failed", "timestamp": "2026-10-01T11:45:00Z"},
+			{"host_id": "host-2", "check": "ssh", "status": "ok", "timestamp": "2026-10-01T11:50:00Z"},
+			{"host_id": "host-2", "check": "ssh", "status": "failed", "timestamp": "2026-10-01T11:55:00Z"}
+		]}`)
+		Expect(status).To(Equal(http.StatusOK))
+
+		status, body := do(http.MethodGet, "/api/v1/hosts/host-2/checks", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["checks"]).To(ConsistOf(SatisfyAll(
+			HaveKeyWithValue("flapping", true),
+			HaveKeyWithValue("flapping_since", "2026-10-01T11:55:00Z"),
+			HaveKeyWithValue("flap_score", BeNumerically(">=", 4)),
+		)))
+
+		status, body = do(http.MethodGet, "/api/v1/manual-queue", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["items"]).To(BeEmpty())
+
+		status, _ = do(http.MethodPost, "/api/v1/manual-queue/unknown/resolve", `{"resolved_by": "alice"}`)
+		Expect(status).To(Equal(http.StatusNotFound))
+		status, _ = do(http.MethodPost, "/api/v1/manual-queue/unknown/resolve", `{}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+	})
+
+	DescribeTable("should reject bad requests",
+		func(method string, path string, body string, expectedStatus int) {
+			status, response := do(method, path, body)
+			Expect(status).To(Equal(expectedStatus))
+			Expect(response).To(HaveKey("error"))
+		},
+		Entry("malformed body", http.MethodPost, "/api/v1/checks", `{"results": [`, http.StatusBadRequest),
+		Entry("unknown field", http.MethodPost, "/api/v1/checks", `{"checks": []}`, http.StatusBadRequest),
+		Entry("empty batch", http.MethodPost, "/api/v1/checks", `{"results": []}`, http.StatusBadRequest),
+		Entry("too large batch", http.MethodPost, "/api/v1/checks",
+			`{"results": [`+strings.Repeat(`{"host_id": "host-1"},`, checks.MAX_BATCH_SIZE)+`{"host_id": "host-1"}]}`, http.StatusRequestEntityTooLarge),
+		Entry("unknown check in history", http.MethodGet, "/api/v1/hosts/host-1/checks/dns/history", "", http.StatusBadRequest),
+		Entry("invalid since", http.MethodGet, "/api/v1/hosts/host-1/checks/ssh/history?since=yesterday", "", http.StatusBadRequest),
+	)
+})
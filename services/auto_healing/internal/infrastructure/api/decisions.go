@@ -0,0 +1,310 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	decisionErrors "github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+)
+
+type dryRunRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+type dryRunResponse struct {
+	ProjectID string `json:"project_id"`
+	Enabled   bool   `json:"enabled"`
+}
+
+type dryRunProjectsResponse struct {
+	Projects []string `json:"projects"`
+}
+
+type decisionResponse struct {
+	Action           string    `json:"action"`
+	Rule             string    `json:"rule,omitempty"`
+	Check            string    `json:"check,omitempty"`
+	Reason           string    `json:"reason"`
+	DryRun           bool      `json:"dry_run"`
+	ApprovalRequired bool      `json:"approval_required"`
+	DecidedAt        time.Time `json:"decided_at"`
+}
+
+type decisionRecordResponse struct {
+	ID        string            `json:"id"`
+	HostID    string            `json:"host_id"`
+	ProjectID string            `json:"project_id"`
+	Decision  decisionResponse  `json:"decision"`
+	Shadow    *decisionResponse `json:"shadow,omitempty"`
+	DecidedAt time.Time         `json:"decided_at"`
+}
+
+type checkStateResponse struct {
+	Check    string    `json:"check"`
+	Failing  bool      `json:"failing"`
+	Since    time.Time `json:"since"`
+	Stale    bool      `json:"stale"`
+	Flapping bool      `json:"flapping"`
+}
+
+type actionRecordResponse struct {
+	Action string    `json:"action"`
+	Check  string    `json:"check"`
+	At     time.Time `json:"at"`
+}
+
+type ruleEvaluationResponse struct {
+	Rule     string `json:"rule"`
+	Check    string `json:"check"`
+	Applied  bool   `json:"applied"`
+	Action   string `json:"action,omitempty"`
+	Reason   string `json:"reason"`
+	Selected bool   `json:"selected"`
+}
+
+type limitsStateResponse struct {
+	Disabled    bool   `json:"disabled"`
+	Reason      string `json:"reason,omitempty"`
+	Actions     int    `json:"actions"`
+	MaxActions  int    `json:"max_actions"`
+	Window      string `json:"window"`
+	InFlight    int    `json:"in_flight"`
+	MaxInFlight int    `json:"max_in_flight"`
+}
+
+type explanationResponse struct {
+	UnitType     string                   `json:"unit_type"`
+	Tier         byte                     `json:"tier"`
+	Restrictions []string                 `json:"restrictions"`
+	Checks       []checkStateResponse     `json:"checks"`
+	History      []actionRecordResponse   `json:"history"`
+	Outage       string                   `json:"outage,omitempty"`
+	Rules        []ruleEvaluationResponse `json:"rules"`
+	Limits       *limitsStateResponse     `json:"limits,omitempty"`
+}
+
+type explainedDecisionResponse struct {
+	decisionRecordResponse
+	Explanation explanationResponse `json:"explanation"`
+}
+
+type transitionResponse struct {
+	Active string `json:"active"`
+	Shadow string `json:"shadow"`
+	Count  int    `json:"count"`
+}
+
+type shadowReportResponse struct {
+	Since       time.Time                `json:"since"`
+	Until       time.Time                `json:"until"`
+	ProjectID   string                   `json:"project_id,omitempty"`
+	Compared    int                      `json:"compared"`
+	Matching    int                      `json:"matching"`
+	Differing   int                      `json:"differing"`
+	Transitions []transitionResponse     `json:"transitions"`
+	Differences []decisionRecordResponse `json:"differences"`
+}
+
+func (s *Server) getShadowReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	until, err := parseTimeParam(query, "until")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	since, err := parseTimeParam(query, "since")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if since.IsZero() {
+		if until.IsZero() {
+			since = time.Now().Add(-24 * time.Hour)
+		} else {
+			since = until.Add(-24 * time.Hour)
+		}
+	}
+
+	report, err := s.decisions.GetShadowReport(r.Context(), entities.DecisionFilter{ProjectID: query.Get("project"), Since: since, Until: until})
+	switch {
+	case errors.Is(err, decisionErrors.ErrInvalidPeriod):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := shadowReportResponse{
+		Since:       report.Since,
+		Until:       report.Until,
+		ProjectID:   report.ProjectID,
+		Compared:    report.Compared,
+		Matching:    report.Matching,
+		Differing:   report.Differing,
+		Transitions: make([]transitionResponse, 0, len(report.Transitions)),
+		Differences: make([]decisionRecordResponse, 0, len(report.Differences)),
+	}
+	for _, transition := range report.Transitions {
+		response.Transitions = append(response.Transitions, transitionResponse{
+			Active: string(transition.Active),
+			Shadow: string(transition.Shadow),
+			Count:  transition.Count,
+		})
+	}
+	for _, record := range report.Differences {
+		response.Differences = append(response.Differences, newDecisionRecordResponse(record))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getDecision(w http.ResponseWriter, r *http.Request) {
+	record, err := s.decisions.GetDecision(r.Context(), r.PathValue("decision_id"))
+	switch {
+	case errors.Is(err, decisionErrors.ErrDecisionNotFound):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newExplainedDecisionResponse(*record))
+}
+
+func (s *Server) listDryRunProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := s.decisions.ListDryRunProjects(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, dryRunProjectsResponse{Projects: projects})
+}
+
+func (s *Server) getProjectDryRun(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("project_id")
+	enabled, err := s.decisions.IsProjectDryRun(r.Context(), projectID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, dryRunResponse{ProjectID: projectID, Enabled: enabled})
+}
+
+func (s *Server) setProjectDryRun(w http.ResponseWriter, r *http.Request) {
+	var request dryRunRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if request.Enabled == nil {
+		writeError(w, http.StatusBadRequest, errors.New("enabled is required"))
+		return
+	}
+	projectID := r.PathValue("project_id")
+	if err := s.decisions.SetProjectDryRun(r.Context(), projectID, *request.Enabled); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, dryRunResponse{ProjectID: projectID, Enabled: *request.Enabled})
+}
+
+func parseTimeParam(query url.Values, name string) (time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+func newDecisionResponse(decision entities.Decision) decisionResponse {
+	return decisionResponse{
+		Action:           string(decision.Action),
+		Rule:             decision.Rule,
+		Check:            string(decision.Check),
+		Reason:           decision.Reason,
+		DryRun:           decision.DryRun,
+		ApprovalRequired: decision.ApprovalRequired,
+		DecidedAt:        decision.DecidedAt,
+	}
+}
+
+func newDecisionRecordResponse(record entities.DecisionRecord) decisionRecordResponse {
+	response := decisionRecordResponse{
+		ID:        record.ID,
+		HostID:    record.HostID,
+		ProjectID: record.ProjectID,
+		Decision:  newDecisionResponse(record.Decision),
+		DecidedAt: record.DecidedAt,
+	}
+	if record.Shadow != nil {
+		shadow := newDecisionResponse(*record.Shadow)
+		response.Shadow = &shadow
+	}
+	return response
+}
+
+func newExplainedDecisionResponse(record entities.DecisionRecord) explainedDecisionResponse {
+	explanation := record.Explanation
+	snapshot := explanation.Snapshot
+	response := explainedDecisionResponse{
+		decisionRecordResponse: newDecisionRecordResponse(record),
+		Explanation: explanationResponse{
+			UnitType:     string(snapshot.UnitType),
+			Tier:         snapshot.Tier,
+			Restrictions: make([]string, 0, len(snapshot.Restrictions)),
+			Checks:       make([]checkStateResponse, 0, len(snapshot.Checks)),
+			History:      make([]actionRecordResponse, 0, len(snapshot.History)),
+			Outage:       snapshot.Outage,
+			Rules:        make([]ruleEvaluationResponse, 0, len(explanation.Rules)),
+		},
+	}
+	for _, restriction := range snapshot.Restrictions {
+		response.Explanation.Restrictions = append(response.Explanation.Restrictions, string(restriction))
+	}
+	for _, check := range snapshot.Checks {
+		response.Explanation.Checks = append(response.Explanation.Checks, checkStateResponse{
+			Check:    string(check.Type),
+			Failing:  check.Failing,
+			Since:    check.Since,
+			Stale:    check.Stale,
+			Flapping: check.Flapping,
+		})
+	}
+	for _, action := range snapshot.History {
+		response.Explanation.History = append(response.Explanation.History, actionRecordResponse{
+			Action: string(action.Action),
+			Check:  string(action.Check),
+			At:     action.At,
+		})
+	}
+	for _, evaluation := range explanation.Rules {
+		response.Explanation.Rules = append(response.Explanation.Rules, ruleEvaluationResponse{
+			Rule:     evaluation.Rule,
+			Check:    string(evaluation.Check),
+			Applied:  evaluation.Applied,
+			Action:   string(evaluation.Action),
+			Reason:   evaluation.Reason,
+			Selected: evaluation.Selected,
+		})
+	}
+	if limits := explanation.Limits; limits != nil {
+		response.Explanation.Limits = &limitsStateResponse{
+			Disabled:    limits.Disabled,
+			Reason:      limits.Reason,
+			Actions:     limits.Actions,
+			MaxActions:  limits.MaxActions,
+			Window:      limits.Window.String(),
+			InFlight:    limits.InFlight,
+			MaxInFlight: limits.MaxInFlight,
+		}
+	}
+	return response
+}
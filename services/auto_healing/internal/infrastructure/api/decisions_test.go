@@ -0,0 +1,176 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Decisions API", func() {
+	var (
+		server          *httptest.Server
+		decisionService *decisions.DecisionService
+		ruleSets        *memory.RuleRepository
+	)
+
+	BeforeEach(func() {
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(entities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		_, err := checkService.IngestResults(context.Background(), []checkEntities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now.Add(-time.Minute)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		ruleSets = memory.NewRuleRepository()
+		decisionService = decisions.NewDomainService(hosts, checkService, memory.NewActionHistory(),
+			memory.NewDecisionRepository(), memory.NewDryRunRepository(), decisions.WithClock(clock), decisions.WithRuleRepository(ruleSets))
+		server = httptest.NewServer(NewServer(checkService, WithDecisions(decisionService)))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should toggle dry run of projects", func() {
+		status, _ := do(http.MethodPut, "/api/v1/projects/search/dry-run", `{}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+
+		status, body := do(http.MethodPut, "/api/v1/projects/search/dry-run", `{"enabled": true}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("enabled", true))
+
+		status, body = do(http.MethodGet, "/api/v1/dry-run", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["projects"]).To(Equal([]interface{}{"search"}))
+
+		status, body = do(http.MethodGet, "/api/v1/projects/web/dry-run", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("enabled", false))
+	})
+
+	It("should apply rules changed through the api to the next decisions", func() {
+		decision, err := decisionService.DecideHost(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionWait))
+
+		status, body := do(http.MethodPut, "/api/v1/rules", `{"rules": [{"name": "ssh-now", "check": "ssh", "unit_types": ["server"], "tiers": [0, 1], "actions": ["redeploy"], "approval": {"actions": ["redeploy"], "tiers": [0]}}]}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["rules"]).To(ConsistOf(And(
+			HaveKeyWithValue("name", "ssh-now"),
+			HaveKeyWithValue("failing_for", "0s"),
+			HaveKeyWithValue("tiers", []interface{}{0.0, 1.0}),
+		)))
+
+		decision, err = decisionService.DecideHost(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decision.Action).To(Equal(entities.ActionRedeploy))
+		Expect(decision.Rule).To(Equal("ssh-now"))
+		Expect(decision.ApprovalRequired).To(BeTrue())
+
+		stored, err := ruleSets.GetRuleSet(context.Background(), entities.RuleSetActive)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Rules).To(Equal(decisionService.Rules()))
+
+		status, body = do(http.MethodGet, "/api/v1/rules", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["rules"]).To(ConsistOf(HaveKeyWithValue("name", "ssh-now")))
+	})
+
+	It("should reject invalid rules keeping the current ones", func() {
+		status, _ := do(http.MethodPut, "/api/v1/rules", `{"rules": [{"name": "ssh", "check": "ssh", "failing_for": "soon", "actions": ["reboot"]}]}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, _ = do(http.MethodPut, "/api/v1/rules", `{"rules": [{"name": "ssh", "check": "ssh", "actions": ["fix"]}]}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, _ = do(http.MethodPut, "/api/v1/rules", `{"rules": [{"name": "ssh", "check": "ssh", "tiers": [300], "actions": ["reboot"]}]}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+
+		Expect(decisionService.Rules()).To(Equal(entities.DefaultRules()))
+		Expect(ruleSets.GetRuleSet(context.Background(), entities.RuleSetActive)).To(BeNil())
+	})
+
+	It("should record shadow decisions of rules set through the api", func() {
+		status, body := do(http.MethodPut, "/api/v1/rules/shadow", `{"rules": [{"name": "ssh-fast", "check": "ssh", "actions": ["redeploy"]}]}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["rules"]).To(ConsistOf(HaveKeyWithValue("name", "ssh-fast")))
+		Expect(ruleSets.GetRuleSet(context.Background(), entities.RuleSetShadow)).To(HaveField("Rules", decisionService.ShadowRules()))
+		Expect(ruleSets.GetRuleSet(context.Background(), entities.RuleSetActive)).To(BeNil())
+
+		_, err := decisionService.DecideHost(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		records, err := decisionService.ListDecisions(context.Background(), entities.DecisionFilter{HostID: "host-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records[0].Decision.Action).To(Equal(entities.ActionWait))
+		Expect(records[0].Shadow).To(HaveField("Action", entities.ActionRedeploy))
+
+		status, _ = do(http.MethodPut, "/api/v1/rules/shadow", `{"rules": [{"name": "broken"}]}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, body = do(http.MethodGet, "/api/v1/rules/shadow", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["rules"]).To(ConsistOf(HaveKeyWithValue("name", "ssh-fast")))
+	})
+
+	It("should report differences of shadow decisions", func() {
+		shadow := entities.Rule{Name: "ssh-fast", Check: core_entities.CheckSSH, Actions: []entities.Action{entities.ActionRedeploy}}
+		Expect(decisionService.SetShadowRules(context.Background(), []entities.Rule{shadow})).To(Succeed())
+		_, err := decisionService.DecideHost(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		status, body := do(http.MethodGet, "/api/v1/decisions/shadow-report?since=2026-10-01T00:00:00Z&until=2026-10-02T00:00:00Z", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("compared", BeEquivalentTo(1)))
+		Expect(body).To(HaveKeyWithValue("differing", BeEquivalentTo(1)))
+		Expect(body["transitions"]).To(ConsistOf(map[string]interface{}{"active": "wait", "shadow": "redeploy", "count": 1.0}))
+		Expect(body["differences"]).To(ConsistOf(HaveKeyWithValue("shadow", HaveKeyWithValue("rule", "ssh-fast"))))
+
+		status, _ = do(http.MethodGet, "/api/v1/decisions/shadow-report?since=yesterday", "")
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, _ = do(http.MethodGet, "/api/v1/decisions/shadow-report?since=2026-10-02T00:00:00Z&until=2026-10-01T00:00:00Z", "")
+		Expect(status).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should explain recorded decisions", func() {
+		_, err := decisionService.DecideHost(context.Background(), "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		records, err := decisionService.ListDecisions(context.Background(), entities.DecisionFilter{HostID: "host-1"})
+		Expect(err).NotTo(HaveOccurred())
+
+		status, body := do(http.MethodGet, "/api/v1/decisions/"+records[0].ID, "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("decision", HaveKeyWithValue("action", "wait")))
+		Expect(body["explanation"]).To(HaveKeyWithValue("checks", ConsistOf(
+			HaveKeyWithValue("failing", true),
+		)))
+		Expect(body["explanation"]).To(HaveKeyWithValue("rules", ContainElement(And(
+			HaveKeyWithValue("rule", "ssh"),
+			HaveKeyWithValue("selected", true),
+		))))
+
+		status, _ = do(http.MethodGet, "/api/v1/decisions/unknown", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gwall-e/auto_healing/internal/domain/inventory/entities"
+	inventoryErrors "github.com/gwall-e/auto_healing/internal/domain/inventory/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+const (
+	EVENT_HOST_ADDED                = "host_added"
+	EVENT_HOST_PROJECT_CHANGED      = "host_project_changed"
+	EVENT_HOST_LOCATION_CHANGED     = "host_location_changed"
+	EVENT_HOST_RESTRICTIONS_CHANGED = "host_restrictions_changed"
+	EVENT_HOST_LABELS_CHANGED       = "host_labels_changed"
+	EVENT_HOST_STATE_CHANGED        = "host_state_changed"
+)
+
+type inventoryEventRequest struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+type inventoryEventResponse struct {
+	Type   string `json:"type"`
+	HostID string `json:"host_id"`
+}
+
+type hostAddedBody struct {
+	ID              string                 `json:"id"`
+	InventoryNumber string                 `json:"inventory_number"`
+	FQDN            string                 `json:"fqdn"`
+	UnitType        core_entities.UnitType `json:"unit_type"`
+	ProjectID       string                 `json:"project_id"`
+	State           string                 `json:"state"`
+	Datacenter      string                 `json:"datacenter"`
+	Rack            string                 `json:"rack"`
+}
+
+type hostProjectChangedBody struct {
+	ID     string `json:"id"`
+	FQDN   string `json:"fqdn"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+type hostLocationChangedBody struct {
+	ID         string `json:"id"`
+	FQDN       string `json:"fqdn"`
+	Datacenter string `json:"datacenter"`
+	Hall       string `json:"hall"`
+	Row        string `json:"row"`
+	Rack       string `json:"rack"`
+	Unit       int    `json:"unit"`
+	Switch     string `json:"switch"`
+	Port       string `json:"port"`
+}
+
+type hostRestrictionsChangedBody struct {
+	ID           string                      `json:"id"`
+	FQDN         string                      `json:"fqdn"`
+	Restrictions []core_entities.Restriction `json:"restrictions"`
+}
+
+type hostLabelsChangedBody struct {
+	ID     string            `json:"id"`
+	FQDN   string            `json:"fqdn"`
+	Labels map[string]string `json:"labels"`
+}
+
+type hostStateChangedBody struct {
+	ID        string `json:"id"`
+	FQDN      string `json:"fqdn"`
+	ProjectID string `json:"project_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Reason    string `json:"reason"`
+}
+
+func (s *Server) applyInventoryEvent(w http.ResponseWriter, r *http.Request) {
+	var request inventoryEventRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	hostID, err := s.applyHostEvent(r.Context(), request)
+	var badRequest *badEventError
+	switch {
+	case errors.As(err, &badRequest):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case errors.Is(err, inventoryErrors.ErrHostNotFound):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inventoryEventResponse{Type: request.Type, HostID: hostID})
+}
+
+type badEventError struct {
+	message string
+}
+
+func (e *badEventError) Error() string {
+	return e.message
+}
+
+func (s *Server) applyHostEvent(ctx context.Context, request inventoryEventRequest) (string, error) {
+	var host struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(request.Event, &host); err != nil || host.ID == "" {
+		return "", &badEventError{message: fmt.Sprintf("%s event has no host id", request.Type)}
+	}
+
+	switch request.Type {
+	case EVENT_HOST_ADDED:
+		var body hostAddedBody
+		if err := decodeEvent(request, &body); err != nil {
+			return "", err
+		}
+		return host.ID, s.inventory.ApplyHostAdded(ctx, (*entities.HostAdded)(&body))
+	case EVENT_HOST_PROJECT_CHANGED:
+		var body hostProjectChangedBody
+		if err := decodeEvent(request, &body); err != nil {
+			return "", err
+		}
+		return host.ID, s.inventory.ApplyHostProjectChanged(ctx, (*entities.HostProjectChanged)(&body))
+	case EVENT_HOST_LOCATION_CHANGED:
+		var body hostLocationChangedBody
+		if err := decodeEvent(request, &body); err != nil {
+			return "", err
+		}
+		return host.ID, s.inventory.ApplyHostLocationChanged(ctx, (*entities.HostLocationChanged)(&body))
+	case EVENT_HOST_RESTRICTIONS_CHANGED:
+		var body hostRestrictionsChangedBody
+		if err := decodeEvent(request, &body); err != nil {
+			return "", err
+		}
+		return host.ID, s.inventory.ApplyHostRestrictionsChanged(ctx, (*entities.HostRestrictionsChanged)(&body))
+	case EVENT_HOST_LABELS_CHANGED:
+		var body hostLabelsChangedBody
+		if err := decodeEvent(request, &body); err != nil {
+			return "", err
+		}
+		return host.ID, s.inventory.ApplyHostLabelsChanged(ctx, (*entities.HostLabelsChanged)(&body))
+	case EVENT_HOST_STATE_CHANGED:
+		var body hostStateChangedBody
+		if err := decodeEvent(request, &body); err != nil {
+			return "", err
+		}
+		return host.ID, s.inventory.ApplyHostStateChanged(ctx, (*entities.HostStateChanged)(&body))
+	default:
+		return "", &badEventError{message: fmt.Sprintf("unknown event type %q", request.Type)}
+	}
+}
+
+func decodeEvent(request inventoryEventRequest, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(request.Event))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return &badEventError{message: fmt.Sprintf("invalid %s event: %v", request.Type, err)}
+	}
+	return nil
+}
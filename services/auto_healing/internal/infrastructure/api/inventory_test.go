@@ -0,0 +1,81 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory"
+	powerEntities "github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inventory API", func() {
+	var (
+		hosts   *memory.HostInfoRepository
+		targets *memory.PowerTargetRepository
+		server  *httptest.Server
+	)
+
+	BeforeEach(func() {
+		hosts = memory.NewHostInfoRepository()
+		targets = memory.NewPowerTargetRepository()
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository())
+		server = httptest.NewServer(NewServer(checkService, WithInventory(inventory.NewDomainService(hosts, targets))))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should fill host info and power targets from hosts events", func() {
+		status, body := do(http.MethodPost, "/api/v1/inventory/events", `{"type": "host_added", "event": {
+			"id": "host-1", "inventory_number": "100500", "fqdn": "host-1.search.dc1", "unit_type": "server",
+			"project_id": "search", "state": "ready", "datacenter": "dc1", "rack": "r1"
+		}}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(Equal(map[string]interface{}{"type": "host_added", "host_id": "host-1"}))
+
+		status, _ = do(http.MethodPost, "/api/v1/inventory/events", `{"type": "host_labels_changed", "event": {
+			"id": "host-1", "fqdn": "host-1.search.dc1", "labels": {"power/address": "bmc-host-1.dc1", "power/secret": "bmc-dc1"}
+		}}`)
+		Expect(status).To(Equal(http.StatusOK))
+
+		Expect(hosts.GetHostInfo(context.Background(), "host-1")).To(Equal(&entities.HostInfo{
+			HostID: "host-1", FQDN: "host-1.search.dc1", ProjectID: "search", UnitType: core_entities.TypeServer,
+			Datacenter: "dc1", Rack: "r1",
+		}))
+		Expect(targets.GetPowerTarget(context.Background(), "host-1")).To(Equal(&powerEntities.PowerTarget{
+			HostID: "host-1", UnitType: core_entities.TypeServer, Address: "bmc-host-1.dc1", SecretID: "bmc-dc1",
+		}))
+	})
+
+	DescribeTable("should reject bad events",
+		func(body string, expectedStatus int) {
+			status, response := do(http.MethodPost, "/api/v1/inventory/events", body)
+			Expect(status).To(Equal(expectedStatus))
+			Expect(response).To(HaveKey("error"))
+		},
+		Entry("unknown type", `{"type": "host_removed", "event": {"id": "host-1"}}`, http.StatusBadRequest),
+		Entry("missing host", `{"type": "host_state_changed", "event": {"from": "ready", "to": "dead"}}`, http.StatusBadRequest),
+		Entry("unknown field", `{"type": "host_state_changed", "event": {"id": "host-1", "tier": 1}}`, http.StatusBadRequest),
+		Entry("unknown host", `{"type": "host_state_changed", "event": {"id": "host-1", "to": "dead"}}`, http.StatusNotFound),
+	)
+})
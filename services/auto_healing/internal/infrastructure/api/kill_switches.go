@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	killSwitchErrors "github.com/gwall-e/auto_healing/internal/domain/killswitch/errors"
+)
+
+type killSwitchRequest struct {
+	Scope     string     `json:"scope"`
+	Target    string     `json:"target,omitempty"`
+	Reason    string     `json:"reason"`
+	Author    string     `json:"author"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Duration  string     `json:"duration,omitempty"`
+}
+
+type releaseKillSwitchRequest struct {
+	ReleasedBy string `json:"released_by"`
+}
+
+type killSwitchResponse struct {
+	ID         string     `json:"id"`
+	Scope      string     `json:"scope"`
+	Target     string     `json:"target,omitempty"`
+	Reason     string     `json:"reason"`
+	Author     string     `json:"author"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	ReleasedBy string     `json:"released_by,omitempty"`
+	Expired    bool       `json:"expired"`
+}
+
+type killSwitchesResponse struct {
+	KillSwitches []killSwitchResponse `json:"kill_switches"`
+}
+
+func (s *Server) listKillSwitches(w http.ResponseWriter, r *http.Request) {
+	since, err := parseTimeParam(r.URL.Query(), "since")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+
+	switches, err := s.switches.ListKillSwitches(r.Context(), since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	response := killSwitchesResponse{KillSwitches: make([]killSwitchResponse, 0, len(switches))}
+	for _, killSwitch := range switches {
+		response.KillSwitches = append(response.KillSwitches, newKillSwitchResponse(killSwitch))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) engageKillSwitch(w http.ResponseWriter, r *http.Request) {
+	var request killSwitchRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	var expiresAt time.Time
+	switch {
+	case request.ExpiresAt != nil && request.Duration != "":
+		writeError(w, http.StatusBadRequest, errors.New("either expires_at or duration is allowed"))
+		return
+	case request.ExpiresAt != nil:
+		expiresAt = *request.ExpiresAt
+	case request.Duration != "":
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid duration: %w", err))
+			return
+		}
+		expiresAt = time.Now().Add(duration)
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("expires_at or duration is required"))
+		return
+	}
+
+	killSwitch, err := s.switches.EngageKillSwitch(r.Context(), entities.KillSwitchRequest{
+		Scope:     entities.Scope(request.Scope),
+		Target:    request.Target,
+		Reason:    request.Reason,
+		Author:    request.Author,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		writeKillSwitchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newKillSwitchResponse(killSwitch))
+}
+
+func (s *Server) getKillSwitch(w http.ResponseWriter, r *http.Request) {
+	killSwitch, err := s.switches.GetKillSwitch(r.Context(), r.PathValue("switch_id"))
+	if err != nil {
+		writeKillSwitchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newKillSwitchResponse(killSwitch))
+}
+
+func (s *Server) releaseKillSwitch(w http.ResponseWriter, r *http.Request) {
+	var request releaseKillSwitchRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	killSwitch, err := s.switches.ReleaseKillSwitch(r.Context(), r.PathValue("switch_id"), request.ReleasedBy)
+	if err != nil {
+		writeKillSwitchError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newKillSwitchResponse(killSwitch))
+}
+
+func writeKillSwitchError(w http.ResponseWriter, err error) {
+	var validationErr *killSwitchErrors.KillSwitchValidationError
+	switch {
+	case errors.Is(err, killSwitchErrors.ErrKillSwitchNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, killSwitchErrors.ErrKillSwitchReleased):
+		writeError(w, http.StatusConflict, err)
+	case errors.As(err, &validationErr):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func newKillSwitchResponse(killSwitch *entities.KillSwitch) killSwitchResponse {
+	return killSwitchResponse{
+		ID:         killSwitch.ID,
+		Scope:      string(killSwitch.Scope),
+		Target:     killSwitch.Target,
+		Reason:     killSwitch.Reason,
+		Author:     killSwitch.Author,
+		Active:     killSwitch.IsActive(time.Now()),
+		CreatedAt:  killSwitch.CreatedAt,
+		ExpiresAt:  killSwitch.ExpiresAt,
+		ReleasedAt: killSwitch.ReleasedAt,
+		ReleasedBy: killSwitch.ReleasedBy,
+		Expired:    killSwitch.Expired,
+	}
+}
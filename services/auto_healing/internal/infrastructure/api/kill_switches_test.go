@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch"
+	killSwitchEntities "github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type noopSender struct{}
+
+func (noopSender) Send(ctx context.Context, notification killSwitchEntities.Notification) error {
+	return nil
+}
+
+var _ = Describe("Kill switches API", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository())
+		killSwitchService := killswitch.NewDomainService(memory.NewKillSwitchRepository(), memory.NewHostInfoRepository(), memory.NewEventLog(),
+			noopSender{})
+		server = httptest.NewServer(NewServer(checkService, WithKillSwitches(killSwitchService)))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should engage and release kill switches", func() {
+		status, body := do(http.MethodPost, "/api/v1/kill-switches",
+			`{"scope": "action", "target": "redeploy", "reason": "broken images", "author": "alice", "duration": "2h"}`)
+		Expect(status).To(Equal(http.StatusCreated))
+		Expect(body).To(HaveKeyWithValue("active", true))
+		id := body["id"].(string)
+
+		status, body = do(http.MethodGet, "/api/v1/kill-switches", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["kill_switches"]).To(ConsistOf(SatisfyAll(
+			HaveKeyWithValue("id", id),
+			HaveKeyWithValue("scope", "action"),
+			HaveKeyWithValue("target", "redeploy"),
+		)))
+
+		status, _ = do(http.MethodPost, "/api/v1/kill-switches/"+id+"/release", `{}`)
+		Expect(status).To(Equal(http.StatusBadRequest))
+		status, body = do(http.MethodPost, "/api/v1/kill-switches/"+id+"/release", `{"released_by": "bob"}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("active", false))
+		Expect(body).To(HaveKeyWithValue("released_by", "bob"))
+		status, _ = do(http.MethodPost, "/api/v1/kill-switches/"+id+"/release", `{"released_by": "bob"}`)
+		Expect(status).To(Equal(http.StatusConflict))
+	})
+
+	DescribeTable("should reject bad requests",
+		func(method string, path string, body string, expectedStatus int) {
+			status, response := do(method, path, body)
+			Expect(status).To(Equal(expectedStatus))
+			Expect(response).To(HaveKey("error"))
+		},
+		Entry("no expiry", http.MethodPost, "/api/v1/kill-switches",
+			`{"scope": "global", "reason": "incident", "author": "alice"}`, http.StatusBadRequest),
+		Entry("both expiries", http.MethodPost, "/api/v1/kill-switches",
+			`{"scope": "global", "reason": "incident", "author": "alice", "duration": "1h", "expires_at": "2030-01-01T00:00:00Z"}`, http.StatusBadRequest),
+		Entry("invalid duration", http.MethodPost, "/api/v1/kill-switches",
+			`{"scope": "global", "reason": "incident", "author": "alice", "duration": "soon"}`, http.StatusBadRequest),
+		Entry("no reason", http.MethodPost, "/api/v1/kill-switches",
+			`{"scope": "global", "author": "alice", "duration": "1h"}`, http.StatusBadRequest),
+		Entry("unknown switch", http.MethodGet, "/api/v1/kill-switches/unknown", "", http.StatusNotFound),
+	)
+})
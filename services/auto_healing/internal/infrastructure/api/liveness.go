@@ -0,0 +1,162 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	livenessErrors "github.com/gwall-e/auto_healing/internal/domain/liveness/errors"
+)
+
+type heartbeatRequest struct {
+	HostID       string    `json:"host_id"`
+	AgentVersion string    `json:"agent_version,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+type heartbeatsRequest struct {
+	Heartbeats []heartbeatRequest `json:"heartbeats"`
+}
+
+type heartbeatsResponse struct {
+	Accepted  int                `json:"accepted"`
+	Recovered []string           `json:"recovered"`
+	Rejected  []rejectedResponse `json:"rejected"`
+}
+
+type hostLivenessResponse struct {
+	HostID           string     `json:"host_id"`
+	AgentVersion     string     `json:"agent_version,omitempty"`
+	LastSeen         time.Time  `json:"last_seen"`
+	Status           string     `json:"status"`
+	UnreachableSince *time.Time `json:"unreachable_since,omitempty"`
+	OutageID         string     `json:"outage_id,omitempty"`
+}
+
+type unreachableHostsResponse struct {
+	Hosts []hostLivenessResponse `json:"hosts"`
+}
+
+type outageResponse struct {
+	ID         string     `json:"id"`
+	Level      string     `json:"level"`
+	Key        string     `json:"key"`
+	Hosts      []string   `json:"hosts"`
+	TotalHosts int        `json:"total_hosts"`
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+}
+
+type outagesResponse struct {
+	Outages []outageResponse `json:"outages"`
+}
+
+func (s *Server) recordHeartbeats(w http.ResponseWriter, r *http.Request) {
+	var request heartbeatsRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	heartbeats := make([]entities.Heartbeat, 0, len(request.Heartbeats))
+	for _, heartbeat := range request.Heartbeats {
+		heartbeats = append(heartbeats, entities.Heartbeat(heartbeat))
+	}
+
+	report, err := s.liveness.RecordHeartbeats(r.Context(), heartbeats)
+	switch {
+	case errors.Is(err, livenessErrors.ErrBatchTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	case errors.Is(err, livenessErrors.ErrEmptyBatch):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := heartbeatsResponse{
+		Accepted:  report.Accepted,
+		Recovered: report.Recovered,
+		Rejected:  make([]rejectedResponse, 0, len(report.Rejected)),
+	}
+	for _, rejected := range report.Rejected {
+		response.Rejected = append(response.Rejected, rejectedResponse(rejected))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getHostLiveness(w http.ResponseWriter, r *http.Request) {
+	host, err := s.liveness.GetHostLiveness(r.Context(), r.PathValue("host_id"))
+	switch {
+	case errors.Is(err, livenessErrors.ErrHostNotFound):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newHostLivenessResponse(host))
+}
+
+func (s *Server) listUnreachableHosts(w http.ResponseWriter, r *http.Request) {
+	hosts, err := s.liveness.ListUnreachable(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	response := unreachableHostsResponse{Hosts: make([]hostLivenessResponse, 0, len(hosts))}
+	for _, host := range hosts {
+		response.Hosts = append(response.Hosts, newHostLivenessResponse(host))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) listOutages(w http.ResponseWriter, r *http.Request) {
+	since, err := parseTimeParam(r.URL.Query(), "since")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+
+	outages, err := s.liveness.ListOutages(r.Context(), since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	response := outagesResponse{Outages: make([]outageResponse, 0, len(outages))}
+	for _, outage := range outages {
+		response.Outages = append(response.Outages, outageResponse{
+			ID:         outage.ID,
+			Level:      string(outage.Domain.Level),
+			Key:        outage.Domain.Key,
+			Hosts:      outage.Hosts,
+			TotalHosts: outage.TotalHosts,
+			StartedAt:  outage.StartedAt,
+			EndedAt:    outage.EndedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newHostLivenessResponse(host *entities.HostLiveness) hostLivenessResponse {
+	return hostLivenessResponse{
+		HostID:           host.HostID,
+		AgentVersion:     host.AgentVersion,
+		LastSeen:         host.LastSeen,
+		Status:           string(host.Status),
+		UnreachableSince: host.UnreachableSince,
+		OutageID:         host.OutageID,
+	}
+}
@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Liveness API", func() {
+	var (
+		now             time.Time
+		livenessService *liveness.LivenessService
+		server          *httptest.Server
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		livenessService = liveness.NewDomainService(memory.NewLivenessRepository(), memory.NewOutageRepository(),
+			memory.NewHostInfoRepository(), checkService, memory.NewEventLog(), liveness.WithClock(clock))
+		server = httptest.NewServer(NewServer(checkService, WithLiveness(livenessService)))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should accept heartbeats and list unreachable hosts", func() {
+		status, body := do(http.MethodPost, "/api/v1/heartbeats", `{"heartbeats": [
+			{"host_id": "host-1", "agent_version": "1.2.0", "timestamp": "2026-10-01T11:50:00Z"},
+			{"host_id": "host-2", "timestamp": "2026-10-01T12:00:00Z"},
+			{"timestamp": "2026-10-01T12:00:00Z"}
+		]}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["accepted"]).To(BeEquivalentTo(2))
+		Expect(body["rejected"]).To(ConsistOf(HaveKeyWithValue("index", BeEquivalentTo(2))))
+
+		Expect(livenessService.DetectUnreachable(context.Background())).To(Succeed())
+
+		status, body = do(http.MethodGet, "/api/v1/liveness/unreachable", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["hosts"]).To(ConsistOf(SatisfyAll(
+			HaveKeyWithValue("host_id", "host-1"),
+			HaveKeyWithValue("status", "unreachable"),
+			HaveKeyWithValue("agent_version", "1.2.0"),
+			HaveKeyWithValue("last_seen", "2026-10-01T11:50:00Z"),
+		)))
+
+		status, body = do(http.MethodGet, "/api/v1/hosts/host-2/liveness", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("status", "alive"))
+
+		status, body = do(http.MethodGet, "/api/v1/outages?since=2026-10-01T00:00:00Z", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["outages"]).To(BeEmpty())
+	})
+
+	DescribeTable("should reject bad requests",
+		func(method string, path string, body string, expectedStatus int) {
+			status, response := do(method, path, body)
+			Expect(status).To(Equal(expectedStatus))
+			Expect(response).To(HaveKey("error"))
+		},
+		Entry("unknown field", http.MethodPost, "/api/v1/heartbeats", `{"beats": []}`, http.StatusBadRequest),
+		Entry("empty batch", http.MethodPost, "/api/v1/heartbeats", `{"heartbeats": []}`, http.StatusBadRequest),
+		Entry("unknown host", http.MethodGet, "/api/v1/hosts/host-9/liveness", "", http.StatusNotFound),
+		Entry("invalid since", http.MethodGet, "/api/v1/outages?since=yesterday", "", http.StatusBadRequest),
+	)
+})
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	checkErrors "github.com/gwall-e/auto_healing/internal/domain/checks/errors"
+)
+
+type resolveRequest struct {
+	ResolvedBy string `json:"resolved_by"`
+}
+
+type transitionBody struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+type manualQueueItemResponse struct {
+	ID            string           `json:"id"`
+	HostID        string           `json:"host_id"`
+	Check         string           `json:"check"`
+	Reason        string           `json:"reason"`
+	FlapScore     float64          `json:"flap_score"`
+	FlappingSince time.Time        `json:"flapping_since"`
+	Transitions   []transitionBody `json:"transitions"`
+	CreatedAt     time.Time        `json:"created_at"`
+	ResolvedAt    *time.Time       `json:"resolved_at,omitempty"`
+	ResolvedBy    string           `json:"resolved_by,omitempty"`
+}
+
+type manualQueueResponse struct {
+	Items []manualQueueItemResponse `json:"items"`
+}
+
+func (s *Server) listManualQueue(w http.ResponseWriter, r *http.Request) {
+	items, err := s.checks.ListManualQueue(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	response := manualQueueResponse{Items: make([]manualQueueItemResponse, 0, len(items))}
+	for _, item := range items {
+		response.Items = append(response.Items, newManualQueueItemResponse(item))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) resolveManualQueueItem(w http.ResponseWriter, r *http.Request) {
+	var request resolveRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if request.ResolvedBy == "" {
+		writeError(w, http.StatusBadRequest, errors.New("resolved_by is required"))
+		return
+	}
+	item, err := s.checks.ResolveManualQueueItem(r.Context(), r.PathValue("item_id"), request.ResolvedBy)
+	switch {
+	case errors.Is(err, checkErrors.ErrManualQueueItemNotFound):
+		writeError(w, http.StatusNotFound, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newManualQueueItemResponse(item))
+}
+
+func newManualQueueItemResponse(item *entities.ManualQueueItem) manualQueueItemResponse {
+	response := manualQueueItemResponse{
+		ID:            item.ID,
+		HostID:        item.HostID,
+		Check:         string(item.Type),
+		Reason:        item.Reason,
+		FlapScore:     item.FlapScore,
+		FlappingSince: item.FlappingSince,
+		Transitions:   make([]transitionBody, 0, len(item.Transitions)),
+		CreatedAt:     item.CreatedAt,
+		ResolvedAt:    item.ResolvedAt,
+		ResolvedBy:    item.ResolvedBy,
+	}
+	for _, transition := range item.Transitions {
+		response.Transitions = append(response.Transitions, transitionBody{
+			From: string(transition.From),
+			To:   string(transition.To),
+			At:   transition.At,
+		})
+	}
+	return response
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	decisionErrors "github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type approvalPolicyBody struct {
+	Actions []string `json:"actions"`
+	Tiers   []int    `json:"tiers"`
+}
+
+type ruleBody struct {
+	Name          string             `json:"name"`
+	Check         string             `json:"check"`
+	FailingFor    string             `json:"failing_for"`
+	UnitTypes     []string           `json:"unit_types"`
+	Tiers         []int              `json:"tiers"`
+	Actions       []string           `json:"actions"`
+	MaxAttempts   int                `json:"max_attempts"`
+	HistoryWindow string             `json:"history_window"`
+	Approval      approvalPolicyBody `json:"approval"`
+}
+
+type rulesBody struct {
+	Rules []ruleBody `json:"rules"`
+}
+
+func (s *Server) getRules(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, newRulesBody(s.decisions.Rules()))
+}
+
+func (s *Server) setRules(w http.ResponseWriter, r *http.Request) {
+	if s.updateRules(w, r, s.decisions.SetRules) {
+		s.getRules(w, r)
+	}
+}
+
+func (s *Server) getShadowRules(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, newRulesBody(s.decisions.ShadowRules()))
+}
+
+func (s *Server) setShadowRules(w http.ResponseWriter, r *http.Request) {
+	if s.updateRules(w, r, s.decisions.SetShadowRules) {
+		s.getShadowRules(w, r)
+	}
+}
+
+func (s *Server) updateRules(w http.ResponseWriter, r *http.Request, set func(context.Context, []entities.Rule) error) bool {
+	var request rulesBody
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	rules, err := parseRules(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return false
+	}
+
+	err = set(r.Context(), rules)
+	var validationErr *decisionErrors.RuleValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		writeError(w, http.StatusBadRequest, err)
+		return false
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return false
+	}
+	return true
+}
+
+func parseRules(body rulesBody) ([]entities.Rule, error) {
+	rules := make([]entities.Rule, 0, len(body.Rules))
+	for _, rule := range body.Rules {
+		failingFor, err := parseOptionalDuration(rule.FailingFor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid failing_for of rule %s: %w", rule.Name, err)
+		}
+		historyWindow, err := parseOptionalDuration(rule.HistoryWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid history_window of rule %s: %w", rule.Name, err)
+		}
+		tiers, err := parseTiers(rule.Tiers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tiers of rule %s: %w", rule.Name, err)
+		}
+		approvalTiers, err := parseTiers(rule.Approval.Tiers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid approval tiers of rule %s: %w", rule.Name, err)
+		}
+
+		parsed := entities.Rule{
+			Name:          rule.Name,
+			Check:         core_entities.CheckType(rule.Check),
+			FailingFor:    failingFor,
+			Tiers:         tiers,
+			MaxAttempts:   rule.MaxAttempts,
+			HistoryWindow: historyWindow,
+			Approval:      entities.ApprovalPolicy{Tiers: approvalTiers},
+		}
+		for _, unitType := range rule.UnitTypes {
+			parsed.UnitTypes = append(parsed.UnitTypes, core_entities.UnitType(unitType))
+		}
+		for _, action := range rule.Actions {
+			parsed.Actions = append(parsed.Actions, entities.Action(action))
+		}
+		for _, action := range rule.Approval.Actions {
+			parsed.Approval.Actions = append(parsed.Approval.Actions, entities.Action(action))
+		}
+		rules = append(rules, parsed)
+	}
+	return rules, nil
+}
+
+func parseOptionalDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func parseTiers(values []int) ([]byte, error) {
+	var tiers []byte
+	for _, value := range values {
+		if value < 0 || value > 255 {
+			return nil, fmt.Errorf("tier %d is out of range", value)
+		}
+		tiers = append(tiers, byte(value))
+	}
+	return tiers, nil
+}
+
+func newRulesBody(rules []entities.Rule) rulesBody {
+	response := rulesBody{Rules: make([]ruleBody, 0, len(rules))}
+	for _, rule := range rules {
+		body := ruleBody{
+			Name:          rule.Name,
+			Check:         string(rule.Check),
+			FailingFor:    rule.FailingFor.String(),
+			UnitTypes:     make([]string, 0, len(rule.UnitTypes)),
+			Tiers:         make([]int, 0, len(rule.Tiers)),
+			Actions:       make([]string, 0, len(rule.Actions)),
+			MaxAttempts:   rule.MaxAttempts,
+			HistoryWindow: rule.HistoryWindow.String(),
+			Approval: approvalPolicyBody{
+				Actions: make([]string, 0, len(rule.Approval.Actions)),
+				Tiers:   make([]int, 0, len(rule.Approval.Tiers)),
+			},
+		}
+		for _, unitType := range rule.UnitTypes {
+			body.UnitTypes = append(body.UnitTypes, string(unitType))
+		}
+		for _, tier := range rule.Tiers {
+			body.Tiers = append(body.Tiers, int(tier))
+		}
+		for _, action := range rule.Actions {
+			body.Actions = append(body.Actions, string(action))
+		}
+		for _, action := range rule.Approval.Actions {
+			body.Approval.Actions = append(body.Approval.Actions, string(action))
+		}
+		for _, tier := range rule.Approval.Tiers {
+			body.Approval.Tiers = append(body.Approval.Tiers, int(tier))
+		}
+		response.Rules = append(response.Rules, body)
+	}
+	return response
+}
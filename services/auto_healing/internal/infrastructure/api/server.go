@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals"
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	"github.com/gwall-e/auto_healing/internal/domain/inventory"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch"
+	"github.com/gwall-e/auto_healing/internal/domain/limits"
+	"github.com/gwall-e/auto_healing/internal/domain/liveness"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+)
+
+const MAX_BODY_SIZE = 4 << 20
+
+type SetupFunc func(*Server)
+
+type Server struct {
+	checks    *checks.CheckService
+	limits    *limits.LimitService
+	decisions *decisions.DecisionService
+	workflows *workflows.WorkflowService
+	liveness  *liveness.LivenessService
+	switches  *killswitch.KillSwitchService
+	timeline  *timeline.TimelineService
+	approvals *approvals.ApprovalService
+	inventory *inventory.InventoryService
+	mux       *http.ServeMux
+}
+
+func WithLimits(limitService *limits.LimitService) SetupFunc {
+	return func(s *Server) {
+		s.limits = limitService
+		s.mux.HandleFunc("GET /api/v1/automation/disabled", s.listDisabledProjects)
+		s.mux.HandleFunc("GET /api/v1/projects/{project_id}/automation", s.getProjectAutomation)
+		s.mux.HandleFunc("POST /api/v1/projects/{project_id}/automation/enable", s.enableProjectAutomation)
+		s.mux.HandleFunc("PUT /api/v1/projects/{project_id}/automation/limits", s.setProjectLimits)
+		s.mux.HandleFunc("DELETE /api/v1/projects/{project_id}/automation/limits", s.resetProjectLimits)
+	}
+}
+
+func WithDecisions(decisionService *decisions.DecisionService) SetupFunc {
+	return func(s *Server) {
+		s.decisions = decisionService
+		s.mux.HandleFunc("GET /api/v1/decisions/shadow-report", s.getShadowReport)
+		s.mux.HandleFunc("GET /api/v1/decisions/{decision_id}", s.getDecision)
+		s.mux.HandleFunc("GET /api/v1/rules", s.getRules)
+		s.mux.HandleFunc("PUT /api/v1/rules", s.setRules)
+		s.mux.HandleFunc("GET /api/v1/rules/shadow", s.getShadowRules)
+		s.mux.HandleFunc("PUT /api/v1/rules/shadow", s.setShadowRules)
+		s.mux.HandleFunc("GET /api/v1/dry-run", s.listDryRunProjects)
+		s.mux.HandleFunc("GET /api/v1/projects/{project_id}/dry-run", s.getProjectDryRun)
+		s.mux.HandleFunc("PUT /api/v1/projects/{project_id}/dry-run", s.setProjectDryRun)
+	}
+}
+
+func WithWorkflows(workflowService *workflows.WorkflowService) SetupFunc {
+	return func(s *Server) {
+		s.workflows = workflowService
+		s.mux.HandleFunc("GET /api/v1/hosts/{host_id}/workflows", s.listHostWorkflows)
+		s.mux.HandleFunc("GET /api/v1/workflows/{workflow_id}", s.getWorkflow)
+		s.mux.HandleFunc("POST /api/v1/workflows/{workflow_id}/cancel", s.cancelWorkflow)
+	}
+}
+
+func WithLiveness(livenessService *liveness.LivenessService) SetupFunc {
+	return func(s *Server) {
+		s.liveness = livenessService
+		s.mux.HandleFunc("POST /api/v1/heartbeats", s.recordHeartbeats)
+		s.mux.HandleFunc("GET /api/v1/hosts/{host_id}/liveness", s.getHostLiveness)
+		s.mux.HandleFunc("GET /api/v1/liveness/unreachable", s.listUnreachableHosts)
+		s.mux.HandleFunc("GET /api/v1/outages", s.listOutages)
+	}
+}
+
+func WithKillSwitches(killSwitchService *killswitch.KillSwitchService) SetupFunc {
+	return func(s *Server) {
+		s.switches = killSwitchService
+		s.mux.HandleFunc("GET /api/v1/kill-switches", s.listKillSwitches)
+		s.mux.HandleFunc("POST /api/v1/kill-switches", s.engageKillSwitch)
+		s.mux.HandleFunc("GET /api/v1/kill-switches/{switch_id}", s.getKillSwitch)
+		s.mux.HandleFunc("POST /api/v1/kill-switches/{switch_id}/release", s.releaseKillSwitch)
+	}
+}
+
+func WithTimeline(timelineService *timeline.TimelineService) SetupFunc {
+	return func(s *Server) {
+		s.timeline = timelineService
+		s.mux.HandleFunc("GET /api/v1/hosts/{host_id}/timeline", s.getHostTimeline)
+		s.mux.HandleFunc("GET /api/v1/hosts/{host_id}/timeline/export", s.exportHostTimeline)
+	}
+}
+
+func WithApprovals(approvalService *approvals.ApprovalService) SetupFunc {
+	return func(s *Server) {
+		s.approvals = approvalService
+		s.mux.HandleFunc("GET /api/v1/approvals", s.listApprovals)
+		s.mux.HandleFunc("GET /api/v1/approvals/{approval_id}", s.getApproval)
+		s.mux.HandleFunc("POST /api/v1/approvals/{approval_id}/approve", s.approveAction)
+		s.mux.HandleFunc("POST /api/v1/approvals/{approval_id}/reject", s.rejectAction)
+	}
+}
+
+func WithInventory(inventoryService *inventory.InventoryService) SetupFunc {
+	return func(s *Server) {
+		s.inventory = inventoryService
+		s.mux.HandleFunc("POST /api/v1/inventory/events", s.applyInventoryEvent)
+	}
+}
+
+func NewServer(checkService *checks.CheckService, setup ...SetupFunc) *Server {
+	s := &Server{checks: checkService, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /api/v1/checks", s.ingestChecks)
+	s.mux.HandleFunc("GET /api/v1/checks/stale", s.listStaleChecks)
+	s.mux.HandleFunc("GET /api/v1/hosts/{host_id}/checks", s.listHostChecks)
+	s.mux.HandleFunc("GET /api/v1/hosts/{host_id}/checks/{check}/history", s.getCheckHistory)
+	s.mux.HandleFunc("GET /api/v1/manual-queue", s.listManualQueue)
+	s.mux.HandleFunc("POST /api/v1/manual-queue/{item_id}/resolve", s.resolveManualQueueItem)
+	for _, fn := range setup {
+		fn(s)
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, target interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_BODY_SIZE))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
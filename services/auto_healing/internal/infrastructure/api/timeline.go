@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/timeline/entities"
+	timelineErrors "github.com/gwall-e/auto_healing/internal/domain/timeline/errors"
+)
+
+type timelineEntryResponse struct {
+	At         time.Time         `json:"at"`
+	Kind       string            `json:"kind"`
+	Check      string            `json:"check,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Action     string            `json:"action,omitempty"`
+	Rule       string            `json:"rule,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+	DryRun     bool              `json:"dry_run,omitempty"`
+	DecisionID string            `json:"decision_id,omitempty"`
+}
+
+type timelineResponse struct {
+	HostID  string                  `json:"host_id"`
+	Since   time.Time               `json:"since"`
+	Until   time.Time               `json:"until"`
+	Entries []timelineEntryResponse `json:"entries"`
+}
+
+type timelineExportResponse struct {
+	timelineResponse
+	ExportedAt time.Time                   `json:"exported_at"`
+	Decisions  []explainedDecisionResponse `json:"decisions"`
+}
+
+func (s *Server) getHostTimeline(w http.ResponseWriter, r *http.Request) {
+	timeline, ok := s.readHostTimeline(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newTimelineResponse(timeline))
+}
+
+func (s *Server) exportHostTimeline(w http.ResponseWriter, r *http.Request) {
+	timeline, ok := s.readHostTimeline(w, r)
+	if !ok {
+		return
+	}
+	response := timelineExportResponse{
+		timelineResponse: newTimelineResponse(timeline),
+		ExportedAt:       time.Now().UTC(),
+		Decisions:        make([]explainedDecisionResponse, 0, len(timeline.Decisions)),
+	}
+	for _, record := range timeline.Decisions {
+		response.Decisions = append(response.Decisions, newExplainedDecisionResponse(record))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "timeline-"+timeline.HostID+".json"))
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) readHostTimeline(w http.ResponseWriter, r *http.Request) (*entities.Timeline, bool) {
+	query := r.URL.Query()
+	since, err := parseTimeParam(query, "since")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	until, err := parseTimeParam(query, "until")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	timeline, err := s.timeline.GetHostTimeline(r.Context(), r.PathValue("host_id"), since, until)
+	switch {
+	case errors.Is(err, timelineErrors.ErrInvalidPeriod), errors.Is(err, timelineErrors.ErrPeriodTooLong):
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return timeline, true
+}
+
+func newTimelineResponse(timeline *entities.Timeline) timelineResponse {
+	response := timelineResponse{
+		HostID:  timeline.HostID,
+		Since:   timeline.Since,
+		Until:   timeline.Until,
+		Entries: make([]timelineEntryResponse, 0, len(timeline.Entries)),
+	}
+	for _, entry := range timeline.Entries {
+		response.Entries = append(response.Entries, timelineEntryResponse{
+			At:         entry.At,
+			Kind:       string(entry.Kind),
+			Check:      string(entry.Check),
+			Status:     string(entry.Status),
+			Metadata:   entry.Metadata,
+			Action:     string(entry.Action),
+			Rule:       entry.Rule,
+			Reason:     entry.Reason,
+			DryRun:     entry.DryRun,
+			DecisionID: entry.DecisionID,
+		})
+	}
+	return response
+}
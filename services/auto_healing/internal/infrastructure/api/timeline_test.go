@@ -0,0 +1,93 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	checkEntities "github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/timeline"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timeline API", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(entities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		history := memory.NewActionHistory()
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(),
+			checks.WithClock(clock), checks.WithStaleTimeout(24*time.Hour))
+		decisionService := decisions.NewDomainService(hosts, checkService, history,
+			memory.NewDecisionRepository(), memory.NewDryRunRepository(), decisions.WithClock(clock))
+
+		_, err := checkService.IngestResults(ctx, []checkEntities.CheckResult{
+			{HostID: "host-1", Type: core_entities.CheckSSH, Status: checkEntities.CheckStatusFailed, Timestamp: now.Add(-time.Hour)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = decisionService.DecideHost(ctx, "host-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history.AddAction(ctx, "host-1", entities.ActionRecord{Action: entities.ActionReboot, Check: core_entities.CheckSSH, At: now})).To(Succeed())
+
+		timelineService := timeline.NewDomainService(checkService, decisionService, history, timeline.WithClock(func() time.Time { return now.Add(time.Minute) }))
+		server = httptest.NewServer(NewServer(checkService, WithTimeline(timelineService)))
+		DeferCleanup(server.Close)
+	})
+
+	get := func(path string) (*http.Response, map[string]interface{}) {
+		response, err := http.Get(server.URL + path)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response, decoded
+	}
+
+	It("should return the host timeline", func() {
+		response, body := get("/api/v1/hosts/host-1/timeline")
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("until", "2026-10-01T12:01:00Z"))
+		Expect(body["entries"]).To(HaveExactElements(
+			And(HaveKeyWithValue("kind", "check"), HaveKeyWithValue("status", "failed")),
+			And(HaveKeyWithValue("kind", "decision"), HaveKeyWithValue("action", "reboot"), HaveKeyWithValue("rule", "ssh")),
+			And(HaveKeyWithValue("kind", "action"), HaveKeyWithValue("action", "reboot")),
+		))
+		Expect(body).NotTo(HaveKey("decisions"))
+
+		response, body = get("/api/v1/hosts/host-1/timeline?since=2026-10-01T12:00:30Z")
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(body["entries"]).To(BeEmpty())
+	})
+
+	It("should export the timeline with explained decisions", func() {
+		response, body := get("/api/v1/hosts/host-1/timeline/export")
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Content-Disposition")).To(Equal(`attachment; filename="timeline-host-1.json"`))
+		Expect(body["entries"]).To(HaveLen(3))
+		Expect(body).To(HaveKey("exported_at"))
+		Expect(body["decisions"]).To(ConsistOf(HaveKeyWithValue("explanation", HaveKey("rules"))))
+	})
+
+	It("should reject invalid periods", func() {
+		response, _ := get("/api/v1/hosts/host-1/timeline?since=yesterday")
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		response, _ = get("/api/v1/hosts/host-1/timeline/export?since=2026-10-02T00:00:00Z&until=2026-10-01T00:00:00Z")
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		response, _ = get("/api/v1/hosts/host-1/timeline?since=2026-01-01T00:00:00Z")
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+type workflowStepResponse struct {
+	Action     string     `json:"action"`
+	Status     string     `json:"status"`
+	Attempts   int        `json:"attempts"`
+	Error      string     `json:"error,omitempty"`
+	Timeout    string     `json:"timeout"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type workflowResponse struct {
+	ID         string                 `json:"id"`
+	HostID     string                 `json:"host_id"`
+	ProjectID  string                 `json:"project_id"`
+	Check      string                 `json:"check"`
+	Rule       string                 `json:"rule,omitempty"`
+	ApprovedBy string                 `json:"approved_by,omitempty"`
+	Status     string                 `json:"status"`
+	Steps      []workflowStepResponse `json:"steps"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+type workflowsResponse struct {
+	Workflows []workflowResponse `json:"workflows"`
+}
+
+func (s *Server) listHostWorkflows(w http.ResponseWriter, r *http.Request) {
+	workflows, err := s.workflows.ListHostWorkflows(r.Context(), r.PathValue("host_id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	response := workflowsResponse{Workflows: make([]workflowResponse, 0, len(workflows))}
+	for _, workflow := range workflows {
+		response.Workflows = append(response.Workflows, newWorkflowResponse(workflow))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflow, err := s.workflows.GetWorkflow(r.Context(), r.PathValue("workflow_id"))
+	if err != nil {
+		writeWorkflowError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newWorkflowResponse(workflow))
+}
+
+func (s *Server) cancelWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflow, err := s.workflows.CancelWorkflow(r.Context(), r.PathValue("workflow_id"))
+	if err != nil {
+		writeWorkflowError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newWorkflowResponse(workflow))
+}
+
+func writeWorkflowError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, workflowErrors.ErrWorkflowNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, workflowErrors.ErrWorkflowFinished),
+		errors.Is(err, workflowErrors.ErrWorkflowVersionConflict):
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func newWorkflowResponse(workflow *entities.Workflow) workflowResponse {
+	response := workflowResponse{
+		ID:         workflow.ID,
+		HostID:     workflow.HostID,
+		ProjectID:  workflow.ProjectID,
+		Check:      string(workflow.Check),
+		Rule:       workflow.Rule,
+		ApprovedBy: workflow.ApprovedBy,
+		Status:     string(workflow.Status),
+		Steps:      make([]workflowStepResponse, 0, len(workflow.Steps)),
+		CreatedAt:  workflow.CreatedAt,
+		UpdatedAt:  workflow.UpdatedAt,
+	}
+	for _, step := range workflow.Steps {
+		response.Steps = append(response.Steps, workflowStepResponse{
+			Action:     string(step.Action),
+			Status:     string(step.Status),
+			Attempts:   step.Attempts,
+			Error:      step.Error,
+			Timeout:    step.Timeout.String(),
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/api"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type noopRunner struct{}
+
+func (noopRunner) RunAction(ctx context.Context, hostID string, action decisionEntities.Action) error {
+	return nil
+}
+
+var _ = Describe("Workflows API", func() {
+	var (
+		server   *httptest.Server
+		workflow *entities.Workflow
+	)
+
+	BeforeEach(func() {
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		clock := func() time.Time { return now }
+		checkService := checks.NewDomainService(memory.NewCheckRepository(), memory.NewManualQueueRepository(), checks.WithClock(clock))
+		hosts := memory.NewHostInfoRepository()
+		hosts.SetHostInfo(decisionEntities.HostInfo{HostID: "host-1", ProjectID: "search", UnitType: core_entities.TypeServer})
+		releases := memory.NewHostReleaseRepository()
+		releases.SetReleased("host-1", decisionEntities.ActionReboot)
+		workflowService := workflows.NewDomainService(memory.NewWorkflowRepository(), noopRunner{}, hosts, releases,
+			checkService, memory.NewActionHistory(), memory.NewEventLog(), workflows.WithClock(clock))
+
+		var err error
+		workflow, err = workflowService.StartWorkflow(context.Background(), entities.WorkflowRequest{
+			HostID:    "host-1",
+			ProjectID: "search",
+			Check:     core_entities.CheckSSH,
+			Actions:   []decisionEntities.Action{decisionEntities.ActionReboot, decisionEntities.ActionReportToDatacenter},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workflowService.ProcessWorkflows(context.Background())).To(Succeed())
+
+		server = httptest.NewServer(NewServer(checkService, WithWorkflows(workflowService)))
+		DeferCleanup(server.Close)
+	})
+
+	do := func(method string, path string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		var decoded map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&decoded)).To(Succeed())
+		return response.StatusCode, decoded
+	}
+
+	It("should show and cancel workflows of a host", func() {
+		status, body := do(http.MethodGet, "/api/v1/hosts/host-1/workflows")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["workflows"]).To(ConsistOf(HaveKeyWithValue("id", workflow.ID)))
+
+		status, body = do(http.MethodGet, "/api/v1/workflows/"+workflow.ID)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("status", "running"))
+		Expect(body["steps"]).To(ConsistOf(
+			SatisfyAll(HaveKeyWithValue("action", "reboot"), HaveKeyWithValue("status", "verifying"), HaveKeyWithValue("timeout", "30m0s")),
+			SatisfyAll(HaveKeyWithValue("action", "report-to-datacenter"), HaveKeyWithValue("status", "pending")),
+		))
+
+		status, body = do(http.MethodPost, "/api/v1/workflows/"+workflow.ID+"/cancel")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("status", "cancelled"))
+
+		status, _ = do(http.MethodPost, "/api/v1/workflows/"+workflow.ID+"/cancel")
+		Expect(status).To(Equal(http.StatusConflict))
+		status, _ = do(http.MethodGet, "/api/v1/workflows/unknown")
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+})
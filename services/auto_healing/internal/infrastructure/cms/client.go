@@ -0,0 +1,114 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/releases/errors"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+const TASKS_PATH = "/tasks"
+
+type task struct {
+	ID      string   `json:"id"`
+	Type    string   `json:"type,omitempty"`
+	Issuer  string   `json:"issuer"`
+	Action  string   `json:"action"`
+	Hosts   []string `json:"hosts"`
+	Status  string   `json:"status,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+type Client struct {
+	client  pkgHttp.HTTPClient
+	headers map[string]string
+}
+
+func NewClient(client pkgHttp.HTTPClient, token string) *Client {
+	headers := map[string]string{"Content-Type": "application/json", "Accept": "application/json"}
+	if token != "" {
+		headers["Authorization"] = "OAuth " + token
+	}
+	return &Client{client: client, headers: headers}
+}
+
+func (c *Client) CreateTask(ctx context.Context, request *entities.Task) (*entities.Task, error) {
+	body, err := json.Marshal(task{
+		ID:     request.ID,
+		Type:   "automated",
+		Issuer: request.Issuer,
+		Action: request.Action,
+		Hosts:  request.Hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result task
+	resp, err := c.client.Post(ctx, TASKS_PATH, bytes.NewReader(body), c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+	return result.toEntity(), nil
+}
+
+func (c *Client) GetTask(ctx context.Context, id string) (*entities.Task, error) {
+	var result task
+	resp, err := c.client.Get(ctx, TASKS_PATH+"/"+id, nil, c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+	return result.toEntity(), nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	resp, err := c.client.Delete(ctx, TASKS_PATH+"/"+id, c.headers)
+	return decodeResponse(resp, err, nil)
+}
+
+func (t task) toEntity() *entities.Task {
+	status := entities.TaskStatus(t.Status)
+	switch status {
+	case entities.TaskApproved, entities.TaskRejected:
+	default:
+		status = entities.TaskInProcess
+	}
+
+	hosts := t.Hosts
+	if hosts == nil {
+		hosts = []string{}
+	}
+	return &entities.Task{
+		ID:      t.ID,
+		Action:  t.Action,
+		Hosts:   hosts,
+		Issuer:  t.Issuer,
+		Status:  status,
+		Message: t.Message,
+	}
+}
+
+func decodeResponse(resp *http.Response, err error, target interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.ErrTaskNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("cms responded with status %d: %s", resp.StatusCode, string(body))
+	}
+	if target == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,131 @@
+package cmstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	StatusInProcess = "in-process"
+	StatusApproved  = "ok"
+	StatusRejected  = "rejected"
+)
+
+type Task struct {
+	ID      string   `json:"id"`
+	Type    string   `json:"type,omitempty"`
+	Issuer  string   `json:"issuer"`
+	Action  string   `json:"action"`
+	Hosts   []string `json:"hosts"`
+	Status  string   `json:"status,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	tasks         map[string]*Task
+	authorization string
+	autoApprove   bool
+}
+
+func NewServer(authorization string) *Server {
+	s := &Server{tasks: map[string]*Task{}, authorization: authorization}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *Server) SetAutoApprove(autoApprove bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoApprove = autoApprove
+}
+
+func (s *Server) Approve(id string) {
+	s.setStatus(id, StatusApproved, "")
+}
+
+func (s *Server) Reject(id string, message string) {
+	s.setStatus(id, StatusRejected, message)
+}
+
+func (s *Server) Tasks() []Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		copied := *task
+		copied.Hosts = slices.Clone(task.Hosts)
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (s *Server) setStatus(id string, status string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if task, ok := s.tasks[id]; ok {
+		task.Status = status
+		task.Message = message
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if s.authorization != "" && r.Header.Get("Authorization") != s.authorization {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/tasks") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/tasks"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		var task Task
+		if err := json.NewDecoder(r.Body).Decode(&task); err != nil || task.ID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		task.Status = StatusInProcess
+		if s.autoApprove {
+			task.Status = StatusApproved
+		}
+		s.tasks[task.ID] = &task
+		writeJSON(w, http.StatusCreated, task)
+	case r.Method == http.MethodGet && id != "":
+		task, ok := s.tasks[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, task)
+	case r.Method == http.MethodDelete && id != "":
+		if _, ok := s.tasks[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.tasks, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,205 @@
+package hypervisor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+const (
+	VMS_PATH = "/api/v1/vms"
+
+	ACTION_START = "start"
+	ACTION_STOP  = "stop"
+	ACTION_RESET = "reset"
+
+	STATE_RUNNING  = "running"
+	STATE_SHUT_OFF = "shut_off"
+)
+
+var bootDevices = map[entities.BootDevice]string{
+	entities.BootDevicePXE:  "network",
+	entities.BootDeviceDisk: "hd",
+}
+
+type vm struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+}
+
+type Driver struct {
+	credentials contracts.CredentialsProvider
+	setup       []pkgHttp.SetupFunc
+}
+
+func NewDriver(credentials contracts.CredentialsProvider, setup ...pkgHttp.SetupFunc) *Driver {
+	return &Driver{credentials: credentials, setup: setup}
+}
+
+func (d *Driver) PowerOn(ctx context.Context, target entities.PowerTarget) error {
+	return d.action(ctx, target, ACTION_START)
+}
+
+func (d *Driver) PowerOff(ctx context.Context, target entities.PowerTarget) error {
+	return d.action(ctx, target, ACTION_STOP)
+}
+
+func (d *Driver) PowerCycle(ctx context.Context, target entities.PowerTarget) error {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	machine, err := c.vm(ctx)
+	if err != nil {
+		return err
+	}
+
+	action := ACTION_RESET
+	if machine.State == STATE_SHUT_OFF {
+		action = ACTION_START
+	}
+	return c.send(ctx, http.MethodPost, c.path+"/"+action, nil)
+}
+
+func (d *Driver) Status(ctx context.Context, target entities.PowerTarget) (entities.PowerState, error) {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return entities.PowerStateUnknown, err
+	}
+	machine, err := c.vm(ctx)
+	if err != nil {
+		return entities.PowerStateUnknown, err
+	}
+
+	switch machine.State {
+	case STATE_RUNNING:
+		return entities.PowerStateOn, nil
+	case STATE_SHUT_OFF:
+		return entities.PowerStateOff, nil
+	default:
+		return entities.PowerStateUnknown, nil
+	}
+}
+
+func (d *Driver) SetBootDevice(ctx context.Context, target entities.PowerTarget, device entities.BootDevice, persistent bool) error {
+	bootDevice, ok := bootDevices[device]
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrUnsupportedBootDevice, device)
+	}
+
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{"device": bootDevice, "persistent": persistent}
+	return c.send(ctx, http.MethodPut, c.path+"/boot", body)
+}
+
+func (d *Driver) ReadSEL(ctx context.Context, target entities.PowerTarget, since time.Time) ([]entities.SELEntry, error) {
+	return nil, fmt.Errorf("%w: vm %s", errors.ErrNoEventLog, target.HostID)
+}
+
+func (d *Driver) action(ctx context.Context, target entities.PowerTarget, action string) error {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, http.MethodPost, c.path+"/"+action, nil)
+}
+
+type conn struct {
+	client  pkgHttp.HTTPClient
+	headers map[string]string
+	path    string
+}
+
+func (d *Driver) connect(ctx context.Context, target entities.PowerTarget) (*conn, error) {
+	if target.Address == "" {
+		return nil, fmt.Errorf("hypervisor address of host %s is not configured", target.HostID)
+	}
+	if target.ResourceID == "" {
+		return nil, fmt.Errorf("vm id of host %s is not configured", target.HostID)
+	}
+	credentials, err := d.credentials.GetCredentials(ctx, target.SecretID)
+	if err != nil {
+		return nil, err
+	}
+
+	address := target.Address
+	if !strings.Contains(address, "://") {
+		address = "https://" + address
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+
+	return &conn{
+		client: pkgHttp.NewClient(address, d.setup...),
+		headers: map[string]string{
+			"Authorization": "Basic " + auth,
+			"Accept":        "application/json",
+			"Content-Type":  "application/json",
+		},
+		path: VMS_PATH + "/" + target.ResourceID,
+	}, nil
+}
+
+func (c *conn) vm(ctx context.Context) (*vm, error) {
+	resp, err := c.client.Get(ctx, c.path, nil, c.headers)
+	var machine vm
+	if err := decodeResponse(resp, err, &machine); err != nil {
+		return nil, err
+	}
+	return &machine, nil
+}
+
+func (c *conn) send(ctx context.Context, method string, path string, body interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	switch method {
+	case http.MethodPut:
+		resp, err = c.client.Put(ctx, path, bytes.NewReader(data), c.headers)
+	default:
+		resp, err = c.client.Post(ctx, path, bytes.NewReader(data), c.headers)
+	}
+	return decodeResponse(resp, err, nil)
+}
+
+func decodeResponse(resp *http.Response, err error, target interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.ErrMachineNotFound
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errors.ErrAccessDenied
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("hypervisor responded with status %d: %s", resp.StatusCode, string(body))
+	}
+	if target == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,105 @@
+package hypervisor_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/hypervisor"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/hypervisor/hypervisortest"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/vault"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Driver", func() {
+	var (
+		ctx    context.Context
+		server *hypervisortest.Server
+		secret *vault.FakeVault
+		driver *Driver
+		target entities.PowerTarget
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = hypervisortest.NewServer("healer", "secret")
+		DeferCleanup(server.Close)
+		server.AddVM("vm-7", "running")
+
+		secret = vault.NewFakeVault()
+		secret.SetCredentials("hypervisor-sas", "healer", "secret")
+		driver = NewDriver(secret)
+		target = entities.PowerTarget{
+			HostID:     "host-1",
+			UnitType:   core_entities.TypeVM,
+			Address:    server.URL,
+			ResourceID: "vm-7",
+			SecretID:   "hypervisor-sas",
+		}
+	})
+
+	vm := func(id string) hypervisortest.VM {
+		machine, ok := server.VM(id)
+		Expect(ok).To(BeTrue())
+		return machine
+	}
+
+	It("should stop and start the vm", func() {
+		Expect(driver.PowerOff(ctx, target)).To(Succeed())
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateOff))
+
+		Expect(driver.PowerOn(ctx, target)).To(Succeed())
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateOn))
+		Expect(vm("vm-7").Actions).To(Equal([]string{"stop", "start"}))
+	})
+
+	It("should reset a running vm and start a stopped one", func() {
+		Expect(driver.PowerCycle(ctx, target)).To(Succeed())
+
+		server.AddVM("vm-8", "shut_off")
+		target.ResourceID = "vm-8"
+		Expect(driver.PowerCycle(ctx, target)).To(Succeed())
+
+		Expect(vm("vm-7").Actions).To(Equal([]string{"reset"}))
+		Expect(vm("vm-8").Actions).To(Equal([]string{"start"}))
+	})
+
+	It("should report transitional states as unknown", func() {
+		server.AddVM("vm-8", "paused")
+		target.ResourceID = "vm-8"
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateUnknown))
+	})
+
+	DescribeTable("should set the boot device",
+		func(device entities.BootDevice, persistent bool, bootDevice string) {
+			Expect(driver.SetBootDevice(ctx, target, device, persistent)).To(Succeed())
+			Expect(vm("vm-7").BootDevice).To(Equal(bootDevice))
+			Expect(vm("vm-7").BootPersistent).To(Equal(persistent))
+		},
+		Entry("pxe once", entities.BootDevicePXE, false, "network"),
+		Entry("disk persistently", entities.BootDeviceDisk, true, "hd"),
+	)
+
+	It("should fail with typed errors", func() {
+		Expect(driver.SetBootDevice(ctx, target, entities.BootDeviceBIOS, false)).To(MatchError(errors.ErrUnsupportedBootDevice))
+		_, err := driver.ReadSEL(ctx, target, time.Time{})
+		Expect(err).To(MatchError(errors.ErrNoEventLog))
+
+		target.ResourceID = "vm-404"
+		_, err = driver.Status(ctx, target)
+		Expect(err).To(MatchError(errors.ErrMachineNotFound))
+
+		target.ResourceID = "vm-7"
+		secret.SetCredentials("hypervisor-sas", "healer", "wrong")
+		Expect(driver.PowerOn(ctx, target)).To(MatchError(errors.ErrAccessDenied))
+
+		target.SecretID = "unknown"
+		Expect(driver.PowerOn(ctx, target)).To(MatchError(errors.ErrSecretNotFound))
+
+		target.ResourceID = ""
+		Expect(driver.PowerOn(ctx, target)).To(MatchError(ContainSubstring("vm id of host host-1 is not configured")))
+	})
+})
@@ -0,0 +1,13 @@
+package hypervisor_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHypervisorSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hypervisor Driver Suite")
+}
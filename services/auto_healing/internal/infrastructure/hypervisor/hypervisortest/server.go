@@ -0,0 +1,126 @@
+package hypervisortest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const VMS_PATH = "/api/v1/vms"
+
+type VM struct {
+	ID             string
+	State          string
+	BootDevice     string
+	BootPersistent bool
+	Actions        []string
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	username string
+	password string
+	vms      map[string]*VM
+}
+
+func NewServer(username string, password string) *Server {
+	s := &Server{username: username, password: password, vms: map[string]*VM{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *Server) AddVM(id string, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vms[id] = &VM{ID: id, State: state, BootDevice: "hd", BootPersistent: true}
+}
+
+func (s *Server) VM(id string) (VM, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	machine, ok := s.vms[id]
+	if !ok {
+		return VM{}, false
+	}
+	result := *machine
+	result.Actions = slices.Clone(machine.Actions)
+	return result, true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.username || password != s.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rest, found := strings.CutPrefix(r.URL.Path, VMS_PATH+"/")
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	id, resource, _ := strings.Cut(rest, "/")
+	machine, ok := s.vms[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case resource == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]string{"id": machine.ID, "state": machine.State})
+	case resource == "boot" && r.Method == http.MethodPut:
+		s.setBoot(w, r, machine)
+	case r.Method == http.MethodPost:
+		s.runAction(w, machine, resource)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *Server) setBoot(w http.ResponseWriter, r *http.Request, machine *VM) {
+	var body struct {
+		Device     string `json:"device"`
+		Persistent bool   `json:"persistent"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Device == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	machine.BootDevice = body.Device
+	machine.BootPersistent = body.Persistent
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) runAction(w http.ResponseWriter, machine *VM, action string) {
+	switch action {
+	case "start":
+		machine.State = "running"
+	case "stop":
+		machine.State = "shut_off"
+	case "reset":
+		if machine.State != "running" {
+			writeJSON(w, http.StatusConflict, map[string]string{"error": "vm is not running"})
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	machine.Actions = append(machine.Actions, action)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,29 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+)
+
+type DatacenterReporter struct {
+	sender     contracts.NotificationSender
+	recipients []string
+}
+
+func NewDatacenterReporter(sender contracts.NotificationSender, recipients []string) *DatacenterReporter {
+	return &DatacenterReporter{sender: sender, recipients: recipients}
+}
+
+func (r *DatacenterReporter) ReportHost(ctx context.Context, host decisionEntities.HostInfo) error {
+	return r.sender.Send(ctx, entities.Notification{
+		Recipients: r.recipients,
+		Subject:    fmt.Sprintf("Host %s needs datacenter attention", host.HostID),
+		Body: fmt.Sprintf("Automated healing could not recover host %s of project %s. Location: datacenter %s, rack %s, switch %s.",
+			host.HostID, host.ProjectID, host.Datacenter, host.Rack, host.Switch),
+		DedupKey: "datacenter-report:" + host.HostID,
+	})
+}
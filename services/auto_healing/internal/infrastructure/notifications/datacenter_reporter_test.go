@@ -0,0 +1,37 @@
+package notifications_test
+
+import (
+	"context"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/notifications"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type recordingSender struct {
+	sent []entities.Notification
+}
+
+func (s *recordingSender) Send(ctx context.Context, notification entities.Notification) error {
+	s.sent = append(s.sent, notification)
+	return nil
+}
+
+var _ = Describe("DatacenterReporter", func() {
+	It("should notify datacenter staff about the host and its location", func() {
+		sender := &recordingSender{}
+		reporter := NewDatacenterReporter(sender, []string{"dc-sas"})
+		Expect(reporter.ReportHost(context.Background(), decisionEntities.HostInfo{
+			HostID: "host-1", ProjectID: "search", Datacenter: "sas", Rack: "1a", Switch: "sas-s1a",
+		})).To(Succeed())
+
+		Expect(sender.sent).To(Equal([]entities.Notification{{
+			Recipients: []string{"dc-sas"},
+			Subject:    "Host host-1 needs datacenter attention",
+			Body:       "Automated healing could not recover host host-1 of project search. Location: datacenter sas, rack 1a, switch sas-s1a.",
+			DedupKey:   "datacenter-report:host-1",
+		}}))
+	})
+})
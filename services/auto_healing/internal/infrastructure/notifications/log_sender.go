@@ -0,0 +1,23 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+)
+
+type LogSender struct {
+	logger *log.Logger
+}
+
+func NewLogSender(logger *log.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, notification entities.Notification) error {
+	s.logger.Printf("notification %s to %s: %s: %s", notification.DedupKey, strings.Join(notification.Recipients, ", "),
+		notification.Subject, notification.Body)
+	return nil
+}
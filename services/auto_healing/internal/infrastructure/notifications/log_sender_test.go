@@ -0,0 +1,27 @@
+package notifications_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/notifications"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogSender", func() {
+	It("should log the notification instead of sending it", func() {
+		var output bytes.Buffer
+		sender := NewLogSender(log.New(&output, "", 0))
+
+		Expect(sender.Send(context.Background(), entities.Notification{
+			Recipients: []string{"alice", "bob"},
+			Subject:    "subject",
+			Body:       "body",
+			DedupKey:   "kill-switch-expired:1",
+		})).To(Succeed())
+		Expect(output.String()).To(Equal("notification kill-switch-expired:1 to alice, bob: subject: body\n"))
+	})
+})
@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+type webhookPayload struct {
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject"`
+	Body       string   `json:"body"`
+	DedupKey   string   `json:"dedup_key"`
+}
+
+type WebhookSender struct {
+	client pkgHttp.HTTPClient
+	url    string
+}
+
+func NewWebhookSender(client pkgHttp.HTTPClient, url string) *WebhookSender {
+	return &WebhookSender{client: client, url: url}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, notification entities.Notification) error {
+	payload, err := json.Marshal(webhookPayload{
+		Recipients: notification.Recipients,
+		Subject:    notification.Subject,
+		Body:       notification.Body,
+		DedupKey:   notification.DedupKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
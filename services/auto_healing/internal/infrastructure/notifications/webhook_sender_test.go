@@ -0,0 +1,64 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/notifications"
+	pkgHttp "github.com/gwall-e/pkg/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookSender", func() {
+	var (
+		server   *httptest.Server
+		received []map[string]interface{}
+		status   int
+	)
+
+	BeforeEach(func() {
+		received = nil
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/notifications"))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			payload := map[string]interface{}{}
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			received = append(received, payload)
+			w.WriteHeader(status)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func() error {
+		sender := NewWebhookSender(pkgHttp.NewClient(""), server.URL+"/notifications")
+		return sender.Send(context.Background(), entities.Notification{
+			Recipients: []string{"alice", "bob"},
+			Subject:    "subject",
+			Body:       "body",
+			DedupKey:   "kill-switch-expired:1",
+		})
+	}
+
+	It("should post the notification as json", func() {
+		Expect(send()).To(Succeed())
+		Expect(received).To(Equal([]map[string]interface{}{{
+			"recipients": []interface{}{"alice", "bob"},
+			"subject":    "subject",
+			"body":       "body",
+			"dedup_key":  "kill-switch-expired:1",
+		}}))
+	})
+
+	It("should fail when the gateway rejects the notification", func() {
+		status = http.StatusBadGateway
+		Expect(send()).To(MatchError(ContainSubstring("responded with status 502")))
+	})
+})
@@ -0,0 +1,315 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+const (
+	SYSTEMS_PATH = "/redfish/v1/Systems"
+
+	RESET_ON            = "On"
+	RESET_FORCE_OFF     = "ForceOff"
+	RESET_POWER_CYCLE   = "PowerCycle"
+	RESET_FORCE_RESTART = "ForceRestart"
+)
+
+var bootTargets = map[entities.BootDevice]string{
+	entities.BootDevicePXE:  "Pxe",
+	entities.BootDeviceDisk: "Hdd",
+	entities.BootDeviceBIOS: "BiosSetup",
+}
+
+type system struct {
+	ID         string `json:"Id"`
+	PowerState string `json:"PowerState"`
+	Actions    struct {
+		Reset struct {
+			Target          string   `json:"target"`
+			AllowableValues []string `json:"ResetType@Redfish.AllowableValues"`
+		} `json:"#ComputerSystem.Reset"`
+	} `json:"Actions"`
+}
+
+type link struct {
+	ID string `json:"@odata.id"`
+}
+
+type collection struct {
+	Members  []link `json:"Members"`
+	NextLink string `json:"Members@odata.nextLink"`
+}
+
+type logEntry struct {
+	ID       string    `json:"Id"`
+	Created  time.Time `json:"Created"`
+	Severity string    `json:"Severity"`
+	Message  string    `json:"Message"`
+}
+
+type logEntries struct {
+	Members  []logEntry `json:"Members"`
+	NextLink string     `json:"Members@odata.nextLink"`
+}
+
+type Driver struct {
+	credentials contracts.CredentialsProvider
+	setup       []pkgHttp.SetupFunc
+}
+
+func NewDriver(credentials contracts.CredentialsProvider, setup ...pkgHttp.SetupFunc) *Driver {
+	return &Driver{credentials: credentials, setup: setup}
+}
+
+func (d *Driver) PowerOn(ctx context.Context, target entities.PowerTarget) error {
+	return d.reset(ctx, target, RESET_ON)
+}
+
+func (d *Driver) PowerOff(ctx context.Context, target entities.PowerTarget) error {
+	return d.reset(ctx, target, RESET_FORCE_OFF)
+}
+
+func (d *Driver) PowerCycle(ctx context.Context, target entities.PowerTarget) error {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	sys, err := c.system(ctx)
+	if err != nil {
+		return err
+	}
+
+	resetType := RESET_POWER_CYCLE
+	switch {
+	case sys.PowerState == "Off":
+		resetType = RESET_ON
+	case len(sys.Actions.Reset.AllowableValues) > 0 && !slices.Contains(sys.Actions.Reset.AllowableValues, RESET_POWER_CYCLE):
+		resetType = RESET_FORCE_RESTART
+	}
+	return c.reset(ctx, sys, resetType)
+}
+
+func (d *Driver) Status(ctx context.Context, target entities.PowerTarget) (entities.PowerState, error) {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return entities.PowerStateUnknown, err
+	}
+	sys, err := c.system(ctx)
+	if err != nil {
+		return entities.PowerStateUnknown, err
+	}
+
+	switch sys.PowerState {
+	case "On":
+		return entities.PowerStateOn, nil
+	case "Off":
+		return entities.PowerStateOff, nil
+	default:
+		return entities.PowerStateUnknown, nil
+	}
+}
+
+func (d *Driver) SetBootDevice(ctx context.Context, target entities.PowerTarget, device entities.BootDevice, persistent bool) error {
+	bootTarget, ok := bootTargets[device]
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrUnsupportedBootDevice, device)
+	}
+	enabled := "Once"
+	if persistent {
+		enabled = "Continuous"
+	}
+
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"Boot": map[string]string{
+			"BootSourceOverrideTarget":  bootTarget,
+			"BootSourceOverrideEnabled": enabled,
+		},
+	}
+	return c.send(ctx, http.MethodPatch, path, body)
+}
+
+func (d *Driver) ReadSEL(ctx context.Context, target entities.PowerTarget, since time.Time) ([]entities.SELEntry, error) {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []entities.SELEntry{}
+	next := path + "/LogServices/SEL/Entries"
+	for next != "" {
+		var page logEntries
+		if err := c.get(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, entry := range page.Members {
+			if entry.Created.Before(since) {
+				continue
+			}
+			entries = append(entries, entities.SELEntry{
+				ID:       entry.ID,
+				Created:  entry.Created,
+				Severity: entry.Severity,
+				Message:  entry.Message,
+			})
+		}
+		next = page.NextLink
+	}
+	slices.SortStableFunc(entries, func(a, b entities.SELEntry) int { return a.Created.Compare(b.Created) })
+	return entries, nil
+}
+
+func (d *Driver) reset(ctx context.Context, target entities.PowerTarget, resetType string) error {
+	c, err := d.connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	sys, err := c.system(ctx)
+	if err != nil {
+		return err
+	}
+	return c.reset(ctx, sys, resetType)
+}
+
+type conn struct {
+	client   pkgHttp.HTTPClient
+	headers  map[string]string
+	systemID string
+	path     string
+}
+
+func (d *Driver) connect(ctx context.Context, target entities.PowerTarget) (*conn, error) {
+	if target.Address == "" {
+		return nil, fmt.Errorf("bmc address of host %s is not configured", target.HostID)
+	}
+	credentials, err := d.credentials.GetCredentials(ctx, target.SecretID)
+	if err != nil {
+		return nil, err
+	}
+
+	address := target.Address
+	if !strings.Contains(address, "://") {
+		address = "https://" + address
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+
+	return &conn{
+		client: pkgHttp.NewClient(address, d.setup...),
+		headers: map[string]string{
+			"Authorization": "Basic " + auth,
+			"Accept":        "application/json",
+			"Content-Type":  "application/json",
+		},
+		systemID: target.ResourceID,
+	}, nil
+}
+
+func (c *conn) systemPath(ctx context.Context) (string, error) {
+	if c.path != "" {
+		return c.path, nil
+	}
+	if c.systemID != "" {
+		c.path = SYSTEMS_PATH + "/" + c.systemID
+		return c.path, nil
+	}
+
+	var systems collection
+	if err := c.get(ctx, SYSTEMS_PATH, &systems); err != nil {
+		return "", err
+	}
+	if len(systems.Members) == 0 {
+		return "", errors.ErrMachineNotFound
+	}
+	c.path = systems.Members[0].ID
+	return c.path, nil
+}
+
+func (c *conn) system(ctx context.Context) (*system, error) {
+	path, err := c.systemPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sys system
+	if err := c.get(ctx, path, &sys); err != nil {
+		return nil, err
+	}
+	return &sys, nil
+}
+
+func (c *conn) reset(ctx context.Context, sys *system, resetType string) error {
+	target := sys.Actions.Reset.Target
+	if target == "" {
+		path, err := c.systemPath(ctx)
+		if err != nil {
+			return err
+		}
+		target = path + "/Actions/ComputerSystem.Reset"
+	}
+	return c.send(ctx, http.MethodPost, target, map[string]string{"ResetType": resetType})
+}
+
+func (c *conn) get(ctx context.Context, path string, target interface{}) error {
+	resp, err := c.client.Get(ctx, path, nil, c.headers)
+	return decodeResponse(resp, err, target)
+}
+
+func (c *conn) send(ctx context.Context, method string, path string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	switch method {
+	case http.MethodPatch:
+		resp, err = c.client.Patch(ctx, path, bytes.NewReader(data), c.headers)
+	default:
+		resp, err = c.client.Post(ctx, path, bytes.NewReader(data), c.headers)
+	}
+	return decodeResponse(resp, err, nil)
+}
+
+func decodeResponse(resp *http.Response, err error, target interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.ErrMachineNotFound
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errors.ErrAccessDenied
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("redfish responded with status %d: %s", resp.StatusCode, string(body))
+	}
+	if target == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,146 @@
+package redfish_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/redfish"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/redfish/redfishtest"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/vault"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Driver", func() {
+	var (
+		ctx    context.Context
+		server *redfishtest.Server
+		secret *vault.FakeVault
+		driver *Driver
+		target entities.PowerTarget
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = redfishtest.NewServer("admin", "secret")
+		DeferCleanup(server.Close)
+		server.AddSystem("1", "On")
+
+		secret = vault.NewFakeVault()
+		secret.SetCredentials("bmc-sas-0001", "admin", "secret")
+		driver = NewDriver(secret)
+		target = entities.PowerTarget{
+			HostID:     "host-1",
+			UnitType:   core_entities.TypeServer,
+			Address:    server.URL,
+			ResourceID: "1",
+			SecretID:   "bmc-sas-0001",
+		}
+	})
+
+	system := func(id string) redfishtest.System {
+		system, ok := server.System(id)
+		Expect(ok).To(BeTrue())
+		return system
+	}
+
+	It("should power off and on", func() {
+		Expect(driver.PowerOff(ctx, target)).To(Succeed())
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateOff))
+
+		Expect(driver.PowerOn(ctx, target)).To(Succeed())
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateOn))
+		Expect(system("1").Resets).To(Equal([]string{"ForceOff", "On"}))
+	})
+
+	It("should cycle with the reset type allowed by the bmc", func() {
+		Expect(driver.PowerCycle(ctx, target)).To(Succeed())
+
+		server.AddSystem("2", "On", "On", "ForceOff", "ForceRestart")
+		target.ResourceID = "2"
+		Expect(driver.PowerCycle(ctx, target)).To(Succeed())
+
+		Expect(system("1").Resets).To(Equal([]string{"PowerCycle"}))
+		Expect(system("2").Resets).To(Equal([]string{"ForceRestart"}))
+	})
+
+	It("should power on a machine which is off instead of cycling it", func() {
+		Expect(driver.PowerOff(ctx, target)).To(Succeed())
+		Expect(driver.PowerCycle(ctx, target)).To(Succeed())
+		Expect(system("1").Resets).To(Equal([]string{"ForceOff", "On"}))
+	})
+
+	It("should discover the system when the id is unknown", func() {
+		server.AddSystem("2", "Off")
+		target.ResourceID = ""
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateOn))
+	})
+
+	It("should report transitional power states as unknown", func() {
+		server.AddSystem("2", "PoweringOn")
+		target.ResourceID = "2"
+		Expect(driver.Status(ctx, target)).To(Equal(entities.PowerStateUnknown))
+	})
+
+	DescribeTable("should override the boot device",
+		func(device entities.BootDevice, persistent bool, bootTarget string, enabled string) {
+			Expect(driver.SetBootDevice(ctx, target, device, persistent)).To(Succeed())
+			Expect(system("1").BootTarget).To(Equal(bootTarget))
+			Expect(system("1").BootEnabled).To(Equal(enabled))
+		},
+		Entry("pxe once", entities.BootDevicePXE, false, "Pxe", "Once"),
+		Entry("disk persistently", entities.BootDeviceDisk, true, "Hdd", "Continuous"),
+		Entry("bios", entities.BootDeviceBIOS, false, "BiosSetup", "Once"),
+	)
+
+	It("should read sel entries across pages", func() {
+		base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		for i, message := range []string{"power on", "memory ecc error", "fan failure"} {
+			server.AddSELEntry("1", redfishtest.SELEntry{
+				ID:       string(rune('1' + i)),
+				Created:  base.Add(time.Duration(i) * time.Hour),
+				Severity: "Warning",
+				Message:  message,
+			})
+		}
+		server.SetPageSize(2)
+
+		entries, err := driver.ReadSEL(ctx, target, base.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(Equal([]entities.SELEntry{
+			{ID: "2", Created: base.Add(time.Hour), Severity: "Warning", Message: "memory ecc error"},
+			{ID: "3", Created: base.Add(2 * time.Hour), Severity: "Warning", Message: "fan failure"},
+		}))
+	})
+
+	It("should fail with typed errors", func() {
+		target.ResourceID = "404"
+		_, err := driver.Status(ctx, target)
+		Expect(err).To(MatchError(errors.ErrMachineNotFound))
+
+		target.ResourceID = "1"
+		secret.SetCredentials("bmc-sas-0001", "admin", "wrong")
+		Expect(driver.PowerOn(ctx, target)).To(MatchError(errors.ErrAccessDenied))
+
+		target.SecretID = "unknown"
+		Expect(driver.PowerOn(ctx, target)).To(MatchError(errors.ErrSecretNotFound))
+
+		Expect(driver.SetBootDevice(ctx, target, "usb", false)).To(MatchError(errors.ErrUnsupportedBootDevice))
+	})
+
+	It("should report rejected actions", func() {
+		server.AddSystem("2", "On", "On", "ForceOff")
+		target.ResourceID = "2"
+		Expect(driver.PowerCycle(ctx, target)).To(MatchError(ContainSubstring("status 400")))
+	})
+
+	It("should stop waiting when the context expires", func() {
+		server.SetDelay(time.Second)
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		Expect(driver.PowerOn(timeoutCtx, target)).To(MatchError(context.DeadlineExceeded))
+	})
+})
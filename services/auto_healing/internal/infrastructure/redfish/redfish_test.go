@@ -0,0 +1,13 @@
+package redfish_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedfishSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redfish Driver Suite")
+}
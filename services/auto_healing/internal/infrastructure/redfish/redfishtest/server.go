@@ -0,0 +1,249 @@
+package redfishtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const SYSTEMS_PATH = "/redfish/v1/Systems"
+
+type SELEntry struct {
+	ID       string
+	Created  time.Time
+	Severity string
+	Message  string
+}
+
+type System struct {
+	ID                string
+	PowerState        string
+	AllowedResetTypes []string
+	BootTarget        string
+	BootEnabled       string
+	Resets            []string
+	SEL               []SELEntry
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	username string
+	password string
+	systems  map[string]*System
+	pageSize int
+	delay    time.Duration
+}
+
+func NewServer(username string, password string) *Server {
+	s := &Server{username: username, password: password, systems: map[string]*System{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *Server) AddSystem(id string, powerState string, allowedResetTypes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.systems[id] = &System{ID: id, PowerState: powerState, AllowedResetTypes: allowedResetTypes, BootEnabled: "Disabled"}
+}
+
+func (s *Server) AddSELEntry(systemID string, entry SELEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if system, ok := s.systems[systemID]; ok {
+		system.SEL = append(system.SEL, entry)
+	}
+}
+
+func (s *Server) SetPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = size
+}
+
+func (s *Server) SetDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = delay
+}
+
+func (s *Server) System(id string) (System, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	system, ok := s.systems[id]
+	if !ok {
+		return System{}, false
+	}
+	result := *system
+	result.Resets = slices.Clone(system.Resets)
+	result.SEL = slices.Clone(system.SEL)
+	return result, true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	delay := s.delay
+	s.mu.Unlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.username || password != s.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == SYSTEMS_PATH && r.Method == http.MethodGet {
+		s.listSystems(w)
+		return
+	}
+	rest, found := strings.CutPrefix(r.URL.Path, SYSTEMS_PATH+"/")
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	id, resource, _ := strings.Cut(rest, "/")
+	system, ok := s.systems[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case resource == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, encodeSystem(system))
+	case resource == "" && r.Method == http.MethodPatch:
+		s.patchSystem(w, r, system)
+	case resource == "Actions/ComputerSystem.Reset" && r.Method == http.MethodPost:
+		s.resetSystem(w, r, system)
+	case resource == "LogServices/SEL/Entries" && r.Method == http.MethodGet:
+		s.listSEL(w, r, system)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *Server) listSystems(w http.ResponseWriter) {
+	ids := make([]string, 0, len(s.systems))
+	for id := range s.systems {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	members := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, map[string]string{"@odata.id": SYSTEMS_PATH + "/" + id})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Members": members})
+}
+
+func (s *Server) patchSystem(w http.ResponseWriter, r *http.Request, system *System) {
+	var body struct {
+		Boot struct {
+			BootSourceOverrideTarget  string
+			BootSourceOverrideEnabled string
+		}
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Boot.BootSourceOverrideTarget == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	system.BootTarget = body.Boot.BootSourceOverrideTarget
+	system.BootEnabled = body.Boot.BootSourceOverrideEnabled
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resetSystem(w http.ResponseWriter, r *http.Request, system *System) {
+	var body struct {
+		ResetType string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if len(system.AllowedResetTypes) > 0 && !slices.Contains(system.AllowedResetTypes, body.ResetType) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "reset type is not allowed"})
+		return
+	}
+
+	switch body.ResetType {
+	case "On":
+		system.PowerState = "On"
+	case "ForceOff":
+		system.PowerState = "Off"
+	case "PowerCycle", "ForceRestart":
+		if system.PowerState != "On" {
+			writeJSON(w, http.StatusConflict, map[string]string{"error": "system is off"})
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	system.Resets = append(system.Resets, body.ResetType)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listSEL(w http.ResponseWriter, r *http.Request, system *System) {
+	skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+	end := len(system.SEL)
+	if s.pageSize > 0 && skip+s.pageSize < end {
+		end = skip + s.pageSize
+	}
+
+	members := make([]interface{}, 0)
+	for _, entry := range system.SEL[min(skip, len(system.SEL)):end] {
+		members = append(members, map[string]string{
+			"Id":       entry.ID,
+			"Created":  entry.Created.Format(time.RFC3339),
+			"Severity": entry.Severity,
+			"Message":  entry.Message,
+		})
+	}
+	page := map[string]interface{}{"Members": members}
+	if end < len(system.SEL) {
+		page["Members@odata.nextLink"] = fmt.Sprintf("%s?$skip=%d", r.URL.Path, end)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func encodeSystem(system *System) interface{} {
+	reset := map[string]interface{}{
+		"target": fmt.Sprintf("%s/%s/Actions/ComputerSystem.Reset", SYSTEMS_PATH, system.ID),
+	}
+	if len(system.AllowedResetTypes) > 0 {
+		reset["ResetType@Redfish.AllowableValues"] = system.AllowedResetTypes
+	}
+	return map[string]interface{}{
+		"@odata.id":  SYSTEMS_PATH + "/" + system.ID,
+		"Id":         system.ID,
+		"PowerState": system.PowerState,
+		"Boot": map[string]string{
+			"BootSourceOverrideTarget":  system.BootTarget,
+			"BootSourceOverrideEnabled": system.BootEnabled,
+		},
+		"Actions": map[string]interface{}{"#ComputerSystem.Reset": reset},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
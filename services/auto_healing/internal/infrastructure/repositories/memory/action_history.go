@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type ActionHistory struct {
+	mu      sync.RWMutex
+	records map[string][]entities.ActionRecord
+}
+
+func NewActionHistory() *ActionHistory {
+	return &ActionHistory{records: map[string][]entities.ActionRecord{}}
+}
+
+func (h *ActionHistory) AddAction(ctx context.Context, hostID string, record entities.ActionRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	records := append(h.records[hostID], record)
+	sort.SliceStable(records, func(i, j int) bool { return records[i].At.Before(records[j].At) })
+	h.records[hostID] = records
+	return nil
+}
+
+func (h *ActionHistory) ListActions(ctx context.Context, hostID string, since time.Time) ([]entities.ActionRecord, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	records := make([]entities.ActionRecord, 0)
+	for _, record := range h.records[hostID] {
+		if !record.At.Before(since) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
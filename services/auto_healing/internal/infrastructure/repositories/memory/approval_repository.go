@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+)
+
+type ApprovalRepository struct {
+	mu        sync.RWMutex
+	approvals map[string]entities.Approval
+}
+
+func NewApprovalRepository() *ApprovalRepository {
+	return &ApprovalRepository{approvals: map[string]entities.Approval{}}
+}
+
+func (r *ApprovalRepository) Save(ctx context.Context, approval *entities.Approval) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.approvals[approval.ID] = *cloneApproval(*approval)
+	return nil
+}
+
+func (r *ApprovalRepository) Get(ctx context.Context, id string) (*entities.Approval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	approval, ok := r.approvals[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneApproval(approval), nil
+}
+
+func (r *ApprovalRepository) FindPendingByHost(ctx context.Context, hostID string) (*entities.Approval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, approval := range r.approvals {
+		if approval.HostID == hostID && approval.IsPending() {
+			return cloneApproval(approval), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *ApprovalRepository) List(ctx context.Context, filter entities.ApprovalFilter) ([]*entities.Approval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	approvals := make([]*entities.Approval, 0)
+	for _, approval := range r.approvals {
+		if filter.Status != "" && approval.Status != filter.Status {
+			continue
+		}
+		if filter.HostID != "" && approval.HostID != filter.HostID {
+			continue
+		}
+		if approval.RequestedAt.Before(filter.Since) {
+			continue
+		}
+		approvals = append(approvals, cloneApproval(approval))
+	}
+	sort.Slice(approvals, func(i, j int) bool { return approvals[i].RequestedAt.After(approvals[j].RequestedAt) })
+	return approvals, nil
+}
+
+func cloneApproval(approval entities.Approval) *entities.Approval {
+	if approval.ResolvedAt != nil {
+		resolvedAt := *approval.ResolvedAt
+		approval.ResolvedAt = &resolvedAt
+	}
+	approval.ClearEvents()
+	return &approval
+}
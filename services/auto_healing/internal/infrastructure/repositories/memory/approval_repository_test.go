@@ -0,0 +1,11 @@
+package memory_test
+
+import (
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeApprovalRepository(func() contracts.ApprovalRepository {
+	return memory.NewApprovalRepository()
+})
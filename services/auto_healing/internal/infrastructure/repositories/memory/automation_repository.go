@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+)
+
+type AutomationRepository struct {
+	mu       sync.RWMutex
+	projects map[string]entities.ProjectAutomation
+	actions  map[string]entities.AutomatedAction
+}
+
+func NewAutomationRepository() *AutomationRepository {
+	return &AutomationRepository{
+		projects: map[string]entities.ProjectAutomation{},
+		actions:  map[string]entities.AutomatedAction{},
+	}
+}
+
+func (r *AutomationRepository) GetProject(ctx context.Context, projectID string) (*entities.ProjectAutomation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.projects[projectID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneProjectAutomation(project), nil
+}
+
+func (r *AutomationRepository) SaveProject(ctx context.Context, project *entities.ProjectAutomation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects[project.ProjectID] = *cloneProjectAutomation(*project)
+	return nil
+}
+
+func (r *AutomationRepository) ListDisabledProjects(ctx context.Context) ([]*entities.ProjectAutomation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]*entities.ProjectAutomation, 0)
+	for _, project := range r.projects {
+		if project.Disabled {
+			projects = append(projects, cloneProjectAutomation(project))
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ProjectID < projects[j].ProjectID })
+	return projects, nil
+}
+
+func (r *AutomationRepository) CreateAction(ctx context.Context, action *entities.AutomatedAction, quotas []entities.ActionQuota) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, quota := range quotas {
+		if r.countActions(quota.ProjectID, quota.Since) >= quota.MaxActions || r.countInFlight(quota.ProjectID) >= quota.MaxInFlight {
+			return false, nil
+		}
+	}
+	r.actions[action.ID] = *cloneAutomatedAction(*action)
+	return true, nil
+}
+
+func (r *AutomationRepository) GetAction(ctx context.Context, id string) (*entities.AutomatedAction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	action, ok := r.actions[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneAutomatedAction(action), nil
+}
+
+func (r *AutomationRepository) FinishAction(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	action, ok := r.actions[id]
+	if !ok {
+		return errors.ErrActionNotFound
+	}
+	action.FinishedAt = &at
+	r.actions[id] = action
+	return nil
+}
+
+func (r *AutomationRepository) GetInFlightAction(ctx context.Context, hostID string) (*entities.AutomatedAction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, action := range r.actions {
+		if action.HostID == hostID && action.InFlight() {
+			return cloneAutomatedAction(action), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *AutomationRepository) CountActions(ctx context.Context, projectID string, since time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.countActions(projectID, since), nil
+}
+
+func (r *AutomationRepository) countActions(projectID string, since time.Time) int {
+	count := 0
+	for _, action := range r.actions {
+		if (projectID == "" || action.ProjectID == projectID) && !action.StartedAt.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *AutomationRepository) CountInFlight(ctx context.Context, projectID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.countInFlight(projectID), nil
+}
+
+func (r *AutomationRepository) countInFlight(projectID string) int {
+	count := 0
+	for _, action := range r.actions {
+		if (projectID == "" || action.ProjectID == projectID) && action.InFlight() {
+			count++
+		}
+	}
+	return count
+}
+
+func cloneProjectAutomation(project entities.ProjectAutomation) *entities.ProjectAutomation {
+	if project.Limits != nil {
+		limits := *project.Limits
+		project.Limits = &limits
+	}
+	return &project
+}
+
+func cloneAutomatedAction(action entities.AutomatedAction) *entities.AutomatedAction {
+	if action.FinishedAt != nil {
+		finishedAt := *action.FinishedAt
+		action.FinishedAt = &finishedAt
+	}
+	return &action
+}
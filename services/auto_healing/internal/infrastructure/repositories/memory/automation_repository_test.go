@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"github.com/gwall-e/auto_healing/internal/domain/limits/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeAutomationRepository(func() contracts.AutomationRepository {
+	return memory.NewAutomationRepository()
+})
+
+var _ = repotest.DescribeActionHistory(func() repotest.ActionHistory {
+	return memory.NewActionHistory()
+})
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type checkKey struct {
+	hostID    string
+	checkType core_entities.CheckType
+}
+
+type CheckRepository struct {
+	mu      sync.RWMutex
+	latest  map[checkKey]entities.HostCheck
+	history map[checkKey][]entities.CheckResult
+}
+
+func NewCheckRepository() *CheckRepository {
+	return &CheckRepository{
+		latest:  map[checkKey]entities.HostCheck{},
+		history: map[checkKey][]entities.CheckResult{},
+	}
+}
+
+func (r *CheckRepository) GetLatest(ctx context.Context, hostID string, checkType core_entities.CheckType) (*entities.HostCheck, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	check, ok := r.latest[checkKey{hostID: hostID, checkType: checkType}]
+	if !ok {
+		return nil, nil
+	}
+	return cloneCheck(check), nil
+}
+
+func (r *CheckRepository) SaveLatest(ctx context.Context, check *entities.HostCheck) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := checkKey{hostID: check.HostID, checkType: check.Type}
+	if stored, ok := r.latest[key]; ok && !check.Timestamp.After(stored.Timestamp) {
+		return false, nil
+	}
+	r.latest[key] = *cloneCheck(*check)
+	return true, nil
+}
+
+func (r *CheckRepository) ListLatest(ctx context.Context, hostID string) ([]*entities.HostCheck, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := make([]*entities.HostCheck, 0)
+	for key, check := range r.latest {
+		if key.hostID == hostID {
+			checks = append(checks, cloneCheck(check))
+		}
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Type < checks[j].Type })
+	return checks, nil
+}
+
+func (r *CheckRepository) ListReportedBefore(ctx context.Context, before time.Time) ([]*entities.HostCheck, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := make([]*entities.HostCheck, 0)
+	for _, check := range r.latest {
+		if check.Timestamp.Before(before) {
+			checks = append(checks, cloneCheck(check))
+		}
+	}
+	sortChecks(checks)
+	return checks, nil
+}
+
+func (r *CheckRepository) ListByStatus(ctx context.Context, status entities.CheckStatus) ([]*entities.HostCheck, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := make([]*entities.HostCheck, 0)
+	for _, check := range r.latest {
+		if check.Status == status {
+			checks = append(checks, cloneCheck(check))
+		}
+	}
+	sortChecks(checks)
+	return checks, nil
+}
+
+func (r *CheckRepository) AppendHistory(ctx context.Context, results []entities.CheckResult) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var now time.Time
+	for _, result := range results {
+		if result.ReceivedAt.After(now) {
+			now = result.ReceivedAt
+		}
+	}
+	r.dropExpiredHistory(now)
+
+	stored := 0
+	for _, result := range results {
+		key := checkKey{hostID: result.HostID, checkType: result.Type}
+		history := r.history[key]
+		i := sort.Search(len(history), func(i int) bool { return !history[i].Timestamp.Before(result.Timestamp) })
+		if i < len(history) && history[i].Timestamp.Equal(result.Timestamp) {
+			continue
+		}
+		result.Metadata = maps.Clone(result.Metadata)
+		r.history[key] = append(history[:i], append([]entities.CheckResult{result}, history[i:]...)...)
+		stored++
+	}
+	return stored, nil
+}
+
+func (r *CheckRepository) ListHistory(ctx context.Context, hostID string, checkType core_entities.CheckType, since time.Time) ([]entities.CheckResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]entities.CheckResult, 0)
+	for _, result := range r.history[checkKey{hostID: hostID, checkType: checkType}] {
+		if !result.Timestamp.Before(since) {
+			result.Metadata = maps.Clone(result.Metadata)
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func (r *CheckRepository) dropExpiredHistory(now time.Time) {
+	for key, history := range r.history {
+		kept := history[:0]
+		for _, result := range history {
+			if result.ExpiresAt.IsZero() || result.ExpiresAt.After(now) {
+				kept = append(kept, result)
+			}
+		}
+		if len(kept) == 0 {
+			delete(r.history, key)
+		} else {
+			r.history[key] = kept
+		}
+	}
+}
+
+func cloneCheck(check entities.HostCheck) *entities.HostCheck {
+	check.Metadata = maps.Clone(check.Metadata)
+	check.Transitions = slices.Clone(check.Transitions)
+	return &check
+}
+
+func sortChecks(checks []*entities.HostCheck) {
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].HostID != checks[j].HostID {
+			return checks[i].HostID < checks[j].HostID
+		}
+		return checks[i].Type < checks[j].Type
+	})
+}
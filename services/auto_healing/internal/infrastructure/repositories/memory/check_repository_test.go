@@ -0,0 +1,39 @@
+package memory_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeCheckRepository(func() contracts.CheckRepository {
+	return memory.NewCheckRepository()
+})
+
+var _ = Describe("CheckRepository", func() {
+	It("should drop history expired by the time of a later append", func() {
+		ctx := context.Background()
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo := memory.NewCheckRepository()
+		result := func(receivedAt time.Time) entities.CheckResult {
+			return entities.CheckResult{HostID: "host-1", Type: core_entities.CheckSSH, Status: entities.CheckStatusOK,
+				Timestamp: receivedAt, ReceivedAt: receivedAt, ExpiresAt: receivedAt.Add(time.Hour)}
+		}
+		_, err := repo.AppendHistory(ctx, []entities.CheckResult{result(now)})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = repo.AppendHistory(ctx, []entities.CheckResult{result(now.Add(time.Hour))})
+		Expect(err).NotTo(HaveOccurred())
+
+		history, err := repo.ListHistory(ctx, "host-1", core_entities.CheckSSH, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(1))
+		Expect(history[0].Timestamp).To(Equal(now.Add(time.Hour)))
+	})
+})
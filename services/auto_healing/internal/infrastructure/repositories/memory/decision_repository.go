@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type DecisionRepository struct {
+	mu      sync.RWMutex
+	records []entities.DecisionRecord
+}
+
+func NewDecisionRepository() *DecisionRepository {
+	return &DecisionRepository{}
+}
+
+func (r *DecisionRepository) SaveDecision(ctx context.Context, record *entities.DecisionRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.records[:0]
+	for _, stored := range r.records {
+		if stored.ExpiresAt.IsZero() || stored.ExpiresAt.After(record.DecidedAt) {
+			kept = append(kept, stored)
+		}
+	}
+	clear(r.records[len(kept):])
+	i := sort.Search(len(kept), func(i int) bool { return kept[i].DecidedAt.After(record.DecidedAt) })
+	r.records = slices.Insert(kept, i, cloneDecisionRecord(*record))
+	return nil
+}
+
+func (r *DecisionRepository) GetDecision(ctx context.Context, id string) (*entities.DecisionRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, record := range r.records {
+		if record.ID == id {
+			cloned := cloneDecisionRecord(record)
+			return &cloned, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *DecisionRepository) ListDecisions(ctx context.Context, filter entities.DecisionFilter) ([]entities.DecisionRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]entities.DecisionRecord, 0)
+	for _, record := range r.records {
+		if filter.ProjectID != "" && record.ProjectID != filter.ProjectID {
+			continue
+		}
+		if filter.HostID != "" && record.HostID != filter.HostID {
+			continue
+		}
+		if record.DecidedAt.Before(filter.Since) || (!filter.Until.IsZero() && !record.DecidedAt.Before(filter.Until)) {
+			continue
+		}
+		records = append(records, cloneDecisionRecord(record))
+	}
+	return records, nil
+}
+
+func cloneDecisionRecord(record entities.DecisionRecord) entities.DecisionRecord {
+	if record.Shadow != nil {
+		shadow := *record.Shadow
+		record.Shadow = &shadow
+	}
+	explanation := &record.Explanation
+	explanation.Snapshot.Restrictions = slices.Clone(explanation.Snapshot.Restrictions)
+	explanation.Snapshot.Checks = slices.Clone(explanation.Snapshot.Checks)
+	explanation.Snapshot.History = slices.Clone(explanation.Snapshot.History)
+	explanation.Rules = slices.Clone(explanation.Rules)
+	if explanation.Limits != nil {
+		limits := *explanation.Limits
+		explanation.Limits = &limits
+	}
+	return record
+}
+
+type DryRunRepository struct {
+	mu       sync.RWMutex
+	projects map[string]bool
+}
+
+func NewDryRunRepository() *DryRunRepository {
+	return &DryRunRepository{projects: map[string]bool{}}
+}
+
+func (r *DryRunRepository) IsDryRun(ctx context.Context, projectID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.projects[projectID], nil
+}
+
+func (r *DryRunRepository) SetDryRun(ctx context.Context, projectID string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if enabled {
+		r.projects[projectID] = true
+	} else {
+		delete(r.projects, projectID)
+	}
+	return nil
+}
+
+func (r *DryRunRepository) ListDryRunProjects(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]string, 0, len(r.projects))
+	for projectID := range r.projects {
+		projects = append(projects, projectID)
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
@@ -0,0 +1,36 @@
+package memory_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeDecisionRepository(func() contracts.DecisionRepository {
+	return memory.NewDecisionRepository()
+})
+
+var _ = Describe("DecisionRepository", func() {
+	It("should drop decisions expired by the time of a later decision", func() {
+		ctx := context.Background()
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo := memory.NewDecisionRepository()
+		Expect(repo.SaveDecision(ctx, &entities.DecisionRecord{ID: "decision-1", DecidedAt: now, ExpiresAt: now.Add(time.Hour)})).To(Succeed())
+		Expect(repo.SaveDecision(ctx, &entities.DecisionRecord{ID: "decision-2", DecidedAt: now.Add(time.Hour), ExpiresAt: now.Add(2 * time.Hour)})).To(Succeed())
+
+		records, err := repo.ListDecisions(ctx, entities.DecisionFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].ID).To(Equal("decision-2"))
+	})
+})
+
+var _ = repotest.DescribeDryRunRepository(func() contracts.DryRunRepository {
+	return memory.NewDryRunRepository()
+})
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type EventLog struct {
+	mu     sync.RWMutex
+	events []interface{}
+}
+
+func NewEventLog() *EventLog {
+	return &EventLog{}
+}
+
+func (l *EventLog) Publish(ctx context.Context, event interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *EventLog) Events() []interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Clone(l.events)
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	limitEntities "github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	limitErrors "github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+	livenessEntities "github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+type HostInfoRepository struct {
+	mu     sync.RWMutex
+	hosts  map[string]entities.HostInfo
+	owners map[string][]string
+}
+
+func NewHostInfoRepository() *HostInfoRepository {
+	return &HostInfoRepository{hosts: map[string]entities.HostInfo{}, owners: map[string][]string{}}
+}
+
+func (r *HostInfoRepository) SetHostInfo(info entities.HostInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info.Restrictions = slices.Clone(info.Restrictions)
+	r.hosts[info.HostID] = info
+}
+
+func (r *HostInfoRepository) SaveHostInfo(ctx context.Context, info *entities.HostInfo) error {
+	r.SetHostInfo(*info)
+	return nil
+}
+
+func (r *HostInfoRepository) DeleteHostInfo(ctx context.Context, hostID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.hosts, hostID)
+	return nil
+}
+
+func (r *HostInfoRepository) GetHostInfo(ctx context.Context, hostID string) (*entities.HostInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.hosts[hostID]
+	if !ok {
+		return nil, errors.ErrHostNotFound
+	}
+	info.Restrictions = slices.Clone(info.Restrictions)
+	return &info, nil
+}
+
+func (r *HostInfoRepository) GetProjectInfo(ctx context.Context, projectID string) (*limitEntities.ProjectInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info := &limitEntities.ProjectInfo{ProjectID: projectID}
+	for _, host := range r.hosts {
+		if host.ProjectID == projectID {
+			info.Tier = host.Tier
+			info.Hosts++
+		}
+	}
+	if projectID == "" || info.Hosts == 0 {
+		return nil, limitErrors.ErrProjectNotFound
+	}
+	return info, nil
+}
+
+func (r *HostInfoRepository) GetHostLocation(ctx context.Context, hostID string) (*livenessEntities.HostLocation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.hosts[hostID]
+	if !ok {
+		return nil, nil
+	}
+	return hostLocation(info), nil
+}
+
+func (r *HostInfoRepository) CountHosts(ctx context.Context, domain livenessEntities.FailureDomain) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, info := range r.hosts {
+		if slices.Contains(hostLocation(info).FailureDomains(), domain) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func hostLocation(info entities.HostInfo) *livenessEntities.HostLocation {
+	return &livenessEntities.HostLocation{
+		HostID:     info.HostID,
+		Datacenter: info.Datacenter,
+		Rack:       info.Rack,
+		Switch:     info.Switch,
+	}
+}
+
+func (r *HostInfoRepository) SetProjectOwners(projectID string, owners []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.owners[projectID] = slices.Clone(owners)
+}
+
+func (r *HostInfoRepository) GetProjectOwners(ctx context.Context, projectID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	owners := slices.Clone(r.owners[projectID])
+	if owners == nil {
+		owners = []string{}
+	}
+	return owners, nil
+}
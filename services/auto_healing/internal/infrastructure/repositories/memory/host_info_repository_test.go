@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	inventoryContracts "github.com/gwall-e/auto_healing/internal/domain/inventory/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeHostInfoRepository(func() repotest.HostInfoRepository {
+	return memory.NewHostInfoRepository()
+})
+
+var _ = repotest.DescribePowerTargetRepository(func() inventoryContracts.PowerTargetRepository {
+	return memory.NewPowerTargetRepository()
+})
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/errors"
+)
+
+type HostProjectResolver struct {
+	mu    sync.RWMutex
+	hosts map[string]string
+}
+
+func NewHostProjectResolver() *HostProjectResolver {
+	return &HostProjectResolver{hosts: map[string]string{}}
+}
+
+func (r *HostProjectResolver) SetHostProject(hostID string, projectID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hosts[hostID] = projectID
+}
+
+func (r *HostProjectResolver) GetHostProject(ctx context.Context, hostID string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projectID, ok := r.hosts[hostID]
+	if !ok {
+		return "", errors.ErrHostNotFound
+	}
+	return projectID, nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+type HostReleaseRepository struct {
+	mu        sync.RWMutex
+	released  map[string][]decisionEntities.Action
+	requested map[string]decisionEntities.Action
+}
+
+func NewHostReleaseRepository() *HostReleaseRepository {
+	return &HostReleaseRepository{
+		released:  map[string][]decisionEntities.Action{},
+		requested: map[string]decisionEntities.Action{},
+	}
+}
+
+func (r *HostReleaseRepository) SetReleased(hostID string, actions ...decisionEntities.Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(actions) == 0 {
+		delete(r.released, hostID)
+		return
+	}
+	r.released[hostID] = slices.Clone(actions)
+	delete(r.requested, hostID)
+}
+
+func (r *HostReleaseRepository) Requested(hostID string) (decisionEntities.Action, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	action, ok := r.requested[hostID]
+	return action, ok
+}
+
+func (r *HostReleaseRepository) EnsureReleased(ctx context.Context, hostID string, projectID string, action decisionEntities.Action) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.Contains(r.released[hostID], action) {
+		return nil
+	}
+	r.requested[hostID] = action
+	return fmt.Errorf("%w: %s of host %s", errors.ErrHostNotReleased, action, hostID)
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+)
+
+type KillSwitchRepository struct {
+	mu       sync.RWMutex
+	switches map[string]entities.KillSwitch
+}
+
+func NewKillSwitchRepository() *KillSwitchRepository {
+	return &KillSwitchRepository{switches: map[string]entities.KillSwitch{}}
+}
+
+func (r *KillSwitchRepository) Save(ctx context.Context, killSwitch *entities.KillSwitch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.switches[killSwitch.ID] = *cloneKillSwitch(*killSwitch)
+	return nil
+}
+
+func (r *KillSwitchRepository) Get(ctx context.Context, id string) (*entities.KillSwitch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	killSwitch, ok := r.switches[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneKillSwitch(killSwitch), nil
+}
+
+func (r *KillSwitchRepository) ListUnreleased(ctx context.Context) ([]*entities.KillSwitch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	switches := make([]*entities.KillSwitch, 0)
+	for _, killSwitch := range r.switches {
+		if killSwitch.ReleasedAt == nil {
+			switches = append(switches, cloneKillSwitch(killSwitch))
+		}
+	}
+	sort.Slice(switches, func(i, j int) bool { return switches[i].CreatedAt.Before(switches[j].CreatedAt) })
+	return switches, nil
+}
+
+func (r *KillSwitchRepository) List(ctx context.Context, since time.Time) ([]*entities.KillSwitch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	switches := make([]*entities.KillSwitch, 0)
+	for _, killSwitch := range r.switches {
+		if killSwitch.ReleasedAt == nil || !killSwitch.ReleasedAt.Before(since) {
+			switches = append(switches, cloneKillSwitch(killSwitch))
+		}
+	}
+	sort.Slice(switches, func(i, j int) bool { return switches[i].CreatedAt.After(switches[j].CreatedAt) })
+	return switches, nil
+}
+
+func cloneKillSwitch(killSwitch entities.KillSwitch) *entities.KillSwitch {
+	if killSwitch.ReleasedAt != nil {
+		releasedAt := *killSwitch.ReleasedAt
+		killSwitch.ReleasedAt = &releasedAt
+	}
+	killSwitch.ClearEvents()
+	return &killSwitch
+}
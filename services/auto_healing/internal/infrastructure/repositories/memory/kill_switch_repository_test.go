@@ -0,0 +1,11 @@
+package memory_test
+
+import (
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeKillSwitchRepository(func() contracts.KillSwitchRepository {
+	return memory.NewKillSwitchRepository()
+})
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+)
+
+type LivenessRepository struct {
+	mu    sync.RWMutex
+	hosts map[string]entities.HostLiveness
+}
+
+func NewLivenessRepository() *LivenessRepository {
+	return &LivenessRepository{hosts: map[string]entities.HostLiveness{}}
+}
+
+func (r *LivenessRepository) GetHost(ctx context.Context, hostID string) (*entities.HostLiveness, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	host, ok := r.hosts[hostID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneHostLiveness(host), nil
+}
+
+func (r *LivenessRepository) SaveHost(ctx context.Context, host *entities.HostLiveness) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hosts[host.HostID] = *cloneHostLiveness(*host)
+	return nil
+}
+
+func (r *LivenessRepository) ListSilent(ctx context.Context, before time.Time) ([]*entities.HostLiveness, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hosts := make([]*entities.HostLiveness, 0)
+	for _, host := range r.hosts {
+		if host.Status == entities.LivenessAlive && host.LastSeen.Before(before) {
+			hosts = append(hosts, cloneHostLiveness(host))
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].HostID < hosts[j].HostID })
+	return hosts, nil
+}
+
+func (r *LivenessRepository) ListUnreachable(ctx context.Context) ([]*entities.HostLiveness, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hosts := make([]*entities.HostLiveness, 0)
+	for _, host := range r.hosts {
+		if host.IsUnreachable() {
+			hosts = append(hosts, cloneHostLiveness(host))
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].HostID < hosts[j].HostID })
+	return hosts, nil
+}
+
+func cloneHostLiveness(host entities.HostLiveness) *entities.HostLiveness {
+	if host.UnreachableSince != nil {
+		since := *host.UnreachableSince
+		host.UnreachableSince = &since
+	}
+	host.ClearEvents()
+	return &host
+}
+
+type OutageRepository struct {
+	mu      sync.RWMutex
+	outages map[string]entities.Outage
+}
+
+func NewOutageRepository() *OutageRepository {
+	return &OutageRepository{outages: map[string]entities.Outage{}}
+}
+
+func (r *OutageRepository) SaveOutage(ctx context.Context, outage *entities.Outage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outages[outage.ID] = *cloneOutage(*outage)
+	return nil
+}
+
+func (r *OutageRepository) GetOutage(ctx context.Context, id string) (*entities.Outage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	outage, ok := r.outages[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneOutage(outage), nil
+}
+
+func (r *OutageRepository) ListOutages(ctx context.Context, since time.Time) ([]*entities.Outage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	outages := make([]*entities.Outage, 0)
+	for _, outage := range r.outages {
+		if outage.IsActive() || !outage.EndedAt.Before(since) {
+			outages = append(outages, cloneOutage(outage))
+		}
+	}
+	sort.Slice(outages, func(i, j int) bool { return outages[i].StartedAt.After(outages[j].StartedAt) })
+	return outages, nil
+}
+
+func (r *OutageRepository) ListActiveOutages(ctx context.Context) ([]*entities.Outage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	outages := make([]*entities.Outage, 0)
+	for _, outage := range r.outages {
+		if outage.IsActive() {
+			outages = append(outages, cloneOutage(outage))
+		}
+	}
+	sort.Slice(outages, func(i, j int) bool { return outages[i].StartedAt.Before(outages[j].StartedAt) })
+	return outages, nil
+}
+
+func cloneOutage(outage entities.Outage) *entities.Outage {
+	outage.Hosts = slices.Clone(outage.Hosts)
+	if outage.EndedAt != nil {
+		endedAt := *outage.EndedAt
+		outage.EndedAt = &endedAt
+	}
+	outage.ClearEvents()
+	return &outage
+}
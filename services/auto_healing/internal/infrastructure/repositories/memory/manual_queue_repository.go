@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+)
+
+type ManualQueueRepository struct {
+	mu    sync.RWMutex
+	items map[string]entities.ManualQueueItem
+}
+
+func NewManualQueueRepository() *ManualQueueRepository {
+	return &ManualQueueRepository{items: map[string]entities.ManualQueueItem{}}
+}
+
+func (r *ManualQueueRepository) Add(ctx context.Context, item *entities.ManualQueueItem) error {
+	return r.Save(ctx, item)
+}
+
+func (r *ManualQueueRepository) Get(ctx context.Context, id string) (*entities.ManualQueueItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneManualQueueItem(item), nil
+}
+
+func (r *ManualQueueRepository) Save(ctx context.Context, item *entities.ManualQueueItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items[item.ID] = *cloneManualQueueItem(*item)
+	return nil
+}
+
+func (r *ManualQueueRepository) ListOpen(ctx context.Context) ([]*entities.ManualQueueItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]*entities.ManualQueueItem, 0)
+	for _, item := range r.items {
+		if item.IsOpen() {
+			items = append(items, cloneManualQueueItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.Before(items[j].CreatedAt) })
+	return items, nil
+}
+
+func cloneManualQueueItem(item entities.ManualQueueItem) *entities.ManualQueueItem {
+	item.Transitions = slices.Clone(item.Transitions)
+	if item.ResolvedAt != nil {
+		resolvedAt := *item.ResolvedAt
+		item.ResolvedAt = &resolvedAt
+	}
+	return &item
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/monitoring/entities"
+)
+
+type MonitoringRepository struct {
+	mu      sync.RWMutex
+	configs map[string]entities.MonitoringConfig
+}
+
+func NewMonitoringRepository() *MonitoringRepository {
+	return &MonitoringRepository{configs: map[string]entities.MonitoringConfig{}}
+}
+
+func (r *MonitoringRepository) Get(ctx context.Context, projectID string) (*entities.MonitoringConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	config, ok := r.configs[projectID]
+	if !ok {
+		return nil, nil
+	}
+	config.Checks = append([]entities.MonitoringCheck(nil), config.Checks...)
+	return &config, nil
+}
+
+func (r *MonitoringRepository) Save(ctx context.Context, config *entities.MonitoringConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *config
+	stored.Checks = append([]entities.MonitoringCheck(nil), config.Checks...)
+	r.configs[config.ProjectID] = stored
+	return nil
+}
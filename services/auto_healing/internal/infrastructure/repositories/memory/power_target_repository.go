@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+)
+
+type PowerTargetRepository struct {
+	mu      sync.RWMutex
+	targets map[string]entities.PowerTarget
+}
+
+func NewPowerTargetRepository() *PowerTargetRepository {
+	return &PowerTargetRepository{targets: map[string]entities.PowerTarget{}}
+}
+
+func (r *PowerTargetRepository) SetPowerTarget(target entities.PowerTarget) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets[target.HostID] = target
+}
+
+func (r *PowerTargetRepository) GetPowerTarget(ctx context.Context, hostID string) (*entities.PowerTarget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	target, ok := r.targets[hostID]
+	if !ok {
+		return nil, nil
+	}
+	return &target, nil
+}
+
+func (r *PowerTargetRepository) SavePowerTarget(ctx context.Context, target *entities.PowerTarget) error {
+	r.SetPowerTarget(*target)
+	return nil
+}
+
+func (r *PowerTargetRepository) DeletePowerTarget(ctx context.Context, hostID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.targets, hostID)
+	return nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+)
+
+type ReleaseRepository struct {
+	mu       sync.RWMutex
+	releases map[string]entities.Release
+}
+
+func NewReleaseRepository() *ReleaseRepository {
+	return &ReleaseRepository{releases: map[string]entities.Release{}}
+}
+
+func (r *ReleaseRepository) GetRelease(ctx context.Context, id string) (*entities.Release, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	release, ok := r.releases[id]
+	if !ok {
+		return nil, nil
+	}
+	return &release, nil
+}
+
+func (r *ReleaseRepository) CreateRelease(ctx context.Context, release *entities.Release) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.releases[release.ID]; ok {
+		return false, nil
+	}
+	r.releases[release.ID] = *release
+	return true, nil
+}
+
+func (r *ReleaseRepository) SaveRelease(ctx context.Context, release *entities.Release) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.releases[release.ID] = *release
+	return nil
+}
+
+func (r *ReleaseRepository) DeleteRelease(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.releases, id)
+	return nil
+}
@@ -0,0 +1,11 @@
+package memory_test
+
+import (
+	"github.com/gwall-e/auto_healing/internal/domain/releases/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeReleaseRepository(func() contracts.ReleaseRepository {
+	return memory.NewReleaseRepository()
+})
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+)
+
+type RuleRepository struct {
+	mu   sync.RWMutex
+	sets map[entities.RuleSetKind]entities.RuleSet
+}
+
+func NewRuleRepository() *RuleRepository {
+	return &RuleRepository{sets: map[entities.RuleSetKind]entities.RuleSet{}}
+}
+
+func (r *RuleRepository) GetRuleSet(ctx context.Context, kind entities.RuleSetKind) (*entities.RuleSet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set, ok := r.sets[kind]
+	if !ok {
+		return nil, nil
+	}
+	return cloneRuleSet(set), nil
+}
+
+func (r *RuleRepository) SaveRuleSet(ctx context.Context, set *entities.RuleSet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sets[set.Kind] = *cloneRuleSet(*set)
+	return nil
+}
+
+func cloneRuleSet(set entities.RuleSet) *entities.RuleSet {
+	set.Rules = slices.Clone(set.Rules)
+	for i, rule := range set.Rules {
+		rule.UnitTypes = slices.Clone(rule.UnitTypes)
+		rule.Tiers = slices.Clone(rule.Tiers)
+		rule.Actions = slices.Clone(rule.Actions)
+		rule.Approval.Actions = slices.Clone(rule.Approval.Actions)
+		rule.Approval.Tiers = slices.Clone(rule.Approval.Tiers)
+		set.Rules[i] = rule
+	}
+	return &set
+}
@@ -0,0 +1,11 @@
+package memory_test
+
+import (
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeRuleRepository(func() contracts.RuleRepository {
+	return memory.NewRuleRepository()
+})
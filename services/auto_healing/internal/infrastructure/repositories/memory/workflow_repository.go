@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+)
+
+type WorkflowRepository struct {
+	mu        sync.RWMutex
+	workflows map[string]entities.Workflow
+}
+
+func NewWorkflowRepository() *WorkflowRepository {
+	return &WorkflowRepository{workflows: map[string]entities.Workflow{}}
+}
+
+func (r *WorkflowRepository) Create(ctx context.Context, workflow *entities.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workflow.Version = 1
+	r.workflows[workflow.ID] = *cloneWorkflow(*workflow)
+	return nil
+}
+
+func (r *WorkflowRepository) Update(ctx context.Context, workflow *entities.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.workflows[workflow.ID]
+	if !ok {
+		return errors.ErrWorkflowNotFound
+	}
+	if stored.Version != workflow.Version {
+		return errors.ErrWorkflowVersionConflict
+	}
+	workflow.Version++
+	r.workflows[workflow.ID] = *cloneWorkflow(*workflow)
+	return nil
+}
+
+func (r *WorkflowRepository) Get(ctx context.Context, id string) (*entities.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflow, ok := r.workflows[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneWorkflow(workflow), nil
+}
+
+func (r *WorkflowRepository) FindActiveByHost(ctx context.Context, hostID string) (*entities.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, workflow := range r.workflows {
+		if workflow.HostID == hostID && workflow.IsActive() {
+			return cloneWorkflow(workflow), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *WorkflowRepository) ListActive(ctx context.Context) ([]*entities.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflows := make([]*entities.Workflow, 0)
+	for _, workflow := range r.workflows {
+		if workflow.IsActive() {
+			workflows = append(workflows, cloneWorkflow(workflow))
+		}
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].CreatedAt.Before(workflows[j].CreatedAt) })
+	return workflows, nil
+}
+
+func (r *WorkflowRepository) ListByHost(ctx context.Context, hostID string) ([]*entities.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflows := make([]*entities.Workflow, 0)
+	for _, workflow := range r.workflows {
+		if workflow.HostID == hostID {
+			workflows = append(workflows, cloneWorkflow(workflow))
+		}
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].CreatedAt.After(workflows[j].CreatedAt) })
+	return workflows, nil
+}
+
+func cloneWorkflow(workflow entities.Workflow) *entities.Workflow {
+	workflow.Steps = slices.Clone(workflow.Steps)
+	if workflow.LeaseUntil != nil {
+		leaseUntil := *workflow.LeaseUntil
+		workflow.LeaseUntil = &leaseUntil
+	}
+	workflow.ClearEvents()
+	return &workflow
+}
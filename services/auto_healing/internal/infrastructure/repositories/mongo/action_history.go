@@ -0,0 +1,57 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ACTION_HISTORY_COLLECTION = "action_history"
+
+type actionDocument struct {
+	HostID                string `bson:"host_id"`
+	entities.ActionRecord `bson:",inline"`
+}
+
+type ActionHistory struct {
+	collection *mongo.Collection
+}
+
+func NewActionHistory(db *mongo.Database) *ActionHistory {
+	return &ActionHistory{collection: db.Collection(ACTION_HISTORY_COLLECTION)}
+}
+
+func (h *ActionHistory) EnsureIndexes(ctx context.Context) error {
+	_, err := h.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "at", Value: 1}},
+		Options: options.Index().SetName("host_at"),
+	})
+	return err
+}
+
+func (h *ActionHistory) AddAction(ctx context.Context, hostID string, record entities.ActionRecord) error {
+	_, err := h.collection.InsertOne(ctx, actionDocument{HostID: hostID, ActionRecord: record})
+	return err
+}
+
+func (h *ActionHistory) ListActions(ctx context.Context, hostID string, since time.Time) ([]entities.ActionRecord, error) {
+	filter := bson.D{{Key: "host_id", Value: hostID}, {Key: "at", Value: bson.D{{Key: "$gte", Value: since}}}}
+	cursor, err := h.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var documents []actionDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	records := make([]entities.ActionRecord, 0, len(documents))
+	for _, document := range documents {
+		records = append(records, document.ActionRecord)
+	}
+	return records, nil
+}
@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const APPROVALS_COLLECTION = "approvals"
+
+type ApprovalRepository struct {
+	collection *mongo.Collection
+}
+
+func NewApprovalRepository(db *mongo.Database) *ApprovalRepository {
+	return &ApprovalRepository{collection: db.Collection(APPROVALS_COLLECTION)}
+}
+
+func (r *ApprovalRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "host_id", Value: 1}},
+			Options: options.Index().SetName("pending_host_id").SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "status", Value: entities.ApprovalPending}}),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "requested_at", Value: -1}},
+			Options: options.Index().SetName("status_requested_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "requested_at", Value: -1}},
+			Options: options.Index().SetName("host_requested_at"),
+		},
+	})
+	return err
+}
+
+func (r *ApprovalRepository) Save(ctx context.Context, approval *entities.Approval) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: approval.ID}}, approval, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *ApprovalRepository) Get(ctx context.Context, id string) (*entities.Approval, error) {
+	return r.findOne(ctx, bson.D{{Key: "_id", Value: id}})
+}
+
+func (r *ApprovalRepository) FindPendingByHost(ctx context.Context, hostID string) (*entities.Approval, error) {
+	return r.findOne(ctx, bson.D{{Key: "host_id", Value: hostID}, {Key: "status", Value: entities.ApprovalPending}})
+}
+
+func (r *ApprovalRepository) List(ctx context.Context, filter entities.ApprovalFilter) ([]*entities.Approval, error) {
+	query := bson.D{{Key: "requested_at", Value: bson.D{{Key: "$gte", Value: filter.Since}}}}
+	if filter.Status != "" {
+		query = append(query, bson.E{Key: "status", Value: filter.Status})
+	}
+	if filter.HostID != "" {
+		query = append(query, bson.E{Key: "host_id", Value: filter.HostID})
+	}
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "requested_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	approvals := make([]*entities.Approval, 0)
+	if err := cursor.All(ctx, &approvals); err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
+
+func (r *ApprovalRepository) findOne(ctx context.Context, filter bson.D) (*entities.Approval, error) {
+	var approval entities.Approval
+	err := r.collection.FindOne(ctx, filter).Decode(&approval)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &approval, nil
+}
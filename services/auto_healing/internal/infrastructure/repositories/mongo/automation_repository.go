@@ -0,0 +1,206 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	AUTOMATION_PROJECTS_COLLECTION = "automation_projects"
+	AUTOMATED_ACTIONS_COLLECTION   = "automated_actions"
+	ACTION_QUOTAS_COLLECTION       = "action_quotas"
+)
+
+type AutomationRepository struct {
+	projects *mongo.Collection
+	actions  *mongo.Collection
+	quotas   *mongo.Collection
+}
+
+func NewAutomationRepository(db *mongo.Database) *AutomationRepository {
+	return &AutomationRepository{
+		projects: db.Collection(AUTOMATION_PROJECTS_COLLECTION),
+		actions:  db.Collection(AUTOMATED_ACTIONS_COLLECTION),
+		quotas:   db.Collection(ACTION_QUOTAS_COLLECTION),
+	}
+}
+
+func (r *AutomationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.projects.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "disabled", Value: 1}},
+		Options: options.Index().SetName("disabled"),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.actions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "finished_at", Value: 1}},
+			Options: options.Index().SetName("host_finished_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}, {Key: "started_at", Value: 1}},
+			Options: options.Index().SetName("project_started_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "started_at", Value: 1}},
+			Options: options.Index().SetName("started_at"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.quotas.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "in_flight", Value: 1}},
+		Options: options.Index().SetName("in_flight"),
+	})
+	return err
+}
+
+func (r *AutomationRepository) GetProject(ctx context.Context, projectID string) (*entities.ProjectAutomation, error) {
+	var project entities.ProjectAutomation
+	err := r.projects.FindOne(ctx, bson.D{{Key: "_id", Value: projectID}}).Decode(&project)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *AutomationRepository) SaveProject(ctx context.Context, project *entities.ProjectAutomation) error {
+	_, err := r.projects.ReplaceOne(ctx, bson.D{{Key: "_id", Value: project.ProjectID}}, project, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *AutomationRepository) ListDisabledProjects(ctx context.Context) ([]*entities.ProjectAutomation, error) {
+	cursor, err := r.projects.Find(ctx, bson.D{{Key: "disabled", Value: true}}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]*entities.ProjectAutomation, 0)
+	if err := cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (r *AutomationRepository) CreateAction(ctx context.Context, action *entities.AutomatedAction, quotas []entities.ActionQuota) (bool, error) {
+	for i, quota := range quotas {
+		reserved, err := r.reserve(ctx, quota, action)
+		if err != nil || !reserved {
+			return false, r.releaseAll(ctx, quotas[:i], action.ID, err)
+		}
+	}
+	if _, err := r.actions.InsertOne(ctx, action); err != nil {
+		return false, r.releaseAll(ctx, quotas, action.ID, err)
+	}
+	return true, nil
+}
+
+func (r *AutomationRepository) reserve(ctx context.Context, quota entities.ActionQuota, action *entities.AutomatedAction) (bool, error) {
+	started := bson.D{{Key: "$filter", Value: bson.D{
+		{Key: "input", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$started", bson.A{}}}}},
+		{Key: "as", Value: "action"},
+		{Key: "cond", Value: bson.D{{Key: "$gte", Value: bson.A{"$$action.at", quota.Since}}}},
+	}}}
+	inFlight := bson.D{{Key: "$ifNull", Value: bson.A{"$in_flight", bson.A{}}}}
+
+	filter := bson.D{
+		{Key: "_id", Value: quota.ProjectID},
+		{Key: "$expr", Value: bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "$lt", Value: bson.A{bson.D{{Key: "$size", Value: started}}, quota.MaxActions}}},
+			bson.D{{Key: "$lt", Value: bson.A{bson.D{{Key: "$size", Value: inFlight}}, quota.MaxInFlight}}},
+		}}}},
+	}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.D{
+		{Key: "started", Value: bson.D{{Key: "$concatArrays", Value: bson.A{
+			started,
+			bson.A{bson.D{{Key: "id", Value: action.ID}, {Key: "at", Value: action.StartedAt}}},
+		}}}},
+		{Key: "in_flight", Value: bson.D{{Key: "$concatArrays", Value: bson.A{inFlight, bson.A{action.ID}}}}},
+	}}}}
+
+	_, err := r.quotas.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *AutomationRepository) releaseAll(ctx context.Context, quotas []entities.ActionQuota, actionID string, cause error) error {
+	for _, quota := range quotas {
+		_, err := r.quotas.UpdateOne(ctx, bson.D{{Key: "_id", Value: quota.ProjectID}}, bson.D{{Key: "$pull", Value: bson.D{
+			{Key: "started", Value: bson.D{{Key: "id", Value: actionID}}},
+			{Key: "in_flight", Value: actionID},
+		}}})
+		if err != nil && cause == nil {
+			cause = err
+		}
+	}
+	return cause
+}
+
+func (r *AutomationRepository) GetAction(ctx context.Context, id string) (*entities.AutomatedAction, error) {
+	var action entities.AutomatedAction
+	err := r.actions.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&action)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &action, nil
+}
+
+func (r *AutomationRepository) FinishAction(ctx context.Context, id string, at time.Time) error {
+	result, err := r.actions.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.D{{Key: "$set", Value: bson.D{{Key: "finished_at", Value: at}}}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.ErrActionNotFound
+	}
+	_, err = r.quotas.UpdateMany(ctx, bson.D{{Key: "in_flight", Value: id}}, bson.D{{Key: "$pull", Value: bson.D{{Key: "in_flight", Value: id}}}})
+	return err
+}
+
+func (r *AutomationRepository) GetInFlightAction(ctx context.Context, hostID string) (*entities.AutomatedAction, error) {
+	var action entities.AutomatedAction
+	err := r.actions.FindOne(ctx, bson.D{{Key: "host_id", Value: hostID}, {Key: "finished_at", Value: nil}}).Decode(&action)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &action, nil
+}
+
+func (r *AutomationRepository) CountActions(ctx context.Context, projectID string, since time.Time) (int, error) {
+	filter := bson.D{{Key: "started_at", Value: bson.D{{Key: "$gte", Value: since}}}}
+	if projectID != "" {
+		filter = append(filter, bson.E{Key: "project_id", Value: projectID})
+	}
+	count, err := r.actions.CountDocuments(ctx, filter)
+	return int(count), err
+}
+
+func (r *AutomationRepository) CountInFlight(ctx context.Context, projectID string) (int, error) {
+	filter := bson.D{{Key: "finished_at", Value: nil}}
+	if projectID != "" {
+		filter = append(filter, bson.E{Key: "project_id", Value: projectID})
+	}
+	count, err := r.actions.CountDocuments(ctx, filter)
+	return int(count), err
+}
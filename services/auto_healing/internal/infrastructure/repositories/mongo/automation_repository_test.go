@@ -0,0 +1,35 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/limits/contracts"
+	repositories "github.com/gwall-e/auto_healing/internal/infrastructure/repositories/mongo"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeAutomationRepository(func() contracts.AutomationRepository {
+	db := client.Database(fmt.Sprintf("automation_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+
+	repo := repositories.NewAutomationRepository(db)
+	Expect(repo.EnsureIndexes(context.Background())).To(Succeed())
+	return repo
+})
+
+var _ = repotest.DescribeActionHistory(func() repotest.ActionHistory {
+	db := client.Database(fmt.Sprintf("action_history_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+
+	history := repositories.NewActionHistory(db)
+	Expect(history.EnsureIndexes(context.Background())).To(Succeed())
+	return history
+})
@@ -0,0 +1,168 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DUPLICATE_KEY_CODE = 11000
+
+	CHECKS_COLLECTION        = "checks"
+	CHECK_HISTORY_COLLECTION = "check_history"
+)
+
+type CheckRepository struct {
+	latest  *mongo.Collection
+	history *mongo.Collection
+}
+
+func NewCheckRepository(db *mongo.Database) *CheckRepository {
+	return &CheckRepository{
+		latest:  db.Collection(CHECKS_COLLECTION),
+		history: db.Collection(CHECK_HISTORY_COLLECTION),
+	}
+}
+
+func (r *CheckRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.latest.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "type", Value: 1}},
+			Options: options.Index().SetName("host_type").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "timestamp", Value: 1}},
+			Options: options.Index().SetName("timestamp"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}},
+			Options: options.Index().SetName("status"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.history.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "type", Value: 1}, {Key: "timestamp", Value: 1}},
+			Options: options.Index().SetName("host_type_timestamp").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
+
+func (r *CheckRepository) GetLatest(ctx context.Context, hostID string, checkType core_entities.CheckType) (*entities.HostCheck, error) {
+	var check entities.HostCheck
+	err := r.latest.FindOne(ctx, bson.D{{Key: "host_id", Value: hostID}, {Key: "type", Value: checkType}}).Decode(&check)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &check, nil
+}
+
+func (r *CheckRepository) SaveLatest(ctx context.Context, check *entities.HostCheck) (bool, error) {
+	filter := bson.D{
+		{Key: "host_id", Value: check.HostID},
+		{Key: "type", Value: check.Type},
+		{Key: "timestamp", Value: bson.D{{Key: "$lt", Value: check.Timestamp}}},
+	}
+	_, err := r.latest.ReplaceOne(ctx, filter, check, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *CheckRepository) ListLatest(ctx context.Context, hostID string) ([]*entities.HostCheck, error) {
+	return r.findChecks(ctx, bson.D{{Key: "host_id", Value: hostID}}, bson.D{{Key: "type", Value: 1}})
+}
+
+func (r *CheckRepository) ListReportedBefore(ctx context.Context, before time.Time) ([]*entities.HostCheck, error) {
+	return r.findChecks(ctx,
+		bson.D{{Key: "timestamp", Value: bson.D{{Key: "$lt", Value: before}}}},
+		bson.D{{Key: "host_id", Value: 1}, {Key: "type", Value: 1}},
+	)
+}
+
+func (r *CheckRepository) ListByStatus(ctx context.Context, status entities.CheckStatus) ([]*entities.HostCheck, error) {
+	return r.findChecks(ctx,
+		bson.D{{Key: "status", Value: status}},
+		bson.D{{Key: "host_id", Value: 1}, {Key: "type", Value: 1}},
+	)
+}
+
+func (r *CheckRepository) AppendHistory(ctx context.Context, results []entities.CheckResult) (int, error) {
+	if len(results) == 0 {
+		return 0, nil
+	}
+	documents := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		documents = append(documents, result)
+	}
+
+	_, err := r.history.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) {
+		if err != nil {
+			return 0, err
+		}
+		return len(results), nil
+	}
+	if bulkErr.WriteConcernError != nil {
+		return 0, err
+	}
+	duplicates := 0
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != DUPLICATE_KEY_CODE {
+			return 0, err
+		}
+		duplicates++
+	}
+	return len(results) - duplicates, nil
+}
+
+func (r *CheckRepository) ListHistory(ctx context.Context, hostID string, checkType core_entities.CheckType, since time.Time) ([]entities.CheckResult, error) {
+	filter := bson.D{
+		{Key: "host_id", Value: hostID},
+		{Key: "type", Value: checkType},
+		{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: since}}},
+	}
+	cursor, err := r.history.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]entities.CheckResult, 0)
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *CheckRepository) findChecks(ctx context.Context, filter bson.D, sort bson.D) ([]*entities.HostCheck, error) {
+	cursor, err := r.latest.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	checks := make([]*entities.HostCheck, 0)
+	if err := cursor.All(ctx, &checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const DECISIONS_COLLECTION = "decisions"
+
+type DecisionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDecisionRepository(db *mongo.Database) *DecisionRepository {
+	return &DecisionRepository{collection: db.Collection(DECISIONS_COLLECTION)}
+}
+
+func (r *DecisionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "decided_at", Value: 1}},
+			Options: options.Index().SetName("host_decided_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}, {Key: "decided_at", Value: 1}},
+			Options: options.Index().SetName("project_decided_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "decided_at", Value: 1}},
+			Options: options.Index().SetName("decided_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
+
+func (r *DecisionRepository) SaveDecision(ctx context.Context, record *entities.DecisionRecord) error {
+	_, err := r.collection.InsertOne(ctx, record)
+	return err
+}
+
+func (r *DecisionRepository) GetDecision(ctx context.Context, id string) (*entities.DecisionRecord, error) {
+	var record entities.DecisionRecord
+	err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&record)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *DecisionRepository) ListDecisions(ctx context.Context, filter entities.DecisionFilter) ([]entities.DecisionRecord, error) {
+	decidedAt := bson.D{{Key: "$gte", Value: filter.Since}}
+	if !filter.Until.IsZero() {
+		decidedAt = append(decidedAt, bson.E{Key: "$lt", Value: filter.Until})
+	}
+	query := bson.D{{Key: "decided_at", Value: decidedAt}}
+	if filter.ProjectID != "" {
+		query = append(query, bson.E{Key: "project_id", Value: filter.ProjectID})
+	}
+	if filter.HostID != "" {
+		query = append(query, bson.E{Key: "host_id", Value: filter.HostID})
+	}
+
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "decided_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]entities.DecisionRecord, 0)
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,24 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	repositories "github.com/gwall-e/auto_healing/internal/infrastructure/repositories/mongo"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeDecisionRepository(func() contracts.DecisionRepository {
+	db := client.Database(fmt.Sprintf("decisions_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+
+	repo := repositories.NewDecisionRepository(db)
+	Expect(repo.EnsureIndexes(context.Background())).To(Succeed())
+	return repo
+})
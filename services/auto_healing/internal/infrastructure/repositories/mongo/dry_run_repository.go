@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const DRY_RUN_PROJECTS_COLLECTION = "dry_run_projects"
+
+type dryRunProject struct {
+	ProjectID string `bson:"_id"`
+}
+
+type DryRunRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDryRunRepository(db *mongo.Database) *DryRunRepository {
+	return &DryRunRepository{collection: db.Collection(DRY_RUN_PROJECTS_COLLECTION)}
+}
+
+func (r *DryRunRepository) IsDryRun(ctx context.Context, projectID string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "_id", Value: projectID}})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *DryRunRepository) SetDryRun(ctx context.Context, projectID string, enabled bool) error {
+	filter := bson.D{{Key: "_id", Value: projectID}}
+	if !enabled {
+		_, err := r.collection.DeleteOne(ctx, filter)
+		return err
+	}
+	_, err := r.collection.ReplaceOne(ctx, filter, dryRunProject{ProjectID: projectID}, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *DryRunRepository) ListDryRunProjects(ctx context.Context) ([]string, error) {
+	cursor, err := r.collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var documents []dryRunProject
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	projects := make([]string, 0, len(documents))
+	for _, document := range documents {
+		projects = append(projects, document.ProjectID)
+	}
+	return projects, nil
+}
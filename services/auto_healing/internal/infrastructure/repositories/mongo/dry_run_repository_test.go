@@ -0,0 +1,21 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	repositories "github.com/gwall-e/auto_healing/internal/infrastructure/repositories/mongo"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeDryRunRepository(func() contracts.DryRunRepository {
+	db := client.Database(fmt.Sprintf("dry_run_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+	return repositories.NewDryRunRepository(db)
+})
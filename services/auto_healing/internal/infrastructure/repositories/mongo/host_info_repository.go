@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	limitEntities "github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	limitErrors "github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+	livenessEntities "github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const HOSTS_COLLECTION = "hosts"
+
+type HostInfoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewHostInfoRepository(db *mongo.Database) *HostInfoRepository {
+	return &HostInfoRepository{collection: db.Collection(HOSTS_COLLECTION)}
+}
+
+func (r *HostInfoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}},
+			Options: options.Index().SetName("project_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "datacenter", Value: 1}, {Key: "rack", Value: 1}},
+			Options: options.Index().SetName("datacenter_rack"),
+		},
+		{
+			Keys:    bson.D{{Key: "switch", Value: 1}},
+			Options: options.Index().SetName("switch"),
+		},
+	})
+	return err
+}
+
+func (r *HostInfoRepository) SaveHostInfo(ctx context.Context, info *entities.HostInfo) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: info.HostID}}, info, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *HostInfoRepository) DeleteHostInfo(ctx context.Context, hostID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: hostID}})
+	return err
+}
+
+func (r *HostInfoRepository) GetHostInfo(ctx context.Context, hostID string) (*entities.HostInfo, error) {
+	info, err := r.find(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.ErrHostNotFound
+	}
+	return info, nil
+}
+
+func (r *HostInfoRepository) GetProjectInfo(ctx context.Context, projectID string) (*limitEntities.ProjectInfo, error) {
+	if projectID == "" {
+		return nil, limitErrors.ErrProjectNotFound
+	}
+	filter := bson.D{{Key: "project_id", Value: projectID}}
+	var host entities.HostInfo
+	err := r.collection.FindOne(ctx, filter).Decode(&host)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, limitErrors.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &limitEntities.ProjectInfo{ProjectID: projectID, Tier: host.Tier, Hosts: int(count)}, nil
+}
+
+func (r *HostInfoRepository) GetHostLocation(ctx context.Context, hostID string) (*livenessEntities.HostLocation, error) {
+	info, err := r.find(ctx, hostID)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	return &livenessEntities.HostLocation{
+		HostID:     info.HostID,
+		Datacenter: info.Datacenter,
+		Rack:       info.Rack,
+		Switch:     info.Switch,
+	}, nil
+}
+
+func (r *HostInfoRepository) CountHosts(ctx context.Context, domain livenessEntities.FailureDomain) (int, error) {
+	var filter bson.D
+	switch domain.Level {
+	case livenessEntities.LocationLevelSwitch:
+		filter = bson.D{{Key: "switch", Value: domain.Key}}
+	case livenessEntities.LocationLevelRack:
+		datacenter, rack, ok := strings.Cut(domain.Key, "/")
+		if !ok {
+			return 0, nil
+		}
+		filter = bson.D{{Key: "datacenter", Value: datacenter}, {Key: "rack", Value: rack}}
+	default:
+		return 0, nil
+	}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	return int(count), err
+}
+
+func (r *HostInfoRepository) GetProjectOwners(ctx context.Context, projectID string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (r *HostInfoRepository) find(ctx context.Context, hostID string) (*entities.HostInfo, error) {
+	var info entities.HostInfo
+	err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: hostID}}).Decode(&info)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &info, nil
+}
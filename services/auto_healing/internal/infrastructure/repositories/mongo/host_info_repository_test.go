@@ -0,0 +1,33 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	inventoryContracts "github.com/gwall-e/auto_healing/internal/domain/inventory/contracts"
+	repositories "github.com/gwall-e/auto_healing/internal/infrastructure/repositories/mongo"
+	"github.com/gwall-e/auto_healing/internal/infrastructure/repositories/repotest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeHostInfoRepository(func() repotest.HostInfoRepository {
+	db := client.Database(fmt.Sprintf("host_info_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+	repo := repositories.NewHostInfoRepository(db)
+	Expect(repo.EnsureIndexes(context.Background())).To(Succeed())
+	return repo
+})
+
+var _ = repotest.DescribePowerTargetRepository(func() inventoryContracts.PowerTargetRepository {
+	db := client.Database(fmt.Sprintf("power_targets_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+	repo := repositories.NewPowerTargetRepository(db)
+	Expect(repo.EnsureIndexes(context.Background())).To(Succeed())
+	return repo
+})
@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const KILL_SWITCHES_COLLECTION = "kill_switches"
+
+type KillSwitchRepository struct {
+	collection *mongo.Collection
+}
+
+func NewKillSwitchRepository(db *mongo.Database) *KillSwitchRepository {
+	return &KillSwitchRepository{collection: db.Collection(KILL_SWITCHES_COLLECTION)}
+}
+
+func (r *KillSwitchRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "released_at", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("released_at_created_at"),
+	})
+	return err
+}
+
+func (r *KillSwitchRepository) Save(ctx context.Context, killSwitch *entities.KillSwitch) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: killSwitch.ID}}, killSwitch, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *KillSwitchRepository) Get(ctx context.Context, id string) (*entities.KillSwitch, error) {
+	var killSwitch entities.KillSwitch
+	err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&killSwitch)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &killSwitch, nil
+}
+
+func (r *KillSwitchRepository) ListUnreleased(ctx context.Context) ([]*entities.KillSwitch, error) {
+	return r.find(ctx, bson.D{{Key: "released_at", Value: nil}}, 1)
+}
+
+func (r *KillSwitchRepository) List(ctx context.Context, since time.Time) ([]*entities.KillSwitch, error) {
+	return r.find(ctx, bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "released_at", Value: nil}},
+		bson.D{{Key: "released_at", Value: bson.D{{Key: "$gte", Value: since}}}},
+	}}}, -1)
+}
+
+func (r *KillSwitchRepository) find(ctx context.Context, filter bson.D, order int) ([]*entities.KillSwitch, error) {
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: order}}))
+	if err != nil {
+		return nil, err
+	}
+	switches := make([]*entities.KillSwitch, 0)
+	if err := cursor.All(ctx, &switches); err != nil {
+		return nil, err
+	}
+	return switches, nil
+}
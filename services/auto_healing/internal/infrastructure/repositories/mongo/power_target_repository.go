@@ -0,0 +1,50 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const POWER_TARGETS_COLLECTION = "power_targets"
+
+type PowerTargetRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPowerTargetRepository(db *mongo.Database) *PowerTargetRepository {
+	return &PowerTargetRepository{collection: db.Collection(POWER_TARGETS_COLLECTION)}
+}
+
+func (r *PowerTargetRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "host_id", Value: 1}},
+		Options: options.Index().SetName("host_id").SetUnique(true),
+	})
+	return err
+}
+
+func (r *PowerTargetRepository) GetPowerTarget(ctx context.Context, hostID string) (*entities.PowerTarget, error) {
+	var target entities.PowerTarget
+	err := r.collection.FindOne(ctx, bson.D{{Key: "host_id", Value: hostID}}).Decode(&target)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (r *PowerTargetRepository) SavePowerTarget(ctx context.Context, target *entities.PowerTarget) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "host_id", Value: target.HostID}}, target, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *PowerTargetRepository) DeletePowerTarget(ctx context.Context, hostID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.D{{Key: "host_id", Value: hostID}})
+	return err
+}
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const HOST_RELEASES_COLLECTION = "host_releases"
+
+type ReleaseRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReleaseRepository(db *mongo.Database) *ReleaseRepository {
+	return &ReleaseRepository{collection: db.Collection(HOST_RELEASES_COLLECTION)}
+}
+
+func (r *ReleaseRepository) GetRelease(ctx context.Context, id string) (*entities.Release, error) {
+	var release entities.Release
+	err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&release)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+func (r *ReleaseRepository) CreateRelease(ctx context.Context, release *entities.Release) (bool, error) {
+	_, err := r.collection.InsertOne(ctx, release)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ReleaseRepository) SaveRelease(ctx context.Context, release *entities.Release) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: release.ID}}, release, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *ReleaseRepository) DeleteRelease(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	return err
+}
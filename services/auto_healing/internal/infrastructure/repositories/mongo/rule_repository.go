@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const RULE_SETS_COLLECTION = "rule_sets"
+
+type RuleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRuleRepository(db *mongo.Database) *RuleRepository {
+	return &RuleRepository{collection: db.Collection(RULE_SETS_COLLECTION)}
+}
+
+func (r *RuleRepository) GetRuleSet(ctx context.Context, kind entities.RuleSetKind) (*entities.RuleSet, error) {
+	var set entities.RuleSet
+	err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: kind}}).Decode(&set)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (r *RuleRepository) SaveRuleSet(ctx context.Context, set *entities.RuleSet) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: set.Kind}}, set, options.Replace().SetUpsert(true))
+	return err
+}
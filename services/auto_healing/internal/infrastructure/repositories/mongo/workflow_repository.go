@@ -0,0 +1,109 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const WORKFLOWS_COLLECTION = "workflows"
+
+type WorkflowRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWorkflowRepository(db *mongo.Database) *WorkflowRepository {
+	return &WorkflowRepository{collection: db.Collection(WORKFLOWS_COLLECTION)}
+}
+
+func (r *WorkflowRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("host_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("status_created_at"),
+		},
+	})
+	return err
+}
+
+func (r *WorkflowRepository) Create(ctx context.Context, workflow *entities.Workflow) error {
+	version := workflow.Version
+	workflow.Version = 1
+
+	if _, err := r.collection.InsertOne(ctx, workflow); err != nil {
+		workflow.Version = version
+		return err
+	}
+	return nil
+}
+
+func (r *WorkflowRepository) Update(ctx context.Context, workflow *entities.Workflow) error {
+	expected := workflow.Version
+	workflow.Version++
+
+	result, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: workflow.ID}, {Key: "version", Value: expected}}, workflow)
+	if err != nil {
+		workflow.Version = expected
+		return err
+	}
+	if result.MatchedCount == 1 {
+		return nil
+	}
+
+	workflow.Version = expected
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "_id", Value: workflow.ID}})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrWorkflowNotFound
+	}
+	return errors.ErrWorkflowVersionConflict
+}
+
+func (r *WorkflowRepository) Get(ctx context.Context, id string) (*entities.Workflow, error) {
+	return r.findOne(ctx, bson.D{{Key: "_id", Value: id}})
+}
+
+func (r *WorkflowRepository) FindActiveByHost(ctx context.Context, hostID string) (*entities.Workflow, error) {
+	return r.findOne(ctx, bson.D{{Key: "host_id", Value: hostID}, {Key: "status", Value: entities.WorkflowRunning}})
+}
+
+func (r *WorkflowRepository) ListActive(ctx context.Context) ([]*entities.Workflow, error) {
+	return r.find(ctx, bson.D{{Key: "status", Value: entities.WorkflowRunning}}, bson.D{{Key: "created_at", Value: 1}})
+}
+
+func (r *WorkflowRepository) ListByHost(ctx context.Context, hostID string) ([]*entities.Workflow, error) {
+	return r.find(ctx, bson.D{{Key: "host_id", Value: hostID}}, bson.D{{Key: "created_at", Value: -1}})
+}
+
+func (r *WorkflowRepository) findOne(ctx context.Context, filter bson.D) (*entities.Workflow, error) {
+	var workflow entities.Workflow
+	if err := r.collection.FindOne(ctx, filter).Decode(&workflow); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *WorkflowRepository) find(ctx context.Context, filter bson.D, sort bson.D) ([]*entities.Workflow, error) {
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	workflows := make([]*entities.Workflow, 0)
+	if err := cursor.All(ctx, &workflows); err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
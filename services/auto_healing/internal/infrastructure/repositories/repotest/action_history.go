@@ -0,0 +1,47 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	decisionContracts "github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	workflowContracts "github.com/gwall-e/auto_healing/internal/domain/workflows/contracts"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ActionHistory interface {
+	decisionContracts.ActionHistory
+	workflowContracts.ActionRecorder
+}
+
+func DescribeActionHistory(newHistory func() ActionHistory) bool {
+	return Describe("ActionHistory contract", func() {
+		var (
+			ctx     context.Context
+			history ActionHistory
+			now     time.Time
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			history = newHistory()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should list actions of a host since the given time in order", func() {
+			reboot := entities.ActionRecord{Action: entities.ActionReboot, Check: core_entities.CheckSSH, At: now.Add(-time.Hour)}
+			redeploy := entities.ActionRecord{Action: entities.ActionRedeploy, Check: core_entities.CheckSSH, At: now}
+			old := entities.ActionRecord{Action: entities.ActionReboot, Check: core_entities.CheckSSH, At: now.Add(-48 * time.Hour)}
+			Expect(history.AddAction(ctx, "host-1", redeploy)).To(Succeed())
+			Expect(history.AddAction(ctx, "host-1", reboot)).To(Succeed())
+			Expect(history.AddAction(ctx, "host-1", old)).To(Succeed())
+			Expect(history.AddAction(ctx, "host-2", reboot)).To(Succeed())
+
+			Expect(history.ListActions(ctx, "host-1", now.Add(-24*time.Hour))).To(Equal([]entities.ActionRecord{reboot, redeploy}))
+			Expect(history.ListActions(ctx, "host-3", now.Add(-24*time.Hour))).To(BeEmpty())
+		})
+	})
+}
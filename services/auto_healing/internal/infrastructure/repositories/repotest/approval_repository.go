@@ -0,0 +1,116 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/approvals/entities"
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeApprovalRepository(newRepository func() contracts.ApprovalRepository) bool {
+	return Describe("ApprovalRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.ApprovalRepository
+			now  time.Time
+		)
+
+		newApproval := func(hostID string, requestedAt time.Time) *entities.Approval {
+			decision := decisionEntities.Decision{
+				HostID: hostID, Action: decisionEntities.ActionRedeploy, Rule: "ssh", Check: core_entities.CheckSSH, Reason: "ssh is failing",
+			}
+			approval := entities.NewApproval("search", decision, requestedAt.Add(time.Hour), requestedAt)
+			approval.ClearEvents()
+			return approval
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should save and load approvals", func() {
+			approval := newApproval("host-1", now)
+			Expect(repo.Save(ctx, approval)).To(Succeed())
+
+			stored, err := repo.Get(ctx, approval.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.HostID).To(Equal("host-1"))
+			Expect(stored.ProjectID).To(Equal("search"))
+			Expect(stored.Action).To(Equal(decisionEntities.ActionRedeploy))
+			Expect(stored.Status).To(Equal(entities.ApprovalPending))
+			Expect(stored.RequestedAt).To(BeTemporally("==", now))
+			Expect(stored.ExpiresAt).To(BeTemporally("==", now.Add(time.Hour)))
+			Expect(stored.ResolvedAt).To(BeNil())
+		})
+
+		It("should return nil for unknown approvals", func() {
+			stored, err := repo.Get(ctx, "unknown")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(BeNil())
+		})
+
+		It("should keep resolutions on save", func() {
+			approval := newApproval("host-1", now)
+			Expect(repo.Save(ctx, approval)).To(Succeed())
+			approval.Approve("alice", "go ahead", "workflow-1", now.Add(time.Minute))
+			Expect(repo.Save(ctx, approval)).To(Succeed())
+
+			stored, err := repo.Get(ctx, approval.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Status).To(Equal(entities.ApprovalApproved))
+			Expect(stored.ResolvedBy).To(Equal("alice"))
+			Expect(stored.Comment).To(Equal("go ahead"))
+			Expect(stored.WorkflowID).To(Equal("workflow-1"))
+			Expect(*stored.ResolvedAt).To(BeTemporally("==", now.Add(time.Minute)))
+		})
+
+		It("should find only pending approvals by host", func() {
+			rejected := newApproval("host-1", now)
+			rejected.Reject("alice", "no", now.Add(time.Minute))
+			pending := newApproval("host-1", now.Add(2*time.Minute))
+			Expect(repo.Save(ctx, rejected)).To(Succeed())
+			Expect(repo.Save(ctx, pending)).To(Succeed())
+			Expect(repo.Save(ctx, newApproval("host-2", now))).To(Succeed())
+
+			stored, err := repo.FindPendingByHost(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.ID).To(Equal(pending.ID))
+
+			stored, err = repo.FindPendingByHost(ctx, "host-3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(BeNil())
+		})
+
+		It("should list approvals by filter, newest first", func() {
+			first := newApproval("host-1", now)
+			first.Expire(now.Add(time.Hour))
+			second := newApproval("host-2", now.Add(time.Minute))
+			third := newApproval("host-1", now.Add(2*time.Minute))
+			for _, approval := range []*entities.Approval{second, first, third} {
+				Expect(repo.Save(ctx, approval)).To(Succeed())
+			}
+
+			ids := func(filter entities.ApprovalFilter) []string {
+				approvals, err := repo.List(ctx, filter)
+				Expect(err).NotTo(HaveOccurred())
+				ids := []string{}
+				for _, approval := range approvals {
+					ids = append(ids, approval.ID)
+				}
+				return ids
+			}
+
+			Expect(ids(entities.ApprovalFilter{})).To(Equal([]string{third.ID, second.ID, first.ID}))
+			Expect(ids(entities.ApprovalFilter{Status: entities.ApprovalPending})).To(Equal([]string{third.ID, second.ID}))
+			Expect(ids(entities.ApprovalFilter{HostID: "host-1"})).To(Equal([]string{third.ID, first.ID}))
+			Expect(ids(entities.ApprovalFilter{Since: now.Add(time.Minute)})).To(Equal([]string{third.ID, second.ID}))
+		})
+	})
+}
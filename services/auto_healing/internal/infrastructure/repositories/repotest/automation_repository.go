@@ -0,0 +1,131 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeAutomationRepository(newRepository func() contracts.AutomationRepository) bool {
+	return Describe("AutomationRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.AutomationRepository
+			now  time.Time
+		)
+
+		newAction := func(id string, hostID string, projectID string, startedAt time.Time) *entities.AutomatedAction {
+			return &entities.AutomatedAction{ID: id, HostID: hostID, ProjectID: projectID, Action: decisionEntities.ActionReboot, StartedAt: startedAt}
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should store project automation state", func() {
+			Expect(repo.GetProject(ctx, "search")).To(BeNil())
+
+			project := entities.NewProjectAutomation("search")
+			project.Disable("too many actions", now)
+			project.Limits = &entities.Limits{MaxActions: 3, Window: time.Hour, MaxInFlightPercent: 10}
+			Expect(repo.SaveProject(ctx, project)).To(Succeed())
+			Expect(repo.SaveProject(ctx, entities.NewProjectAutomation("web"))).To(Succeed())
+
+			Expect(repo.GetProject(ctx, "search")).To(Equal(project))
+			disabled, err := repo.ListDisabledProjects(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disabled).To(Equal([]*entities.ProjectAutomation{project}))
+
+			project.Enable("admin", now.Add(time.Hour))
+			Expect(repo.SaveProject(ctx, project)).To(Succeed())
+			Expect(repo.ListDisabledProjects(ctx)).To(BeEmpty())
+		})
+
+		It("should track in flight actions and count actions within a window", func() {
+			unlimited := []entities.ActionQuota{{MaxActions: 100, MaxInFlight: 100}}
+			for _, action := range []*entities.AutomatedAction{
+				newAction("action-1", "host-1", "search", now.Add(-2*time.Hour)),
+				newAction("action-2", "host-2", "search", now),
+				newAction("action-3", "host-3", "web", now),
+			} {
+				Expect(repo.CreateAction(ctx, action, unlimited)).To(BeTrue())
+			}
+			Expect(repo.GetAction(ctx, "action-2")).To(Equal(newAction("action-2", "host-2", "search", now)))
+			Expect(repo.GetAction(ctx, "unknown")).To(BeNil())
+
+			Expect(repo.FinishAction(ctx, "action-1", now)).To(Succeed())
+			Expect(repo.FinishAction(ctx, "unknown", now)).To(MatchError(errors.ErrActionNotFound))
+			finished, err := repo.GetAction(ctx, "action-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*finished.FinishedAt).To(BeTemporally("==", now))
+
+			Expect(repo.GetInFlightAction(ctx, "host-1")).To(BeNil())
+			Expect(repo.GetInFlightAction(ctx, "host-2")).To(HaveField("ID", "action-2"))
+			Expect(repo.CountActions(ctx, "search", now.Add(-time.Hour))).To(Equal(1))
+			Expect(repo.CountActions(ctx, "", now.Add(-3*time.Hour))).To(Equal(3))
+			Expect(repo.CountInFlight(ctx, "search")).To(Equal(1))
+			Expect(repo.CountInFlight(ctx, "")).To(Equal(2))
+		})
+
+		It("should not create actions over a quota", func() {
+			quotas := func() []entities.ActionQuota {
+				return []entities.ActionQuota{
+					{Since: now.Add(-time.Hour), MaxActions: 3, MaxInFlight: 3},
+					{ProjectID: "search", Since: now.Add(-time.Hour), MaxActions: 2, MaxInFlight: 1},
+				}
+			}
+			Expect(repo.CreateAction(ctx, newAction("action-1", "host-1", "search", now), quotas())).To(BeTrue())
+			Expect(repo.CreateAction(ctx, newAction("action-2", "host-2", "search", now), quotas())).To(BeFalse())
+			Expect(repo.GetAction(ctx, "action-2")).To(BeNil())
+
+			Expect(repo.FinishAction(ctx, "action-1", now)).To(Succeed())
+			Expect(repo.CreateAction(ctx, newAction("action-2", "host-2", "search", now), quotas())).To(BeTrue())
+			Expect(repo.FinishAction(ctx, "action-2", now)).To(Succeed())
+			Expect(repo.CreateAction(ctx, newAction("action-3", "host-3", "search", now), quotas())).To(BeFalse())
+
+			Expect(repo.CreateAction(ctx, newAction("action-4", "host-4", "web", now), quotas()[:1])).To(BeTrue())
+			Expect(repo.CreateAction(ctx, newAction("action-5", "host-5", "web", now), quotas()[:1])).To(BeFalse())
+
+			now = now.Add(2 * time.Hour)
+			Expect(repo.CreateAction(ctx, newAction("action-6", "host-6", "search", now), quotas())).To(BeTrue())
+			Expect(repo.CountActions(ctx, "", time.Time{})).To(Equal(4))
+		})
+
+		It("should not go over a quota with concurrent creates", func() {
+			quotas := []entities.ActionQuota{{ProjectID: "search", Since: now.Add(-time.Hour), MaxActions: 3, MaxInFlight: 10}}
+			var (
+				wg      sync.WaitGroup
+				mu      sync.Mutex
+				created int
+			)
+			for n := 0; n < 10; n++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					ok, err := repo.CreateAction(ctx, newAction(fmt.Sprintf("action-%d", n), fmt.Sprintf("host-%d", n), "search", now), quotas)
+					Expect(err).NotTo(HaveOccurred())
+					if ok {
+						mu.Lock()
+						created++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			Expect(created).To(Equal(3))
+			Expect(repo.CountActions(ctx, "search", now.Add(-time.Hour))).To(Equal(3))
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/checks/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/checks/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeCheckRepository(newRepository func() contracts.CheckRepository) bool {
+	return Describe("CheckRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.CheckRepository
+			now  time.Time
+		)
+
+		newResult := func(hostID string, checkType core_entities.CheckType, status entities.CheckStatus, timestamp time.Time) entities.CheckResult {
+			return entities.CheckResult{
+				HostID:     hostID,
+				Type:       checkType,
+				Status:     status,
+				Timestamp:  timestamp,
+				Metadata:   map[string]string{"source": "agent"},
+				ReceivedAt: timestamp,
+				ExpiresAt:  timestamp.Add(time.Hour),
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should keep only the latest result of a check", func() {
+			check := entities.NewHostCheck(newResult("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, now))
+			saved, err := repo.SaveLatest(ctx, check)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeTrue())
+
+			older := entities.NewHostCheck(newResult("host-1", core_entities.CheckSSH, entities.CheckStatusOK, now.Add(-time.Minute)))
+			saved, err = repo.SaveLatest(ctx, older)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeFalse())
+
+			same := entities.NewHostCheck(newResult("host-1", core_entities.CheckSSH, entities.CheckStatusOK, now))
+			saved, err = repo.SaveLatest(ctx, same)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeFalse())
+
+			newer := entities.NewHostCheck(newResult("host-1", core_entities.CheckSSH, entities.CheckStatusOK, now.Add(time.Minute)))
+			saved, err = repo.SaveLatest(ctx, newer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeTrue())
+
+			stored, err := repo.GetLatest(ctx, "host-1", core_entities.CheckSSH)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Status).To(Equal(entities.CheckStatusOK))
+			Expect(stored.Timestamp).To(BeTemporally("==", now.Add(time.Minute)))
+			Expect(stored.Metadata).To(Equal(map[string]string{"source": "agent"}))
+		})
+
+		It("should return nil for checks never reported", func() {
+			stored, err := repo.GetLatest(ctx, "host-1", core_entities.CheckSSH)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(BeNil())
+		})
+
+		It("should list latest checks of a host and checks reported before a time", func() {
+			for _, result := range []entities.CheckResult{
+				newResult("host-2", core_entities.CheckSSH, entities.CheckStatusOK, now.Add(-time.Hour)),
+				newResult("host-1", core_entities.CheckSSH, entities.CheckStatusOK, now),
+				newResult("host-1", core_entities.CheckDisk, entities.CheckStatusFailed, now.Add(-time.Hour)),
+			} {
+				_, err := repo.SaveLatest(ctx, entities.NewHostCheck(result))
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			checks, err := repo.ListLatest(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(2))
+			Expect(checks[0].Type < checks[1].Type).To(BeTrue())
+
+			stale, err := repo.ListReportedBefore(ctx, now.Add(-time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stale).To(HaveLen(2))
+			Expect(stale[0].HostID).To(Equal("host-1"))
+			Expect(stale[0].Type).To(Equal(core_entities.CheckDisk))
+			Expect(stale[1].HostID).To(Equal("host-2"))
+
+			failing, err := repo.ListByStatus(ctx, entities.CheckStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failing).To(HaveLen(1))
+			Expect(failing[0].HostID).To(Equal("host-1"))
+			Expect(failing[0].Type).To(Equal(core_entities.CheckDisk))
+		})
+
+		It("should append history skipping results already stored", func() {
+			stored, err := repo.AppendHistory(ctx, []entities.CheckResult{
+				newResult("host-1", core_entities.CheckSSH, entities.CheckStatusOK, now.Add(time.Minute)),
+				newResult("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, now),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(2))
+
+			stored, err = repo.AppendHistory(ctx, []entities.CheckResult{
+				newResult("host-1", core_entities.CheckSSH, entities.CheckStatusFailed, now),
+				newResult("host-1", core_entities.CheckSSH, entities.CheckStatusOK, now.Add(2*time.Minute)),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(1))
+
+			history, err := repo.ListHistory(ctx, "host-1", core_entities.CheckSSH, now.Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+			Expect(history[0].Timestamp).To(BeTemporally("==", now.Add(time.Minute)))
+			Expect(history[1].Timestamp).To(BeTemporally("==", now.Add(2*time.Minute)))
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeDecisionRepository(newRepository func() contracts.DecisionRepository) bool {
+	return Describe("DecisionRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.DecisionRepository
+			now  time.Time
+		)
+
+		newRecord := func(id string, hostID string, projectID string, decidedAt time.Time) *entities.DecisionRecord {
+			return &entities.DecisionRecord{
+				ID:        id,
+				HostID:    hostID,
+				ProjectID: projectID,
+				Decision: entities.Decision{
+					HostID: hostID, Action: entities.ActionReboot, Rule: "ssh", Check: core_entities.CheckSSH, DecidedAt: decidedAt,
+				},
+				Explanation: entities.Explanation{
+					Snapshot: entities.HostSnapshot{
+						HostID: hostID, ProjectID: projectID, UnitType: core_entities.TypeServer, Now: decidedAt,
+						Checks: []entities.CheckState{{Type: core_entities.CheckSSH, Failing: true, Since: decidedAt.Add(-time.Hour)}},
+					},
+					Rules:  []entities.RuleEvaluation{{Rule: "ssh", Check: core_entities.CheckSSH, Applied: true, Action: entities.ActionReboot, Selected: true}},
+					Limits: &entities.LimitsState{Actions: 1, MaxActions: 10, Window: time.Hour},
+				},
+				DecidedAt: decidedAt,
+				ExpiresAt: decidedAt.Add(24 * time.Hour),
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should save and load decisions with explanations", func() {
+			record := newRecord("decision-1", "host-1", "search", now)
+			record.Shadow = &entities.Decision{HostID: "host-1", Action: entities.ActionRedeploy, DryRun: true}
+			Expect(repo.SaveDecision(ctx, record)).To(Succeed())
+
+			stored, err := repo.GetDecision(ctx, "decision-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Decision.Action).To(Equal(entities.ActionReboot))
+			Expect(stored.Shadow.Action).To(Equal(entities.ActionRedeploy))
+			Expect(stored.Explanation.Snapshot.Checks).To(HaveLen(1))
+			Expect(stored.Explanation.Rules[0].Selected).To(BeTrue())
+			Expect(stored.Explanation.Limits.Window).To(Equal(time.Hour))
+			Expect(stored.DecidedAt).To(BeTemporally("==", now))
+			Expect(stored.ExpiresAt).To(BeTemporally("==", now.Add(24*time.Hour)))
+		})
+
+		It("should return nil for unknown decisions", func() {
+			stored, err := repo.GetDecision(ctx, "unknown")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(BeNil())
+		})
+
+		It("should list decisions within the period ordered by decision time", func() {
+			for _, record := range []*entities.DecisionRecord{
+				newRecord("decision-3", "host-1", "search", now.Add(2*time.Minute)),
+				newRecord("decision-1", "host-1", "search", now),
+				newRecord("decision-2", "host-2", "search", now.Add(time.Minute)),
+				newRecord("decision-4", "host-3", "mail", now.Add(3*time.Minute)),
+			} {
+				Expect(repo.SaveDecision(ctx, record)).To(Succeed())
+			}
+
+			ids := func(filter entities.DecisionFilter) []string {
+				records, err := repo.ListDecisions(ctx, filter)
+				Expect(err).NotTo(HaveOccurred())
+				ids := []string{}
+				for _, record := range records {
+					ids = append(ids, record.ID)
+				}
+				return ids
+			}
+
+			Expect(ids(entities.DecisionFilter{})).To(Equal([]string{"decision-1", "decision-2", "decision-3", "decision-4"}))
+			Expect(ids(entities.DecisionFilter{ProjectID: "search"})).To(Equal([]string{"decision-1", "decision-2", "decision-3"}))
+			Expect(ids(entities.DecisionFilter{HostID: "host-1"})).To(Equal([]string{"decision-1", "decision-3"}))
+			Expect(ids(entities.DecisionFilter{Since: now.Add(time.Minute), Until: now.Add(3 * time.Minute)})).
+				To(Equal([]string{"decision-2", "decision-3"}))
+		})
+	})
+}
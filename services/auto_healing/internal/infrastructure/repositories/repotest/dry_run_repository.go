@@ -0,0 +1,41 @@
+package repotest
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeDryRunRepository(newRepository func() contracts.DryRunRepository) bool {
+	return Describe("DryRunRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.DryRunRepository
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+		})
+
+		It("should list no projects before any toggle", func() {
+			Expect(repo.IsDryRun(ctx, "search")).To(BeFalse())
+			Expect(repo.ListDryRunProjects(ctx)).To(BeEmpty())
+		})
+
+		It("should toggle dry run of projects", func() {
+			Expect(repo.SetDryRun(ctx, "web", true)).To(Succeed())
+			Expect(repo.SetDryRun(ctx, "search", true)).To(Succeed())
+			Expect(repo.SetDryRun(ctx, "search", true)).To(Succeed())
+			Expect(repo.IsDryRun(ctx, "search")).To(BeTrue())
+			Expect(repo.ListDryRunProjects(ctx)).To(Equal([]string{"search", "web"}))
+
+			Expect(repo.SetDryRun(ctx, "web", false)).To(Succeed())
+			Expect(repo.SetDryRun(ctx, "mail", false)).To(Succeed())
+			Expect(repo.IsDryRun(ctx, "web")).To(BeFalse())
+			Expect(repo.ListDryRunProjects(ctx)).To(Equal([]string{"search"}))
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package repotest
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/errors"
+	inventoryContracts "github.com/gwall-e/auto_healing/internal/domain/inventory/contracts"
+	killSwitchContracts "github.com/gwall-e/auto_healing/internal/domain/killswitch/contracts"
+	limitContracts "github.com/gwall-e/auto_healing/internal/domain/limits/contracts"
+	limitEntities "github.com/gwall-e/auto_healing/internal/domain/limits/entities"
+	limitErrors "github.com/gwall-e/auto_healing/internal/domain/limits/errors"
+	livenessContracts "github.com/gwall-e/auto_healing/internal/domain/liveness/contracts"
+	livenessEntities "github.com/gwall-e/auto_healing/internal/domain/liveness/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type HostInfoRepository interface {
+	inventoryContracts.HostInfoRepository
+	limitContracts.ProjectInfoProvider
+	livenessContracts.LocationProvider
+	killSwitchContracts.OwnerProvider
+}
+
+func DescribeHostInfoRepository(newRepository func() HostInfoRepository) bool {
+	return Describe("HostInfoRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo HostInfoRepository
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			for _, info := range []*entities.HostInfo{
+				{HostID: "host-1", FQDN: "host-1.search.dc1", ProjectID: "search", UnitType: core_entities.TypeServer, Tier: 1,
+					Restrictions: []core_entities.Restriction{core_entities.RestrictionNoReboot}, Datacenter: "dc1", Rack: "r1", Switch: "sw1"},
+				{HostID: "host-2", ProjectID: "search", UnitType: core_entities.TypeServer, Tier: 1, Datacenter: "dc1", Rack: "r1", Switch: "sw2"},
+				{HostID: "host-3", ProjectID: "mail", UnitType: core_entities.TypeVM, Datacenter: "dc2", Rack: "r1"},
+			} {
+				Expect(repo.SaveHostInfo(ctx, info)).To(Succeed())
+			}
+		})
+
+		It("should save, replace and delete host info", func() {
+			info, err := repo.GetHostInfo(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.FQDN).To(Equal("host-1.search.dc1"))
+			Expect(info.Restrictions).To(Equal([]core_entities.Restriction{core_entities.RestrictionNoReboot}))
+
+			info.ProjectID = "web"
+			info.Restrictions = nil
+			Expect(repo.SaveHostInfo(ctx, info)).To(Succeed())
+			info, err = repo.GetHostInfo(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.ProjectID).To(Equal("web"))
+			Expect(info.Restrictions).To(BeEmpty())
+
+			Expect(repo.DeleteHostInfo(ctx, "host-1")).To(Succeed())
+			Expect(repo.DeleteHostInfo(ctx, "host-1")).To(Succeed())
+			_, err = repo.GetHostInfo(ctx, "host-1")
+			Expect(err).To(MatchError(errors.ErrHostNotFound))
+		})
+
+		It("should summarize projects", func() {
+			Expect(repo.GetProjectInfo(ctx, "search")).To(Equal(&limitEntities.ProjectInfo{ProjectID: "search", Tier: 1, Hosts: 2}))
+			_, err := repo.GetProjectInfo(ctx, "unknown")
+			Expect(err).To(MatchError(limitErrors.ErrProjectNotFound))
+			_, err = repo.GetProjectInfo(ctx, "")
+			Expect(err).To(MatchError(limitErrors.ErrProjectNotFound))
+			Expect(repo.GetProjectOwners(ctx, "search")).To(BeEmpty())
+		})
+
+		It("should locate hosts and count them by failure domain", func() {
+			Expect(repo.GetHostLocation(ctx, "host-1")).To(Equal(&livenessEntities.HostLocation{HostID: "host-1", Datacenter: "dc1", Rack: "r1", Switch: "sw1"}))
+			Expect(repo.GetHostLocation(ctx, "unknown")).To(BeNil())
+
+			rack := livenessEntities.FailureDomain{Level: livenessEntities.LocationLevelRack, Key: livenessEntities.RackKey("dc1", "r1")}
+			Expect(repo.CountHosts(ctx, rack)).To(Equal(2))
+			Expect(repo.CountHosts(ctx, livenessEntities.FailureDomain{Level: livenessEntities.LocationLevelSwitch, Key: "sw2"})).To(Equal(1))
+			Expect(repo.CountHosts(ctx, livenessEntities.FailureDomain{Level: livenessEntities.LocationLevelSwitch, Key: "sw3"})).To(Equal(0))
+		})
+	})
+}
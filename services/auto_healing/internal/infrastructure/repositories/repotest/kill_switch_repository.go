@@ -0,0 +1,65 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/killswitch/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeKillSwitchRepository(newRepository func() contracts.KillSwitchRepository) bool {
+	return Describe("KillSwitchRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.KillSwitchRepository
+			now  time.Time
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		})
+
+		engage := func(id string, scope entities.Scope, target string, createdAt time.Time) *entities.KillSwitch {
+			killSwitch := entities.NewKillSwitch(id, entities.KillSwitchRequest{
+				Scope: scope, Target: target, Reason: "maintenance", Author: "alice", ExpiresAt: createdAt.Add(time.Hour),
+			}, createdAt)
+			killSwitch.ClearEvents()
+			Expect(repo.Save(ctx, killSwitch)).To(Succeed())
+			return killSwitch
+		}
+
+		It("should save and update kill switches", func() {
+			Expect(repo.Get(ctx, "switch-1")).To(BeNil())
+
+			killSwitch := engage("switch-1", entities.ScopeProject, "search", now)
+			Expect(repo.Get(ctx, "switch-1")).To(Equal(killSwitch))
+
+			killSwitch.Release("bob", now.Add(time.Minute))
+			killSwitch.ClearEvents()
+			Expect(repo.Save(ctx, killSwitch)).To(Succeed())
+			Expect(repo.Get(ctx, "switch-1")).To(Equal(killSwitch))
+		})
+
+		It("should list unreleased and recently released kill switches", func() {
+			global := engage("switch-1", entities.ScopeGlobal, "", now.Add(-3*time.Hour))
+			project := engage("switch-2", entities.ScopeProject, "search", now.Add(-2*time.Hour))
+			action := engage("switch-3", entities.ScopeAction, "redeploy", now.Add(-time.Hour))
+
+			project.Release("bob", now.Add(-90*time.Minute))
+			project.ClearEvents()
+			Expect(repo.Save(ctx, project)).To(Succeed())
+			global.Expire([]string{"alice"}, now.Add(-30*time.Minute))
+			global.ClearEvents()
+			Expect(repo.Save(ctx, global)).To(Succeed())
+
+			Expect(repo.ListUnreleased(ctx)).To(Equal([]*entities.KillSwitch{action}))
+			Expect(repo.List(ctx, now.Add(-time.Hour))).To(Equal([]*entities.KillSwitch{action, global}))
+			Expect(repo.List(ctx, now.Add(-2*time.Hour))).To(Equal([]*entities.KillSwitch{action, project, global}))
+		})
+	})
+}
@@ -0,0 +1,41 @@
+package repotest
+
+import (
+	"context"
+
+	"github.com/gwall-e/auto_healing/internal/domain/inventory/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribePowerTargetRepository(newRepository func() contracts.PowerTargetRepository) bool {
+	return Describe("PowerTargetRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.PowerTargetRepository
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+		})
+
+		It("should save, replace and delete power targets", func() {
+			Expect(repo.GetPowerTarget(ctx, "host-1")).To(BeNil())
+
+			target := &entities.PowerTarget{HostID: "host-1", UnitType: core_entities.TypeServer, Address: "bmc-host-1.dc1", SecretID: "bmc-dc1"}
+			Expect(repo.SavePowerTarget(ctx, target)).To(Succeed())
+			Expect(repo.GetPowerTarget(ctx, "host-1")).To(Equal(target))
+
+			target.ResourceID = "System.Embedded.1"
+			Expect(repo.SavePowerTarget(ctx, target)).To(Succeed())
+			Expect(repo.GetPowerTarget(ctx, "host-1")).To(Equal(target))
+
+			Expect(repo.DeletePowerTarget(ctx, "host-1")).To(Succeed())
+			Expect(repo.DeletePowerTarget(ctx, "host-1")).To(Succeed())
+			Expect(repo.GetPowerTarget(ctx, "host-1")).To(BeNil())
+		})
+	})
+}
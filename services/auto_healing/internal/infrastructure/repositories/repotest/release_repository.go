@@ -0,0 +1,58 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/releases/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/releases/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeReleaseRepository(newRepository func() contracts.ReleaseRepository) bool {
+	return Describe("ReleaseRepository contract", func() {
+		var (
+			ctx     context.Context
+			repo    contracts.ReleaseRepository
+			release *entities.Release
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			release = &entities.Release{
+				ID:          entities.ReleaseID("host-1", decisionEntities.ActionReboot),
+				HostID:      "host-1",
+				ProjectID:   "search",
+				Action:      decisionEntities.ActionReboot,
+				TaskID:      "task-1",
+				RequestedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+			}
+		})
+
+		It("should create a release once", func() {
+			Expect(repo.GetRelease(ctx, release.ID)).To(BeNil())
+			Expect(repo.CreateRelease(ctx, release)).To(BeTrue())
+
+			duplicate := *release
+			duplicate.TaskID = "task-2"
+			Expect(repo.CreateRelease(ctx, &duplicate)).To(BeFalse())
+			Expect(repo.GetRelease(ctx, release.ID)).To(Equal(release))
+		})
+
+		It("should save approvals and delete releases", func() {
+			Expect(repo.CreateRelease(ctx, release)).To(BeTrue())
+			approvedAt := release.RequestedAt.Add(time.Minute)
+			release.ApprovedAt = &approvedAt
+			Expect(repo.SaveRelease(ctx, release)).To(Succeed())
+			Expect(repo.GetRelease(ctx, release.ID)).To(Equal(release))
+
+			Expect(repo.DeleteRelease(ctx, release.ID)).To(Succeed())
+			Expect(repo.DeleteRelease(ctx, release.ID)).To(Succeed())
+			Expect(repo.GetRelease(ctx, release.ID)).To(BeNil())
+			Expect(repo.CreateRelease(ctx, release)).To(BeTrue())
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeRuleRepository(newRepository func() contracts.RuleRepository) bool {
+	return Describe("RuleRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.RuleRepository
+			now  time.Time
+		)
+
+		rule := entities.Rule{
+			Name:          "ssh",
+			Check:         core_entities.CheckSSH,
+			FailingFor:    15 * time.Minute,
+			UnitTypes:     []core_entities.UnitType{core_entities.TypeServer},
+			Tiers:         []byte{0, 1},
+			Actions:       []entities.Action{entities.ActionReboot, entities.ActionRedeploy},
+			MaxAttempts:   2,
+			HistoryWindow: 12 * time.Hour,
+			Approval:      entities.ApprovalPolicy{Actions: []entities.Action{entities.ActionRedeploy}, Tiers: []byte{0}},
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should return nil for rule sets never saved", func() {
+			Expect(repo.GetRuleSet(ctx, entities.RuleSetActive)).To(BeNil())
+		})
+
+		It("should replace the saved rule set", func() {
+			Expect(repo.SaveRuleSet(ctx, &entities.RuleSet{Kind: entities.RuleSetActive, Rules: []entities.Rule{rule}, UpdatedAt: now})).To(Succeed())
+
+			memory := rule
+			memory.Name, memory.Check = "memory", core_entities.CheckMemory
+			Expect(repo.SaveRuleSet(ctx, &entities.RuleSet{Kind: entities.RuleSetActive, Rules: []entities.Rule{rule, memory}, UpdatedAt: now.Add(time.Hour)})).To(Succeed())
+
+			stored, err := repo.GetRuleSet(ctx, entities.RuleSetActive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Kind).To(Equal(entities.RuleSetActive))
+			Expect(stored.Rules).To(Equal([]entities.Rule{rule, memory}))
+			Expect(stored.UpdatedAt).To(BeTemporally("==", now.Add(time.Hour)))
+		})
+	})
+}
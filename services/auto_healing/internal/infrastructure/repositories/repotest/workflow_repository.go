@@ -0,0 +1,122 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	decisionEntities "github.com/gwall-e/auto_healing/internal/domain/decisions/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/contracts"
+	"github.com/gwall-e/auto_healing/internal/domain/workflows/entities"
+	workflowErrors "github.com/gwall-e/auto_healing/internal/domain/workflows/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeWorkflowRepository(newRepository func() contracts.WorkflowRepository) bool {
+	return Describe("WorkflowRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.WorkflowRepository
+			now  time.Time
+		)
+
+		newWorkflow := func(hostID string, createdAt time.Time) *entities.Workflow {
+			return entities.NewWorkflow(entities.WorkflowRequest{
+				HostID:    hostID,
+				ProjectID: "search",
+				Check:     core_entities.CheckSSH,
+				Rule:      "ssh",
+				Actions:   []decisionEntities.Action{decisionEntities.ActionReboot, decisionEntities.ActionReportToDatacenter},
+			}, map[decisionEntities.Action]time.Duration{decisionEntities.ActionReboot: 30 * time.Minute}, createdAt)
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should save and load workflows with step progress and lease", func() {
+			workflow := newWorkflow("host-1", now)
+			Expect(repo.Create(ctx, workflow)).To(Succeed())
+			Expect(workflow.Version).To(Equal(int64(1)))
+
+			Expect(workflow.Claim("executor-1", now, now.Add(time.Minute))).To(BeTrue())
+			workflow.StartStep(0, now)
+			Expect(repo.Update(ctx, workflow)).To(Succeed())
+			Expect(workflow.Version).To(Equal(int64(2)))
+
+			stored, err := repo.Get(ctx, workflow.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Owner).To(Equal("executor-1"))
+			Expect(*stored.LeaseUntil).To(BeTemporally("==", now.Add(time.Minute)))
+			Expect(stored.Steps).To(HaveLen(2))
+			Expect(stored.Steps[0].Status).To(Equal(entities.StepRunning))
+			Expect(stored.Steps[0].Timeout).To(Equal(30 * time.Minute))
+			Expect(*stored.Steps[0].StartedAt).To(BeTemporally("==", now))
+			Expect(stored.Version).To(Equal(int64(2)))
+			Expect(stored.Events()).To(BeEmpty())
+		})
+
+		It("should reject updates of a workflow changed concurrently", func() {
+			workflow := newWorkflow("host-1", now)
+			Expect(repo.Create(ctx, workflow)).To(Succeed())
+
+			first, err := repo.Get(ctx, workflow.ID)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := repo.Get(ctx, workflow.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first.Claim("executor-1", now, now.Add(time.Minute))).To(BeTrue())
+			Expect(repo.Update(ctx, first)).To(Succeed())
+
+			Expect(second.Claim("executor-2", now, now.Add(time.Minute))).To(BeTrue())
+			Expect(repo.Update(ctx, second)).To(MatchError(workflowErrors.ErrWorkflowVersionConflict))
+			Expect(second.Version).To(Equal(int64(1)))
+
+			stored, err := repo.Get(ctx, workflow.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Owner).To(Equal("executor-1"))
+		})
+
+		It("should report updates of unknown workflows", func() {
+			Expect(repo.Update(ctx, newWorkflow("host-1", now))).To(MatchError(workflowErrors.ErrWorkflowNotFound))
+		})
+
+		It("should find active workflows", func() {
+			finished := newWorkflow("host-1", now)
+			Expect(repo.Create(ctx, finished)).To(Succeed())
+			finished.Cancel(now)
+			Expect(repo.Update(ctx, finished)).To(Succeed())
+
+			later := newWorkflow("host-2", now.Add(time.Minute))
+			Expect(repo.Create(ctx, later)).To(Succeed())
+			earlier := newWorkflow("host-1", now.Add(time.Second))
+			Expect(repo.Create(ctx, earlier)).To(Succeed())
+
+			active, err := repo.FindActiveByHost(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active.ID).To(Equal(earlier.ID))
+
+			workflows, err := repo.ListActive(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workflows).To(HaveLen(2))
+			Expect(workflows[0].ID).To(Equal(earlier.ID))
+			Expect(workflows[1].ID).To(Equal(later.ID))
+
+			workflows, err = repo.ListByHost(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workflows).To(HaveLen(2))
+			Expect(workflows[0].ID).To(Equal(earlier.ID))
+			Expect(workflows[1].ID).To(Equal(finished.ID))
+
+			missing, err := repo.Get(ctx, "unknown")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeNil())
+			missing, err = repo.FindActiveByHost(ctx, "host-3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeNil())
+		})
+	})
+}
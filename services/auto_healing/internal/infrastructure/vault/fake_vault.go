@@ -0,0 +1,35 @@
+package vault
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+)
+
+type FakeVault struct {
+	mu          sync.RWMutex
+	credentials map[string]entities.Credentials
+}
+
+func NewFakeVault() *FakeVault {
+	return &FakeVault{credentials: map[string]entities.Credentials{}}
+}
+
+func (v *FakeVault) SetCredentials(secretID string, username string, password string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.credentials[secretID] = entities.Credentials{Username: username, Password: password}
+}
+
+func (v *FakeVault) GetCredentials(ctx context.Context, secretID string) (*entities.Credentials, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	credentials, ok := v.credentials[secretID]
+	if !ok {
+		return nil, errors.ErrSecretNotFound
+	}
+	return &credentials, nil
+}
@@ -0,0 +1,72 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+const (
+	DEFAULT_MOUNT = "secret"
+	TOKEN_HEADER  = "X-Vault-Token"
+)
+
+type kvResponse struct {
+	Data struct {
+		Data struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"data"`
+	} `json:"data"`
+}
+
+type KVVault struct {
+	client pkgHttp.HTTPClient
+	token  string
+	mount  string
+}
+
+func NewKVVault(client pkgHttp.HTTPClient, token string, mount string) *KVVault {
+	if mount == "" {
+		mount = DEFAULT_MOUNT
+	}
+	return &KVVault{client: client, token: token, mount: strings.Trim(mount, "/")}
+}
+
+func (v *KVVault) GetCredentials(ctx context.Context, secretID string) (*entities.Credentials, error) {
+	if secretID == "" {
+		return nil, errors.ErrSecretNotFound
+	}
+	path := fmt.Sprintf("/v1/%s/data/%s", v.mount, strings.TrimPrefix(secretID, "/"))
+	resp, err := v.client.Get(ctx, path, nil, map[string]string{TOKEN_HEADER: v.token, "Accept": "application/json"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", errors.ErrSecretNotFound, secretID)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("%w: vault rejected access to %s", errors.ErrAccessDenied, secretID)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("vault responded with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var secret kvResponse
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, err
+	}
+	if secret.Data.Data.Username == "" {
+		return nil, fmt.Errorf("%w: %s has no username", errors.ErrSecretNotFound, secretID)
+	}
+	return &entities.Credentials{Username: secret.Data.Data.Username, Password: secret.Data.Data.Password}, nil
+}
@@ -0,0 +1,58 @@
+package vault_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gwall-e/auto_healing/internal/domain/power/entities"
+	"github.com/gwall-e/auto_healing/internal/domain/power/errors"
+	. "github.com/gwall-e/auto_healing/internal/infrastructure/vault"
+	pkgHttp "github.com/gwall-e/pkg/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KVVault", func() {
+	var (
+		ctx    context.Context
+		server *httptest.Server
+		secret *KVVault
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Header.Get(TOKEN_HEADER) != "root":
+				w.WriteHeader(http.StatusForbidden)
+			case r.URL.Path == "/v1/healing/data/bmc/rack-1":
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{
+						"data":     map[string]string{"username": "healer", "password": "secret"},
+						"metadata": map[string]interface{}{"version": 3},
+					},
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+		secret = NewKVVault(pkgHttp.NewClient(server.URL), "root", "healing")
+	})
+
+	It("should read credentials from the kv engine", func() {
+		Expect(secret.GetCredentials(ctx, "bmc/rack-1")).To(Equal(&entities.Credentials{Username: "healer", Password: "secret"}))
+	})
+
+	It("should report unknown secrets and rejected tokens", func() {
+		_, err := secret.GetCredentials(ctx, "bmc/rack-2")
+		Expect(err).To(MatchError(errors.ErrSecretNotFound))
+		_, err = secret.GetCredentials(ctx, "")
+		Expect(err).To(MatchError(errors.ErrSecretNotFound))
+
+		_, err = NewKVVault(pkgHttp.NewClient(server.URL), "stale", "healing").GetCredentials(ctx, "bmc/rack-1")
+		Expect(err).To(MatchError(errors.ErrAccessDenied))
+	})
+})
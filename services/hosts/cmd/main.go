@@ -1,7 +1,47 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	repositories "github.com/gwall-e/hosts/internal/infrastructure/repositories/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DEFAULT_MONGO_URI      = "mongodb://localhost:27017"
+	DEFAULT_MONGO_DATABASE = "hosts"
+	STARTUP_TIMEOUT        = 30 * time.Second
+)
 
 func main() {
-    fmt.Println("Hosts service starting...")
-}
\ No newline at end of file
+	fmt.Println("Hosts service starting...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), STARTUP_TIMEOUT)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(getEnv("MONGO_URI", DEFAULT_MONGO_URI)))
+	if err != nil {
+		log.Fatalf("connect to mongo: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	db := client.Database(getEnv("MONGO_DATABASE", DEFAULT_MONGO_DATABASE))
+	if err := repositories.NewHostRepository(db).EnsureIndexes(ctx); err != nil {
+		log.Fatalf("create hosts indexes: %v", err)
+	}
+	if err := repositories.NewHostMoveTaskRepository(db).EnsureIndexes(ctx); err != nil {
+		log.Fatalf("create host moves indexes: %v", err)
+	}
+}
+
+func getEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
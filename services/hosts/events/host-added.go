@@ -0,0 +1,14 @@
+package events
+
+import "github.com/gwall-e/pkg/core_entities"
+
+type HostAddedEvent struct {
+	ID              string                 `bson:"id"`
+	InventoryNumber string                 `bson:"inventory_number"`
+	FQDN            string                 `bson:"fqdn"`
+	UnitType        core_entities.UnitType `bson:"unit_type"`
+	ProjectID       string                 `bson:"project_id"`
+	State           string                 `bson:"state"`
+	Datacenter      string                 `bson:"datacenter"`
+	Rack            string                 `bson:"rack"`
+}
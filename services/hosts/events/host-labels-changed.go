@@ -0,0 +1,7 @@
+package events
+
+type HostLabelsChangedEvent struct {
+	ID     string            `bson:"id"`
+	FQDN   string            `bson:"fqdn"`
+	Labels map[string]string `bson:"labels"`
+}
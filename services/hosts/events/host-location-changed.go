@@ -0,0 +1,13 @@
+package events
+
+type HostLocationChangedEvent struct {
+	ID         string `bson:"id"`
+	FQDN       string `bson:"fqdn"`
+	Datacenter string `bson:"datacenter"`
+	Hall       string `bson:"hall"`
+	Row        string `bson:"row"`
+	Rack       string `bson:"rack"`
+	Unit       int    `bson:"unit"`
+	Switch     string `bson:"switch"`
+	Port       string `bson:"port"`
+}
@@ -0,0 +1,10 @@
+package events
+
+type HostMoveStepChangedEvent struct {
+	TaskID   string `bson:"task_id"`
+	HostID   string `bson:"host_id"`
+	Step     string `bson:"step"`
+	Status   string `bson:"status"`
+	Attempts int    `bson:"attempts"`
+	Error    string `bson:"error"`
+}
@@ -0,0 +1,9 @@
+package events
+
+type HostProjectChangedEvent struct {
+	ID     string `bson:"id"`
+	FQDN   string `bson:"fqdn"`
+	From   string `bson:"from"`
+	To     string `bson:"to"`
+	Reason string `bson:"reason"`
+}
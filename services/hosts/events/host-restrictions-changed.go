@@ -0,0 +1,9 @@
+package events
+
+import "github.com/gwall-e/pkg/core_entities"
+
+type HostRestrictionsChangedEvent struct {
+	ID           string                      `bson:"id"`
+	FQDN         string                      `bson:"fqdn"`
+	Restrictions []core_entities.Restriction `bson:"restrictions"`
+}
@@ -0,0 +1,10 @@
+package events
+
+type HostStateChangedEvent struct {
+	ID        string `bson:"id"`
+	FQDN      string `bson:"fqdn"`
+	ProjectID string `bson:"project_id"`
+	From      string `bson:"from"`
+	To        string `bson:"to"`
+	Reason    string `bson:"reason"`
+}
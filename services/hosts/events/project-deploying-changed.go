@@ -0,0 +1,10 @@
+package events
+
+type ProjectDeployingChangedEvent struct {
+	ID                string   `bson:"id"`
+	Config            string   `bson:"config"`
+	Tags              []string `bson:"tags"`
+	Network           int      `bson:"network_vlan"`
+	Policy            string   `bson:"policy"`
+	DeployCertificate bool     `bson:"deploy_certificate"`
+}
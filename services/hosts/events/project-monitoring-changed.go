@@ -0,0 +1,20 @@
+package events
+
+import (
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type MonitoringCheck struct {
+	Type             core_entities.CheckType `bson:"type"`
+	Enabled          bool                    `bson:"enabled"`
+	FailureThreshold int                     `bson:"failure_threshold"`
+	GracePeriod      time.Duration           `bson:"grace_period"`
+	AllowAutomation  bool                    `bson:"allow_automation"`
+}
+
+type ProjectMonitoringChangedEvent struct {
+	ID     string            `bson:"id"`
+	Checks []MonitoringCheck `bson:"checks"`
+}
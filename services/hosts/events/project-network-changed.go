@@ -0,0 +1,12 @@
+package events
+
+type ProjectNetworkChangedEvent struct {
+	ID                string `bson:"id"`
+	OwnedVlans        []int  `bson:"owned_vlans"`
+	VlanScheme        string `bson:"vlan_scheme"`
+	NativeVlan        int    `bson:"native_vlan"`
+	ExtraVlans        []int  `bson:"extra_vlans"`
+	DNSDomain         string `bson:"dns_domain"`
+	ShortnameTemplate string `bson:"shortname_template"`
+	HbfProjectID      int    `bson:"hbf_project_id"`
+}
@@ -0,0 +1,6 @@
+package events
+
+type ProjectNotificationChangedEvent struct {
+	ID       string   `bson:"id"`
+	Channels []string `bson:"channels"`
+}
@@ -0,0 +1,9 @@
+package events
+
+import "github.com/gwall-e/pkg/core_entities"
+
+type ProjectRestrictionsChangedEvent struct {
+	ID           string                      `bson:"id"`
+	Restrictions []core_entities.Restriction `bson:"restrictions"`
+	Effective    []core_entities.Restriction `bson:"effective"`
+}
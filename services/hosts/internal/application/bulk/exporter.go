@@ -0,0 +1,62 @@
+package bulk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+)
+
+type Exporter struct {
+	hosts HostReader
+}
+
+func NewExporter(hosts HostReader) *Exporter {
+	return &Exporter{hosts: hosts}
+}
+
+func (e *Exporter) Export(ctx context.Context, w io.Writer, format Format, filter contracts.HostFilter) (int, error) {
+	var write func(record HostRecord) error
+	var flush func() error
+
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvColumns); err != nil {
+			return 0, err
+		}
+		write = func(record HostRecord) error { return writer.Write(record.csvRow()) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case FormatJSONL:
+		encoder := json.NewEncoder(w)
+		write = func(record HostRecord) error { return encoder.Encode(record) }
+		flush = func() error { return nil }
+	default:
+		return 0, fmt.Errorf("unknown format %q", format)
+	}
+
+	count := 0
+	request := contracts.HostPageRequest{Limit: contracts.MAX_HOSTS_PAGE_SIZE}
+	for {
+		page, err := e.hosts.List(ctx, filter, request)
+		if err != nil {
+			return count, err
+		}
+		for _, host := range page.Hosts {
+			if err := write(recordFromHost(host)); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if page.NextCursor == "" {
+			return count, flush()
+		}
+		request.Cursor = page.NextCursor
+	}
+}
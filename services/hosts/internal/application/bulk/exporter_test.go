@@ -0,0 +1,90 @@
+package bulk_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/application/bulk"
+	"github.com/gwall-e/hosts/internal/domain/hosts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporter", func() {
+	var (
+		ctx      context.Context
+		repo     *memory.HostRepository
+		importer *bulk.Importer
+		exporter *bulk.Exporter
+	)
+
+	const input = `{"inventory_number":"100001","unit_type":"server","project_id":"search","fqdn":"a.search.example.net","macs":["aa:bb:cc:dd:ee:01"],"location":{"datacenter":"sas","rack":"a1","unit":2}}
+{"inventory_number":"100002","unit_type":"server","project_id":"search","fqdn":"b.search.example.net"}
+{"inventory_number":"100003","unit_type":"server"}
+`
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = memory.NewHostRepository()
+		service := hosts.NewDomainService(repo, fakeProjects{
+			"search": &projects.Project{ID: "search", Type: core_entities.TypeServer},
+		}, memory.NewHostMoveTaskRepository(), nil, nil)
+		importer = bulk.NewImporter(service, repo)
+		exporter = bulk.NewExporter(repo)
+
+		report, err := importer.Import(ctx, strings.NewReader(input), bulk.ImportOptions{Format: bulk.FormatJSONL})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Created).To(Equal(3))
+	})
+
+	It("should export hosts of a project as csv", func() {
+		var out bytes.Buffer
+		count, err := exporter.Export(ctx, &out, bulk.FormatCSV, contracts.HostFilter{ProjectID: "search"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(2))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(Equal("id,inventory_number,fqdn,unit_type,project_id,macs,datacenter,hall,row,rack,unit,switch,port,state,status"))
+		Expect(out.String()).To(ContainSubstring(",100001,a.search.example.net,server,search,aa:bb:cc:dd:ee:01,sas,,,a1,2,,,assigned,ready\n"))
+	})
+
+	It("should export hosts filtered by state as json lines", func() {
+		var out bytes.Buffer
+		count, err := exporter.Export(ctx, &out, bulk.FormatJSONL, contracts.HostFilter{
+			States: []entities.HostState{entities.HostStateFree},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(out.String()).To(ContainSubstring(`"inventory_number":"100003"`))
+		Expect(out.String()).To(ContainSubstring(`"state":"free"`))
+	})
+
+	DescribeTable("should produce files importable again without changes",
+		func(format bulk.Format) {
+			var out bytes.Buffer
+			_, err := exporter.Export(ctx, &out, format, contracts.HostFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			report, err := importer.Import(ctx, &out, bulk.ImportOptions{Format: format})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Errors).To(BeEmpty())
+			Expect(report.Skipped).To(Equal(3))
+		},
+		Entry("csv", bulk.FormatCSV),
+		Entry("json lines", bulk.FormatJSONL),
+	)
+
+	It("should parse format names", func() {
+		Expect(bulk.ParseFormat("CSV")).To(Equal(bulk.FormatCSV))
+		Expect(bulk.ParseFormat("ndjson")).To(Equal(bulk.FormatJSONL))
+		_, err := bulk.ParseFormat("xml")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,201 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	projectContracts "github.com/gwall-e/hosts/internal/domain/projects/contracts"
+)
+
+const DEFAULT_BATCH_SIZE = 500
+
+type HostRegistry interface {
+	AddHost(ctx context.Context, host *entities.Host) error
+	CheckNewHost(ctx context.Context, host *entities.Host, reserved projectContracts.HostnameChecker) error
+}
+
+type HostReader interface {
+	GetByInventoryNumber(ctx context.Context, inventoryNumber string) (*entities.Host, error)
+	List(ctx context.Context, filter contracts.HostFilter, page contracts.HostPageRequest) (*contracts.HostPage, error)
+}
+
+type RowError struct {
+	Line            int    `json:"line"`
+	InventoryNumber string `json:"inventory_number,omitempty"`
+	Message         string `json:"message"`
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+type ImportProgress struct {
+	Processed int
+	Created   int
+	Skipped   int
+	Failed    int
+}
+
+type ImportReport struct {
+	DryRun  bool       `json:"dry_run"`
+	Total   int        `json:"total"`
+	Created int        `json:"created"`
+	Skipped int        `json:"skipped"`
+	Failed  int        `json:"failed"`
+	Errors  []RowError `json:"errors"`
+}
+
+type ImportOptions struct {
+	Format    Format
+	DryRun    bool
+	BatchSize int
+	Progress  func(ImportProgress)
+}
+
+type Importer struct {
+	registry HostRegistry
+	hosts    HostReader
+}
+
+func NewImporter(registry HostRegistry, hosts HostReader) *Importer {
+	return &Importer{registry: registry, hosts: hosts}
+}
+
+func (i *Importer) Import(ctx context.Context, r io.Reader, options ImportOptions) (*ImportReport, error) {
+	reader, err := newRowReader(r, options.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = DEFAULT_BATCH_SIZE
+	}
+
+	report := &ImportReport{DryRun: options.DryRun, Errors: []RowError{}}
+	seen := newSeenValues()
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		rows, err := readBatch(reader, batchSize)
+		if err != nil {
+			return report, err
+		}
+		if len(rows) == 0 {
+			return report, nil
+		}
+
+		for _, row := range rows {
+			created, err := i.importRow(ctx, row, seen, options.DryRun)
+			switch {
+			case err != nil:
+				report.Failed++
+				report.Errors = append(report.Errors, RowError{
+					Line:            row.Line,
+					InventoryNumber: row.Record.InventoryNumber,
+					Message:         err.Error(),
+				})
+			case created:
+				report.Created++
+			default:
+				report.Skipped++
+			}
+		}
+		report.Total += len(rows)
+
+		if options.Progress != nil {
+			options.Progress(ImportProgress{
+				Processed: report.Total,
+				Created:   report.Created,
+				Skipped:   report.Skipped,
+				Failed:    report.Failed,
+			})
+		}
+	}
+}
+
+func (i *Importer) importRow(ctx context.Context, row row, seen *seenValues, dryRun bool) (bool, error) {
+	if row.Err != nil {
+		return false, row.Err
+	}
+
+	host, err := row.Record.toHost()
+	if err != nil {
+		return false, err
+	}
+	if err := seen.add(row.Line, host); err != nil {
+		return false, err
+	}
+
+	stored, err := i.hosts.GetByInventoryNumber(ctx, host.InventoryNumber)
+	if err != nil {
+		return false, err
+	}
+	if stored != nil {
+		if diff := diffHosts(host, stored); len(diff) > 0 {
+			return false, fmt.Errorf("host is already registered with different %s", strings.Join(diff, ", "))
+		}
+		return false, nil
+	}
+
+	rendered := host.FQDN == ""
+	if dryRun {
+		err = i.registry.CheckNewHost(ctx, host, seen)
+	} else {
+		err = i.registry.AddHost(ctx, host)
+	}
+	if err != nil {
+		return false, err
+	}
+	if rendered && host.FQDN != "" {
+		seen.values[fqdnKey(host.FQDN)] = row.Line
+	}
+	return true, nil
+}
+
+type seenValues struct {
+	values map[string]int
+}
+
+func newSeenValues() *seenValues {
+	return &seenValues{values: map[string]int{}}
+}
+
+func (s *seenValues) FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error) {
+	var existing []string
+	for _, fqdn := range fqdns {
+		if _, ok := s.values[fqdnKey(fqdn)]; ok {
+			existing = append(existing, fqdn)
+		}
+	}
+	return existing, nil
+}
+
+func (s *seenValues) add(line int, host *entities.Host) error {
+	keys := []string{"inventory number " + host.InventoryNumber}
+	if host.FQDN != "" {
+		keys = append(keys, fqdnKey(host.FQDN))
+	}
+	for _, mac := range host.MACs {
+		keys = append(keys, "mac "+mac)
+	}
+
+	for _, key := range keys {
+		if first, ok := s.values[key]; ok {
+			return fmt.Errorf("%s is already used on line %d", key, first)
+		}
+	}
+	for _, key := range keys {
+		s.values[key] = line
+	}
+	return nil
+}
+
+func fqdnKey(fqdn string) string {
+	return "fqdn " + fqdn
+}
@@ -0,0 +1,207 @@
+package bulk_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/application/bulk"
+	"github.com/gwall-e/hosts/internal/domain/hosts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Importer", func() {
+	var (
+		ctx      context.Context
+		repo     *memory.HostRepository
+		importer *bulk.Importer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = memory.NewHostRepository()
+		service := hosts.NewDomainService(repo, fakeProjects{
+			"search": &projects.Project{
+				ID:   "search",
+				Type: core_entities.TypeServer,
+				Network: &projectEntities.Network{
+					DNSDomain:         "search.example.net",
+					ShortnameTemplate: "{location}-{index:03d}",
+				},
+			},
+		}, memory.NewHostMoveTaskRepository(), nil, nil)
+		importer = bulk.NewImporter(service, repo)
+	})
+
+	const validCSV = `inventory_number,unit_type,project_id,macs,datacenter,rack,unit
+100001,server,search,aa:bb:cc:dd:ee:01;aa:bb:cc:dd:ee:02,sas,a1,1
+100002,server,search,,sas,a1,3
+100003,server,,,vla,,
+`
+
+	It("should import csv rows rendering fqdns", func() {
+		report, err := importer.Import(ctx, strings.NewReader(validCSV), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(Equal(&bulk.ImportReport{Total: 3, Created: 3, Errors: []bulk.RowError{}}))
+
+		host, err := repo.GetByInventoryNumber(ctx, "100002")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.FQDN).To(Equal("sas-002.search.example.net"))
+		Expect(host.State).To(Equal(entities.HostStateAssigned))
+		Expect(host.Location).To(Equal(entities.HostLocation{Datacenter: "sas", Rack: "a1", Unit: 3}))
+
+		free, err := repo.GetByInventoryNumber(ctx, "100003")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(free.State).To(Equal(entities.HostStateFree))
+	})
+
+	It("should be idempotent on inventory number", func() {
+		_, err := importer.Import(ctx, strings.NewReader(validCSV), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := importer.Import(ctx, strings.NewReader(validCSV), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Created).To(Equal(0))
+		Expect(report.Skipped).To(Equal(3))
+	})
+
+	It("should report rows conflicting with registered hosts", func() {
+		_, err := importer.Import(ctx, strings.NewReader(validCSV), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := importer.Import(ctx, strings.NewReader(`inventory_number,unit_type,project_id,datacenter
+100003,server,search,man
+`), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Errors).To(Equal([]bulk.RowError{{
+			Line:            2,
+			InventoryNumber: "100003",
+			Message:         "host is already registered with different project_id, location",
+		}}))
+	})
+
+	It("should report row errors and import the valid rows", func() {
+		input := `inventory_number,unit_type,project_id,macs,rack,unit
+100001,server,,,,
+100002,router,,,,
+100003,server,unknown,,,
+100004,server,,not-a-mac,,
+100005,server,,,,x
+100006,server,,,a1,
+100001,server,,,,
+100007,server
+100008,vm,search,,,
+`
+		report, err := importer.Import(ctx, strings.NewReader(input), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Total).To(Equal(9))
+		Expect(report.Created).To(Equal(1))
+		Expect(report.Failed).To(Equal(8))
+
+		lines := make([]int, 0, len(report.Errors))
+		for _, rowErr := range report.Errors {
+			lines = append(lines, rowErr.Line)
+		}
+		Expect(lines).To(Equal([]int{3, 4, 5, 6, 7, 8, 9, 10}))
+		Expect(report.Errors[0].Message).To(ContainSubstring("unit_type"))
+		Expect(report.Errors[1].Message).To(ContainSubstring("project not found"))
+		Expect(report.Errors[5].Message).To(Equal("inventory number 100001 is already used on line 2"))
+		Expect(report.Errors[7].Message).To(ContainSubstring("accepts only server hosts"))
+	})
+
+	It("should validate without changes in dry-run", func() {
+		report, err := importer.Import(ctx, strings.NewReader(validCSV+"100004,vm,search,,,,\n"), bulk.ImportOptions{
+			Format: bulk.FormatCSV,
+			DryRun: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.DryRun).To(BeTrue())
+		Expect(report.Created).To(Equal(3))
+		Expect(report.Failed).To(Equal(1))
+		Expect(repo.GetByInventoryNumber(ctx, "100001")).To(BeNil())
+	})
+
+	It("should render distinct fqdns in dry-run", func() {
+		input := `inventory_number,unit_type,project_id,fqdn,datacenter
+100001,server,search,,sas
+100002,server,search,sas-002.search.example.net,sas
+100003,server,search,,sas
+100004,server,search,sas-001.search.example.net,sas
+100005,server,search,,
+`
+		report, err := importer.Import(ctx, strings.NewReader(input), bulk.ImportOptions{Format: bulk.FormatCSV, DryRun: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Created).To(Equal(3))
+		Expect(report.Errors).To(HaveLen(2))
+		Expect(report.Errors[0]).To(Equal(bulk.RowError{
+			Line:            5,
+			InventoryNumber: "100004",
+			Message:         "fqdn sas-001.search.example.net is already used on line 2",
+		}))
+		Expect(report.Errors[1].Line).To(Equal(6))
+		Expect(report.Errors[1].Message).To(ContainSubstring("location"))
+
+		report, err = importer.Import(ctx, strings.NewReader(input), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Created).To(Equal(3))
+		host, err := repo.GetByInventoryNumber(ctx, "100003")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.FQDN).To(Equal("sas-003.search.example.net"))
+	})
+
+	It("should import json lines", func() {
+		input := `{"inventory_number":"100001","unit_type":"server","project_id":"search","fqdn":"custom.search.example.net","location":{"datacenter":"sas"}}
+
+{"inventory_number":"100002","unit_type":"server","macs":["AA-BB-CC-DD-EE-01"]}
+{"inventory_number":"100003","unit_type":"server","color":"red"}
+{"inventory_number":
+`
+		report, err := importer.Import(ctx, strings.NewReader(input), bulk.ImportOptions{Format: bulk.FormatJSONL})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Created).To(Equal(2))
+		Expect(report.Errors).To(HaveLen(2))
+		Expect(report.Errors[0].Line).To(Equal(4))
+		Expect(report.Errors[0].Message).To(ContainSubstring("color"))
+		Expect(report.Errors[1].Line).To(Equal(5))
+
+		host, err := repo.GetByMAC(ctx, "aa:bb:cc:dd:ee:01")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.InventoryNumber).To(Equal("100002"))
+	})
+
+	It("should report progress per batch", func() {
+		var progress []bulk.ImportProgress
+		_, err := importer.Import(ctx, strings.NewReader(validCSV), bulk.ImportOptions{
+			Format:    bulk.FormatCSV,
+			BatchSize: 2,
+			Progress:  func(p bulk.ImportProgress) { progress = append(progress, p) },
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress).To(Equal([]bulk.ImportProgress{
+			{Processed: 2, Created: 2},
+			{Processed: 3, Created: 3},
+		}))
+	})
+
+	It("should stop when context is cancelled", func() {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := importer.Import(cancelled, strings.NewReader(validCSV), bulk.ImportOptions{Format: bulk.FormatCSV})
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	DescribeTable("should reject unreadable input",
+		func(input string, message string) {
+			_, err := importer.Import(ctx, strings.NewReader(input), bulk.ImportOptions{Format: bulk.FormatCSV})
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("empty input", "", "header is missing"),
+		Entry("unknown column", "inventory_number,unit_type,color\n", `unknown csv column "color"`),
+		Entry("missing required column", "inventory_number\n", `required csv column "unit_type"`),
+	)
+})
@@ -0,0 +1,130 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+type row struct {
+	Line   int
+	Record HostRecord
+	Err    error
+}
+
+type rowReader interface {
+	Next() (row, error)
+}
+
+func newRowReader(r io.Reader, format Format) (rowReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		return newJSONLReader(r), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func readBatch(reader rowReader, size int) ([]row, error) {
+	batch := make([]row, 0, size)
+	for len(batch) < size {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, row)
+	}
+	return batch, nil
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv header is missing")
+		}
+		return nil, err
+	}
+
+	header := make(map[string]int, len(columns))
+	for i, column := range columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(csvColumns, column) {
+			return nil, fmt.Errorf("unknown csv column %q", column)
+		}
+		if _, ok := header[column]; ok {
+			return nil, fmt.Errorf("csv column %q is duplicated", column)
+		}
+		header[column] = i
+	}
+	for _, column := range requiredCSVColumns {
+		if _, ok := header[column]; !ok {
+			return nil, fmt.Errorf("required csv column %q is missing", column)
+		}
+	}
+	return &csvReader{reader: reader, header: header}, nil
+}
+
+func (r *csvReader) Next() (row, error) {
+	values, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return row{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+		}
+		return row{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	record, err := recordFromCSV(r.header, values)
+	return row{Line: line, Record: record, Err: err}, nil
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &jsonlReader{scanner: scanner}
+}
+
+func (r *jsonlReader) Next() (row, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var record HostRecord
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&record)
+		return row{Line: r.line, Record: record, Err: err}, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return row{}, err
+	}
+	return row{}, io.EOF
+}
@@ -0,0 +1,170 @@
+package bulk
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSONL, "jsonlines", "ndjson":
+		return FormatJSONL, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected csv or jsonl", value)
+}
+
+type LocationRecord struct {
+	Datacenter string `json:"datacenter,omitempty"`
+	Hall       string `json:"hall,omitempty"`
+	Row        string `json:"row,omitempty"`
+	Rack       string `json:"rack,omitempty"`
+	Unit       int    `json:"unit,omitempty"`
+	Switch     string `json:"switch,omitempty"`
+	Port       string `json:"port,omitempty"`
+}
+
+type HostRecord struct {
+	ID              string         `json:"id,omitempty"`
+	InventoryNumber string         `json:"inventory_number"`
+	FQDN            string         `json:"fqdn,omitempty"`
+	UnitType        string         `json:"unit_type"`
+	ProjectID       string         `json:"project_id,omitempty"`
+	MACs            []string       `json:"macs,omitempty"`
+	Location        LocationRecord `json:"location"`
+	State           string         `json:"state,omitempty"`
+	Status          string         `json:"status,omitempty"`
+}
+
+var csvColumns = []string{
+	"id", "inventory_number", "fqdn", "unit_type", "project_id", "macs",
+	"datacenter", "hall", "row", "rack", "unit", "switch", "port", "state", "status",
+}
+
+var requiredCSVColumns = []string{"inventory_number", "unit_type"}
+
+func recordFromHost(host *entities.Host) HostRecord {
+	return HostRecord{
+		ID:              host.ID,
+		InventoryNumber: host.InventoryNumber,
+		FQDN:            host.FQDN,
+		UnitType:        string(host.UnitType),
+		ProjectID:       host.ProjectID,
+		MACs:            slices.Clone(host.MACs),
+		Location: LocationRecord{
+			Datacenter: host.Location.Datacenter,
+			Hall:       host.Location.Hall,
+			Row:        host.Location.Row,
+			Rack:       host.Location.Rack,
+			Unit:       host.Location.Unit,
+			Switch:     host.Location.Switch,
+			Port:       host.Location.Port,
+		},
+		State:  string(host.State),
+		Status: string(host.Status),
+	}
+}
+
+func (r HostRecord) location() entities.HostLocation {
+	return entities.HostLocation{
+		Datacenter: r.Location.Datacenter,
+		Hall:       r.Location.Hall,
+		Row:        r.Location.Row,
+		Rack:       r.Location.Rack,
+		Unit:       r.Location.Unit,
+		Switch:     r.Location.Switch,
+		Port:       r.Location.Port,
+	}
+}
+
+func (r HostRecord) toHost() (*entities.Host, error) {
+	host, err := entities.NewHost(r.InventoryNumber, r.FQDN, core_entities.UnitType(r.UnitType), r.location(), r.MACs)
+	if err != nil {
+		return nil, err
+	}
+	host.ProjectID = r.ProjectID
+	return host, nil
+}
+
+func (r HostRecord) csvRow() []string {
+	unit := ""
+	if r.Location.Unit != 0 {
+		unit = strconv.Itoa(r.Location.Unit)
+	}
+	return []string{
+		r.ID, r.InventoryNumber, r.FQDN, r.UnitType, r.ProjectID, strings.Join(r.MACs, ";"),
+		r.Location.Datacenter, r.Location.Hall, r.Location.Row, r.Location.Rack, unit,
+		r.Location.Switch, r.Location.Port, r.State, r.Status,
+	}
+}
+
+func recordFromCSV(header map[string]int, row []string) (HostRecord, error) {
+	value := func(column string) string {
+		if i, ok := header[column]; ok {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	record := HostRecord{
+		InventoryNumber: value("inventory_number"),
+		FQDN:            value("fqdn"),
+		UnitType:        value("unit_type"),
+		ProjectID:       value("project_id"),
+		MACs: strings.FieldsFunc(value("macs"), func(r rune) bool {
+			return r == ';' || r == ' '
+		}),
+		Location: LocationRecord{
+			Datacenter: value("datacenter"),
+			Hall:       value("hall"),
+			Row:        value("row"),
+			Rack:       value("rack"),
+			Switch:     value("switch"),
+			Port:       value("port"),
+		},
+	}
+	if unit := value("unit"); unit != "" {
+		n, err := strconv.Atoi(unit)
+		if err != nil {
+			return record, fmt.Errorf("unit %q is not a number", unit)
+		}
+		record.Location.Unit = n
+	}
+	return record, nil
+}
+
+func diffHosts(imported *entities.Host, stored *entities.Host) []string {
+	var diff []string
+	if imported.FQDN != "" && imported.FQDN != stored.FQDN {
+		diff = append(diff, "fqdn")
+	}
+	if imported.UnitType != stored.UnitType {
+		diff = append(diff, "unit_type")
+	}
+	if imported.ProjectID != stored.ProjectID {
+		diff = append(diff, "project_id")
+	}
+	if imported.Location != stored.Location {
+		diff = append(diff, "location")
+	}
+	importedMACs, storedMACs := slices.Clone(imported.MACs), slices.Clone(stored.MACs)
+	slices.Sort(importedMACs)
+	slices.Sort(storedMACs)
+	if !slices.Equal(importedMACs, storedMACs) {
+		diff = append(diff, "macs")
+	}
+	return diff
+}
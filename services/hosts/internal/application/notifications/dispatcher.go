@@ -0,0 +1,196 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+const (
+	DEFAULT_SEND_ATTEMPTS        = 3
+	DEFAULT_RETRY_DELAY          = time.Second
+	DEFAULT_DEDUPLICATION_WINDOW = 10 * time.Minute
+)
+
+type SetupFunc func(*Dispatcher)
+
+type Dispatcher struct {
+	projects    ProjectProvider
+	senders     map[entities.NotificationChannelType]Sender
+	attempts    int
+	retryDelay  time.Duration
+	dedupWindow time.Duration
+	now         func() time.Time
+
+	mu         sync.Mutex
+	deliveries map[string]delivery
+}
+
+type delivery struct {
+	sentAt    time.Time
+	confirmed bool
+}
+
+func WithSender(channelType entities.NotificationChannelType, sender Sender) SetupFunc {
+	return func(d *Dispatcher) {
+		d.senders[channelType] = sender
+	}
+}
+
+func WithRetry(attempts int, delay time.Duration) SetupFunc {
+	return func(d *Dispatcher) {
+		d.attempts = attempts
+		d.retryDelay = delay
+	}
+}
+
+func WithDeduplicationWindow(window time.Duration) SetupFunc {
+	return func(d *Dispatcher) {
+		d.dedupWindow = window
+	}
+}
+
+func WithClock(now func() time.Time) SetupFunc {
+	return func(d *Dispatcher) {
+		d.now = now
+	}
+}
+
+func NewDispatcher(projects ProjectProvider, setup ...SetupFunc) *Dispatcher {
+	d := &Dispatcher{
+		projects:    projects,
+		senders:     map[entities.NotificationChannelType]Sender{},
+		attempts:    DEFAULT_SEND_ATTEMPTS,
+		retryDelay:  DEFAULT_RETRY_DELAY,
+		dedupWindow: DEFAULT_DEDUPLICATION_WINDOW,
+		now:         time.Now,
+		deliveries:  map[string]delivery{},
+	}
+
+	for _, fn := range setup {
+		fn(d)
+	}
+
+	return d
+}
+
+func (d *Dispatcher) Dispatch(ctx context.Context, events ...interface{}) error {
+	var errs []error
+	for _, event := range events {
+		message, ok := messageFromEvent(event)
+		if !ok {
+			continue
+		}
+		if err := d.Send(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) Send(ctx context.Context, message Message) error {
+	project, err := d.projects.GetProject(ctx, message.ProjectID)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, channel := range project.Notification.Resolve(message.EventType, message.Severity) {
+		if err := d.sendToChannel(ctx, channel, message); err != nil {
+			errs = append(errs, fmt.Errorf("channel %s: %w", channel.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) sendToChannel(ctx context.Context, channel entities.NotificationChannel, message Message) error {
+	sender, ok := d.senders[channel.Type]
+	if !ok {
+		return fmt.Errorf("no sender for channel type %s", channel.Type)
+	}
+
+	var errs []error
+	for _, recipient := range channel.Recipients {
+		if err := d.sendToRecipient(ctx, sender, channel, recipient, message); err != nil {
+			errs = append(errs, fmt.Errorf("recipient %s: %w", recipient, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) sendToRecipient(ctx context.Context, sender Sender, channel entities.NotificationChannel, recipient string, message Message) error {
+	key := message.ProjectID + "/" + channel.Name + "/" + recipient + "/" + message.DedupKey
+	if message.DedupKey != "" && !d.reserve(key) {
+		return nil
+	}
+
+	single := channel
+	single.Recipients = []string{recipient}
+	err := d.sendWithRetry(ctx, sender, single, message)
+	if message.DedupKey != "" {
+		if err == nil {
+			d.confirm(key)
+		} else {
+			d.release(key)
+		}
+	}
+	return err
+}
+
+func (d *Dispatcher) sendWithRetry(ctx context.Context, sender Sender, channel entities.NotificationChannel, message Message) error {
+	delay := d.retryDelay
+	var err error
+	for attempt := 1; attempt <= d.attempts; attempt++ {
+		err = sender.Send(ctx, channel, message)
+		if err == nil {
+			return nil
+		}
+		if attempt == d.attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return err
+}
+
+func (d *Dispatcher) reserve(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for k, delivery := range d.deliveries {
+		if delivery.confirmed && now.Sub(delivery.sentAt) >= d.dedupWindow {
+			delete(d.deliveries, k)
+		}
+	}
+	if _, ok := d.deliveries[key]; ok {
+		return false
+	}
+	d.deliveries[key] = delivery{sentAt: now}
+	return true
+}
+
+func (d *Dispatcher) confirm(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deliveries[key] = delivery{sentAt: d.now(), confirmed: true}
+}
+
+func (d *Dispatcher) release(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.deliveries, key)
+}
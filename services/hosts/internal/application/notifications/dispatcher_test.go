@@ -0,0 +1,164 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gwall-e/hosts/events"
+	. "github.com/gwall-e/hosts/internal/application/notifications"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeProjectProvider struct {
+	projects map[string]*projects.Project
+}
+
+func (p *fakeProjectProvider) GetProject(ctx context.Context, id string) (*projects.Project, error) {
+	return p.projects[id], nil
+}
+
+type fakeSender struct {
+	sent       []string
+	recipients []string
+	failures   int
+	broken     map[string]bool
+	onSend     func()
+}
+
+func (s *fakeSender) Send(ctx context.Context, channel entities.NotificationChannel, message Message) error {
+	if s.onSend != nil {
+		onSend := s.onSend
+		s.onSend = nil
+		onSend()
+	}
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("temporary failure")
+	}
+	for _, recipient := range channel.Recipients {
+		if s.broken[recipient] {
+			return errors.New("recipient is unreachable")
+		}
+	}
+	s.sent = append(s.sent, channel.Name+":"+message.EventType)
+	s.recipients = append(s.recipients, channel.Recipients...)
+	return nil
+}
+
+var _ = Describe("Dispatcher", func() {
+	var (
+		provider   *fakeProjectProvider
+		email      *fakeSender
+		webhook    *fakeSender
+		now        time.Time
+		dispatcher *Dispatcher
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		provider = &fakeProjectProvider{projects: map[string]*projects.Project{
+			"search": {
+				ID: "search",
+				Notification: &entities.Notification{
+					Channels: []entities.NotificationChannel{
+						{Name: "owners", Type: entities.NotificationChannelEmail, Recipients: []string{"owners@example.com"}},
+						{Name: "alerts", Type: entities.NotificationChannelWebhook, Recipients: []string{"http://example.com/hook"}, MinSeverity: entities.NotificationSeverityWarning},
+					},
+					Routes: []entities.NotificationRoute{
+						{EventType: entities.NotificationEventAny, Channels: []string{"owners"}},
+						{EventType: EventProjectNetworkChanged, Channels: []string{"alerts", "owners"}},
+						{EventType: EventProjectAdded, Channels: []string{"alerts"}},
+					},
+				},
+			},
+		}}
+		email = &fakeSender{}
+		webhook = &fakeSender{}
+		dispatcher = NewDispatcher(provider,
+			WithSender(entities.NotificationChannelEmail, email),
+			WithSender(entities.NotificationChannelWebhook, webhook),
+			WithRetry(3, time.Millisecond),
+			WithDeduplicationWindow(time.Minute),
+			WithClock(func() time.Time { return now }),
+		)
+	})
+
+	It("should route events by type and severity", func() {
+		err := dispatcher.Dispatch(context.Background(),
+			&events.ProjectAddedEvent{ID: "search"},
+			&events.ProjectNetworkChangedEvent{ID: "search", NativeVlan: 100},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(email.sent).To(Equal([]string{"owners:" + EventProjectAdded, "owners:" + EventProjectNetworkChanged}))
+		Expect(webhook.sent).To(Equal([]string{"alerts:" + EventProjectNetworkChanged}))
+	})
+
+	It("should skip unknown events and projects", func() {
+		Expect(dispatcher.Dispatch(context.Background(), "unknown", &events.ProjectAddedEvent{ID: "missing"})).To(Succeed())
+		Expect(email.sent).To(BeEmpty())
+	})
+
+	It("should retry failed deliveries", func() {
+		email.failures = 2
+		Expect(dispatcher.Dispatch(context.Background(), &events.ProjectAddedEvent{ID: "search"})).To(Succeed())
+		Expect(email.sent).To(HaveLen(1))
+	})
+
+	It("should return error when attempts are exhausted", func() {
+		email.failures = 3
+		err := dispatcher.Dispatch(context.Background(), &events.ProjectAddedEvent{ID: "search"})
+		Expect(err).To(MatchError(ContainSubstring("channel owners")))
+		Expect(email.sent).To(BeEmpty())
+	})
+
+	It("should deduplicate identical events within the window", func() {
+		event := &events.ProjectNetworkChangedEvent{ID: "search", NativeVlan: 100}
+		Expect(dispatcher.Dispatch(context.Background(), event, event)).To(Succeed())
+		Expect(email.sent).To(HaveLen(1))
+
+		now = now.Add(2 * time.Minute)
+		Expect(dispatcher.Dispatch(context.Background(), event)).To(Succeed())
+		Expect(email.sent).To(HaveLen(2))
+	})
+
+	It("should retry only recipients that failed", func() {
+		channel := &provider.projects["search"].Notification.Channels[0]
+		channel.Recipients = []string{"owners@example.com", "broken@example.com", "oncall@example.com"}
+		email.broken = map[string]bool{"broken@example.com": true}
+
+		err := dispatcher.Dispatch(context.Background(), &events.ProjectAddedEvent{ID: "search"})
+		Expect(err).To(MatchError(ContainSubstring("recipient broken@example.com")))
+		Expect(email.recipients).To(Equal([]string{"owners@example.com", "oncall@example.com"}))
+
+		delete(email.broken, "broken@example.com")
+		Expect(dispatcher.Dispatch(context.Background(), &events.ProjectAddedEvent{ID: "search"})).To(Succeed())
+		Expect(email.recipients).To(Equal([]string{"owners@example.com", "oncall@example.com", "broken@example.com"}))
+	})
+
+	It("should not deliver a message again while its delivery is in progress", func() {
+		event := &events.ProjectNetworkChangedEvent{ID: "search", NativeVlan: 100}
+		email.onSend = func() {
+			Expect(dispatcher.Dispatch(context.Background(), event)).To(Succeed())
+		}
+		Expect(dispatcher.Dispatch(context.Background(), event)).To(Succeed())
+		Expect(email.sent).To(HaveLen(1))
+	})
+
+	It("should deliver a message again after its delivery failed", func() {
+		event := &events.ProjectNetworkChangedEvent{ID: "search", NativeVlan: 100}
+		email.failures = 3
+		Expect(dispatcher.Dispatch(context.Background(), event)).NotTo(Succeed())
+		Expect(dispatcher.Dispatch(context.Background(), event)).To(Succeed())
+		Expect(email.sent).To(HaveLen(1))
+	})
+
+	It("should fail for channels without sender", func() {
+		dispatcher = NewDispatcher(provider, WithSender(entities.NotificationChannelEmail, email))
+		err := dispatcher.Dispatch(context.Background(), &events.ProjectNetworkChangedEvent{ID: "search"})
+		Expect(err).To(MatchError(ContainSubstring("no sender")))
+	})
+})
@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+const (
+	EventProjectAdded               = "project.added"
+	EventProjectNetworkChanged      = "project.network_changed"
+	EventProjectDeployingChanged    = "project.deploying_changed"
+	EventProjectNotificationChanged = "project.notification_changed"
+	EventProjectMonitoringChanged   = "project.monitoring_changed"
+)
+
+type Message struct {
+	ProjectID string
+	EventType string
+	Severity  entities.NotificationSeverity
+	Subject   string
+	Body      string
+	DedupKey  string
+}
+
+type Sender interface {
+	Send(ctx context.Context, channel entities.NotificationChannel, message Message) error
+}
+
+type ProjectProvider interface {
+	GetProject(ctx context.Context, id string) (*projects.Project, error)
+}
+
+func messageFromEvent(event interface{}) (Message, bool) {
+	var (
+		projectID string
+		eventType string
+		severity  entities.NotificationSeverity
+	)
+
+	switch e := event.(type) {
+	case *events.ProjectAddedEvent:
+		projectID, eventType, severity = e.ID, EventProjectAdded, entities.NotificationSeverityInfo
+	case *events.ProjectNetworkChangedEvent:
+		projectID, eventType, severity = e.ID, EventProjectNetworkChanged, entities.NotificationSeverityWarning
+	case *events.ProjectDeployingChangedEvent:
+		projectID, eventType, severity = e.ID, EventProjectDeployingChanged, entities.NotificationSeverityInfo
+	case *events.ProjectNotificationChangedEvent:
+		projectID, eventType, severity = e.ID, EventProjectNotificationChanged, entities.NotificationSeverityInfo
+	case *events.ProjectMonitoringChangedEvent:
+		projectID, eventType, severity = e.ID, EventProjectMonitoringChanged, entities.NotificationSeverityInfo
+	default:
+		return Message{}, false
+	}
+
+	body, err := json.MarshalIndent(event, "", "  ")
+	if err != nil {
+		return Message{}, false
+	}
+	hash := sha256.Sum256(body)
+
+	return Message{
+		ProjectID: projectID,
+		EventType: eventType,
+		Severity:  severity,
+		Subject:   fmt.Sprintf("[%s] %s: %s", severity, projectID, eventType),
+		Body:      string(body),
+		DedupKey:  eventType + ":" + hex.EncodeToString(hash[:]),
+	}, true
+}
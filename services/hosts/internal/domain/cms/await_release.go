@@ -0,0 +1,35 @@
+package cms
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+func (s *CMSService) AwaitRelease(ctx context.Context, project *projects.Project, taskID string) (*entities.CMSTask, error) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		task, err := s.GetRelease(ctx, project, taskID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch task.Status {
+		case entities.CMSTaskApproved:
+			return task, nil
+		case entities.CMSTaskRejected:
+			return task, &cmsErrors.TaskRejectedError{TaskID: taskID, Message: task.Message}
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package cms_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCMSSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CMS Domain Suite")
+}
@@ -0,0 +1,25 @@
+package cms
+
+import (
+	"context"
+	"errors"
+
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+func (s *CMSService) CompleteRelease(ctx context.Context, project *projects.Project, taskID string) error {
+	clients, err := s.clients(project)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, c := range clients {
+		err := c.client.DeleteTask(ctx, taskID)
+		if err != nil && !errors.Is(err, cmsErrors.ErrTaskNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
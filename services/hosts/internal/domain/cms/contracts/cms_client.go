@@ -0,0 +1,19 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+type CMSClient interface {
+	CreateTask(ctx context.Context, task *entities.CMSTask) (*entities.CMSTask, error)
+	GetTask(ctx context.Context, id string) (*entities.CMSTask, error)
+	DeleteTask(ctx context.Context, id string) error
+	ListTasks(ctx context.Context) ([]entities.CMSTask, error)
+}
+
+type CMSClientFactory interface {
+	NewClient(cms projectEntities.CMS) (CMSClient, error)
+}
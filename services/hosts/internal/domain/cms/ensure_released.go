@@ -0,0 +1,24 @@
+package cms
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+func (s *CMSService) EnsureReleased(ctx context.Context, project *projects.Project, taskID string, host string) error {
+	task, err := s.GetRelease(ctx, project, taskID)
+	if err != nil {
+		return err
+	}
+
+	if task.Status != entities.CMSTaskApproved {
+		return &cmsErrors.HostNotReleasedError{TaskID: taskID, Host: host}
+	}
+	if task.Hosts != nil && !task.HasHost(host) {
+		return &cmsErrors.HostNotReleasedError{TaskID: taskID, Host: host}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package entities
+
+type CMSAction string
+
+const (
+	CMSActionReboot      CMSAction = "reboot"
+	CMSActionRedeploy    CMSAction = "redeploy"
+	CMSActionProfile     CMSAction = "profile"
+	CMSActionMaintenance CMSAction = "maintenance"
+)
+
+func (a CMSAction) IsValid() bool {
+	switch a {
+	case CMSActionReboot, CMSActionRedeploy, CMSActionProfile, CMSActionMaintenance:
+		return true
+	}
+	return false
+}
+
+type CMSTaskStatus string
+
+const (
+	CMSTaskInProcess CMSTaskStatus = "in-process"
+	CMSTaskApproved  CMSTaskStatus = "ok"
+	CMSTaskRejected  CMSTaskStatus = "rejected"
+)
+
+type CMSTask struct {
+	ID      string        `bson:"id"`
+	Action  CMSAction     `bson:"action"`
+	Hosts   []string      `bson:"hosts"`
+	Issuer  string        `bson:"issuer"`
+	Status  CMSTaskStatus `bson:"status"`
+	Message string        `bson:"message"`
+}
+
+func (t *CMSTask) HasHost(host string) bool {
+	for _, h := range t.Hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
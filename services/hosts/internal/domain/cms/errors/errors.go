@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTaskNotFound       = errors.New("cms task not found")
+	ErrCMSNotConfigured   = errors.New("project has no enabled cms and deactivation without cms is not allowed")
+	ErrUnsupportedVersion = errors.New("unsupported cms protocol version")
+)
+
+type BusyHostsLimitError struct {
+	Limit     int
+	Busy      int
+	Requested int
+}
+
+func (e *BusyHostsLimitError) Error() string {
+	return fmt.Sprintf("cms busy hosts limit exceeded: limit %d, busy %d, requested %d", e.Limit, e.Busy, e.Requested)
+}
+
+type TaskRejectedError struct {
+	TaskID  string
+	Message string
+}
+
+func (e *TaskRejectedError) Error() string {
+	return fmt.Sprintf("cms rejected task %s: %s", e.TaskID, e.Message)
+}
+
+type HostNotReleasedError struct {
+	TaskID string
+	Host   string
+}
+
+func (e *HostNotReleasedError) Error() string {
+	return fmt.Sprintf("host %s is not released by cms task %s", e.Host, e.TaskID)
+}
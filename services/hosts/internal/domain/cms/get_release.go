@@ -0,0 +1,40 @@
+package cms
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+func (s *CMSService) GetRelease(ctx context.Context, project *projects.Project, taskID string) (*entities.CMSTask, error) {
+	clients, err := s.clients(project)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &entities.CMSTask{ID: taskID, Status: entities.CMSTaskInProcess}
+	if len(clients) == 0 {
+		if !deactivationWithoutCMSAllowed(project) {
+			return nil, cmsErrors.ErrCMSNotConfigured
+		}
+		task.Status = entities.CMSTaskApproved
+		return task, nil
+	}
+
+	statuses := make([]*entities.CMSTask, 0, len(clients))
+	for _, c := range clients {
+		status, err := c.client.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if len(task.Hosts) == 0 {
+			task.Action = status.Action
+			task.Hosts = status.Hosts
+			task.Issuer = status.Issuer
+		}
+		statuses = append(statuses, status)
+	}
+	return aggregate(task, statuses), nil
+}
@@ -0,0 +1,146 @@
+package cms_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/gwall-e/hosts/internal/domain/cms"
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+	infraCMS "github.com/gwall-e/hosts/internal/infrastructure/cms"
+	"github.com/gwall-e/hosts/internal/infrastructure/cms/cmstest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CMSService", func() {
+	for _, version := range []string{infraCMS.CMS_VERSION_V1, infraCMS.CMS_VERSION_V2} {
+		version := version
+
+		Context("with protocol "+version, func() {
+			var (
+				server  *cmstest.Server
+				project *projects.Project
+				service *CMSService
+				ctx     context.Context
+			)
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				server = cmstest.NewServer()
+				server.RequireAuthorization("OAuth secret")
+				project = &projects.Project{
+					ID: "search",
+					CMS: []projectEntities.CMS{{
+						Enabled:      true,
+						URL:          server.URL,
+						Version:      version,
+						MaxBusyHosts: 3,
+						Auth:         projectEntities.CMSAuth{Type: "oauth", Value: "secret"},
+					}},
+				}
+				service = NewDomainService(infraCMS.NewClientFactory(), time.Millisecond)
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("should not release hosts until CMS approves the task", func() {
+				task, err := service.RequestRelease(ctx, project, entities.CMSActionReboot, []string{"host-1"}, "walle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.Status).To(Equal(entities.CMSTaskInProcess))
+
+				var notReleased *cmsErrors.HostNotReleasedError
+				Expect(errors.As(service.EnsureReleased(ctx, project, task.ID, "host-1"), &notReleased)).To(BeTrue())
+
+				server.Approve(task.ID)
+				released, err := service.AwaitRelease(ctx, project, task.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(released.Hosts).To(Equal([]string{"host-1"}))
+				Expect(service.EnsureReleased(ctx, project, task.ID, "host-1")).To(Succeed())
+				Expect(errors.As(service.EnsureReleased(ctx, project, task.ID, "host-2"), &notReleased)).To(BeTrue())
+			})
+
+			It("should report rejected tasks", func() {
+				task, err := service.RequestRelease(ctx, project, entities.CMSActionRedeploy, []string{"host-1"}, "walle")
+				Expect(err).NotTo(HaveOccurred())
+
+				server.Reject(task.ID, "service is degraded")
+				_, err = service.AwaitRelease(ctx, project, task.ID)
+				var rejected *cmsErrors.TaskRejectedError
+				Expect(errors.As(err, &rejected)).To(BeTrue())
+				Expect(rejected.Message).To(Equal("service is degraded"))
+			})
+
+			It("should stop waiting when context is done", func() {
+				task, err := service.RequestRelease(ctx, project, entities.CMSActionMaintenance, []string{"host-1"}, "walle")
+				Expect(err).NotTo(HaveOccurred())
+
+				waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+				defer cancel()
+				_, err = service.AwaitRelease(waitCtx, project, task.ID)
+				Expect(err).To(MatchError(context.DeadlineExceeded))
+			})
+
+			It("should respect max busy hosts", func() {
+				server.AddTask(cmstest.Task{ID: "other", Action: "reboot", Hosts: []string{"host-7", "host-8"}})
+				server.AddTask(cmstest.Task{ID: "rejected", Action: "reboot", Hosts: []string{"host-9"}, Status: cmstest.StatusRejected})
+
+				_, err := service.RequestRelease(ctx, project, entities.CMSActionReboot, []string{"host-1", "host-2"}, "walle")
+				var limitErr *cmsErrors.BusyHostsLimitError
+				Expect(errors.As(err, &limitErr)).To(BeTrue())
+				Expect(limitErr.Busy).To(Equal(2))
+
+				_, err = service.RequestRelease(ctx, project, entities.CMSActionReboot, []string{"host-1"}, "walle")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should delete task on completion", func() {
+				server.SetAutoApprove(true)
+				task, err := service.RequestRelease(ctx, project, entities.CMSActionReboot, []string{"host-1"}, "walle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.Status).To(Equal(entities.CMSTaskApproved))
+
+				Expect(service.CompleteRelease(ctx, project, task.ID)).To(Succeed())
+				Expect(server.Tasks()).To(BeEmpty())
+				Expect(service.EnsureReleased(ctx, project, task.ID, "host-1")).To(MatchError(cmsErrors.ErrTaskNotFound))
+			})
+		})
+	}
+
+	Context("without enabled CMS", func() {
+		var (
+			project *projects.Project
+			service *CMSService
+		)
+
+		BeforeEach(func() {
+			project = &projects.Project{ID: "search", CMS: []projectEntities.CMS{{Enabled: false}}}
+			service = NewDomainService(infraCMS.NewClientFactory(), time.Millisecond)
+		})
+
+		It("should refuse release by default", func() {
+			_, err := service.RequestRelease(context.Background(), project, entities.CMSActionReboot, []string{"host-1"}, "walle")
+			Expect(err).To(MatchError(cmsErrors.ErrCMSNotConfigured))
+		})
+
+		It("should release immediately when deactivation without CMS is allowed", func() {
+			project.Task = &projectEntities.Task{DeactivateWithoutCMS: true}
+			task, err := service.RequestRelease(context.Background(), project, entities.CMSActionReboot, []string{"host-1"}, "walle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Status).To(Equal(entities.CMSTaskApproved))
+			Expect(service.EnsureReleased(context.Background(), project, task.ID, "host-1")).To(Succeed())
+		})
+	})
+
+	It("should reject unsupported protocol versions", func() {
+		project := &projects.Project{CMS: []projectEntities.CMS{{Enabled: true, URL: "http://cms", Version: "v9"}}}
+		service := NewDomainService(infraCMS.NewClientFactory(), time.Millisecond)
+		_, err := service.RequestRelease(context.Background(), project, entities.CMSActionReboot, []string{"host-1"}, "walle")
+		Expect(err).To(MatchError(cmsErrors.ErrUnsupportedVersion))
+	})
+})
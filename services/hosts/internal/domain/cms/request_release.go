@@ -0,0 +1,105 @@
+package cms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+func (s *CMSService) RequestRelease(ctx context.Context, project *projects.Project, action entities.CMSAction, hosts []string, issuer string) (*entities.CMSTask, error) {
+	if !action.IsValid() {
+		return nil, fmt.Errorf("unknown cms action %q", action)
+	}
+
+	task := &entities.CMSTask{
+		ID:     uuid.NewString(),
+		Action: action,
+		Hosts:  hosts,
+		Issuer: issuer,
+		Status: entities.CMSTaskInProcess,
+	}
+
+	clients, err := s.clients(project)
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		if !deactivationWithoutCMSAllowed(project) {
+			return nil, cmsErrors.ErrCMSNotConfigured
+		}
+		task.Status = entities.CMSTaskApproved
+		return task, nil
+	}
+
+	for _, c := range clients {
+		if err := checkBusyHosts(ctx, c, len(hosts)); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]*entities.CMSTask, 0, len(clients))
+	for i, c := range clients {
+		created, err := c.client.CreateTask(ctx, task)
+		if err != nil {
+			rollback(ctx, clients[:i], task.ID)
+			return nil, err
+		}
+		statuses = append(statuses, created)
+	}
+
+	return aggregate(task, statuses), nil
+}
+
+func checkBusyHosts(ctx context.Context, c projectCMS, requested int) error {
+	if c.config.MaxBusyHosts <= 0 {
+		return nil
+	}
+
+	tasks, err := c.client.ListTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	busy := map[string]struct{}{}
+	for _, t := range tasks {
+		if t.Status == entities.CMSTaskRejected {
+			continue
+		}
+		for _, host := range t.Hosts {
+			busy[host] = struct{}{}
+		}
+	}
+
+	if len(busy)+requested > c.config.MaxBusyHosts {
+		return &cmsErrors.BusyHostsLimitError{Limit: c.config.MaxBusyHosts, Busy: len(busy), Requested: requested}
+	}
+	return nil
+}
+
+func rollback(ctx context.Context, clients []projectCMS, taskID string) {
+	for _, c := range clients {
+		_ = c.client.DeleteTask(ctx, taskID)
+	}
+}
+
+func aggregate(task *entities.CMSTask, statuses []*entities.CMSTask) *entities.CMSTask {
+	result := *task
+	result.Status = entities.CMSTaskApproved
+	result.Message = ""
+	for _, status := range statuses {
+		switch status.Status {
+		case entities.CMSTaskRejected:
+			result.Status = entities.CMSTaskRejected
+			result.Message = status.Message
+			return &result
+		case entities.CMSTaskApproved:
+		default:
+			result.Status = entities.CMSTaskInProcess
+		}
+	}
+	return &result
+}
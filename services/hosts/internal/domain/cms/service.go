@@ -0,0 +1,42 @@
+package cms
+
+import (
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+type CMSService struct {
+	factory      contracts.CMSClientFactory
+	pollInterval time.Duration
+}
+
+func NewDomainService(factory contracts.CMSClientFactory, pollInterval time.Duration) *CMSService {
+	return &CMSService{factory: factory, pollInterval: pollInterval}
+}
+
+type projectCMS struct {
+	config projectEntities.CMS
+	client contracts.CMSClient
+}
+
+func (s *CMSService) clients(project *projects.Project) ([]projectCMS, error) {
+	var result []projectCMS
+	for _, config := range project.CMS {
+		if !config.Enabled {
+			continue
+		}
+		client, err := s.factory.NewClient(config)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, projectCMS{config: config, client: client})
+	}
+	return result, nil
+}
+
+func deactivationWithoutCMSAllowed(project *projects.Project) bool {
+	return project.Task != nil && project.Task.DeactivateWithoutCMS
+}
@@ -2,9 +2,137 @@ package hosts
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectContracts "github.com/gwall-e/hosts/internal/domain/projects/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/shortname"
 )
 
 func (c *HostService) AddHost(ctx context.Context, host *entities.Host) error {
+	projectID := host.ProjectID
+	if err := c.prepareNewHost(ctx, host, c.renderer); err != nil {
+		return err
+	}
+	if err := host.Register(projectID); err != nil {
+		return err
+	}
+	return c.hosts.Create(ctx, host)
+}
+
+func (c *HostService) CheckNewHost(ctx context.Context, host *entities.Host, reserved projectContracts.HostnameChecker) error {
+	renderer := c.renderer
+	if reserved != nil {
+		renderer = shortname.NewRenderer(takenHostnames{c.hosts, reserved})
+	}
+	return c.prepareNewHost(ctx, host, renderer)
+}
+
+func (c *HostService) prepareNewHost(ctx context.Context, host *entities.Host, renderer *shortname.Renderer) error {
+	project, err := c.checkNewHost(ctx, host)
+	if err != nil {
+		return err
+	}
+	if host.FQDN != "" || project == nil || project.Network == nil || project.Network.ShortnameTemplate == "" {
+		return nil
+	}
+
+	fqdn, err := renderer.RenderFQDN(ctx, project.Network, shortname.Params{
+		Location: host.Location.Datacenter,
+		Rack:     host.Location.Rack,
+		Project:  project.ID,
+	})
+	if err != nil {
+		return err
+	}
+	host.FQDN = fqdn
+	return c.checkDuplicates(ctx, host)
+}
+
+type takenHostnames []projectContracts.HostnameChecker
+
+func (t takenHostnames) FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error) {
+	var taken []string
+	for _, checker := range t {
+		existing, err := checker.FindExistingFQDNs(ctx, fqdns)
+		if err != nil {
+			return nil, err
+		}
+		taken = append(taken, existing...)
+	}
+	return taken, nil
+}
+
+func (c *HostService) checkNewHost(ctx context.Context, host *entities.Host) (*projects.Project, error) {
+	var project *projects.Project
+	if host.ProjectID != "" {
+		var err error
+		project, err = c.projects.GetProject(ctx, host.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			return nil, fmt.Errorf("%w: %s", errors.ErrProjectNotFound, host.ProjectID)
+		}
+		if err := checkProjectAccepts(project, host); err != nil {
+			return nil, err
+		}
+		if operation, ok := host.RegistrationOperation(project.Network != nil); ok {
+			if err := host.CheckOperation(operation, project.EffectiveRestrictions(), false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.checkDuplicates(ctx, host); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func checkProjectAccepts(project *projects.Project, host *entities.Host) error {
+	if project.Type != "" && project.Type != host.UnitType {
+		return &errors.HostValidationError{
+			Field:   "unit_type",
+			Message: fmt.Sprintf("project %s accepts only %s hosts", project.ID, project.Type),
+		}
+	}
+	if project.Deploying != nil && !project.Deploying.Policy.AllowsUnitType(host.UnitType) {
+		return &errors.HostValidationError{
+			Field:   "unit_type",
+			Message: fmt.Sprintf("deploy policy %s of project %s does not allow %s hosts", project.Deploying.Policy, project.ID, host.UnitType),
+		}
+	}
+	return nil
+}
+
+func (c *HostService) checkDuplicates(ctx context.Context, host *entities.Host) error {
+	existing, err := c.hosts.GetByInventoryNumber(ctx, host.InventoryNumber)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: inventory number %s", errors.ErrHostAlreadyExists, host.InventoryNumber)
+	}
+
+	existing, err = c.hosts.GetByFQDN(ctx, host.FQDN)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: fqdn %s", errors.ErrHostAlreadyExists, host.FQDN)
+	}
+
+	for _, mac := range host.MACs {
+		existing, err = c.hosts.GetByMAC(ctx, mac)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ID != host.ID {
+			return fmt.Errorf("%w: mac %s", errors.ErrHostAlreadyExists, mac)
+		}
+	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,155 @@
+package hosts_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/hosts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AddHost", func() {
+	var (
+		ctx     context.Context
+		repo    *memory.HostRepository
+		service *hosts.HostService
+		search  *projects.Project
+	)
+
+	newHost := func(inv string, fqdn string, projectID string) *entities.Host {
+		host, err := entities.NewHost(inv, fqdn, core_entities.TypeServer, entities.HostLocation{Datacenter: "sas", Rack: "1a"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		host.ProjectID = projectID
+		return host
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = memory.NewHostRepository()
+		search = &projects.Project{
+			ID:   "search",
+			Type: core_entities.TypeServer,
+			Network: &projectEntities.Network{
+				DNSDomain:         "search.example.net",
+				ShortnameTemplate: "{location}-{index:04d}",
+			},
+		}
+		service = hosts.NewDomainService(repo, fakeProjects{
+			"search": search,
+			"vms":    {ID: "vms", Type: core_entities.TypeVM},
+			"yt": {
+				ID:        "yt",
+				Deploying: &projectEntities.Deploying{Policy: projectEntities.DeployPolicyYTDedicated},
+			},
+		}, memory.NewHostMoveTaskRepository(), nil, nil)
+	})
+
+	It("should refuse hosts cabled to a switch in a project whose vlans can not be changed", func() {
+		search.Restrictions = []core_entities.Restriction{core_entities.RestrictionNoVlanChange}
+
+		host, err := entities.NewHost("100001", "", core_entities.TypeServer, entities.HostLocation{Datacenter: "sas", Switch: "sas-s1"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		host.ProjectID = "search"
+		var restrictedErr *hostErrors.HostRestrictedError
+		Expect(errors.As(service.AddHost(ctx, host), &restrictedErr)).To(BeTrue())
+
+		Expect(service.AddHost(ctx, newHost("100002", "", "search"))).To(Succeed())
+	})
+
+	It("should add host to project rendering fqdn from template", func() {
+		host := newHost("100001", "", "search")
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+
+		Expect(host.FQDN).To(Equal("sas-0001.search.example.net"))
+		Expect(host.State).To(Equal(entities.HostStateAssigned))
+		Expect(host.Events()).To(ConsistOf(&events.HostAddedEvent{
+			ID:              host.ID,
+			InventoryNumber: "100001",
+			FQDN:            "sas-0001.search.example.net",
+			UnitType:        core_entities.TypeServer,
+			ProjectID:       "search",
+			State:           "assigned",
+			Datacenter:      "sas",
+			Rack:            "1a",
+		}))
+
+		stored, err := repo.GetByInventoryNumber(ctx, "100001")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.ID).To(Equal(host.ID))
+		Expect(stored.Version).To(Equal(int64(1)))
+	})
+
+	It("should pick the next free name", func() {
+		Expect(service.AddHost(ctx, newHost("100001", "", "search"))).To(Succeed())
+		host := newHost("100002", "", "search")
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+		Expect(host.FQDN).To(Equal("sas-0002.search.example.net"))
+	})
+
+	It("should keep the given fqdn", func() {
+		host := newHost("100001", "custom.search.example.net", "search")
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+		Expect(host.FQDN).To(Equal("custom.search.example.net"))
+	})
+
+	It("should add free host without project", func() {
+		host := newHost("100001", "", "")
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+		Expect(host.FQDN).To(BeEmpty())
+		Expect(host.State).To(Equal(entities.HostStateFree))
+	})
+
+	It("should reject unknown project", func() {
+		Expect(service.AddHost(ctx, newHost("100001", "", "unknown"))).To(MatchError(hostErrors.ErrProjectNotFound))
+	})
+
+	DescribeTable("should reject unit types the project does not accept",
+		func(projectID string, unitType core_entities.UnitType) {
+			host := newHost("100001", "", projectID)
+			host.UnitType = unitType
+
+			err := service.AddHost(ctx, host)
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+			Expect(validationErr.Field).To(Equal("unit_type"))
+		},
+		Entry("project type mismatch", "vms", core_entities.TypeServer),
+		Entry("deploy policy restriction", "yt", core_entities.TypeVM),
+	)
+
+	DescribeTable("should reject duplicates",
+		func(inv string, fqdn string) {
+			Expect(service.AddHost(ctx, newHost("100001", "host.search.example.net", "search"))).To(Succeed())
+			Expect(service.AddHost(ctx, newHost(inv, fqdn, "search"))).To(MatchError(hostErrors.ErrHostAlreadyExists))
+		},
+		Entry("by inventory number", "100001", "other.search.example.net"),
+		Entry("by fqdn", "100002", "host.search.example.net"),
+	)
+
+	It("should reject hosts with a mac of another host", func() {
+		first := newHost("100001", "", "")
+		first.MACs = []string{"aa:bb:cc:dd:ee:01"}
+		Expect(service.AddHost(ctx, first)).To(Succeed())
+
+		second := newHost("100002", "", "")
+		second.MACs = []string{"aa:bb:cc:dd:ee:02", "aa:bb:cc:dd:ee:01"}
+		err := service.AddHost(ctx, second)
+		Expect(err).To(MatchError(hostErrors.ErrHostAlreadyExists))
+		Expect(err).To(MatchError(ContainSubstring("mac aa:bb:cc:dd:ee:01")))
+	})
+
+	It("should reject already stored hosts", func() {
+		host := newHost("100001", "", "")
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+		host.InventoryNumber = "100002"
+		Expect(service.AddHost(ctx, host)).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,27 @@
+package hosts
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+)
+
+func (c *HostService) ChangeHostState(ctx context.Context, hostID string, to entities.HostState, reason string) (*entities.Host, error) {
+	host, err := c.getHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := c.getProject(ctx, host.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if operation, ok := host.StateChangeOperation(to); ok {
+		if err := host.CheckOperation(operation, project.EffectiveRestrictions(), false); err != nil {
+			return nil, err
+		}
+	}
+	if err := host.ChangeState(to, reason); err != nil {
+		return nil, err
+	}
+	return host, c.hosts.Update(ctx, host)
+}
@@ -0,0 +1,59 @@
+package hosts_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChangeHostState", func() {
+	var (
+		ctx     context.Context
+		repo    *memory.HostRepository
+		service *hosts.HostService
+		search  *projects.Project
+		host    *entities.Host
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = memory.NewHostRepository()
+		search = &projects.Project{ID: "search", Type: core_entities.TypeServer}
+		service = hosts.NewDomainService(repo, fakeProjects{"search": search}, memory.NewHostMoveTaskRepository(), nil, nil)
+
+		var err error
+		host, err = entities.NewHost("100001", "sas-0001.search.example.net", core_entities.TypeServer, entities.HostLocation{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		host.ProjectID = "search"
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+	})
+
+	It("should store the new state", func() {
+		_, err := service.ChangeHostState(ctx, host.ID, entities.HostStateMaintenance, "disk replacement")
+		Expect(err).NotTo(HaveOccurred())
+
+		stored, err := repo.GetByID(ctx, host.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.State).To(Equal(entities.HostStateMaintenance))
+	})
+
+	It("should not release hosts that can not be wiped", func() {
+		search.Restrictions = []core_entities.Restriction{core_entities.RestrictionNoReboot}
+
+		_, err := service.ChangeHostState(ctx, host.ID, entities.HostStateFree, "")
+		var restrictedErr *hostErrors.HostRestrictedError
+		Expect(errors.As(err, &restrictedErr)).To(BeTrue())
+		Expect(restrictedErr.Operation).To(Equal(string(entities.HostOperationEraseDisks)))
+
+		_, err = service.ChangeHostState(ctx, host.ID, entities.HostStateMaintenance, "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+)
+
+type HostMoveTaskRepository interface {
+	Create(ctx context.Context, task *entities.HostMoveTask) error
+	Update(ctx context.Context, task *entities.HostMoveTask) error
+	GetByID(ctx context.Context, id string) (*entities.HostMoveTask, error)
+	FindActiveByHost(ctx context.Context, hostID string) (*entities.HostMoveTask, error)
+}
@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+type HostOperator interface {
+	SwitchVlans(ctx context.Context, host *entities.Host, nativeVlan int, extraVlans []int) error
+	EraseDisks(ctx context.Context, host *entities.Host) error
+	Redeploy(ctx context.Context, host *entities.Host, deploying *projectEntities.Deploying) error
+}
@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"context"
+
+	cmsEntities "github.com/gwall-e/hosts/internal/domain/cms/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+type HostReleaser interface {
+	RequestRelease(ctx context.Context, project *projects.Project, action cmsEntities.CMSAction, hosts []string, issuer string) (*cmsEntities.CMSTask, error)
+	AwaitRelease(ctx context.Context, project *projects.Project, taskID string) (*cmsEntities.CMSTask, error)
+	CompleteRelease(ctx context.Context, project *projects.Project, taskID string) error
+}
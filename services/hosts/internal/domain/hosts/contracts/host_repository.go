@@ -1,6 +1,57 @@
 package contracts
 
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/pkg/selectors"
+)
+
+const (
+	DEFAULT_HOSTS_PAGE_SIZE = 100
+	MAX_HOSTS_PAGE_SIZE     = 1000
+)
+
+type HostFilter struct {
+	ProjectID  string
+	States     []entities.HostState
+	Datacenter string
+	Hall       string
+	Row        string
+	Rack       string
+	Switch     string
+	Selector   selectors.Selector
+}
+
+type HostPageRequest struct {
+	Cursor string
+	Limit  int
+}
+
+func (r HostPageRequest) PageSize() int {
+	switch {
+	case r.Limit <= 0:
+		return DEFAULT_HOSTS_PAGE_SIZE
+	case r.Limit > MAX_HOSTS_PAGE_SIZE:
+		return MAX_HOSTS_PAGE_SIZE
+	default:
+		return r.Limit
+	}
+}
+
+type HostPage struct {
+	Hosts      []*entities.Host
+	NextCursor string
+}
+
 // HostRepository defines the interface for hosts repository operations
 type HostRepository interface {
-	// Methods will be added later
-}
\ No newline at end of file
+	Create(ctx context.Context, host *entities.Host) error
+	Update(ctx context.Context, host *entities.Host) error
+	GetByID(ctx context.Context, id string) (*entities.Host, error)
+	GetByInventoryNumber(ctx context.Context, inventoryNumber string) (*entities.Host, error)
+	GetByFQDN(ctx context.Context, fqdn string) (*entities.Host, error)
+	GetByMAC(ctx context.Context, mac string) (*entities.Host, error)
+	List(ctx context.Context, filter HostFilter, page HostPageRequest) (*HostPage, error)
+	FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error)
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+type ProjectProvider interface {
+	GetProject(ctx context.Context, id string) (*projects.Project, error)
+}
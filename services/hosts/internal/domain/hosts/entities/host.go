@@ -1,4 +1,260 @@
 package entities
 
+import (
+	"github.com/google/uuid"
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/hosts/validators"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
 type Host struct {
-}
\ No newline at end of file
+	ID              string                      `bson:"_id"`
+	InventoryNumber string                      `bson:"inventory_number"`
+	FQDN            string                      `bson:"fqdn"`
+	UnitType        core_entities.UnitType      `bson:"unit_type"`
+	ProjectID       string                      `bson:"project_id"`
+	Location        HostLocation                `bson:"location"`
+	MACs            []string                    `bson:"macs"`
+	Restrictions    []core_entities.Restriction `bson:"restrictions"`
+	Labels          map[string]string           `bson:"labels"`
+	State           HostState                   `bson:"state"`
+	Status          HostStatus                  `bson:"status"`
+	Version         int64                       `bson:"version"`
+	events          []interface{}               `bson:"-"`
+}
+
+func NewHost(inventoryNumber string, fqdn string, unitType core_entities.UnitType, location HostLocation, macs []string) (*Host, error) {
+	host := &Host{
+		ID:              uuid.NewString(),
+		InventoryNumber: inventoryNumber,
+		FQDN:            fqdn,
+		UnitType:        unitType,
+		Location:        location,
+		MACs:            macs,
+		Restrictions:    []core_entities.Restriction{},
+		Labels:          map[string]string{},
+		State:           HostStateFree,
+		Status:          HostStatusReady,
+	}
+	if err := host.validate(); err != nil {
+		return nil, err
+	}
+	return host, nil
+}
+
+func (h *Host) validate() error {
+	if err := validators.ValidateInventoryNumber(h.InventoryNumber); err != nil {
+		return err
+	}
+	if err := validators.ValidateFQDN(h.FQDN); err != nil {
+		return err
+	}
+	if err := validators.ValidateUnitType(h.UnitType); err != nil {
+		return err
+	}
+	if err := h.Location.Validate(); err != nil {
+		return err
+	}
+	if err := validators.ValidateRestrictions(h.Restrictions); err != nil {
+		return err
+	}
+	if err := validators.ValidateLabels(h.Labels); err != nil {
+		return err
+	}
+	macs, err := validators.NormalizeMACs(h.MACs)
+	if err != nil {
+		return err
+	}
+	h.MACs = macs
+	return nil
+}
+
+func (h *Host) Register(projectID string) error {
+	if h.Version != 0 || h.State != HostStateFree {
+		return &errors.HostValidationError{
+			Field:   "state",
+			Message: "only new free hosts can be registered",
+		}
+	}
+	if h.ID == "" {
+		h.ID = uuid.NewString()
+	}
+	if h.Status == "" {
+		h.Status = HostStatusReady
+	}
+	if h.Restrictions == nil {
+		h.Restrictions = []core_entities.Restriction{}
+	}
+	if h.Labels == nil {
+		h.Labels = map[string]string{}
+	}
+	if err := h.validate(); err != nil {
+		return err
+	}
+
+	h.ProjectID = projectID
+	if projectID != "" {
+		h.State = HostStateAssigned
+	}
+
+	h.addEvent(&events.HostAddedEvent{
+		ID:              h.ID,
+		InventoryNumber: h.InventoryNumber,
+		FQDN:            h.FQDN,
+		UnitType:        h.UnitType,
+		ProjectID:       h.ProjectID,
+		State:           string(h.State),
+		Datacenter:      h.Location.Datacenter,
+		Rack:            h.Location.Rack,
+	})
+	return nil
+}
+
+func (h *Host) Events() []interface{} {
+	return h.events
+}
+
+func (h *Host) ClearEvents() {
+	h.events = nil
+}
+
+func (h *Host) addEvent(event interface{}) {
+	h.events = append(h.events, event)
+}
+
+func (h *Host) Assign(projectID string, reason string) error {
+	if projectID == "" {
+		return &errors.HostValidationError{
+			Field:   "project_id",
+			Message: "project id is required",
+		}
+	}
+	if h.State != HostStateFree {
+		return &errors.InvalidStateTransitionError{From: string(h.State), To: string(HostStateAssigned)}
+	}
+
+	h.ProjectID = projectID
+	return h.ChangeState(HostStateAssigned, reason)
+}
+
+func (h *Host) ChangeState(to HostState, reason string) error {
+	if !to.IsValid() || !h.State.CanTransitionTo(to) {
+		return &errors.InvalidStateTransitionError{From: string(h.State), To: string(to)}
+	}
+	if to.HasProject() && h.ProjectID == "" {
+		return &errors.HostValidationError{
+			Field:   "project_id",
+			Message: "host must be assigned to a project",
+		}
+	}
+	if !h.Status.IsIdle() && to != HostStateDead {
+		return &errors.HostBusyError{Status: string(h.Status), To: string(to)}
+	}
+
+	from := h.State
+	projectID := h.ProjectID
+	h.State = to
+	if !to.HasProject() {
+		h.ProjectID = ""
+	}
+
+	h.addEvent(&events.HostStateChangedEvent{
+		ID:        h.ID,
+		FQDN:      h.FQDN,
+		ProjectID: projectID,
+		From:      string(from),
+		To:        string(to),
+		Reason:    reason,
+	})
+	return nil
+}
+
+func (h *Host) SetStatus(to HostStatus, reason string) error {
+	if h.State == HostStateDecommissioned || !to.IsValid() || !h.Status.CanTransitionTo(to) {
+		return &errors.InvalidStatusTransitionError{State: string(h.State), From: string(h.Status), To: string(to)}
+	}
+
+	from := h.Status
+	h.Status = to
+
+	h.addEvent(&events.HostStatusChangedEvent{
+		ID:        h.ID,
+		FQDN:      h.FQDN,
+		ProjectID: h.ProjectID,
+		From:      string(from),
+		To:        string(to),
+		Reason:    reason,
+	})
+	return nil
+}
+
+func (h *Host) MoveTo(projectID string, reason string) error {
+	if h.State == HostStateFree {
+		return h.Assign(projectID, reason)
+	}
+	if projectID == "" {
+		return &errors.HostValidationError{
+			Field:   "project_id",
+			Message: "project id is required",
+		}
+	}
+	if !h.State.HasProject() || h.State == HostStateDead || !h.Status.IsIdle() {
+		return &errors.InvalidStateTransitionError{From: string(h.State), To: string(HostStateAssigned)}
+	}
+
+	from := h.ProjectID
+	fromState := h.State
+	h.ProjectID = projectID
+	h.State = HostStateAssigned
+
+	h.addEvent(&events.HostProjectChangedEvent{
+		ID:     h.ID,
+		FQDN:   h.FQDN,
+		From:   from,
+		To:     projectID,
+		Reason: reason,
+	})
+	if fromState != HostStateAssigned {
+		h.addEvent(&events.HostStateChangedEvent{
+			ID:        h.ID,
+			FQDN:      h.FQDN,
+			ProjectID: projectID,
+			From:      string(fromState),
+			To:        string(HostStateAssigned),
+			Reason:    reason,
+		})
+	}
+	return nil
+}
+
+func (h *Host) IsOutOfService() bool {
+	switch h.State {
+	case HostStateMaintenance, HostStateProbation, HostStateDead:
+		return true
+	}
+	return !h.Status.IsIdle()
+}
+
+func (h *Host) SetLocation(location HostLocation) error {
+	if err := location.Validate(); err != nil {
+		return err
+	}
+	if h.Location == location {
+		return nil
+	}
+
+	h.Location = location
+	h.addEvent(&events.HostLocationChangedEvent{
+		ID:         h.ID,
+		FQDN:       h.FQDN,
+		Datacenter: location.Datacenter,
+		Hall:       location.Hall,
+		Row:        location.Row,
+		Rack:       location.Rack,
+		Unit:       location.Unit,
+		Switch:     location.Switch,
+		Port:       location.Port,
+	})
+	return nil
+}
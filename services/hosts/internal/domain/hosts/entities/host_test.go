@@ -0,0 +1,169 @@
+package entities_test
+
+import (
+	"errors"
+
+	"github.com/gwall-e/hosts/events"
+	. "github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Host", func() {
+	var host *Host
+
+	BeforeEach(func() {
+		var err error
+		host, err = NewHost("100200300", "sas-0001.search.yandex.net", core_entities.TypeServer,
+			HostLocation{Datacenter: "sas", Rack: "1a"}, []string{"AA:BB:CC:DD:EE:FF", "00-11-22-33-44-55"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create a free ready host with normalized macs", func() {
+		Expect(host.ID).NotTo(BeEmpty())
+		Expect(host.State).To(Equal(HostStateFree))
+		Expect(host.Status).To(Equal(HostStatusReady))
+		Expect(host.MACs).To(Equal([]string{"aa:bb:cc:dd:ee:ff", "00:11:22:33:44:55"}))
+		Expect(host.Events()).To(BeEmpty())
+	})
+
+	DescribeTable("should validate new hosts",
+		func(inv string, fqdn string, unitType core_entities.UnitType, macs []string, field string) {
+			_, err := NewHost(inv, fqdn, unitType, HostLocation{}, macs)
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+			Expect(validationErr.Field).To(Equal(field))
+		},
+		Entry("empty inventory number", "", "", core_entities.TypeServer, nil, "inventory_number"),
+		Entry("invalid inventory number", "inv 1", "", core_entities.TypeServer, nil, "inventory_number"),
+		Entry("invalid fqdn", "1", "Host_1", core_entities.TypeServer, nil, "fqdn"),
+		Entry("unknown unit type", "1", "", core_entities.UnitType("router"), nil, "unit_type"),
+		Entry("invalid mac", "1", "", core_entities.TypeServer, []string{"zz:zz"}, "macs"),
+		Entry("duplicated mac", "1", "", core_entities.TypeServer, []string{"aa:bb:cc:dd:ee:ff", "AA-BB-CC-DD-EE-FF"}, "macs"),
+	)
+
+	It("should emit event on assignment", func() {
+		Expect(host.Assign("search", "onboarding")).To(Succeed())
+		Expect(host.State).To(Equal(HostStateAssigned))
+		Expect(host.ProjectID).To(Equal("search"))
+		Expect(host.Events()).To(ConsistOf(&events.HostStateChangedEvent{
+			ID:        host.ID,
+			FQDN:      host.FQDN,
+			ProjectID: "search",
+			From:      "free",
+			To:        "assigned",
+			Reason:    "onboarding",
+		}))
+	})
+
+	It("should require project for assignment", func() {
+		var validationErr *hostErrors.HostValidationError
+		Expect(errors.As(host.Assign("", ""), &validationErr)).To(BeTrue())
+	})
+
+	DescribeTable("should follow lifecycle transitions",
+		func(path []HostState, to HostState, allowed bool) {
+			Expect(host.Assign("search", "")).To(Succeed())
+			for _, state := range path {
+				Expect(host.ChangeState(state, "")).To(Succeed())
+			}
+
+			err := host.ChangeState(to, "")
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.State).To(Equal(to))
+			} else {
+				var transitionErr *hostErrors.InvalidStateTransitionError
+				Expect(errors.As(err, &transitionErr)).To(BeTrue())
+			}
+		},
+		Entry("assigned to ready", nil, HostStateReady, true),
+		Entry("ready to maintenance", []HostState{HostStateReady}, HostStateMaintenance, true),
+		Entry("maintenance to probation", []HostState{HostStateMaintenance}, HostStateProbation, true),
+		Entry("probation to ready", []HostState{HostStateProbation}, HostStateReady, true),
+		Entry("dead to decommissioned", []HostState{HostStateDead}, HostStateDecommissioned, true),
+		Entry("ready to decommissioned", []HostState{HostStateReady}, HostStateDecommissioned, false),
+		Entry("assigned to assigned", nil, HostStateAssigned, false),
+		Entry("decommissioned to free", []HostState{HostStateDead, HostStateDecommissioned}, HostStateFree, false),
+		Entry("unknown state", nil, HostState("lost"), false),
+	)
+
+	It("should not put free hosts into maintenance", func() {
+		var transitionErr *hostErrors.InvalidStateTransitionError
+		Expect(errors.As(host.ChangeState(HostStateMaintenance, ""), &transitionErr)).To(BeTrue())
+	})
+
+	It("should leave project when becoming free", func() {
+		Expect(host.Assign("search", "")).To(Succeed())
+		Expect(host.ChangeState(HostStateFree, "released")).To(Succeed())
+		Expect(host.ProjectID).To(BeEmpty())
+		Expect(host.Events()[1]).To(HaveField("ProjectID", "search"))
+	})
+
+	It("should not change state while operation is running except marking host dead", func() {
+		Expect(host.Assign("search", "")).To(Succeed())
+		Expect(host.SetStatus(HostStatusDeploying, "")).To(Succeed())
+
+		var busyErr *hostErrors.HostBusyError
+		Expect(errors.As(host.ChangeState(HostStateReady, ""), &busyErr)).To(BeTrue())
+		Expect(busyErr.Status).To(Equal("deploying"))
+		Expect(host.ChangeState(HostStateDead, "")).To(Succeed())
+	})
+
+	DescribeTable("should follow status transitions",
+		func(from []HostStatus, to HostStatus, allowed bool) {
+			Expect(host.Assign("search", "")).To(Succeed())
+			for _, status := range from {
+				Expect(host.SetStatus(status, "")).To(Succeed())
+			}
+
+			err := host.SetStatus(to, "")
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.Status).To(Equal(to))
+				Expect(host.Events()[len(host.Events())-1]).To(BeAssignableToTypeOf(&events.HostStatusChangedEvent{}))
+			} else {
+				var transitionErr *hostErrors.InvalidStatusTransitionError
+				Expect(errors.As(err, &transitionErr)).To(BeTrue())
+			}
+		},
+		Entry("ready to rebooting", nil, HostStatusRebooting, true),
+		Entry("rebooting to ready", []HostStatus{HostStatusRebooting}, HostStatusReady, true),
+		Entry("deploying to failed", []HostStatus{HostStatusDeploying}, HostStatusFailed, true),
+		Entry("failed to profiling", []HostStatus{HostStatusDeploying, HostStatusFailed}, HostStatusProfiling, true),
+		Entry("rebooting to deploying", []HostStatus{HostStatusRebooting}, HostStatusDeploying, false),
+		Entry("ready to ready", nil, HostStatusReady, false),
+		Entry("unknown status", nil, HostStatus("flying"), false),
+	)
+
+	It("should not change status of decommissioned hosts", func() {
+		Expect(host.ChangeState(HostStateDecommissioned, "")).To(Succeed())
+		Expect(host.SetStatus(HostStatusRebooting, "")).To(HaveOccurred())
+	})
+
+	It("should move host between projects returning it to assigned state", func() {
+		Expect(host.Assign("search", "")).To(Succeed())
+		Expect(host.ChangeState(HostStateReady, "")).To(Succeed())
+		host.ClearEvents()
+
+		Expect(host.MoveTo("ads", "moved")).To(Succeed())
+		Expect(host.ProjectID).To(Equal("ads"))
+		Expect(host.State).To(Equal(HostStateAssigned))
+		Expect(host.Events()).To(HaveLen(2))
+		Expect(host.Events()[0]).To(Equal(&events.HostProjectChangedEvent{
+			ID:     host.ID,
+			FQDN:   host.FQDN,
+			From:   "search",
+			To:     "ads",
+			Reason: "moved",
+		}))
+	})
+
+	It("should not move dead hosts", func() {
+		Expect(host.Assign("search", "")).To(Succeed())
+		Expect(host.ChangeState(HostStateDead, "")).To(Succeed())
+		Expect(host.MoveTo("ads", "")).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"maps"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/hosts/validators"
+	"github.com/gwall-e/pkg/selectors"
+)
+
+var HOST_SELECTOR_FIELDS = map[string]string{
+	"project":             "project_id",
+	"unit_type":           "unit_type",
+	"location.datacenter": "location.datacenter",
+	"location.hall":       "location.hall",
+	"location.row":        "location.row",
+	"location.rack":       "location.rack",
+	"location.switch":     "location.switch",
+}
+
+func (h *Host) Get(key string) (string, bool) {
+	var value string
+	switch key {
+	case "project":
+		value = h.ProjectID
+	case "unit_type":
+		value = string(h.UnitType)
+	case "location.datacenter":
+		value = h.Location.Datacenter
+	case "location.hall":
+		value = h.Location.Hall
+	case "location.row":
+		value = h.Location.Row
+	case "location.rack":
+		value = h.Location.Rack
+	case "location.switch":
+		value = h.Location.Switch
+	default:
+		value, ok := h.Labels[key]
+		return value, ok
+	}
+	return value, value != ""
+}
+
+func (h *Host) Matches(selector selectors.Selector) bool {
+	return selector.Matches(h)
+}
+
+func (h *Host) SetLabels(labels map[string]string) error {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if err := validators.ValidateLabels(labels); err != nil {
+		return err
+	}
+	if maps.Equal(h.Labels, labels) {
+		return nil
+	}
+
+	h.Labels = maps.Clone(labels)
+	h.addLabelsChangedEvent()
+	return nil
+}
+
+func (h *Host) SetLabel(key string, value string) error {
+	labels := maps.Clone(h.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[key] = value
+	return h.SetLabels(labels)
+}
+
+func (h *Host) RemoveLabel(key string) {
+	if _, ok := h.Labels[key]; !ok {
+		return
+	}
+	delete(h.Labels, key)
+	h.addLabelsChangedEvent()
+}
+
+func (h *Host) addLabelsChangedEvent() {
+	h.addEvent(&events.HostLabelsChangedEvent{
+		ID:     h.ID,
+		FQDN:   h.FQDN,
+		Labels: maps.Clone(h.Labels),
+	})
+}
@@ -0,0 +1,79 @@
+package entities_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gwall-e/hosts/events"
+	. "github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	"github.com/gwall-e/pkg/selectors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Host labels", func() {
+	var host *Host
+
+	BeforeEach(func() {
+		var err error
+		host, err = NewHost("inv-1", "host-1.example.net", core_entities.TypeServer,
+			HostLocation{Datacenter: "sas", Rack: "b12", Switch: "sas1-s12"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.Assign("search", "")).To(Succeed())
+		host.ClearEvents()
+	})
+
+	It("should set and remove labels with events", func() {
+		Expect(host.SetLabel("role", "db")).To(Succeed())
+		Expect(host.SetLabel("team/owner", "infra")).To(Succeed())
+		Expect(host.Labels).To(Equal(map[string]string{"role": "db", "team/owner": "infra"}))
+
+		host.RemoveLabel("role")
+		host.RemoveLabel("unknown")
+		Expect(host.Labels).To(Equal(map[string]string{"team/owner": "infra"}))
+		Expect(host.Events()).To(HaveLen(3))
+		Expect(host.Events()[2]).To(Equal(&events.HostLabelsChangedEvent{
+			ID:     host.ID,
+			FQDN:   host.FQDN,
+			Labels: map[string]string{"team/owner": "infra"},
+		}))
+	})
+
+	It("should not emit events when labels do not change", func() {
+		Expect(host.SetLabels(map[string]string{"role": "db"})).To(Succeed())
+		Expect(host.SetLabels(map[string]string{"role": "db"})).To(Succeed())
+		Expect(host.Events()).To(HaveLen(1))
+	})
+
+	DescribeTable("should reject invalid labels",
+		func(key string, value string) {
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(host.SetLabel(key, value), &validationErr)).To(BeTrue())
+			Expect(host.Labels).To(BeEmpty())
+			Expect(host.Events()).To(BeEmpty())
+		},
+		Entry("empty key", "", "db"),
+		Entry("dotted key", "location.rack", "b12"),
+		Entry("reserved key", "project", "search"),
+		Entry("upper case key", "Role", "db"),
+		Entry("long key", strings.Repeat("a", 64), "db"),
+		Entry("invalid value", "role", "db cache"),
+		Entry("long value", "role", strings.Repeat("a", 64)),
+	)
+
+	DescribeTable("should match selectors on labels and fields",
+		func(selector string, matches bool) {
+			Expect(host.SetLabels(map[string]string{"role": "db", "gpu": ""})).To(Succeed())
+			Expect(host.Matches(selectors.MustParse(selector))).To(Equal(matches))
+		},
+		Entry("label", "role=db,gpu", true),
+		Entry("missing label", "zone", false),
+		Entry("project", "project in (search, web)", true),
+		Entry("unit type", "unit_type!=server", false),
+		Entry("location", "location.datacenter=sas,location.switch=sas1-s12", true),
+		Entry("empty location field", "!location.hall", true),
+		Entry("empty location field value", "location.hall=", false),
+	)
+})
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/hosts/validators"
+)
+
+type LocationLevel string
+
+const (
+	LocationLevelDatacenter LocationLevel = "datacenter"
+	LocationLevelHall       LocationLevel = "hall"
+	LocationLevelRow        LocationLevel = "row"
+	LocationLevelRack       LocationLevel = "rack"
+	LocationLevelSwitch     LocationLevel = "switch"
+)
+
+func (l LocationLevel) IsValid() bool {
+	switch l {
+	case LocationLevelDatacenter, LocationLevelHall, LocationLevelRow, LocationLevelRack, LocationLevelSwitch:
+		return true
+	}
+	return false
+}
+
+type HostLocation struct {
+	Datacenter string `bson:"datacenter"`
+	Hall       string `bson:"hall"`
+	Row        string `bson:"row"`
+	Rack       string `bson:"rack"`
+	Unit       int    `bson:"unit"`
+	Switch     string `bson:"switch"`
+	Port       string `bson:"port"`
+}
+
+func (l HostLocation) Validate() error {
+	names := []struct {
+		field string
+		value string
+	}{
+		{"location.datacenter", l.Datacenter},
+		{"location.hall", l.Hall},
+		{"location.row", l.Row},
+		{"location.rack", l.Rack},
+		{"location.switch", l.Switch},
+		{"location.port", l.Port},
+	}
+	for _, name := range names {
+		if err := validators.ValidateLocationName(name.field, name.value); err != nil {
+			return err
+		}
+	}
+	if err := validators.ValidateRackUnit(l.Unit); err != nil {
+		return err
+	}
+
+	requirements := []struct {
+		field    string
+		set      bool
+		requires string
+		present  bool
+	}{
+		{"location.hall", l.Hall != "", "datacenter", l.Datacenter != ""},
+		{"location.row", l.Row != "", "hall", l.Hall != ""},
+		{"location.rack", l.Rack != "", "datacenter", l.Datacenter != ""},
+		{"location.unit", l.Unit != 0, "rack", l.Rack != ""},
+		{"location.port", l.Port != "", "switch", l.Switch != ""},
+	}
+	for _, r := range requirements {
+		if r.set && !r.present {
+			return &errors.HostValidationError{
+				Field:   r.field,
+				Message: fmt.Sprintf("%s is required", r.requires),
+			}
+		}
+	}
+	return nil
+}
+
+func (l HostLocation) FailureDomain(level LocationLevel) (string, bool) {
+	switch level {
+	case LocationLevelDatacenter:
+		return l.Datacenter, l.Datacenter != ""
+	case LocationLevelHall:
+		return l.Datacenter + "/" + l.Hall, l.Datacenter != "" && l.Hall != ""
+	case LocationLevelRow:
+		return l.Datacenter + "/" + l.Hall + "/" + l.Row, l.Datacenter != "" && l.Hall != "" && l.Row != ""
+	case LocationLevelRack:
+		return l.Datacenter + "/" + l.Rack, l.Datacenter != "" && l.Rack != ""
+	case LocationLevelSwitch:
+		return l.Switch, l.Switch != ""
+	}
+	return "", false
+}
@@ -0,0 +1,95 @@
+package entities_test
+
+import (
+	"errors"
+
+	. "github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostLocation", func() {
+	full := HostLocation{Datacenter: "sas", Hall: "2", Row: "b", Rack: "b12", Unit: 40, Switch: "sas1-s12", Port: "ge1/0/40"}
+
+	It("should accept complete and empty locations", func() {
+		Expect(full.Validate()).To(Succeed())
+		Expect(HostLocation{}.Validate()).To(Succeed())
+	})
+
+	DescribeTable("should reject inconsistent locations",
+		func(location HostLocation, field string) {
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(location.Validate(), &validationErr)).To(BeTrue())
+			Expect(validationErr.Field).To(Equal(field))
+		},
+		Entry("invalid name", HostLocation{Datacenter: "sas dc"}, "location.datacenter"),
+		Entry("hall without datacenter", HostLocation{Hall: "2"}, "location.hall"),
+		Entry("row without hall", HostLocation{Datacenter: "sas", Row: "b"}, "location.row"),
+		Entry("rack without datacenter", HostLocation{Rack: "b12"}, "location.rack"),
+		Entry("unit without rack", HostLocation{Datacenter: "sas", Unit: 3}, "location.unit"),
+		Entry("unit out of range", HostLocation{Datacenter: "sas", Rack: "b12", Unit: 61}, "location.unit"),
+		Entry("port without switch", HostLocation{Port: "ge1/0/1"}, "location.port"),
+	)
+
+	DescribeTable("should build failure domain keys",
+		func(location HostLocation, level LocationLevel, key string, ok bool) {
+			domain, defined := location.FailureDomain(level)
+			Expect(defined).To(Equal(ok))
+			Expect(domain).To(Equal(key))
+		},
+		Entry("datacenter", full, LocationLevelDatacenter, "sas", true),
+		Entry("hall", full, LocationLevelHall, "sas/2", true),
+		Entry("row", full, LocationLevelRow, "sas/2/b", true),
+		Entry("rack", full, LocationLevelRack, "sas/b12", true),
+		Entry("switch", full, LocationLevelSwitch, "sas1-s12", true),
+		Entry("unknown rack", HostLocation{Datacenter: "sas"}, LocationLevelRack, "sas/", false),
+	)
+})
+
+var _ = Describe("FailureDomainLimit", func() {
+	newRackHost := func(rack string, outOfService bool) *Host {
+		host, err := NewHost("1", "", core_entities.TypeServer, HostLocation{Datacenter: "sas", Rack: rack}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.Assign("search", "")).To(Succeed())
+		if outOfService {
+			Expect(host.ChangeState(HostStateMaintenance, "")).To(Succeed())
+		}
+		return host
+	}
+
+	limit := FailureDomainLimit{Level: LocationLevelRack, MaxUnavailable: 2}
+
+	It("should validate the limit", func() {
+		Expect(limit.Validate()).To(Succeed())
+		Expect(FailureDomainLimit{Level: "building", MaxUnavailable: 1}.Validate()).To(HaveOccurred())
+		Expect(FailureDomainLimit{Level: LocationLevelRack}.Validate()).To(HaveOccurred())
+	})
+
+	It("should count already unavailable hosts of the domain", func() {
+		down := newRackHost("a1", true)
+		candidate := newRackHost("a1", false)
+		other := newRackHost("a2", true)
+
+		Expect(limit.Check([]*Host{candidate}, []*Host{down, candidate, other})).To(Succeed())
+
+		second := newRackHost("a1", false)
+		err := limit.Check([]*Host{candidate, second}, []*Host{down, candidate, second, other})
+		var limitErr *hostErrors.FailureDomainLimitError
+		Expect(errors.As(err, &limitErr)).To(BeTrue())
+		Expect(limitErr.Domain).To(Equal("sas/a1"))
+		Expect(limitErr.Unavailable).To(Equal(3))
+	})
+
+	It("should not count candidates that are already unavailable twice", func() {
+		down := newRackHost("a1", true)
+		Expect(limit.Check([]*Host{down, newRackHost("a1", false)}, []*Host{down})).To(Succeed())
+	})
+
+	It("should ignore hosts without the domain in location", func() {
+		host, err := NewHost("1", "", core_entities.TypeServer, HostLocation{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(FailureDomainLimit{Level: LocationLevelRack, MaxUnavailable: 1}.Check([]*Host{host, host}, nil)).To(Succeed())
+	})
+})
@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+type HostMoveStepType string
+
+const (
+	HostMoveStepCMSRelease    HostMoveStepType = "cms-release"
+	HostMoveStepSwitchVlans   HostMoveStepType = "switch-vlans"
+	HostMoveStepEraseDisks    HostMoveStepType = "erase-disks"
+	HostMoveStepRedeploy      HostMoveStepType = "redeploy"
+	HostMoveStepChangeProject HostMoveStepType = "change-project"
+	HostMoveStepCMSComplete   HostMoveStepType = "cms-complete"
+)
+
+func (t HostMoveStepType) Operation() (HostOperation, bool) {
+	switch t {
+	case HostMoveStepSwitchVlans:
+		return HostOperationSwitchVlans, true
+	case HostMoveStepEraseDisks:
+		return HostOperationEraseDisks, true
+	case HostMoveStepRedeploy:
+		return HostOperationRedeploy, true
+	}
+	return "", false
+}
+
+type HostMoveStepStatus string
+
+const (
+	HostMoveStepPending HostMoveStepStatus = "pending"
+	HostMoveStepRunning HostMoveStepStatus = "running"
+	HostMoveStepDone    HostMoveStepStatus = "done"
+	HostMoveStepFailed  HostMoveStepStatus = "failed"
+)
+
+type HostMoveTaskStatus string
+
+const (
+	HostMoveTaskPending   HostMoveTaskStatus = "pending"
+	HostMoveTaskRunning   HostMoveTaskStatus = "running"
+	HostMoveTaskFailed    HostMoveTaskStatus = "failed"
+	HostMoveTaskDone      HostMoveTaskStatus = "done"
+	HostMoveTaskCancelled HostMoveTaskStatus = "cancelled"
+)
+
+type HostMoveOptions struct {
+	EraseDisks bool `bson:"erase_disks"`
+	KeepConfig bool `bson:"keep_config"`
+}
+
+type HostMoveStep struct {
+	Type       HostMoveStepType   `bson:"type"`
+	Status     HostMoveStepStatus `bson:"status"`
+	Attempts   int                `bson:"attempts"`
+	Error      string             `bson:"error"`
+	StartedAt  *time.Time         `bson:"started_at"`
+	FinishedAt *time.Time         `bson:"finished_at"`
+}
+
+type HostMoveTask struct {
+	ID              string             `bson:"_id"`
+	HostID          string             `bson:"host_id"`
+	SourceProjectID string             `bson:"source_project_id"`
+	TargetProjectID string             `bson:"target_project_id"`
+	Options         HostMoveOptions    `bson:"options"`
+	Steps           []HostMoveStep     `bson:"steps"`
+	Status          HostMoveTaskStatus `bson:"status"`
+	CMSTaskID       string             `bson:"cms_task_id"`
+	LeaseUntil      *time.Time         `bson:"lease_until"`
+	Version         int64              `bson:"version"`
+	CreatedAt       time.Time          `bson:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at"`
+	events          []interface{}      `bson:"-"`
+}
+
+func NewHostMoveTask(hostID string, sourceProjectID string, targetProjectID string, options HostMoveOptions, steps []HostMoveStepType, now time.Time) *HostMoveTask {
+	task := &HostMoveTask{
+		ID:              uuid.NewString(),
+		HostID:          hostID,
+		SourceProjectID: sourceProjectID,
+		TargetProjectID: targetProjectID,
+		Options:         options,
+		Steps:           make([]HostMoveStep, 0, len(steps)),
+		Status:          HostMoveTaskPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	for _, step := range steps {
+		task.Steps = append(task.Steps, HostMoveStep{Type: step, Status: HostMoveStepPending})
+	}
+	return task
+}
+
+func (t *HostMoveTask) Events() []interface{} {
+	return t.events
+}
+
+func (t *HostMoveTask) ClearEvents() {
+	t.events = nil
+}
+
+func (t *HostMoveTask) addEvent(event interface{}) {
+	t.events = append(t.events, event)
+}
+
+func (t *HostMoveTask) IsActive() bool {
+	return t.Status != HostMoveTaskDone && t.Status != HostMoveTaskCancelled
+}
+
+func (t *HostMoveTask) CurrentStep() *HostMoveStep {
+	for i := range t.Steps {
+		if t.Steps[i].Status != HostMoveStepDone {
+			return &t.Steps[i]
+		}
+	}
+	return nil
+}
+
+func (t *HostMoveTask) StartStep(now time.Time) error {
+	step := t.CurrentStep()
+	if step == nil || t.Status == HostMoveTaskFailed || !t.IsActive() {
+		return &errors.HostMoveTaskStatusError{TaskID: t.ID, Status: string(t.Status)}
+	}
+
+	t.Status = HostMoveTaskRunning
+	step.Status = HostMoveStepRunning
+	step.Attempts++
+	step.Error = ""
+	step.StartedAt = &now
+	step.FinishedAt = nil
+	t.UpdatedAt = now
+	t.addStepEvent(step)
+	return nil
+}
+
+func (t *HostMoveTask) CompleteStep(now time.Time) {
+	step := t.CurrentStep()
+	if step == nil {
+		return
+	}
+
+	step.Status = HostMoveStepDone
+	step.FinishedAt = &now
+	t.UpdatedAt = now
+	t.addStepEvent(step)
+
+	if t.CurrentStep() == nil {
+		t.Status = HostMoveTaskDone
+		t.LeaseUntil = nil
+	}
+}
+
+func (t *HostMoveTask) FailStep(now time.Time, err error) {
+	step := t.CurrentStep()
+	if step == nil {
+		return
+	}
+
+	step.Status = HostMoveStepFailed
+	step.Error = err.Error()
+	step.FinishedAt = &now
+	t.Status = HostMoveTaskFailed
+	t.LeaseUntil = nil
+	t.UpdatedAt = now
+	t.addStepEvent(step)
+}
+
+func (t *HostMoveTask) Claim(now time.Time, until time.Time) error {
+	if !t.IsActive() {
+		return &errors.HostMoveTaskStatusError{TaskID: t.ID, Status: string(t.Status)}
+	}
+	if t.Status == HostMoveTaskRunning && t.LeaseUntil != nil && now.Before(*t.LeaseUntil) {
+		return fmt.Errorf("%w: task %s", errors.ErrHostMoveInProgress, t.ID)
+	}
+
+	if step := t.CurrentStep(); step != nil && t.Status == HostMoveTaskFailed {
+		step.Status = HostMoveStepPending
+	}
+	t.Status = HostMoveTaskRunning
+	t.LeaseUntil = &until
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *HostMoveTask) RenewLease(until time.Time) {
+	if t.Status == HostMoveTaskRunning {
+		t.LeaseUntil = &until
+	}
+}
+
+func (t *HostMoveTask) Cancel(now time.Time) error {
+	if !t.IsActive() {
+		return &errors.HostMoveTaskStatusError{TaskID: t.ID, Status: string(t.Status)}
+	}
+
+	t.Status = HostMoveTaskCancelled
+	t.LeaseUntil = nil
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *HostMoveTask) addStepEvent(step *HostMoveStep) {
+	t.addEvent(&events.HostMoveStepChangedEvent{
+		TaskID:   t.ID,
+		HostID:   t.HostID,
+		Step:     string(step.Type),
+		Status:   string(step.Status),
+		Attempts: step.Attempts,
+		Error:    step.Error,
+	})
+}
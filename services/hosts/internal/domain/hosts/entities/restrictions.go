@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"slices"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/hosts/validators"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type HostOperation string
+
+const (
+	HostOperationReboot      HostOperation = "reboot"
+	HostOperationRedeploy    HostOperation = "redeploy"
+	HostOperationProfile     HostOperation = "profile"
+	HostOperationEraseDisks  HostOperation = "erase-disks"
+	HostOperationSwitchVlans HostOperation = "switch-vlans"
+)
+
+var hostOperationRestrictions = map[HostOperation]core_entities.Restriction{
+	HostOperationReboot:      core_entities.RestrictionNoReboot,
+	HostOperationRedeploy:    core_entities.RestrictionNoRedeploy,
+	HostOperationProfile:     core_entities.RestrictionNoProfile,
+	HostOperationEraseDisks:  core_entities.RestrictionNoRedeploy,
+	HostOperationSwitchVlans: core_entities.RestrictionNoVlanChange,
+}
+
+func (o HostOperation) Restriction() core_entities.Restriction {
+	return hostOperationRestrictions[o]
+}
+
+func (h *Host) StateChangeOperation(to HostState) (HostOperation, bool) {
+	if to == HostStateFree && h.State.HasProject() {
+		return HostOperationEraseDisks, true
+	}
+	return "", false
+}
+
+func (h *Host) LocationChangeOperation(to HostLocation) (HostOperation, bool) {
+	if h.Location.Switch != "" && (h.Location.Switch != to.Switch || h.Location.Port != to.Port) {
+		return HostOperationSwitchVlans, true
+	}
+	return "", false
+}
+
+func (h *Host) RegistrationOperation(projectHasNetwork bool) (HostOperation, bool) {
+	if projectHasNetwork && h.Location.Switch != "" {
+		return HostOperationSwitchVlans, true
+	}
+	return "", false
+}
+
+func (h *Host) SetRestrictions(restrictions []core_entities.Restriction) error {
+	if err := validators.ValidateRestrictions(restrictions); err != nil {
+		return err
+	}
+	restrictions = slices.Clone(restrictions)
+	slices.Sort(restrictions)
+	restrictions = slices.Compact(restrictions)
+	if restrictions == nil {
+		restrictions = []core_entities.Restriction{}
+	}
+	if slices.Equal(h.Restrictions, restrictions) {
+		return nil
+	}
+
+	h.Restrictions = restrictions
+	h.addEvent(&events.HostRestrictionsChangedEvent{
+		ID:           h.ID,
+		FQDN:         h.FQDN,
+		Restrictions: slices.Clone(restrictions),
+	})
+	return nil
+}
+
+func (h *Host) EffectiveRestrictions(projectDefaults []core_entities.Restriction) []core_entities.Restriction {
+	return core_entities.EffectiveRestrictions(projectDefaults, h.Restrictions)
+}
+
+func (h *Host) CheckOperation(operation HostOperation, projectDefaults []core_entities.Restriction, automated bool) error {
+	restriction, forbidden := core_entities.ForbiddenBy(h.EffectiveRestrictions(projectDefaults), operation.Restriction(), automated)
+	if !forbidden {
+		return nil
+	}
+	return &errors.HostRestrictedError{
+		HostID:      h.ID,
+		Operation:   string(operation),
+		Restriction: string(restriction),
+	}
+}
@@ -0,0 +1,93 @@
+package entities_test
+
+import (
+	"errors"
+
+	"github.com/gwall-e/hosts/events"
+	. "github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Host restrictions", func() {
+	var host *Host
+
+	BeforeEach(func() {
+		var err error
+		host, err = NewHost("inv-1", "host-1.example.net", core_entities.TypeServer, HostLocation{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should store sorted unique restrictions and emit an event on change", func() {
+		restrictions := []core_entities.Restriction{core_entities.RestrictionNoVlanChange, core_entities.RestrictionNoReboot, core_entities.RestrictionNoReboot}
+		Expect(host.SetRestrictions(restrictions)).To(Succeed())
+		Expect(host.SetRestrictions(restrictions)).To(Succeed())
+
+		Expect(host.Restrictions).To(Equal([]core_entities.Restriction{core_entities.RestrictionNoReboot, core_entities.RestrictionNoVlanChange}))
+		Expect(host.Events()).To(Equal([]interface{}{&events.HostRestrictionsChangedEvent{
+			ID:           host.ID,
+			FQDN:         host.FQDN,
+			Restrictions: host.Restrictions,
+		}}))
+	})
+
+	It("should reject unknown restrictions", func() {
+		var validationErr *hostErrors.HostValidationError
+		Expect(errors.As(host.SetRestrictions([]core_entities.Restriction{"no-power"}), &validationErr)).To(BeTrue())
+		Expect(host.Restrictions).To(BeEmpty())
+	})
+
+	DescribeTable("should check operations against effective restrictions",
+		func(hostRestrictions []core_entities.Restriction, projectDefaults []core_entities.Restriction, operation HostOperation, automated bool, expected core_entities.Restriction) {
+			Expect(host.SetRestrictions(hostRestrictions)).To(Succeed())
+			err := host.CheckOperation(operation, projectDefaults, automated)
+			if expected == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			var restrictedErr *hostErrors.HostRestrictedError
+			Expect(errors.As(err, &restrictedErr)).To(BeTrue())
+			Expect(restrictedErr.Restriction).To(Equal(string(expected)))
+			Expect(restrictedErr.Operation).To(Equal(string(operation)))
+		},
+		Entry("unrestricted", nil, nil, HostOperationReboot, true, core_entities.Restriction("")),
+		Entry("host restriction", []core_entities.Restriction{core_entities.RestrictionNoVlanChange}, nil,
+			HostOperationSwitchVlans, false, core_entities.RestrictionNoVlanChange),
+		Entry("implied by project default", nil, []core_entities.Restriction{core_entities.RestrictionNoReboot},
+			HostOperationProfile, false, core_entities.RestrictionNoProfile),
+		Entry("erase disks needs redeploy", []core_entities.Restriction{core_entities.RestrictionNoRedeploy}, nil,
+			HostOperationEraseDisks, false, core_entities.RestrictionNoRedeploy),
+		Entry("narrower does not imply broader", []core_entities.Restriction{core_entities.RestrictionNoProfile}, nil,
+			HostOperationReboot, false, core_entities.Restriction("")),
+		Entry("automated under no automation", []core_entities.Restriction{core_entities.RestrictionNoAutomation}, nil,
+			HostOperationReboot, true, core_entities.RestrictionNoAutomation),
+		Entry("manual under no automation", []core_entities.Restriction{core_entities.RestrictionNoAutomation}, nil,
+			HostOperationReboot, false, core_entities.Restriction("")),
+	)
+
+	It("should map host data changes to the operations they cause", func() {
+		host.ProjectID = "search"
+		host.State = HostStateReady
+		host.Location = HostLocation{Datacenter: "sas", Switch: "sas-s1", Port: "1"}
+
+		operation, ok := host.StateChangeOperation(HostStateFree)
+		Expect(ok).To(BeTrue())
+		Expect(operation).To(Equal(HostOperationEraseDisks))
+		_, ok = host.StateChangeOperation(HostStateMaintenance)
+		Expect(ok).To(BeFalse())
+
+		operation, ok = host.LocationChangeOperation(HostLocation{Datacenter: "sas", Switch: "sas-s1", Port: "2"})
+		Expect(ok).To(BeTrue())
+		Expect(operation).To(Equal(HostOperationSwitchVlans))
+		_, ok = host.LocationChangeOperation(HostLocation{Datacenter: "sas", Rack: "a1", Switch: "sas-s1", Port: "1"})
+		Expect(ok).To(BeFalse())
+
+		operation, ok = host.RegistrationOperation(true)
+		Expect(ok).To(BeTrue())
+		Expect(operation).To(Equal(HostOperationSwitchVlans))
+		_, ok = host.RegistrationOperation(false)
+		Expect(ok).To(BeFalse())
+	})
+})
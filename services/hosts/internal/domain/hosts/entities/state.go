@@ -0,0 +1,78 @@
+package entities
+
+type HostState string
+
+const (
+	HostStateFree           HostState = "free"
+	HostStateAssigned       HostState = "assigned"
+	HostStateReady          HostState = "ready"
+	HostStateMaintenance    HostState = "maintenance"
+	HostStateProbation      HostState = "probation"
+	HostStateDead           HostState = "dead"
+	HostStateDecommissioned HostState = "decommissioned"
+)
+
+var hostStateTransitions = map[HostState][]HostState{
+	HostStateFree:           {HostStateAssigned, HostStateDecommissioned},
+	HostStateAssigned:       {HostStateReady, HostStateMaintenance, HostStateProbation, HostStateDead, HostStateFree},
+	HostStateReady:          {HostStateAssigned, HostStateMaintenance, HostStateProbation, HostStateDead, HostStateFree},
+	HostStateMaintenance:    {HostStateAssigned, HostStateReady, HostStateProbation, HostStateDead, HostStateFree},
+	HostStateProbation:      {HostStateAssigned, HostStateReady, HostStateMaintenance, HostStateDead, HostStateFree},
+	HostStateDead:           {HostStateAssigned, HostStateMaintenance, HostStateProbation, HostStateFree, HostStateDecommissioned},
+	HostStateDecommissioned: {},
+}
+
+func (s HostState) IsValid() bool {
+	_, ok := hostStateTransitions[s]
+	return ok
+}
+
+func (s HostState) CanTransitionTo(to HostState) bool {
+	for _, allowed := range hostStateTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s HostState) HasProject() bool {
+	return s != HostStateFree && s != HostStateDecommissioned
+}
+
+type HostStatus string
+
+const (
+	HostStatusReady          HostStatus = "ready"
+	HostStatusDeploying      HostStatus = "deploying"
+	HostStatusRebooting      HostStatus = "rebooting"
+	HostStatusProfiling      HostStatus = "profiling"
+	HostStatusPreparing      HostStatus = "preparing"
+	HostStatusSwitchingVlans HostStatus = "switching-vlans"
+	HostStatusPoweringOff    HostStatus = "powering-off"
+	HostStatusPoweringOn     HostStatus = "powering-on"
+	HostStatusFailed         HostStatus = "failed"
+)
+
+func (s HostStatus) IsValid() bool {
+	switch s {
+	case HostStatusReady, HostStatusDeploying, HostStatusRebooting, HostStatusProfiling, HostStatusPreparing,
+		HostStatusSwitchingVlans, HostStatusPoweringOff, HostStatusPoweringOn, HostStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (s HostStatus) IsIdle() bool {
+	return s == HostStatusReady || s == HostStatusFailed
+}
+
+func (s HostStatus) CanTransitionTo(to HostStatus) bool {
+	if s == to {
+		return false
+	}
+	if s.IsIdle() {
+		return true
+	}
+	return to.IsIdle()
+}
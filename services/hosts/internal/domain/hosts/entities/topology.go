@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+type FailureDomainLimit struct {
+	Level          LocationLevel `bson:"level"`
+	MaxUnavailable int           `bson:"max_unavailable"`
+}
+
+func (l FailureDomainLimit) Validate() error {
+	if !l.Level.IsValid() {
+		return &errors.HostValidationError{
+			Field:   "failure_domain_limit.level",
+			Message: fmt.Sprintf("unknown location level %q", l.Level),
+		}
+	}
+	if l.MaxUnavailable < 1 {
+		return &errors.HostValidationError{
+			Field:   "failure_domain_limit.max_unavailable",
+			Message: "at least one host must be allowed",
+		}
+	}
+	return nil
+}
+
+func (l FailureDomainLimit) Check(candidates []*Host, domainHosts []*Host) error {
+	unavailable := map[string]map[string]struct{}{}
+	mark := func(host *Host) {
+		domain, ok := host.Location.FailureDomain(l.Level)
+		if !ok {
+			return
+		}
+		if unavailable[domain] == nil {
+			unavailable[domain] = map[string]struct{}{}
+		}
+		unavailable[domain][host.ID] = struct{}{}
+	}
+
+	for _, host := range domainHosts {
+		if host.IsOutOfService() {
+			mark(host)
+		}
+	}
+	for _, host := range candidates {
+		mark(host)
+	}
+
+	for _, host := range candidates {
+		domain, ok := host.Location.FailureDomain(l.Level)
+		if ok && len(unavailable[domain]) > l.MaxUnavailable {
+			return &errors.FailureDomainLimitError{
+				Level:       string(l.Level),
+				Domain:      domain,
+				Limit:       l.MaxUnavailable,
+				Unavailable: len(unavailable[domain]),
+			}
+		}
+	}
+	return nil
+}
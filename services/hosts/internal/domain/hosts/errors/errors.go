@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrHostNotFound        = errors.New("host not found")
+	ErrHostAlreadyExists   = errors.New("host with the same inventory number, fqdn or mac already exists")
+	ErrHostVersionConflict = errors.New("host was modified concurrently")
+	ErrProjectNotFound     = errors.New("project not found")
+	ErrMoveTaskNotFound    = errors.New("host move task not found")
+	ErrMoveVersionConflict = errors.New("host move task was modified concurrently")
+	ErrHostMoveInProgress  = errors.New("host is already being moved")
+)
+
+type HostValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e HostValidationError) Error() string {
+	return fmt.Sprintf("host validation error, field: %s, err: %s", e.Field, e.Message)
+}
+
+type InvalidStateTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidStateTransitionError) Error() string {
+	return fmt.Sprintf("invalid host state transition from %s to %s", e.From, e.To)
+}
+
+type HostBusyError struct {
+	Status string
+	To     string
+}
+
+func (e *HostBusyError) Error() string {
+	return fmt.Sprintf("host is %s, state can not change to %s until the operation finishes", e.Status, e.To)
+}
+
+type InvalidStatusTransitionError struct {
+	State string
+	From  string
+	To    string
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("invalid host status transition from %s to %s in state %s", e.From, e.To, e.State)
+}
+
+type HostMoveTaskStatusError struct {
+	TaskID string
+	Status string
+}
+
+func (e *HostMoveTaskStatusError) Error() string {
+	return fmt.Sprintf("host move task %s can not proceed in status %s", e.TaskID, e.Status)
+}
+
+type HostMoveStepError struct {
+	TaskID string
+	Step   string
+	Err    error
+}
+
+func (e *HostMoveStepError) Error() string {
+	return fmt.Sprintf("host move task %s failed at step %s: %v", e.TaskID, e.Step, e.Err)
+}
+
+func (e *HostMoveStepError) Unwrap() error {
+	return e.Err
+}
+
+type FailureDomainLimitError struct {
+	Level       string
+	Domain      string
+	Limit       int
+	Unavailable int
+}
+
+func (e *FailureDomainLimitError) Error() string {
+	return fmt.Sprintf("%s %s would have %d hosts out of service, limit is %d", e.Level, e.Domain, e.Unavailable, e.Limit)
+}
+
+type LocationImportError struct {
+	InventoryNumber string
+	Err             error
+}
+
+func (e *LocationImportError) Error() string {
+	return fmt.Sprintf("location of host %s: %v", e.InventoryNumber, e.Err)
+}
+
+func (e *LocationImportError) Unwrap() error {
+	return e.Err
+}
+
+type HostRestrictedError struct {
+	HostID      string
+	Operation   string
+	Restriction string
+}
+
+func (e *HostRestrictedError) Error() string {
+	return fmt.Sprintf("%s of host %s is forbidden by restriction %s", e.Operation, e.HostID, e.Restriction)
+}
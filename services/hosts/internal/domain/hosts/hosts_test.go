@@ -0,0 +1,21 @@
+package hosts_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hosts Suite")
+}
+
+type fakeProjects map[string]*projects.Project
+
+func (f fakeProjects) GetProject(ctx context.Context, id string) (*projects.Project, error) {
+	return f[id], nil
+}
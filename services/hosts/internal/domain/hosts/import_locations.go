@@ -0,0 +1,159 @@
+package hosts
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+type LocationRecord struct {
+	InventoryNumber string
+	Location        entities.HostLocation
+}
+
+func (c *HostService) ImportLocations(ctx context.Context, records []LocationRecord) ([]*entities.Host, error) {
+	var errs []error
+	hosts := make([]*entities.Host, 0, len(records))
+	imported := map[string]entities.HostLocation{}
+
+	for _, record := range records {
+		recordErr := func(err error) {
+			errs = append(errs, &errors.LocationImportError{InventoryNumber: record.InventoryNumber, Err: err})
+		}
+
+		if err := record.Location.Validate(); err != nil {
+			recordErr(err)
+			continue
+		}
+		host, err := c.hosts.GetByInventoryNumber(ctx, record.InventoryNumber)
+		if err != nil {
+			return nil, err
+		}
+		if host == nil {
+			recordErr(errors.ErrHostNotFound)
+			continue
+		}
+		if _, ok := imported[host.ID]; ok {
+			recordErr(fmt.Errorf("host is listed more than once"))
+			continue
+		}
+		if operation, ok := host.LocationChangeOperation(record.Location); ok {
+			project, err := c.getProject(ctx, host.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			if err := host.CheckOperation(operation, project.EffectiveRestrictions(), false); err != nil {
+				recordErr(err)
+				continue
+			}
+		}
+
+		imported[host.ID] = record.Location
+		hosts = append(hosts, host)
+	}
+	if len(errs) > 0 {
+		return nil, stdErrors.Join(errs...)
+	}
+
+	if err := c.checkTopology(ctx, hosts, imported); err != nil {
+		return nil, err
+	}
+
+	for _, host := range hosts {
+		if err := host.SetLocation(imported[host.ID]); err != nil {
+			return nil, err
+		}
+		if len(host.Events()) == 0 {
+			continue
+		}
+		if err := c.hosts.Update(ctx, host); err != nil {
+			return nil, &errors.LocationImportError{InventoryNumber: host.InventoryNumber, Err: err}
+		}
+	}
+	return hosts, nil
+}
+
+type topologyEntry struct {
+	inventoryNumber string
+	location        entities.HostLocation
+}
+
+func (c *HostService) checkTopology(ctx context.Context, hosts []*entities.Host, imported map[string]entities.HostLocation) error {
+	entries := map[string]topologyEntry{}
+	filters := map[string]contracts.HostFilter{}
+	for _, host := range hosts {
+		location := imported[host.ID]
+		entries[host.ID] = topologyEntry{inventoryNumber: host.InventoryNumber, location: location}
+		for _, level := range []entities.LocationLevel{entities.LocationLevelRack, entities.LocationLevelSwitch} {
+			if domain, ok := location.FailureDomain(level); ok {
+				filters[string(level)+":"+domain], _ = failureDomainFilter(location, level)
+			}
+		}
+	}
+
+	for _, filter := range filters {
+		stored, err := c.listAll(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, host := range stored {
+			if _, ok := entries[host.ID]; !ok {
+				entries[host.ID] = topologyEntry{inventoryNumber: host.InventoryNumber, location: host.Location}
+			}
+		}
+	}
+
+	var errs []error
+	for _, host := range hosts {
+		entry := entries[host.ID]
+		location := entry.location
+		conflict := func(reason string, other topologyEntry) {
+			errs = append(errs, &errors.LocationImportError{
+				InventoryNumber: entry.inventoryNumber,
+				Err:             fmt.Errorf("%s by host %s", reason, other.inventoryNumber),
+			})
+		}
+
+		if rack, ok := location.FailureDomain(entities.LocationLevelRack); ok {
+			if other, ok := findRackConflict(entries, host.ID, rack, func(o entities.HostLocation) bool {
+				return o.Hall != location.Hall || o.Row != location.Row
+			}); ok {
+				conflict(fmt.Sprintf("rack %s is placed in another hall or row", rack), other)
+			}
+
+			if location.Unit != 0 {
+				if other, ok := findRackConflict(entries, host.ID, rack, func(o entities.HostLocation) bool {
+					return o.Unit == location.Unit
+				}); ok {
+					conflict(fmt.Sprintf("unit %d of rack %s is taken", location.Unit, rack), other)
+				}
+			}
+		}
+
+		if location.Switch != "" {
+			for id, other := range entries {
+				if id != host.ID && other.location.Switch == location.Switch && other.location.Datacenter != location.Datacenter {
+					conflict(fmt.Sprintf("switch %s is placed in another datacenter", location.Switch), other)
+					break
+				}
+			}
+		}
+	}
+	return stdErrors.Join(errs...)
+}
+
+func findRackConflict(entries map[string]topologyEntry, hostID string, rack string, conflicts func(entities.HostLocation) bool) (topologyEntry, bool) {
+	for id, other := range entries {
+		if id == hostID {
+			continue
+		}
+		if otherRack, ok := other.location.FailureDomain(entities.LocationLevelRack); ok && otherRack == rack && conflicts(other.location) {
+			return other, true
+		}
+	}
+	return topologyEntry{}, false
+}
@@ -0,0 +1,352 @@
+package hosts
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cmsEntities "github.com/gwall-e/hosts/internal/domain/cms/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+)
+
+const (
+	MOVE_CMS_ISSUER = "hosts"
+	MOVE_LEASE      = 5 * time.Minute
+)
+
+type moveRun struct {
+	mu   sync.Mutex
+	task *entities.HostMoveTask
+}
+
+func (c *HostService) MoveHost(ctx context.Context, hostID string, targetProjectID string, options entities.HostMoveOptions) (*entities.HostMoveTask, error) {
+	if targetProjectID == "" {
+		return nil, &errors.HostValidationError{Field: "project_id", Message: "target project is required"}
+	}
+	host, err := c.getHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if host.ProjectID == targetProjectID {
+		return nil, &errors.HostValidationError{
+			Field:   "project_id",
+			Message: fmt.Sprintf("host is already in project %s", targetProjectID),
+		}
+	}
+	if host.State == entities.HostStateDead || host.State == entities.HostStateDecommissioned || !host.Status.IsIdle() {
+		return nil, &errors.InvalidStateTransitionError{From: string(host.State), To: string(entities.HostStateAssigned)}
+	}
+
+	active, err := c.moves.FindActiveByHost(ctx, host.ID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("%w: task %s", errors.ErrHostMoveInProgress, active.ID)
+	}
+
+	source, err := c.getProject(ctx, host.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := c.getProject(ctx, targetProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrProjectNotFound, targetProjectID)
+	}
+	if err := checkProjectAccepts(target, host); err != nil {
+		return nil, err
+	}
+
+	steps := PlanMove(source, target, options)
+	for _, step := range steps {
+		if err := checkMoveStepAllowed(host, source, target, step); err != nil {
+			return nil, err
+		}
+	}
+
+	now := c.now()
+	task := entities.NewHostMoveTask(host.ID, host.ProjectID, target.ID, options, steps, now)
+	if err := task.Claim(now, now.Add(MOVE_LEASE)); err != nil {
+		return nil, err
+	}
+	if err := c.moves.Create(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, c.runHostMove(ctx, task)
+}
+
+func (c *HostService) ResumeHostMove(ctx context.Context, taskID string) (*entities.HostMoveTask, error) {
+	task, err := c.GetHostMove(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	now := c.now()
+	if err := task.Claim(now, now.Add(MOVE_LEASE)); err != nil {
+		return nil, err
+	}
+	if err := c.moves.Update(ctx, task); err != nil {
+		if stdErrors.Is(err, errors.ErrMoveVersionConflict) {
+			return nil, fmt.Errorf("%w: task %s", errors.ErrHostMoveInProgress, task.ID)
+		}
+		return nil, err
+	}
+	return task, c.runHostMove(ctx, task)
+}
+
+func (c *HostService) GetHostMove(ctx context.Context, taskID string) (*entities.HostMoveTask, error) {
+	task, err := c.moves.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrMoveTaskNotFound, taskID)
+	}
+	return task, nil
+}
+
+func (c *HostService) CancelHostMove(ctx context.Context, taskID string) (*entities.HostMoveTask, error) {
+	task, err := c.GetHostMove(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.Cancel(c.now()); err != nil {
+		return nil, err
+	}
+	return task, c.moves.Update(ctx, task)
+}
+
+func (c *HostService) runHostMove(ctx context.Context, task *entities.HostMoveTask) error {
+	run := &moveRun{task: task}
+	ctx, release := c.holdMoveLease(ctx, run)
+	defer release()
+
+	for step := task.CurrentStep(); step != nil; step = task.CurrentStep() {
+		err := c.updateMove(ctx, run, func(task *entities.HostMoveTask) error {
+			return task.StartStep(c.now())
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := c.runMoveStep(ctx, run, step.Type); err != nil {
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
+			saveErr := c.updateMove(ctx, run, func(task *entities.HostMoveTask) error {
+				task.FailStep(c.now(), err)
+				return nil
+			})
+			if saveErr != nil {
+				return saveErr
+			}
+			return &errors.HostMoveStepError{TaskID: task.ID, Step: string(step.Type), Err: err}
+		}
+
+		err = c.updateMove(ctx, run, func(task *entities.HostMoveTask) error {
+			task.CompleteStep(c.now())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *HostService) holdMoveLease(ctx context.Context, run *moveRun) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(MOVE_LEASE / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.updateMove(ctx, run, nil); err != nil {
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		wg.Wait()
+		cancel(nil)
+	}
+}
+
+func (c *HostService) updateMove(ctx context.Context, run *moveRun, mutate func(*entities.HostMoveTask) error) error {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	if mutate != nil {
+		if err := mutate(run.task); err != nil {
+			return err
+		}
+	}
+	run.task.RenewLease(c.now().Add(MOVE_LEASE))
+	return c.moves.Update(ctx, run.task)
+}
+
+func (c *HostService) runMoveStep(ctx context.Context, run *moveRun, step entities.HostMoveStepType) error {
+	task := run.task
+	host, err := c.getHost(ctx, task.HostID)
+	if err != nil {
+		return err
+	}
+	source, err := c.getProject(ctx, task.SourceProjectID)
+	if err != nil {
+		return err
+	}
+	target, err := c.getProject(ctx, task.TargetProjectID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return fmt.Errorf("%w: %s", errors.ErrProjectNotFound, task.TargetProjectID)
+	}
+	if err := checkMoveStepAllowed(host, source, target, step); err != nil {
+		return err
+	}
+
+	switch step {
+	case entities.HostMoveStepCMSRelease:
+		return c.releaseForMove(ctx, run, source, host)
+	case entities.HostMoveStepSwitchVlans:
+		native, extra := 0, []int(nil)
+		if target.Network != nil {
+			native, extra = target.Network.NativeVlan, target.Network.ExtraVlans
+		}
+		return c.operate(ctx, host, entities.HostStatusSwitchingVlans, func() error {
+			return c.operator.SwitchVlans(ctx, host, native, extra)
+		})
+	case entities.HostMoveStepEraseDisks:
+		return c.operate(ctx, host, entities.HostStatusPreparing, func() error {
+			return c.operator.EraseDisks(ctx, host)
+		})
+	case entities.HostMoveStepRedeploy:
+		deploying := moveDeploying(source, target, task.Options)
+		if deploying == nil {
+			return &errors.HostValidationError{Field: "deploying", Message: "no deploying config to redeploy the host with"}
+		}
+		return c.operate(ctx, host, entities.HostStatusDeploying, func() error {
+			return c.operator.Redeploy(ctx, host, deploying)
+		})
+	case entities.HostMoveStepChangeProject:
+		if host.ProjectID == task.TargetProjectID {
+			return nil
+		}
+		if err := host.MoveTo(task.TargetProjectID, "moved by task "+task.ID); err != nil {
+			return err
+		}
+		return c.hosts.Update(ctx, host)
+	case entities.HostMoveStepCMSComplete:
+		if task.CMSTaskID == "" {
+			return nil
+		}
+		return c.releaser.CompleteRelease(ctx, source, task.CMSTaskID)
+	default:
+		return fmt.Errorf("unknown host move step %q", step)
+	}
+}
+
+func checkMoveStepAllowed(host *entities.Host, source *projects.Project, target *projects.Project, step entities.HostMoveStepType) error {
+	operation, ok := step.Operation()
+	if !ok {
+		return nil
+	}
+	defaults := append(source.EffectiveRestrictions(), target.EffectiveRestrictions()...)
+	return host.CheckOperation(operation, defaults, false)
+}
+
+func (c *HostService) releaseForMove(ctx context.Context, run *moveRun, source *projects.Project, host *entities.Host) error {
+	task := run.task
+	if task.CMSTaskID == "" {
+		action := cmsEntities.CMSActionMaintenance
+		for _, step := range task.Steps {
+			if step.Type == entities.HostMoveStepRedeploy {
+				action = cmsEntities.CMSActionRedeploy
+			}
+		}
+
+		release, err := c.releaser.RequestRelease(ctx, source, action, []string{host.FQDN}, MOVE_CMS_ISSUER)
+		if err != nil {
+			return err
+		}
+		err = c.updateMove(ctx, run, func(task *entities.HostMoveTask) error {
+			task.CMSTaskID = release.ID
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := c.releaser.AwaitRelease(ctx, source, task.CMSTaskID)
+	return err
+}
+
+func (c *HostService) operate(ctx context.Context, host *entities.Host, status entities.HostStatus, fn func() error) error {
+	if host.Status != status {
+		if err := host.SetStatus(status, ""); err != nil {
+			return err
+		}
+		if err := c.hosts.Update(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	opErr := fn()
+	result := entities.HostStatusReady
+	reason := ""
+	if opErr != nil {
+		result, reason = entities.HostStatusFailed, opErr.Error()
+	}
+	if err := host.SetStatus(result, reason); err != nil {
+		return err
+	}
+	if err := c.hosts.Update(ctx, host); err != nil {
+		return err
+	}
+	return opErr
+}
+
+func (c *HostService) getHost(ctx context.Context, id string) (*entities.Host, error) {
+	host, err := c.hosts.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if host == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrHostNotFound, id)
+	}
+	return host, nil
+}
+
+func (c *HostService) getProject(ctx context.Context, id string) (*projects.Project, error) {
+	if id == "" {
+		return nil, nil
+	}
+	project, err := c.projects.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrProjectNotFound, id)
+	}
+	return project, nil
+}
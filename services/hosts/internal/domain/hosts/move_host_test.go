@@ -0,0 +1,400 @@
+package hosts_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/hosts/events"
+	cmsEntities "github.com/gwall-e/hosts/internal/domain/cms/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeReleaser struct {
+	requested []cmsEntities.CMSAction
+	completed []string
+}
+
+func (f *fakeReleaser) RequestRelease(ctx context.Context, project *projects.Project, action cmsEntities.CMSAction, hosts []string, issuer string) (*cmsEntities.CMSTask, error) {
+	f.requested = append(f.requested, action)
+	return &cmsEntities.CMSTask{ID: fmt.Sprintf("cms-%d", len(f.requested)), Action: action, Hosts: hosts, Issuer: issuer}, nil
+}
+
+func (f *fakeReleaser) AwaitRelease(ctx context.Context, project *projects.Project, taskID string) (*cmsEntities.CMSTask, error) {
+	return &cmsEntities.CMSTask{ID: taskID, Status: cmsEntities.CMSTaskApproved}, nil
+}
+
+func (f *fakeReleaser) CompleteRelease(ctx context.Context, project *projects.Project, taskID string) error {
+	f.completed = append(f.completed, taskID)
+	return nil
+}
+
+type fakeOperator struct {
+	calls  []string
+	fail   map[string]error
+	onCall func(name string)
+}
+
+func (f *fakeOperator) call(name string) error {
+	f.calls = append(f.calls, name)
+	if f.onCall != nil {
+		onCall := f.onCall
+		f.onCall = nil
+		onCall(name)
+	}
+	err := f.fail[name]
+	delete(f.fail, name)
+	return err
+}
+
+func (f *fakeOperator) SwitchVlans(ctx context.Context, host *entities.Host, nativeVlan int, extraVlans []int) error {
+	return f.call(fmt.Sprintf("switch-vlans %d %v", nativeVlan, extraVlans))
+}
+
+func (f *fakeOperator) EraseDisks(ctx context.Context, host *entities.Host) error {
+	return f.call("erase-disks")
+}
+
+func (f *fakeOperator) Redeploy(ctx context.Context, host *entities.Host, deploying *projectEntities.Deploying) error {
+	return f.call("redeploy " + deploying.Config)
+}
+
+var _ = Describe("MoveHost", func() {
+	var (
+		search *projects.Project
+		ads    *projects.Project
+	)
+
+	BeforeEach(func() {
+		search = &projects.Project{
+			ID:        "search",
+			Type:      core_entities.TypeServer,
+			CMS:       []projectEntities.CMS{{Enabled: true, URL: "http://cms.search"}},
+			Network:   &projectEntities.Network{NativeVlan: 604, ExtraVlans: []int{700}},
+			Deploying: &projectEntities.Deploying{Config: "search-focal", Tags: []string{"a", "b"}},
+		}
+		ads = &projects.Project{
+			ID:        "ads",
+			Type:      core_entities.TypeServer,
+			Network:   &projectEntities.Network{NativeVlan: 542},
+			Deploying: &projectEntities.Deploying{Config: "ads-jammy"},
+		}
+	})
+
+	DescribeTable("should plan steps from project differences",
+		func(mutate func(source *projects.Project, target *projects.Project), options entities.HostMoveOptions, free bool, expected []entities.HostMoveStepType) {
+			mutate(search, ads)
+			source := search
+			if free {
+				source = nil
+			}
+			Expect(hosts.PlanMove(source, ads, options)).To(Equal(expected))
+		},
+		Entry("full reconfiguration", func(_, _ *projects.Project) {}, entities.HostMoveOptions{}, false,
+			[]entities.HostMoveStepType{
+				entities.HostMoveStepCMSRelease,
+				entities.HostMoveStepSwitchVlans,
+				entities.HostMoveStepRedeploy,
+				entities.HostMoveStepChangeProject,
+				entities.HostMoveStepCMSComplete,
+			}),
+		Entry("same configuration without cms", func(source, target *projects.Project) {
+			source.CMS = nil
+			target.Network = &projectEntities.Network{NativeVlan: 604, ExtraVlans: []int{700, 700}}
+			target.Deploying = &projectEntities.Deploying{Config: "search-focal", Tags: []string{"b", "a"}}
+		}, entities.HostMoveOptions{}, false,
+			[]entities.HostMoveStepType{entities.HostMoveStepChangeProject}),
+		Entry("disabled cms", func(source, _ *projects.Project) { source.CMS[0].Enabled = false }, entities.HostMoveOptions{KeepConfig: true}, false,
+			[]entities.HostMoveStepType{entities.HostMoveStepSwitchVlans, entities.HostMoveStepChangeProject}),
+		Entry("keep config", func(_, _ *projects.Project) {}, entities.HostMoveOptions{KeepConfig: true}, false,
+			[]entities.HostMoveStepType{
+				entities.HostMoveStepCMSRelease,
+				entities.HostMoveStepSwitchVlans,
+				entities.HostMoveStepChangeProject,
+				entities.HostMoveStepCMSComplete,
+			}),
+		Entry("erase disks redeploys even with kept config", func(source, target *projects.Project) {
+			source.CMS = nil
+			target.Network = source.Network
+		}, entities.HostMoveOptions{EraseDisks: true, KeepConfig: true}, false,
+			[]entities.HostMoveStepType{entities.HostMoveStepEraseDisks, entities.HostMoveStepRedeploy, entities.HostMoveStepChangeProject}),
+		Entry("free host", func(_, _ *projects.Project) {}, entities.HostMoveOptions{}, true,
+			[]entities.HostMoveStepType{entities.HostMoveStepSwitchVlans, entities.HostMoveStepRedeploy, entities.HostMoveStepChangeProject}),
+		Entry("target without deploy config", func(source, target *projects.Project) {
+			source.CMS = nil
+			target.Network = source.Network
+			target.Deploying = nil
+		}, entities.HostMoveOptions{}, false,
+			[]entities.HostMoveStepType{entities.HostMoveStepChangeProject}),
+		Entry("erase disks without any deploy config", func(_, target *projects.Project) {
+			target.Deploying = nil
+		}, entities.HostMoveOptions{EraseDisks: true}, true,
+			[]entities.HostMoveStepType{entities.HostMoveStepSwitchVlans, entities.HostMoveStepEraseDisks, entities.HostMoveStepChangeProject}),
+	)
+
+	Describe("execution", func() {
+		var (
+			ctx      context.Context
+			repo     *memory.HostRepository
+			moves    *memory.HostMoveTaskRepository
+			releaser *fakeReleaser
+			operator *fakeOperator
+			service  *hosts.HostService
+			host     *entities.Host
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = memory.NewHostRepository()
+			moves = memory.NewHostMoveTaskRepository()
+			releaser = &fakeReleaser{}
+			operator = &fakeOperator{fail: map[string]error{}}
+			service = hosts.NewDomainService(repo, fakeProjects{"search": search, "ads": ads}, moves, releaser, operator)
+
+			var err error
+			host, err = entities.NewHost("100001", "sas-0001.search.example.net", core_entities.TypeServer, entities.HostLocation{}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			host.ProjectID = "search"
+			Expect(service.AddHost(ctx, host)).To(Succeed())
+		})
+
+		It("should run all planned steps", func() {
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Status).To(Equal(entities.HostMoveTaskDone))
+			for _, step := range task.Steps {
+				Expect(step.Status).To(Equal(entities.HostMoveStepDone))
+				Expect(step.Attempts).To(Equal(1))
+			}
+
+			Expect(operator.calls).To(Equal([]string{"switch-vlans 542 []", "redeploy ads-jammy"}))
+			Expect(releaser.requested).To(Equal([]cmsEntities.CMSAction{cmsEntities.CMSActionRedeploy}))
+			Expect(releaser.completed).To(Equal([]string{"cms-1"}))
+
+			moved, err := repo.GetByID(ctx, host.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(moved.ProjectID).To(Equal("ads"))
+			Expect(moved.State).To(Equal(entities.HostStateAssigned))
+			Expect(moved.Status).To(Equal(entities.HostStatusReady))
+
+			stored, err := service.GetHostMove(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Status).To(Equal(entities.HostMoveTaskDone))
+		})
+
+		It("should keep the source deploy config", func() {
+			_, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{EraseDisks: true, KeepConfig: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operator.calls).To(Equal([]string{"switch-vlans 542 []", "erase-disks", "redeploy search-focal"}))
+		})
+
+		It("should stop at the failed step and resume from it", func() {
+			operator.fail["redeploy ads-jammy"] = errors.New("deploy timeout")
+
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			var stepErr *hostErrors.HostMoveStepError
+			Expect(errors.As(err, &stepErr)).To(BeTrue())
+			Expect(stepErr.Step).To(Equal(string(entities.HostMoveStepRedeploy)))
+
+			stored, err := service.GetHostMove(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Status).To(Equal(entities.HostMoveTaskFailed))
+			Expect(stored.Steps[1].Status).To(Equal(entities.HostMoveStepDone))
+			Expect(stored.Steps[2].Status).To(Equal(entities.HostMoveStepFailed))
+			Expect(stored.Steps[2].Error).To(Equal("deploy timeout"))
+			Expect(stored.Steps[3].Status).To(Equal(entities.HostMoveStepPending))
+
+			failed, err := repo.GetByID(ctx, host.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failed.ProjectID).To(Equal("search"))
+			Expect(failed.Status).To(Equal(entities.HostStatusFailed))
+
+			_, err = service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).To(MatchError(hostErrors.ErrHostMoveInProgress))
+
+			resumed, err := service.ResumeHostMove(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resumed.Status).To(Equal(entities.HostMoveTaskDone))
+			Expect(resumed.Steps[2].Attempts).To(Equal(2))
+			Expect(operator.calls).To(Equal([]string{"switch-vlans 542 []", "redeploy ads-jammy", "redeploy ads-jammy"}))
+			Expect(releaser.requested).To(HaveLen(1))
+		})
+
+		It("should not resume a task while another runner holds it", func() {
+			var resumeErr error
+			operator.onCall = func(string) {
+				active, err := moves.FindActiveByHost(ctx, host.ID)
+				Expect(err).NotTo(HaveOccurred())
+				_, resumeErr = service.ResumeHostMove(ctx, active.ID)
+			}
+
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Status).To(Equal(entities.HostMoveTaskDone))
+			Expect(resumeErr).To(MatchError(hostErrors.ErrHostMoveInProgress))
+			Expect(operator.calls).To(Equal([]string{"switch-vlans 542 []", "redeploy ads-jammy"}))
+		})
+
+		It("should let only one of concurrent resumes claim the task", func() {
+			operator.fail["switch-vlans 542 []"] = errors.New("switch unavailable")
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).To(HaveOccurred())
+
+			stale, err := moves.GetByID(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = service.ResumeHostMove(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stale.Claim(time.Now(), time.Now().Add(time.Minute))).To(Succeed())
+			Expect(moves.Update(ctx, stale)).To(MatchError(hostErrors.ErrMoveVersionConflict))
+			Expect(operator.calls).To(Equal([]string{"switch-vlans 542 []", "switch-vlans 542 []", "redeploy ads-jammy"}))
+		})
+
+		It("should take over a running task whose runner is gone", func() {
+			operator.fail["switch-vlans 542 []"] = errors.New("switch unavailable")
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).To(HaveOccurred())
+
+			abandoned, err := moves.GetByID(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(abandoned.Claim(time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))).To(Succeed())
+			Expect(moves.Update(ctx, abandoned)).To(Succeed())
+
+			resumed, err := service.ResumeHostMove(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resumed.Status).To(Equal(entities.HostMoveTaskDone))
+			Expect(resumed.LeaseUntil).To(BeNil())
+		})
+
+		It("should report step progress with events", func() {
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{KeepConfig: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Events()).To(ContainElement(&events.HostMoveStepChangedEvent{
+				TaskID:   task.ID,
+				HostID:   host.ID,
+				Step:     string(entities.HostMoveStepSwitchVlans),
+				Status:   string(entities.HostMoveStepDone),
+				Attempts: 1,
+			}))
+		})
+
+		It("should allow a new move after cancellation", func() {
+			operator.fail["switch-vlans 542 []"] = errors.New("switch unavailable")
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).To(HaveOccurred())
+
+			_, err = service.CancelHostMove(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = service.ResumeHostMove(ctx, task.ID)
+			Expect(err).To(HaveOccurred())
+
+			_, err = service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should refuse moves forbidden by restrictions before starting", func() {
+			search.Restrictions = []core_entities.Restriction{core_entities.RestrictionNoReboot}
+
+			_, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			var restrictedErr *hostErrors.HostRestrictedError
+			Expect(errors.As(err, &restrictedErr)).To(BeTrue())
+			Expect(restrictedErr.Operation).To(Equal(string(entities.HostOperationRedeploy)))
+			Expect(restrictedErr.Restriction).To(Equal(string(core_entities.RestrictionNoRedeploy)))
+			Expect(operator.calls).To(BeEmpty())
+			Expect(releaser.requested).To(BeEmpty())
+
+			active, err := moves.FindActiveByHost(ctx, host.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeNil())
+
+			_, err = service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{KeepConfig: true})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should refuse moves forbidden by defaults of the target project", func() {
+			ads.Restrictions = []core_entities.Restriction{core_entities.RestrictionNoRedeploy}
+
+			_, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			var restrictedErr *hostErrors.HostRestrictedError
+			Expect(errors.As(err, &restrictedErr)).To(BeTrue())
+			Expect(restrictedErr.Restriction).To(Equal(string(core_entities.RestrictionNoRedeploy)))
+			Expect(operator.calls).To(BeEmpty())
+		})
+
+		It("should stop a resumed move at a step restricted meanwhile", func() {
+			operator.fail["switch-vlans 542 []"] = errors.New("switch unavailable")
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).To(HaveOccurred())
+
+			_, err = service.SetHostRestrictions(ctx, host.ID, []core_entities.Restriction{core_entities.RestrictionNoVlanChange})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = service.ResumeHostMove(ctx, task.ID)
+			var restrictedErr *hostErrors.HostRestrictedError
+			Expect(errors.As(err, &restrictedErr)).To(BeTrue())
+			Expect(restrictedErr.Restriction).To(Equal(string(core_entities.RestrictionNoVlanChange)))
+			Expect(operator.calls).To(HaveLen(1))
+		})
+
+		It("should combine host and project restrictions", func() {
+			search.Restrictions = []core_entities.Restriction{core_entities.RestrictionNoRedeploy}
+			_, err := service.SetHostRestrictions(ctx, host.ID, []core_entities.Restriction{core_entities.RestrictionNoAutomation})
+			Expect(err).NotTo(HaveOccurred())
+
+			effective, err := service.GetEffectiveRestrictions(ctx, host.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(effective).To(Equal([]core_entities.Restriction{
+				core_entities.RestrictionNoAutomation,
+				core_entities.RestrictionNoProfile,
+				core_entities.RestrictionNoRedeploy,
+			}))
+
+			_, err = service.SetHostRestrictions(ctx, host.ID, []core_entities.Restriction{"no-power"})
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+		})
+
+		It("should reject moving into the current project", func() {
+			_, err := service.MoveHost(ctx, host.ID, "search", entities.HostMoveOptions{})
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+		})
+
+		It("should refuse to redeploy a resumed move whose target lost its deploy config", func() {
+			operator.fail["switch-vlans 542 []"] = errors.New("switch unavailable")
+			task, err := service.MoveHost(ctx, host.ID, "ads", entities.HostMoveOptions{})
+			Expect(err).To(HaveOccurred())
+
+			ads.Deploying = nil
+			_, err = service.ResumeHostMove(ctx, task.ID)
+			var stepErr *hostErrors.HostMoveStepError
+			Expect(errors.As(err, &stepErr)).To(BeTrue())
+			Expect(stepErr.Step).To(Equal(string(entities.HostMoveStepRedeploy)))
+			Expect(operator.calls).To(Equal([]string{"switch-vlans 542 []", "switch-vlans 542 []"}))
+		})
+
+		It("should reject an empty target project", func() {
+			_, err := service.MoveHost(ctx, host.ID, "", entities.HostMoveOptions{})
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+			Expect(validationErr.Field).To(Equal("project_id"))
+			Expect(operator.calls).To(BeEmpty())
+		})
+
+		It("should reject unknown hosts and projects", func() {
+			_, err := service.MoveHost(ctx, "unknown", "ads", entities.HostMoveOptions{})
+			Expect(err).To(MatchError(hostErrors.ErrHostNotFound))
+			_, err = service.MoveHost(ctx, host.ID, "unknown", entities.HostMoveOptions{})
+			Expect(err).To(MatchError(hostErrors.ErrProjectNotFound))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package hosts
+
+import (
+	"slices"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+func PlanMove(source *projects.Project, target *projects.Project, options entities.HostMoveOptions) []entities.HostMoveStepType {
+	var steps []entities.HostMoveStepType
+
+	release := source != nil && hasEnabledCMS(source)
+	if release {
+		steps = append(steps, entities.HostMoveStepCMSRelease)
+	}
+	if vlansDiffer(networkOf(source), target.Network) {
+		steps = append(steps, entities.HostMoveStepSwitchVlans)
+	}
+	if options.EraseDisks {
+		steps = append(steps, entities.HostMoveStepEraseDisks)
+	}
+	redeploy := options.EraseDisks || (!options.KeepConfig && deployingDiffers(deployingOf(source), target.Deploying))
+	if redeploy && moveDeploying(source, target, options) != nil {
+		steps = append(steps, entities.HostMoveStepRedeploy)
+	}
+	steps = append(steps, entities.HostMoveStepChangeProject)
+	if release {
+		steps = append(steps, entities.HostMoveStepCMSComplete)
+	}
+	return steps
+}
+
+func hasEnabledCMS(project *projects.Project) bool {
+	for _, cms := range project.CMS {
+		if cms.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func networkOf(project *projects.Project) *projectEntities.Network {
+	if project == nil {
+		return nil
+	}
+	return project.Network
+}
+
+func deployingOf(project *projects.Project) *projectEntities.Deploying {
+	if project == nil {
+		return nil
+	}
+	return project.Deploying
+}
+
+func moveDeploying(source *projects.Project, target *projects.Project, options entities.HostMoveOptions) *projectEntities.Deploying {
+	if options.KeepConfig {
+		return deployingOf(source)
+	}
+	return target.Deploying
+}
+
+func vlansDiffer(source *projectEntities.Network, target *projectEntities.Network) bool {
+	if source == nil || target == nil {
+		return source != target
+	}
+	return source.NativeVlan != target.NativeVlan || !sameSet(source.ExtraVlans, target.ExtraVlans)
+}
+
+func deployingDiffers(source *projectEntities.Deploying, target *projectEntities.Deploying) bool {
+	if source == nil || target == nil {
+		return source != target
+	}
+	return source.Config != target.Config ||
+		source.Policy != target.Policy ||
+		source.Network != target.Network ||
+		!sameSet(source.Tags, target.Tags)
+}
+
+func sameSet[T string | int](a []T, b []T) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
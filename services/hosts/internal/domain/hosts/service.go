@@ -1,8 +1,30 @@
 package hosts
 
+import (
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/shortname"
+)
+
 type HostService struct {
+	hosts    contracts.HostRepository
+	projects contracts.ProjectProvider
+	moves    contracts.HostMoveTaskRepository
+	releaser contracts.HostReleaser
+	operator contracts.HostOperator
+	renderer *shortname.Renderer
+	now      func() time.Time
 }
 
-func NewDomainService() *HostService {
-	return &HostService{}
-}
\ No newline at end of file
+func NewDomainService(hosts contracts.HostRepository, projects contracts.ProjectProvider, moves contracts.HostMoveTaskRepository, releaser contracts.HostReleaser, operator contracts.HostOperator) *HostService {
+	return &HostService{
+		hosts:    hosts,
+		projects: projects,
+		moves:    moves,
+		releaser: releaser,
+		operator: operator,
+		renderer: shortname.NewRenderer(hosts),
+		now:      time.Now,
+	}
+}
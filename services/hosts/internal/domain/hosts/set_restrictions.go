@@ -0,0 +1,34 @@
+package hosts
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func (c *HostService) SetHostRestrictions(ctx context.Context, hostID string, restrictions []core_entities.Restriction) (*entities.Host, error) {
+	host, err := c.getHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if err := host.SetRestrictions(restrictions); err != nil {
+		return nil, err
+	}
+	if len(host.Events()) == 0 {
+		return host, nil
+	}
+	return host, c.hosts.Update(ctx, host)
+}
+
+func (c *HostService) GetEffectiveRestrictions(ctx context.Context, hostID string) ([]core_entities.Restriction, error) {
+	host, err := c.getHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := c.getProject(ctx, host.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return host.EffectiveRestrictions(project.EffectiveRestrictions()), nil
+}
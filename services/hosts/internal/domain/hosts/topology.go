@@ -0,0 +1,104 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+func (c *HostService) GetFailureDomainHosts(ctx context.Context, hostID string, level entities.LocationLevel) ([]*entities.Host, error) {
+	host, err := c.getHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, ok := failureDomainFilter(host.Location, level)
+	if !ok {
+		return nil, &errors.HostValidationError{
+			Field:   "location",
+			Message: fmt.Sprintf("host %s has no %s in its location", host.ID, level),
+		}
+	}
+
+	domainHosts, err := c.listAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Host, 0, len(domainHosts))
+	for _, h := range domainHosts {
+		if h.ID != host.ID {
+			result = append(result, h)
+		}
+	}
+	return result, nil
+}
+
+func (c *HostService) CheckFailureDomainLimit(ctx context.Context, limit entities.FailureDomainLimit, hostIDs ...string) error {
+	if err := limit.Validate(); err != nil {
+		return err
+	}
+
+	candidates := make([]*entities.Host, 0, len(hostIDs))
+	filters := map[string]contracts.HostFilter{}
+	for _, id := range hostIDs {
+		host, err := c.getHost(ctx, id)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, host)
+
+		if domain, ok := host.Location.FailureDomain(limit.Level); ok {
+			filters[domain], _ = failureDomainFilter(host.Location, limit.Level)
+		}
+	}
+
+	var domainHosts []*entities.Host
+	for _, filter := range filters {
+		hosts, err := c.listAll(ctx, filter)
+		if err != nil {
+			return err
+		}
+		domainHosts = append(domainHosts, hosts...)
+	}
+	return limit.Check(candidates, domainHosts)
+}
+
+func failureDomainFilter(location entities.HostLocation, level entities.LocationLevel) (contracts.HostFilter, bool) {
+	if _, ok := location.FailureDomain(level); !ok {
+		return contracts.HostFilter{}, false
+	}
+
+	switch level {
+	case entities.LocationLevelDatacenter:
+		return contracts.HostFilter{Datacenter: location.Datacenter}, true
+	case entities.LocationLevelHall:
+		return contracts.HostFilter{Datacenter: location.Datacenter, Hall: location.Hall}, true
+	case entities.LocationLevelRow:
+		return contracts.HostFilter{Datacenter: location.Datacenter, Hall: location.Hall, Row: location.Row}, true
+	case entities.LocationLevelRack:
+		return contracts.HostFilter{Datacenter: location.Datacenter, Rack: location.Rack}, true
+	case entities.LocationLevelSwitch:
+		return contracts.HostFilter{Switch: location.Switch}, true
+	}
+	return contracts.HostFilter{}, false
+}
+
+func (c *HostService) listAll(ctx context.Context, filter contracts.HostFilter) ([]*entities.Host, error) {
+	var result []*entities.Host
+	request := contracts.HostPageRequest{Limit: contracts.MAX_HOSTS_PAGE_SIZE}
+	for {
+		page, err := c.hosts.List(ctx, filter, request)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Hosts...)
+		if page.NextCursor == "" {
+			return result, nil
+		}
+		request.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,161 @@
+package hosts_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Topology", func() {
+	var (
+		ctx     context.Context
+		repo    *memory.HostRepository
+		service *hosts.HostService
+		stored  []*entities.Host
+	)
+
+	addHost := func(n int, location entities.HostLocation) *entities.Host {
+		host, err := entities.NewHost(fmt.Sprintf("10000%d", n), "", core_entities.TypeServer, location, nil)
+		Expect(err).NotTo(HaveOccurred())
+		host.ProjectID = "search"
+		Expect(service.AddHost(ctx, host)).To(Succeed())
+		return host
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = memory.NewHostRepository()
+		service = hosts.NewDomainService(repo, fakeProjects{"search": {ID: "search", Type: core_entities.TypeServer}},
+			memory.NewHostMoveTaskRepository(), nil, nil)
+
+		stored = []*entities.Host{
+			addHost(1, entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a1", Unit: 1, Switch: "sas-s1"}),
+			addHost(2, entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a1", Unit: 3, Switch: "sas-s1"}),
+			addHost(3, entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a2", Unit: 1, Switch: "sas-s2"}),
+			addHost(4, entities.HostLocation{Datacenter: "vla", Rack: "a1", Unit: 1}),
+		}
+	})
+
+	Describe("GetFailureDomainHosts", func() {
+		DescribeTable("should return hosts sharing the domain",
+			func(level entities.LocationLevel, expected []string) {
+				neighbours, err := service.GetFailureDomainHosts(ctx, stored[0].ID, level)
+				Expect(err).NotTo(HaveOccurred())
+
+				inventory := make([]string, 0, len(neighbours))
+				for _, host := range neighbours {
+					inventory = append(inventory, host.InventoryNumber)
+				}
+				Expect(inventory).To(ConsistOf(expected))
+			},
+			Entry("rack", entities.LocationLevelRack, []string{"100002"}),
+			Entry("switch", entities.LocationLevelSwitch, []string{"100002"}),
+			Entry("row", entities.LocationLevelRow, []string{"100002", "100003"}),
+			Entry("datacenter", entities.LocationLevelDatacenter, []string{"100002", "100003"}),
+		)
+
+		It("should fail when location misses the level", func() {
+			_, err := service.GetFailureDomainHosts(ctx, stored[3].ID, entities.LocationLevelSwitch)
+			var validationErr *hostErrors.HostValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+		})
+	})
+
+	Describe("CheckFailureDomainLimit", func() {
+		limit := entities.FailureDomainLimit{Level: entities.LocationLevelRack, MaxUnavailable: 1}
+
+		It("should allow one host per rack", func() {
+			Expect(service.CheckFailureDomainLimit(ctx, limit, stored[0].ID, stored[2].ID, stored[3].ID)).To(Succeed())
+		})
+
+		It("should reject the second host of a rack", func() {
+			err := service.CheckFailureDomainLimit(ctx, limit, stored[0].ID, stored[1].ID)
+			var limitErr *hostErrors.FailureDomainLimitError
+			Expect(errors.As(err, &limitErr)).To(BeTrue())
+			Expect(limitErr.Domain).To(Equal("sas/a1"))
+		})
+
+		It("should count hosts already out of service", func() {
+			Expect(stored[1].ChangeState(entities.HostStateMaintenance, "")).To(Succeed())
+			Expect(repo.Update(ctx, stored[1])).To(Succeed())
+
+			Expect(service.CheckFailureDomainLimit(ctx, limit, stored[0].ID)).To(HaveOccurred())
+			Expect(service.CheckFailureDomainLimit(ctx, limit, stored[3].ID)).To(Succeed())
+		})
+	})
+
+	Describe("ImportLocations", func() {
+		It("should update locations of many hosts", func() {
+			updated, err := service.ImportLocations(ctx, []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a2", Unit: 5, Switch: "sas-s2"}},
+				{InventoryNumber: "100002", Location: entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a1", Unit: 1, Switch: "sas-s1"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(HaveLen(2))
+
+			host, err := repo.GetByInventoryNumber(ctx, "100001")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(host.Location.Rack).To(Equal("a2"))
+			Expect(host.Location.Unit).To(Equal(5))
+		})
+
+		It("should refuse recabling hosts whose vlans can not be changed", func() {
+			_, err := service.SetHostRestrictions(ctx, stored[0].ID, []core_entities.Restriction{core_entities.RestrictionNoVlanChange})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = service.ImportLocations(ctx, []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a1", Unit: 1, Switch: "sas-s2"}},
+			})
+			var restrictedErr *hostErrors.HostRestrictedError
+			Expect(errors.As(err, &restrictedErr)).To(BeTrue())
+			Expect(restrictedErr.Operation).To(Equal(string(entities.HostOperationSwitchVlans)))
+
+			_, err = service.ImportLocations(ctx, []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a1", Unit: 2, Switch: "sas-s1"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject inconsistent topology without changes",
+			func(records []hosts.LocationRecord, message string) {
+				_, err := service.ImportLocations(ctx, records)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+
+				host, err := repo.GetByInventoryNumber(ctx, "100001")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.Location).To(Equal(stored[0].Location))
+			},
+			Entry("taken unit", []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "a", Rack: "a2", Unit: 1}},
+			}, "unit 1 of rack sas/a2 is taken by host 100003"),
+			Entry("rack in two rows", []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "sas", Hall: "1", Row: "b", Rack: "a2", Unit: 7}},
+			}, "rack sas/a2 is placed in another hall or row by host 100003"),
+			Entry("switch in two datacenters", []hosts.LocationRecord{
+				{InventoryNumber: "100004", Location: entities.HostLocation{Datacenter: "vla", Rack: "a1", Unit: 1, Switch: "sas-s1"}},
+			}, "switch sas-s1 is placed in another datacenter"),
+			Entry("conflict inside the batch", []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "man", Rack: "c1", Unit: 2}},
+				{InventoryNumber: "100002", Location: entities.HostLocation{Datacenter: "man", Rack: "c1", Unit: 2}},
+			}, "unit 2 of rack man/c1 is taken"),
+			Entry("unknown host", []hosts.LocationRecord{
+				{InventoryNumber: "999999", Location: entities.HostLocation{Datacenter: "sas"}},
+			}, "host not found"),
+			Entry("duplicated host", []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "sas"}},
+				{InventoryNumber: "100001", Location: entities.HostLocation{Datacenter: "vla"}},
+			}, "listed more than once"),
+			Entry("invalid location", []hosts.LocationRecord{
+				{InventoryNumber: "100001", Location: entities.HostLocation{Unit: 4}},
+			}, "location.unit"),
+		)
+	})
+})
@@ -0,0 +1,11 @@
+package validators
+
+const (
+	MAX_INVENTORY_NUMBER_LENGTH = 32
+	MAX_FQDN_LENGTH             = 253
+	MAX_LOCATION_NAME_LENGTH    = 64
+	MAX_RACK_UNIT               = 60
+	MAX_LABEL_KEY_LENGTH        = 63
+	MAX_LABEL_VALUE_LENGTH      = 63
+	MAX_LABELS_PER_HOST         = 64
+)
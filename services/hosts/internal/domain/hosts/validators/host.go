@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+var (
+	inventoryNumberRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	fqdnRegexp            = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+)
+
+func ValidateInventoryNumber(inv string) error {
+	if inv == "" {
+		return &errors.HostValidationError{
+			Field:   "inventory_number",
+			Message: "inventory number is required",
+		}
+	}
+	if len(inv) > MAX_INVENTORY_NUMBER_LENGTH || !inventoryNumberRegexp.MatchString(inv) {
+		return &errors.HostValidationError{
+			Field:   "inventory_number",
+			Message: fmt.Sprintf("%q is not a valid inventory number", inv),
+		}
+	}
+	return nil
+}
+
+func ValidateFQDN(fqdn string) error {
+	if fqdn == "" {
+		return nil
+	}
+	if len(fqdn) > MAX_FQDN_LENGTH || !fqdnRegexp.MatchString(fqdn) {
+		return &errors.HostValidationError{
+			Field:   "fqdn",
+			Message: fmt.Sprintf("%q is not a valid fqdn", fqdn),
+		}
+	}
+	return nil
+}
+
+func ValidateUnitType(unitType core_entities.UnitType) error {
+	if !unitType.IsValid() {
+		return &errors.HostValidationError{
+			Field:   "unit_type",
+			Message: fmt.Sprintf("unknown unit type %q", unitType),
+		}
+	}
+	return nil
+}
+
+func NormalizeMACs(macs []string) ([]string, error) {
+	result := make([]string, 0, len(macs))
+	seen := make(map[string]struct{}, len(macs))
+	for _, mac := range macs {
+		hw, err := net.ParseMAC(strings.TrimSpace(mac))
+		if err != nil || len(hw) != 6 {
+			return nil, &errors.HostValidationError{
+				Field:   "macs",
+				Message: fmt.Sprintf("%q is not a valid mac address", mac),
+			}
+		}
+		normalized := hw.String()
+		if _, ok := seen[normalized]; ok {
+			return nil, &errors.HostValidationError{
+				Field:   "macs",
+				Message: fmt.Sprintf("mac address %s is duplicated", normalized),
+			}
+		}
+		seen[normalized] = struct{}{}
+		result = append(result, normalized)
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+var (
+	labelKeyRegexp   = regexp.MustCompile(`^[a-z0-9]([a-z0-9_/-]*[a-z0-9])?$`)
+	labelValueRegexp = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+)
+
+var RESERVED_LABEL_KEYS = []string{"project", "unit_type"}
+
+func ValidateLabelKey(key string) error {
+	if len(key) > MAX_LABEL_KEY_LENGTH || !labelKeyRegexp.MatchString(key) {
+		return &errors.HostValidationError{
+			Field:   "labels",
+			Message: fmt.Sprintf("%q is not a valid label key", key),
+		}
+	}
+	for _, reserved := range RESERVED_LABEL_KEYS {
+		if key == reserved {
+			return &errors.HostValidationError{
+				Field:   "labels",
+				Message: fmt.Sprintf("label key %q is reserved", key),
+			}
+		}
+	}
+	return nil
+}
+
+func ValidateLabelValue(key string, value string) error {
+	if len(value) > MAX_LABEL_VALUE_LENGTH || !labelValueRegexp.MatchString(value) {
+		return &errors.HostValidationError{
+			Field:   "labels." + key,
+			Message: fmt.Sprintf("%q is not a valid label value", value),
+		}
+	}
+	return nil
+}
+
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MAX_LABELS_PER_HOST {
+		return &errors.HostValidationError{
+			Field:   "labels",
+			Message: fmt.Sprintf("host can have at most %d labels", MAX_LABELS_PER_HOST),
+		}
+	}
+	for key, value := range labels {
+		if err := ValidateLabelKey(key); err != nil {
+			return err
+		}
+		if err := ValidateLabelValue(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+var locationNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+func ValidateLocationName(field string, name string) error {
+	if name == "" {
+		return nil
+	}
+	if len(name) > MAX_LOCATION_NAME_LENGTH || !locationNameRegexp.MatchString(name) {
+		return &errors.HostValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("%q is not a valid name", name),
+		}
+	}
+	return nil
+}
+
+func ValidateRackUnit(unit int) error {
+	if unit < 0 || unit > MAX_RACK_UNIT {
+		return &errors.HostValidationError{
+			Field:   "location.unit",
+			Message: fmt.Sprintf("unit must be between 1 and %d or 0 when unknown", MAX_RACK_UNIT),
+		}
+	}
+	return nil
+}
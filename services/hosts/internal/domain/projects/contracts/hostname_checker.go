@@ -0,0 +1,7 @@
+package contracts
+
+import "context"
+
+type HostnameChecker interface {
+	FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error)
+}
@@ -0,0 +1,7 @@
+package contracts
+
+import "context"
+
+type NetworkRepository interface {
+	FindVlanOwners(ctx context.Context, vlans []int) (map[int]string, error)
+}
@@ -0,0 +1,7 @@
+package contracts
+
+import "context"
+
+type SecretVault interface {
+	ResolveVersion(ctx context.Context, secretID string, version string) (string, error)
+}
@@ -7,6 +7,7 @@ type CMSAuth struct {
 
 type CMS struct {
 	Enabled      bool    `bson:"enabled"`
+	URL          string  `bson:"url"`
 	Version      string  `bson:"version"`
 	MaxBusyHosts int     `bson:"max_busy_hosts"`
 	Auth         CMSAuth `bson:"auth"`
@@ -1,19 +1,116 @@
 package entities
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type DeployPolicy string
+
+const (
+	DeployPolicyPassthrough            DeployPolicy = "PASSTHROUGH"
+	DeployPolicyDiskManager            DeployPolicy = "DISKMANAGER"
+	DeployPolicyShared                 DeployPolicy = "SHARED"
+	DeployPolicySharedCryptsetup       DeployPolicy = "SHARED_CRYPTSETUP"
+	DeployPolicySharedStripedNVMe      DeployPolicy = "SHARED_STRIPED_NVME"
+	DeployPolicySharedLVM              DeployPolicy = "SHAREDLVM"
+	DeployPolicySharedLVMExtendedPlace DeployPolicy = "SHAREDLVM_EXTENDED_PLACE"
+	DeployPolicyYTDedicated            DeployPolicy = "YT_DEDICATED"
+	DeployPolicyYTDedicatedTest        DeployPolicy = "YT_DEDICATED_TEST"
+	DeployPolicyYTShared               DeployPolicy = "YT_SHARED"
+	DeployPolicyYTMasters              DeployPolicy = "YT_MASTERS"
+	DeployPolicyYTMastersK8S           DeployPolicy = "YT_MASTERS_K8S"
+	DeployPolicyYTStorage              DeployPolicy = "YT_STORAGE"
+	DeployPolicyYTDefaultShared        DeployPolicy = "YT_DEFAULT_SHARED"
+	DeployPolicyYTDefaultStorage       DeployPolicy = "YT_DEFAULT_STORAGE"
+	DeployPolicyYTSacrifice            DeployPolicy = "YT_SACRIFICE"
+	DeployPolicyMDSDedicated           DeployPolicy = "MDS_DEDICATED"
+)
+
+var deployPolicies = []DeployPolicy{
+	DeployPolicyPassthrough,
+	DeployPolicyDiskManager,
+	DeployPolicyShared,
+	DeployPolicySharedCryptsetup,
+	DeployPolicySharedStripedNVMe,
+	DeployPolicySharedLVM,
+	DeployPolicySharedLVMExtendedPlace,
+	DeployPolicyYTDedicated,
+	DeployPolicyYTDedicatedTest,
+	DeployPolicyYTShared,
+	DeployPolicyYTMasters,
+	DeployPolicyYTMastersK8S,
+	DeployPolicyYTStorage,
+	DeployPolicyYTDefaultShared,
+	DeployPolicyYTDefaultStorage,
+	DeployPolicyYTSacrifice,
+	DeployPolicyMDSDedicated,
+}
+
+var serverUnitTypes = []core_entities.UnitType{core_entities.TypeServer, core_entities.TypeShadowServer}
+
+func DeployPolicies() []DeployPolicy {
+	return append([]DeployPolicy(nil), deployPolicies...)
+}
+
+func ParseDeployPolicy(value string) (DeployPolicy, error) {
+	policy := DeployPolicy(strings.ToUpper(strings.TrimSpace(value)))
+	if !policy.IsValid() {
+		return "", &errors.ProjectValidationError{
+			Field:   "deploying.policy",
+			Message: fmt.Sprintf("unknown deploy policy %q", value),
+		}
+	}
+	return policy, nil
+}
+
+func (p DeployPolicy) IsValid() bool {
+	for _, policy := range deployPolicies {
+		if policy == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (p DeployPolicy) IsYT() bool {
+	return strings.HasPrefix(string(p), "YT_")
+}
+
+func (p DeployPolicy) AllowedUnitTypes() []core_entities.UnitType {
+	if p.IsYT() || p == DeployPolicyMDSDedicated {
+		return serverUnitTypes
+	}
+	return nil
+}
+
+func (p DeployPolicy) AllowsUnitType(unitType core_entities.UnitType) bool {
+	allowed := p.AllowedUnitTypes()
+	if allowed == nil {
+		return true
+	}
+	for _, t := range allowed {
+		if t == unitType {
+			return true
+		}
+	}
+	return false
+}
+
 type Secret struct {
-	// TODO: Add fields when structure is known
+	Name     string `bson:"name"`
+	SecretID string `bson:"secret_id"`
+	Version  string `bson:"version"`
 }
 
 type Deploying struct {
-	Config  string   `bson:"config"`
-	Tags    []string `bson:"tags"`
-	Network string   `bson:"network"`
-
-	// Policy defines deployment policy (possible values: PASSTHROUGH, DISKMANAGER, SHARED,
-	// SHARED_CRYPTSETUP, SHARED_STRIPED_NVME, SHAREDLVM, SHAREDLVM_EXTENDED_PLACE,
-	// YT_DEDICATED, YT_DEDICATED_TEST, YT_SHARED, YT_MASTERS, YT_MASTERS_K8S, YT_STORAGE,
-	// YT_DEFAULT_SHARED, YT_DEFAULT_STORAGE, YT_SACRIFICE, MDS_DEDICATED)
-	Policy            string   `bson:"policy"`
-	Secrets           []Secret `bson:"secrets"`
-	DeployCertificate bool     `bson:"deploy_certificate"`
+	Config            string       `bson:"config"`
+	Tags              []string     `bson:"tags"`
+	Network           int          `bson:"network_vlan"`
+	Policy            DeployPolicy `bson:"policy"`
+	Secrets           []Secret     `bson:"secrets"`
+	DeployCertificate bool         `bson:"deploy_certificate"`
 }
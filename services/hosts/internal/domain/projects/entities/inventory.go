@@ -2,5 +2,5 @@ package entities
 
 // Inventory represents project inventory information
 type Inventory struct {
-    BotProjectID string `bson:"bot_project_id"`
-}
\ No newline at end of file
+	BotProjectID string `bson:"bot_project_id"`
+}
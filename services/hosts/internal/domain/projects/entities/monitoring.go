@@ -1,4 +1,82 @@
 package entities
 
+import (
+	"time"
+
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+type MonitoringCheck struct {
+	Type             core_entities.CheckType `bson:"type"`
+	Enabled          bool                    `bson:"enabled"`
+	FailureThreshold int                     `bson:"failure_threshold"`
+	GracePeriod      time.Duration           `bson:"grace_period"`
+	AllowAutomation  bool                    `bson:"allow_automation"`
+}
+
 type Monitoring struct {
-}
\ No newline at end of file
+	Checks []MonitoringCheck `bson:"checks"`
+}
+
+type MonitoringCatalogueItem struct {
+	Defaults    MonitoringCheck
+	Automatable bool
+}
+
+var monitoringCatalogue = map[core_entities.CheckType]MonitoringCatalogueItem{
+	core_entities.CheckUnreachable:   catalogueItem(core_entities.CheckUnreachable, true, 3, 10*time.Minute, true),
+	core_entities.CheckSSH:           catalogueItem(core_entities.CheckSSH, true, 3, 10*time.Minute, true),
+	core_entities.CheckMeta:          catalogueItem(core_entities.CheckMeta, true, 1, 30*time.Minute, false),
+	core_entities.CheckMemory:        catalogueItem(core_entities.CheckMemory, true, 1, 0, true),
+	core_entities.CheckDisk:          catalogueItem(core_entities.CheckDisk, true, 1, 0, true),
+	core_entities.CheckLink:          catalogueItem(core_entities.CheckLink, true, 3, 5*time.Minute, true),
+	core_entities.CheckBMC:           catalogueItem(core_entities.CheckBMC, true, 3, 30*time.Minute, false),
+	core_entities.CheckCPU:           catalogueItem(core_entities.CheckCPU, true, 1, 0, true),
+	core_entities.CheckGPU:           catalogueItem(core_entities.CheckGPU, false, 1, 0, true),
+	core_entities.CheckReboots:       catalogueItem(core_entities.CheckReboots, true, 1, time.Hour, false),
+	core_entities.CheckTaintedKernel: catalogueItem(core_entities.CheckTaintedKernel, true, 1, 0, true),
+	core_entities.CheckFSCheck:       catalogueItem(core_entities.CheckFSCheck, true, 1, 0, true),
+	core_entities.CheckOverheat:      catalogueItem(core_entities.CheckOverheat, true, 3, 15*time.Minute, true),
+	core_entities.CheckInfiniband:    catalogueItem(core_entities.CheckInfiniband, false, 3, 5*time.Minute, true),
+}
+
+func catalogueItem(checkType core_entities.CheckType, enabled bool, threshold int, grace time.Duration, automatable bool) MonitoringCatalogueItem {
+	return MonitoringCatalogueItem{
+		Defaults: MonitoringCheck{
+			Type:             checkType,
+			Enabled:          enabled,
+			FailureThreshold: threshold,
+			GracePeriod:      grace,
+			AllowAutomation:  automatable,
+		},
+		Automatable: automatable,
+	}
+}
+
+func LookupMonitoringCheck(checkType core_entities.CheckType) (MonitoringCatalogueItem, bool) {
+	item, ok := monitoringCatalogue[checkType]
+	return item, ok
+}
+
+func (m *Monitoring) Effective() []MonitoringCheck {
+	overrides := map[core_entities.CheckType]MonitoringCheck{}
+	if m != nil {
+		for _, check := range m.Checks {
+			overrides[check.Type] = check
+		}
+	}
+
+	result := make([]MonitoringCheck, 0, len(monitoringCatalogue))
+	for _, checkType := range core_entities.CheckTypes() {
+		item, ok := monitoringCatalogue[checkType]
+		if !ok {
+			continue
+		}
+		check := item.Defaults
+		if override, ok := overrides[checkType]; ok {
+			check = override
+		}
+		result = append(result, check)
+	}
+	return result
+}
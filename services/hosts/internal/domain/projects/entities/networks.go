@@ -12,6 +12,22 @@ const (
 	VlanSchemeMTNWithoutFastBone VlanScheme = "MTN_WITHOUT_FASTBONE"
 )
 
+func (s VlanScheme) IsValid() bool {
+	switch s {
+	case VlanSchemeStatic, VlanSchemeMTN, VlanSchemeMTNHostID, VlanSchemeCloud, VlanSchemeMock, VlanSchemeMTNWithoutFastBone:
+		return true
+	}
+	return false
+}
+
+func (s VlanScheme) IsMTN() bool {
+	switch s {
+	case VlanSchemeMTN, VlanSchemeMTNHostID, VlanSchemeMTNWithoutFastBone:
+		return true
+	}
+	return false
+}
+
 type Network struct {
 	OwnedVlans        []int      `bson:"owned_vlans"`
 	VlanScheme        VlanScheme `bson:"vlan_scheme"`
@@ -23,3 +39,12 @@ type Network struct {
 	YcIAMFolderID     string     `bson:"yc_iam_folder_id"`
 	HbfProjectID      int        `bson:"hbf_project_id"`
 }
+
+func (n *Network) OwnsVlan(vlan int) bool {
+	for _, owned := range n.OwnedVlans {
+		if owned == vlan {
+			return true
+		}
+	}
+	return false
+}
@@ -1,4 +1,99 @@
 package entities
 
+type NotificationChannelType string
+
+const (
+	NotificationChannelEmail   NotificationChannelType = "email"
+	NotificationChannelWebhook NotificationChannelType = "webhook"
+	NotificationChannelChat    NotificationChannelType = "chat"
+)
+
+func (t NotificationChannelType) IsValid() bool {
+	switch t {
+	case NotificationChannelEmail, NotificationChannelWebhook, NotificationChannelChat:
+		return true
+	}
+	return false
+}
+
+type NotificationSeverity string
+
+const (
+	NotificationSeverityInfo     NotificationSeverity = "info"
+	NotificationSeverityWarning  NotificationSeverity = "warning"
+	NotificationSeverityError    NotificationSeverity = "error"
+	NotificationSeverityCritical NotificationSeverity = "critical"
+)
+
+var notificationSeverityLevels = map[NotificationSeverity]int{
+	NotificationSeverityInfo:     1,
+	NotificationSeverityWarning:  2,
+	NotificationSeverityError:    3,
+	NotificationSeverityCritical: 4,
+}
+
+func (s NotificationSeverity) IsValid() bool {
+	_, ok := notificationSeverityLevels[s]
+	return ok
+}
+
+func (s NotificationSeverity) AtLeast(threshold NotificationSeverity) bool {
+	if threshold == "" {
+		return true
+	}
+	return notificationSeverityLevels[s] >= notificationSeverityLevels[threshold]
+}
+
+const NotificationEventAny = "*"
+
+type NotificationChannel struct {
+	Name        string                  `bson:"name"`
+	Type        NotificationChannelType `bson:"type"`
+	Recipients  []string                `bson:"recipients"`
+	MinSeverity NotificationSeverity    `bson:"min_severity"`
+}
+
+type NotificationRoute struct {
+	EventType   string               `bson:"event_type"`
+	Channels    []string             `bson:"channels"`
+	MinSeverity NotificationSeverity `bson:"min_severity"`
+}
+
 type Notification struct {
-}
\ No newline at end of file
+	Channels []NotificationChannel `bson:"channels"`
+	Routes   []NotificationRoute   `bson:"routes"`
+}
+
+func (n *Notification) Resolve(eventType string, severity NotificationSeverity) []NotificationChannel {
+	if n == nil {
+		return nil
+	}
+
+	channels := make(map[string]NotificationChannel, len(n.Channels))
+	for _, channel := range n.Channels {
+		channels[channel.Name] = channel
+	}
+
+	var result []NotificationChannel
+	seen := map[string]struct{}{}
+	for _, route := range n.Routes {
+		if route.EventType != eventType && route.EventType != NotificationEventAny {
+			continue
+		}
+		if !severity.AtLeast(route.MinSeverity) {
+			continue
+		}
+		for _, name := range route.Channels {
+			channel, ok := channels[name]
+			if !ok || !severity.AtLeast(channel.MinSeverity) {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			result = append(result, channel)
+		}
+	}
+	return result
+}
@@ -1,9 +1,14 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 )
 
+var (
+	ErrSecretNotFound = errors.New("secret not found")
+)
+
 type ProjectValidationError struct {
 	Field   string
 	Message string
@@ -11,22 +11,23 @@ import (
 )
 
 type Project struct {
-	ID           string                 `bson:"_id"`
-	Name         string                 `bson:"name"`
-	Type         core_entities.UnitType `bson:"type"`
-	Tags         []string               `bson:"tags"`
-	Description  string                 `bson:"description"`
-	CMS          []entities.CMS         `bson:"cms"`
-	Network      *entities.Network      `bson:"network"`
-	Deploying    *entities.Deploying    `bson:"deploying"`
-	Profiling    *entities.Profiling    `bson:"profiling"`
-	Notification *entities.Notification `bson:"notification"`
-	Monitoring   *entities.Monitoring   `bson:"monitoring"`
-	Task         *entities.Task         `bson:"task"`
-	Tier         byte                   `bson:"tier"`
-	Owners       []string               `bson:"owners"`
-	Inventory    *entities.Inventory    `bson:"inventory"`
-	events       []interface{}          `bson:"_"`
+	ID           string                      `bson:"_id"`
+	Name         string                      `bson:"name"`
+	Type         core_entities.UnitType      `bson:"type"`
+	Tags         []string                    `bson:"tags"`
+	Description  string                      `bson:"description"`
+	CMS          []entities.CMS              `bson:"cms"`
+	Network      *entities.Network           `bson:"network"`
+	Deploying    *entities.Deploying         `bson:"deploying"`
+	Profiling    *entities.Profiling         `bson:"profiling"`
+	Notification *entities.Notification      `bson:"notification"`
+	Monitoring   *entities.Monitoring        `bson:"monitoring"`
+	Task         *entities.Task              `bson:"task"`
+	Restrictions []core_entities.Restriction `bson:"restrictions"`
+	Tier         byte                        `bson:"tier"`
+	Owners       []string                    `bson:"owners"`
+	Inventory    *entities.Inventory         `bson:"inventory"`
+	events       []interface{}               `bson:"_"`
 }
 
 func (p *Project) Events() []interface{} {
@@ -56,6 +57,7 @@ func NewProject(ctx context.Context, checker contracts.ProjectChecker, id string
 		Notification: nil,
 		Monitoring:   nil,
 		Task:         nil,
+		Restrictions: []core_entities.Restriction{},
 		Tier:         0,
 		Owners:       []string{},
 		Inventory:    nil,
@@ -0,0 +1,30 @@
+package projects
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/projects/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/validators"
+)
+
+func (p *Project) SetDeploying(ctx context.Context, vault contracts.SecretVault, deploying *entities.Deploying) error {
+	deploying, err := validators.ValidateDeploying(ctx, vault, p.Type, p.Network, deploying)
+	if err != nil {
+		return err
+	}
+
+	p.Deploying = deploying
+
+	p.addEvent(&events.ProjectDeployingChangedEvent{
+		ID:                p.ID,
+		Config:            deploying.Config,
+		Tags:              deploying.Tags,
+		Network:           deploying.Network,
+		Policy:            string(deploying.Policy),
+		DeployCertificate: deploying.DeployCertificate,
+	})
+
+	return nil
+}
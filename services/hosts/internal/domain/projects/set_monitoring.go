@@ -0,0 +1,31 @@
+package projects
+
+import (
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/validators"
+)
+
+func (p *Project) SetMonitoring(monitoring *entities.Monitoring) error {
+	err := validators.ValidateMonitoring(monitoring)
+	if err != nil {
+		return err
+	}
+
+	p.Monitoring = monitoring
+
+	effective := monitoring.Effective()
+	checks := make([]events.MonitoringCheck, 0, len(effective))
+	for _, check := range effective {
+		checks = append(checks, events.MonitoringCheck{
+			Type:             check.Type,
+			Enabled:          check.Enabled,
+			FailureThreshold: check.FailureThreshold,
+			GracePeriod:      check.GracePeriod,
+			AllowAutomation:  check.AllowAutomation,
+		})
+	}
+	p.addEvent(&events.ProjectMonitoringChangedEvent{ID: p.ID, Checks: checks})
+
+	return nil
+}
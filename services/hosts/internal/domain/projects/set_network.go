@@ -0,0 +1,32 @@
+package projects
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/projects/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/validators"
+)
+
+func (p *Project) SetNetwork(ctx context.Context, repo contracts.NetworkRepository, network *entities.Network) error {
+	err := validators.ValidateNetwork(ctx, repo, p.ID, network)
+	if err != nil {
+		return err
+	}
+
+	p.Network = network
+
+	p.addEvent(&events.ProjectNetworkChangedEvent{
+		ID:                p.ID,
+		OwnedVlans:        network.OwnedVlans,
+		VlanScheme:        string(network.VlanScheme),
+		NativeVlan:        network.NativeVlan,
+		ExtraVlans:        network.ExtraVlans,
+		DNSDomain:         network.DNSDomain,
+		ShortnameTemplate: network.ShortnameTemplate,
+		HbfProjectID:      network.HbfProjectID,
+	})
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package projects
+
+import (
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/validators"
+)
+
+func (p *Project) SetNotification(notification *entities.Notification) error {
+	err := validators.ValidateNotification(notification)
+	if err != nil {
+		return err
+	}
+
+	p.Notification = notification
+
+	channels := make([]string, 0, len(notification.Channels))
+	for _, channel := range notification.Channels {
+		channels = append(channels, channel.Name)
+	}
+	p.addEvent(&events.ProjectNotificationChangedEvent{ID: p.ID, Channels: channels})
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package projects
+
+import (
+	"slices"
+
+	"github.com/gwall-e/hosts/events"
+	"github.com/gwall-e/hosts/internal/domain/projects/validators"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func (p *Project) SetRestrictions(restrictions []core_entities.Restriction) error {
+	err := validators.ValidateRestrictions(restrictions)
+	if err != nil {
+		return err
+	}
+
+	p.Restrictions = slices.Clone(restrictions)
+	if p.Restrictions == nil {
+		p.Restrictions = []core_entities.Restriction{}
+	}
+
+	p.addEvent(&events.ProjectRestrictionsChangedEvent{
+		ID:           p.ID,
+		Restrictions: p.Restrictions,
+		Effective:    core_entities.EffectiveRestrictions(p.Restrictions),
+	})
+
+	return nil
+}
+
+func (p *Project) EffectiveRestrictions() []core_entities.Restriction {
+	if p == nil {
+		return core_entities.EffectiveRestrictions()
+	}
+	return core_entities.EffectiveRestrictions(p.Restrictions)
+}
@@ -0,0 +1,82 @@
+package shortname
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+)
+
+const RENDER_BATCH_SIZE = 64
+
+type Renderer struct {
+	checker contracts.HostnameChecker
+}
+
+func NewRenderer(checker contracts.HostnameChecker) *Renderer {
+	return &Renderer{checker: checker}
+}
+
+func (r *Renderer) RenderFQDN(ctx context.Context, network *entities.Network, params Params, reserved ...string) (string, error) {
+	if network == nil || network.ShortnameTemplate == "" {
+		return "", &errors.ProjectValidationError{
+			Field:   "network.shortname_template",
+			Message: "project has no shortname template",
+		}
+	}
+	if network.DNSDomain == "" {
+		return "", &errors.ProjectValidationError{
+			Field:   "network.dns_domain",
+			Message: "project has no dns domain",
+		}
+	}
+
+	tpl, err := Parse(network.ShortnameTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	taken := make(map[string]struct{}, len(reserved))
+	for _, fqdn := range reserved {
+		taken[fqdn] = struct{}{}
+	}
+
+	for start := 1; start <= tpl.MaxIndex(); start += RENDER_BATCH_SIZE {
+		end := min(start+RENDER_BATCH_SIZE-1, tpl.MaxIndex())
+
+		candidates := make([]string, 0, end-start+1)
+		for index := start; index <= end; index++ {
+			name, err := tpl.Render(params, index)
+			if err != nil {
+				return "", err
+			}
+			candidates = append(candidates, JoinFQDN(name, network.DNSDomain))
+		}
+
+		existing, err := r.checker.FindExistingFQDNs(ctx, candidates)
+		if err != nil {
+			return "", err
+		}
+		for _, fqdn := range existing {
+			taken[fqdn] = struct{}{}
+		}
+
+		for _, fqdn := range candidates {
+			if _, ok := taken[fqdn]; !ok {
+				return fqdn, nil
+			}
+		}
+	}
+
+	return "", &errors.ProjectValidationError{
+		Field:   "network.shortname_template",
+		Message: fmt.Sprintf("all %d names of template %q are already taken", tpl.MaxIndex(), tpl),
+	}
+}
+
+func JoinFQDN(shortname string, domain string) string {
+	return shortname + "." + strings.Trim(domain, ".")
+}
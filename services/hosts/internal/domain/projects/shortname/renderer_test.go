@@ -0,0 +1,92 @@
+package shortname_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	projectErrors "github.com/gwall-e/hosts/internal/domain/projects/errors"
+	. "github.com/gwall-e/hosts/internal/domain/projects/shortname"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeHostnameChecker struct {
+	existing map[string]struct{}
+	calls    int
+}
+
+func (c *fakeHostnameChecker) FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error) {
+	c.calls++
+	var result []string
+	for _, fqdn := range fqdns {
+		if _, ok := c.existing[fqdn]; ok {
+			result = append(result, fqdn)
+		}
+	}
+	return result, nil
+}
+
+var _ = Describe("Renderer", func() {
+	var (
+		checker  *fakeHostnameChecker
+		renderer *Renderer
+		network  *entities.Network
+		params   Params
+	)
+
+	BeforeEach(func() {
+		checker = &fakeHostnameChecker{existing: map[string]struct{}{}}
+		renderer = NewRenderer(checker)
+		network = &entities.Network{
+			DNSDomain:         "search.yandex.net.",
+			ShortnameTemplate: "{location}-{index:03d}",
+		}
+		params = Params{Location: "sas"}
+	})
+
+	It("should render the first index when nothing is taken", func() {
+		fqdn, err := renderer.RenderFQDN(context.Background(), network, params)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fqdn).To(Equal("sas-001.search.yandex.net"))
+	})
+
+	It("should skip taken and reserved names deterministically", func() {
+		checker.existing["sas-001.search.yandex.net"] = struct{}{}
+		checker.existing["sas-003.search.yandex.net"] = struct{}{}
+
+		fqdn, err := renderer.RenderFQDN(context.Background(), network, params, "sas-002.search.yandex.net")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fqdn).To(Equal("sas-004.search.yandex.net"))
+	})
+
+	It("should look through several batches", func() {
+		for i := 1; i <= RENDER_BATCH_SIZE+1; i++ {
+			checker.existing[fmt.Sprintf("sas-%03d.search.yandex.net", i)] = struct{}{}
+		}
+
+		fqdn, err := renderer.RenderFQDN(context.Background(), network, params)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fqdn).To(Equal(fmt.Sprintf("sas-%03d.search.yandex.net", RENDER_BATCH_SIZE+2)))
+		Expect(checker.calls).To(Equal(2))
+	})
+
+	It("should return validation error when all names are taken", func() {
+		network.ShortnameTemplate = "{location}-{index:01d}"
+		for i := 1; i <= 9; i++ {
+			checker.existing[fmt.Sprintf("sas-%d.search.yandex.net", i)] = struct{}{}
+		}
+
+		_, err := renderer.RenderFQDN(context.Background(), network, params)
+		var validationErr *projectErrors.ProjectValidationError
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		Expect(validationErr.Message).To(ContainSubstring("already taken"))
+	})
+
+	It("should require dns domain", func() {
+		network.DNSDomain = ""
+		_, err := renderer.RenderFQDN(context.Background(), network, params)
+		Expect(err).To(MatchError(ContainSubstring("network.dns_domain")))
+	})
+})
@@ -0,0 +1,13 @@
+package shortname_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestShortnameSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Shortname Template Suite")
+}
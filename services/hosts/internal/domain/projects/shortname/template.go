@@ -0,0 +1,210 @@
+package shortname
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+)
+
+const (
+	PlaceholderLocation = "location"
+	PlaceholderRack     = "rack"
+	PlaceholderIndex    = "index"
+	PlaceholderProject  = "project"
+)
+
+const (
+	MAX_LABEL_LENGTH  = 63
+	DEFAULT_MAX_INDEX = 9999
+)
+
+var (
+	labelRegexp  = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	literalChars = regexp.MustCompile(`^[a-z0-9-]*$`)
+	indexSpec    = regexp.MustCompile(`^(?:0([1-9]))?d$`)
+	invalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+type Params struct {
+	Location string
+	Rack     string
+	Project  string
+}
+
+type segment struct {
+	literal     string
+	placeholder string
+	width       int
+	zeroPad     bool
+}
+
+type Template struct {
+	raw      string
+	segments []segment
+	maxIndex int
+}
+
+func Parse(raw string) (*Template, error) {
+	if raw == "" {
+		return nil, templateError("template is empty")
+	}
+
+	t := &Template{raw: raw, maxIndex: DEFAULT_MAX_INDEX}
+	hasIndex := false
+
+	rest := raw
+	for len(rest) > 0 {
+		open := strings.IndexByte(rest, '{')
+		closing := strings.IndexByte(rest, '}')
+		if closing >= 0 && (open < 0 || closing < open) {
+			return nil, templateError(fmt.Sprintf("unexpected '}' at position %d", len(raw)-len(rest)+closing))
+		}
+		if open < 0 {
+			open = len(rest)
+		}
+
+		if open > 0 {
+			literal := rest[:open]
+			if !literalChars.MatchString(literal) {
+				return nil, templateError(fmt.Sprintf("literal %q may contain only lowercase letters, digits and hyphens", literal))
+			}
+			t.segments = append(t.segments, segment{literal: literal})
+			rest = rest[open:]
+			continue
+		}
+
+		end := strings.IndexByte(rest, '}')
+		if end < 0 {
+			return nil, templateError(fmt.Sprintf("unclosed '{' at position %d", len(raw)-len(rest)))
+		}
+
+		seg, err := parsePlaceholder(rest[1:end])
+		if err != nil {
+			return nil, err
+		}
+		if seg.placeholder == PlaceholderIndex {
+			if hasIndex {
+				return nil, templateError("placeholder {index} must be used once")
+			}
+			hasIndex = true
+			if seg.zeroPad {
+				t.maxIndex = pow10(seg.width) - 1
+			}
+		}
+		t.segments = append(t.segments, seg)
+		rest = rest[end+1:]
+	}
+
+	if !hasIndex {
+		return nil, templateError("placeholder {index} is required to produce unique names")
+	}
+
+	return t, nil
+}
+
+func parsePlaceholder(body string) (segment, error) {
+	name, spec, hasSpec := strings.Cut(body, ":")
+	switch name {
+	case PlaceholderLocation, PlaceholderRack, PlaceholderProject:
+		if hasSpec {
+			return segment{}, templateError(fmt.Sprintf("placeholder {%s} does not accept format", name))
+		}
+		return segment{placeholder: name}, nil
+	case PlaceholderIndex:
+		seg := segment{placeholder: name}
+		if !hasSpec {
+			return seg, nil
+		}
+		match := indexSpec.FindStringSubmatch(spec)
+		if match == nil {
+			return segment{}, templateError(fmt.Sprintf("invalid index format %q, expected e.g. 04d", spec))
+		}
+		if match[1] != "" {
+			seg.zeroPad = true
+			seg.width, _ = strconv.Atoi(match[1])
+		}
+		return seg, nil
+	case "":
+		return segment{}, templateError("empty placeholder")
+	default:
+		return segment{}, templateError(fmt.Sprintf("unknown placeholder {%s}", name))
+	}
+}
+
+func (t *Template) String() string {
+	return t.raw
+}
+
+func (t *Template) MaxIndex() int {
+	return t.maxIndex
+}
+
+func (t *Template) Render(params Params, index int) (string, error) {
+	if index < 1 || index > t.maxIndex {
+		return "", templateError(fmt.Sprintf("index %d is out of range 1-%d", index, t.maxIndex))
+	}
+
+	var sb strings.Builder
+	for _, seg := range t.segments {
+		if seg.placeholder == "" {
+			sb.WriteString(seg.literal)
+			continue
+		}
+
+		value, err := seg.value(params, index)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(value)
+	}
+
+	name := sb.String()
+	if len(name) > MAX_LABEL_LENGTH {
+		return "", templateError(fmt.Sprintf("rendered name %q is longer than %d characters", name, MAX_LABEL_LENGTH))
+	}
+	if !labelRegexp.MatchString(name) {
+		return "", templateError(fmt.Sprintf("rendered name %q is not a valid hostname label", name))
+	}
+	return name, nil
+}
+
+func (s segment) value(params Params, index int) (string, error) {
+	var raw string
+	switch s.placeholder {
+	case PlaceholderIndex:
+		if s.zeroPad {
+			return fmt.Sprintf("%0*d", s.width, index), nil
+		}
+		return strconv.Itoa(index), nil
+	case PlaceholderLocation:
+		raw = params.Location
+	case PlaceholderRack:
+		raw = params.Rack
+	case PlaceholderProject:
+		raw = params.Project
+	}
+
+	value := strings.Trim(invalidChars.ReplaceAllString(strings.ToLower(raw), "-"), "-")
+	if value == "" {
+		return "", templateError(fmt.Sprintf("placeholder {%s} has no value", s.placeholder))
+	}
+	return value, nil
+}
+
+func templateError(message string) error {
+	return &errors.ProjectValidationError{
+		Field:   "network.shortname_template",
+		Message: message,
+	}
+}
+
+func pow10(n int) int {
+	result := 1
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package shortname_test
+
+import (
+	"errors"
+
+	projectErrors "github.com/gwall-e/hosts/internal/domain/projects/errors"
+	. "github.com/gwall-e/hosts/internal/domain/projects/shortname"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template", func() {
+	params := Params{Location: "SAS", Rack: "1A_2", Project: "search"}
+
+	DescribeTable("should render valid templates",
+		func(raw string, index int, expected string) {
+			tpl, err := Parse(raw)
+			Expect(err).NotTo(HaveOccurred())
+
+			name, err := tpl.Render(params, index)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal(expected))
+		},
+		Entry("all placeholders", "{location}-{rack}-{index:04d}", 7, "sas-1a-2-0007"),
+		Entry("project prefix", "{project}{index}", 42, "search42"),
+		Entry("literal suffix", "{location}{index:03d}-srv", 5, "sas005-srv"),
+	)
+
+	DescribeTable("should reject invalid templates",
+		func(raw string) {
+			_, err := Parse(raw)
+			var validationErr *projectErrors.ProjectValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+			Expect(validationErr.Field).To(Equal("network.shortname_template"))
+		},
+		Entry("empty", ""),
+		Entry("without index", "{location}-{rack}"),
+		Entry("duplicated index", "{index}-{index}"),
+		Entry("unknown placeholder", "{dc}-{index}"),
+		Entry("unclosed brace", "{location-{index}"),
+		Entry("unexpected closing brace", "host}{index}"),
+		Entry("empty placeholder", "{}{index}"),
+		Entry("format on string placeholder", "{rack:04d}{index}"),
+		Entry("space padded index", "{index:4d}"),
+		Entry("uppercase literal", "Host{index}"),
+		Entry("dot in literal", "host.{index}"),
+	)
+
+	It("should limit index by zero padded width", func() {
+		tpl, err := Parse("h{index:02d}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tpl.MaxIndex()).To(Equal(99))
+
+		_, err = tpl.Render(params, 100)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject missing placeholder values", func() {
+		tpl, err := Parse("{rack}-{index}")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tpl.Render(Params{}, 1)
+		Expect(err).To(MatchError(ContainSubstring("{rack} has no value")))
+	})
+
+	It("should reject names longer than dns label", func() {
+		tpl, err := Parse("{project}{index}")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tpl.Render(Params{Project: "a-very-long-project-name-that-definitely-does-not-fit-into-a-dns-label"}, 1)
+		Expect(err).To(MatchError(ContainSubstring("longer than")))
+	})
+})
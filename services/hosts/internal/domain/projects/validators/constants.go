@@ -1,3 +1,17 @@
 package validators
 
+import "time"
+
 const MAX_ID_LENGT = 32
+
+const MAX_DNS_DOMAIN_LENGTH = 253
+
+const (
+	MIN_VLAN_ID = 1
+	MAX_VLAN_ID = 4094
+)
+
+const (
+	MAX_FAILURE_THRESHOLD = 100
+	MAX_GRACE_PERIOD      = 24 * time.Hour
+)
@@ -0,0 +1,119 @@
+package validators
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func ValidateDeploying(ctx context.Context, vault contracts.SecretVault, projectType core_entities.UnitType, network *entities.Network, deploying *entities.Deploying) (*entities.Deploying, error) {
+	if deploying == nil {
+		return nil, &errors.ProjectValidationError{
+			Field:   "deploying",
+			Message: "deploying is required",
+		}
+	}
+
+	if deploying.Config == "" {
+		return nil, &errors.ProjectValidationError{
+			Field:   "deploying.config",
+			Message: "config is required",
+		}
+	}
+
+	policy, err := entities.ParseDeployPolicy(string(deploying.Policy))
+	if err != nil {
+		return nil, err
+	}
+	if !policy.AllowsUnitType(projectType) {
+		return nil, &errors.ProjectValidationError{
+			Field:   "deploying.policy",
+			Message: fmt.Sprintf("policy %s is not allowed for %s projects", policy, projectType),
+		}
+	}
+
+	if err := validateDeployNetwork(network, deploying.Network); err != nil {
+		return nil, err
+	}
+
+	secrets, err := validateSecrets(ctx, vault, deploying.Secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := *deploying
+	normalized.Tags = append([]string(nil), deploying.Tags...)
+	normalized.Policy = policy
+	normalized.Secrets = secrets
+	return &normalized, nil
+}
+
+func validateDeployNetwork(network *entities.Network, vlan int) error {
+	if vlan == 0 {
+		return nil
+	}
+	if network == nil {
+		return &errors.ProjectValidationError{
+			Field:   "deploying.network",
+			Message: "project network must be configured before deploy network is set",
+		}
+	}
+	if vlan == network.NativeVlan || network.OwnsVlan(vlan) {
+		return nil
+	}
+	for _, extra := range network.ExtraVlans {
+		if extra == vlan {
+			return nil
+		}
+	}
+	return &errors.ProjectValidationError{
+		Field:   "deploying.network",
+		Message: fmt.Sprintf("vlan %d does not belong to project network", vlan),
+	}
+}
+
+func validateSecrets(ctx context.Context, vault contracts.SecretVault, secrets []entities.Secret) ([]entities.Secret, error) {
+	names := make(map[string]struct{}, len(secrets))
+	pinned := make([]entities.Secret, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret.Name == "" {
+			return nil, &errors.ProjectValidationError{
+				Field:   "deploying.secrets",
+				Message: "secret name is required",
+			}
+		}
+		if _, ok := names[secret.Name]; ok {
+			return nil, &errors.ProjectValidationError{
+				Field:   "deploying.secrets",
+				Message: fmt.Sprintf("secret %q is duplicated", secret.Name),
+			}
+		}
+		names[secret.Name] = struct{}{}
+
+		if secret.SecretID == "" {
+			return nil, &errors.ProjectValidationError{
+				Field:   "deploying.secrets",
+				Message: fmt.Sprintf("secret %q has no secret id", secret.Name),
+			}
+		}
+
+		version, err := vault.ResolveVersion(ctx, secret.SecretID, secret.Version)
+		if stdErrors.Is(err, errors.ErrSecretNotFound) {
+			return nil, &errors.ProjectValidationError{
+				Field:   "deploying.secrets",
+				Message: fmt.Sprintf("secret %q version %q is not found in vault", secret.SecretID, secret.Version),
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		secret.Version = version
+		pinned = append(pinned, secret)
+	}
+	return pinned, nil
+}
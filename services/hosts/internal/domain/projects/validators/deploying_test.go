@@ -0,0 +1,136 @@
+package validators_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	projectErrors "github.com/gwall-e/hosts/internal/domain/projects/errors"
+	. "github.com/gwall-e/hosts/internal/domain/projects/validators"
+	"github.com/gwall-e/hosts/internal/infrastructure/vault"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateDeploying", func() {
+	var (
+		fakeVault *vault.FakeVault
+		network   *entities.Network
+		deploying *entities.Deploying
+	)
+
+	BeforeEach(func() {
+		fakeVault = vault.NewFakeVault()
+		fakeVault.AddVersion("sec-1", "ver-1")
+		fakeVault.AddVersion("sec-1", "ver-2")
+
+		network = &entities.Network{
+			OwnedVlans: []int{100, 200},
+			VlanScheme: entities.VlanSchemeStatic,
+			NativeVlan: 100,
+			ExtraVlans: []int{300},
+		}
+		deploying = &entities.Deploying{
+			Config: "web",
+			Policy: "yt_storage",
+			Secrets: []entities.Secret{
+				{Name: "ssh-key", SecretID: "sec-1"},
+			},
+		}
+	})
+
+	validate := func(unitType core_entities.UnitType) error {
+		_, err := ValidateDeploying(context.Background(), fakeVault, unitType, network, deploying)
+		return err
+	}
+
+	expectFieldError := func(err error, field string) {
+		var validationErr *projectErrors.ProjectValidationError
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		Expect(validationErr.Field).To(Equal(field))
+	}
+
+	It("should normalize policy and pin latest secret version", func() {
+		normalized, err := ValidateDeploying(context.Background(), fakeVault, core_entities.TypeServer, network, deploying)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(normalized.Policy).To(Equal(entities.DeployPolicyYTStorage))
+		Expect(normalized.Secrets[0].Version).To(Equal("ver-2"))
+		Expect(deploying.Policy).To(Equal(entities.DeployPolicy("yt_storage")))
+		Expect(deploying.Secrets[0].Version).To(BeEmpty())
+	})
+
+	It("should keep explicitly requested secret version", func() {
+		deploying.Secrets[0].Version = "ver-1"
+		normalized, err := ValidateDeploying(context.Background(), fakeVault, core_entities.TypeServer, network, deploying)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(normalized.Secrets[0].Version).To(Equal("ver-1"))
+	})
+
+	It("should leave the input untouched when validation fails midway", func() {
+		deploying.Secrets = append(deploying.Secrets, entities.Secret{Name: "token", SecretID: "sec-missing"})
+		expectFieldError(validate(core_entities.TypeServer), "deploying.secrets")
+		Expect(deploying.Policy).To(Equal(entities.DeployPolicy("yt_storage")))
+		Expect(deploying.Secrets[0].Version).To(BeEmpty())
+	})
+
+	It("should require config", func() {
+		deploying.Config = ""
+		expectFieldError(validate(core_entities.TypeServer), "deploying.config")
+	})
+
+	It("should reject unknown policy", func() {
+		deploying.Policy = "RAID100"
+		expectFieldError(validate(core_entities.TypeServer), "deploying.policy")
+	})
+
+	DescribeTable("should restrict server-only policies",
+		func(policy entities.DeployPolicy, unitType core_entities.UnitType, allowed bool) {
+			deploying.Policy = policy
+			err := validate(unitType)
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				expectFieldError(err, "deploying.policy")
+			}
+		},
+		Entry("YT on server", entities.DeployPolicyYTDedicated, core_entities.TypeServer, true),
+		Entry("YT on shadow server", entities.DeployPolicyYTMasters, core_entities.TypeShadowServer, true),
+		Entry("YT on vm", entities.DeployPolicyYTShared, core_entities.TypeVM, false),
+		Entry("MDS on mac", entities.DeployPolicyMDSDedicated, core_entities.TypeMac, false),
+		Entry("SHARED on vm", entities.DeployPolicyShared, core_entities.TypeVM, true),
+	)
+
+	DescribeTable("should check deploy network against project network",
+		func(vlan int, allowed bool) {
+			deploying.Network = vlan
+			err := validate(core_entities.TypeServer)
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				expectFieldError(err, "deploying.network")
+			}
+		},
+		Entry("default", 0, true),
+		Entry("native vlan", 100, true),
+		Entry("owned vlan", 200, true),
+		Entry("extra vlan", 300, true),
+		Entry("foreign vlan", 400, false),
+	)
+
+	It("should require project network for explicit deploy network", func() {
+		network = nil
+		deploying.Network = 100
+		expectFieldError(validate(core_entities.TypeServer), "deploying.network")
+	})
+
+	It("should reject secrets missing in vault", func() {
+		deploying.Secrets[0].Version = "ver-3"
+		expectFieldError(validate(core_entities.TypeServer), "deploying.secrets")
+	})
+
+	It("should reject duplicated secret names", func() {
+		deploying.Secrets = append(deploying.Secrets, entities.Secret{Name: "ssh-key", SecretID: "sec-1"})
+		expectFieldError(validate(core_entities.TypeServer), "deploying.secrets")
+	})
+})
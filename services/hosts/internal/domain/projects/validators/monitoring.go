@@ -0,0 +1,56 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func ValidateMonitoring(monitoring *entities.Monitoring) error {
+	if monitoring == nil {
+		return &errors.ProjectValidationError{
+			Field:   "monitoring",
+			Message: "monitoring is required",
+		}
+	}
+
+	seen := make(map[core_entities.CheckType]struct{}, len(monitoring.Checks))
+	for _, check := range monitoring.Checks {
+		item, ok := entities.LookupMonitoringCheck(check.Type)
+		if !ok {
+			return &errors.ProjectValidationError{
+				Field:   "monitoring.checks",
+				Message: fmt.Sprintf("unknown check type %q", check.Type),
+			}
+		}
+		if _, ok := seen[check.Type]; ok {
+			return &errors.ProjectValidationError{
+				Field:   "monitoring.checks",
+				Message: fmt.Sprintf("check %s is duplicated", check.Type),
+			}
+		}
+		seen[check.Type] = struct{}{}
+
+		if check.FailureThreshold < 1 || check.FailureThreshold > MAX_FAILURE_THRESHOLD {
+			return &errors.ProjectValidationError{
+				Field:   "monitoring.checks",
+				Message: fmt.Sprintf("check %s failure threshold must be in range 1-%d", check.Type, MAX_FAILURE_THRESHOLD),
+			}
+		}
+		if check.GracePeriod < 0 || check.GracePeriod > MAX_GRACE_PERIOD {
+			return &errors.ProjectValidationError{
+				Field:   "monitoring.checks",
+				Message: fmt.Sprintf("check %s grace period must be in range 0-%s", check.Type, MAX_GRACE_PERIOD),
+			}
+		}
+		if check.AllowAutomation && !item.Automatable {
+			return &errors.ProjectValidationError{
+				Field:   "monitoring.checks",
+				Message: fmt.Sprintf("check %s cannot trigger automation", check.Type),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package validators_test
+
+import (
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	. "github.com/gwall-e/hosts/internal/domain/projects/validators"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateMonitoring", func() {
+	var monitoring *entities.Monitoring
+
+	BeforeEach(func() {
+		monitoring = &entities.Monitoring{
+			Checks: []entities.MonitoringCheck{
+				{Type: core_entities.CheckSSH, Enabled: true, FailureThreshold: 5, GracePeriod: time.Hour, AllowAutomation: true},
+				{Type: core_entities.CheckGPU, Enabled: true, FailureThreshold: 1},
+			},
+		}
+	})
+
+	It("should accept valid configuration", func() {
+		Expect(ValidateMonitoring(monitoring)).To(Succeed())
+	})
+
+	DescribeTable("should reject invalid configuration",
+		func(mutate func(m *entities.Monitoring), message string) {
+			mutate(monitoring)
+			Expect(ValidateMonitoring(monitoring)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown check", func(m *entities.Monitoring) { m.Checks[0].Type = "dns" }, "unknown check type"),
+		Entry("duplicated check", func(m *entities.Monitoring) { m.Checks[1].Type = core_entities.CheckSSH }, "duplicated"),
+		Entry("zero threshold", func(m *entities.Monitoring) { m.Checks[0].FailureThreshold = 0 }, "failure threshold"),
+		Entry("negative grace period", func(m *entities.Monitoring) { m.Checks[0].GracePeriod = -time.Second }, "grace period"),
+		Entry("too long grace period", func(m *entities.Monitoring) { m.Checks[0].GracePeriod = 48 * time.Hour }, "grace period"),
+		Entry("automation for informational check", func(m *entities.Monitoring) {
+			m.Checks[0] = entities.MonitoringCheck{Type: core_entities.CheckMeta, FailureThreshold: 1, AllowAutomation: true}
+		}, "cannot trigger automation"),
+	)
+
+	It("should merge project checks with catalogue defaults", func() {
+		effective := monitoring.Effective()
+		Expect(effective).To(HaveLen(len(core_entities.CheckTypes())))
+
+		byType := map[core_entities.CheckType]entities.MonitoringCheck{}
+		for _, check := range effective {
+			byType[check.Type] = check
+		}
+		Expect(byType[core_entities.CheckSSH].FailureThreshold).To(Equal(5))
+		Expect(byType[core_entities.CheckGPU].Enabled).To(BeTrue())
+		Expect(byType[core_entities.CheckInfiniband].Enabled).To(BeFalse())
+		Expect(byType[core_entities.CheckMeta].AllowAutomation).To(BeFalse())
+	})
+
+	It("should return catalogue defaults for project without monitoring", func() {
+		var empty *entities.Monitoring
+		Expect(empty.Effective()).To(HaveLen(len(core_entities.CheckTypes())))
+	})
+})
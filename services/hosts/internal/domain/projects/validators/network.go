@@ -0,0 +1,171 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/contracts"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+	"github.com/gwall-e/hosts/internal/domain/projects/shortname"
+)
+
+var dnsDomainRegexp = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func ValidateNetwork(ctx context.Context, repo contracts.NetworkRepository, projectID string, network *entities.Network) error {
+	if network == nil {
+		return &errors.ProjectValidationError{
+			Field:   "network",
+			Message: "network is required",
+		}
+	}
+
+	if !network.VlanScheme.IsValid() {
+		return &errors.ProjectValidationError{
+			Field:   "network.vlan_scheme",
+			Message: fmt.Sprintf("unknown vlan scheme %q", network.VlanScheme),
+		}
+	}
+
+	if err := validateVlanList("network.owned_vlans", network.OwnedVlans); err != nil {
+		return err
+	}
+	if err := validateVlanList("network.extra_vlans", network.ExtraVlans); err != nil {
+		return err
+	}
+	for _, vlan := range network.ExtraVlans {
+		if network.OwnsVlan(vlan) {
+			return &errors.ProjectValidationError{
+				Field:   "network.extra_vlans",
+				Message: fmt.Sprintf("vlan %d is already listed in owned vlans", vlan),
+			}
+		}
+	}
+
+	if network.NativeVlan != 0 && !isValidVlanID(network.NativeVlan) {
+		return &errors.ProjectValidationError{
+			Field:   "network.native_vlan",
+			Message: fmt.Sprintf("vlan %d is out of range %d-%d", network.NativeVlan, MIN_VLAN_ID, MAX_VLAN_ID),
+		}
+	}
+
+	if err := validateVlanScheme(network); err != nil {
+		return err
+	}
+
+	if err := validateNaming(network); err != nil {
+		return err
+	}
+
+	return validateVlansOwnership(ctx, repo, projectID, network.OwnedVlans)
+}
+
+func validateVlanScheme(network *entities.Network) error {
+	switch {
+	case network.VlanScheme == entities.VlanSchemeStatic:
+		if network.NativeVlan == 0 {
+			return &errors.ProjectValidationError{
+				Field:   "network.native_vlan",
+				Message: "native vlan is required for STATIC scheme",
+			}
+		}
+		if !network.OwnsVlan(network.NativeVlan) {
+			return &errors.ProjectValidationError{
+				Field:   "network.native_vlan",
+				Message: fmt.Sprintf("native vlan %d must be one of owned vlans", network.NativeVlan),
+			}
+		}
+	case network.VlanScheme.IsMTN():
+		if network.HbfProjectID <= 0 {
+			return &errors.ProjectValidationError{
+				Field:   "network.hbf_project_id",
+				Message: fmt.Sprintf("hbf project id is required for %s scheme", network.VlanScheme),
+			}
+		}
+	case network.VlanScheme == entities.VlanSchemeCloud:
+		if network.YcDNSZoneID == "" {
+			return &errors.ProjectValidationError{
+				Field:   "network.yc_dns_zone_id",
+				Message: "yandex cloud dns zone id is required for CLOUD scheme",
+			}
+		}
+		if network.YcIAMFolderID == "" {
+			return &errors.ProjectValidationError{
+				Field:   "network.yc_iam_folder_id",
+				Message: "yandex cloud iam folder id is required for CLOUD scheme",
+			}
+		}
+	}
+	return nil
+}
+
+func validateNaming(network *entities.Network) error {
+	if network.DNSDomain != "" && (len(network.DNSDomain) > MAX_DNS_DOMAIN_LENGTH || !dnsDomainRegexp.MatchString(network.DNSDomain)) {
+		return &errors.ProjectValidationError{
+			Field:   "network.dns_domain",
+			Message: fmt.Sprintf("%q is not a valid dns domain", network.DNSDomain),
+		}
+	}
+
+	if network.ShortnameTemplate == "" {
+		return nil
+	}
+	if network.DNSDomain == "" {
+		return &errors.ProjectValidationError{
+			Field:   "network.dns_domain",
+			Message: "dns domain is required when shortname template is set",
+		}
+	}
+	_, err := shortname.Parse(network.ShortnameTemplate)
+	return err
+}
+
+func validateVlanList(field string, vlans []int) error {
+	seen := make(map[int]struct{}, len(vlans))
+	for _, vlan := range vlans {
+		if !isValidVlanID(vlan) {
+			return &errors.ProjectValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("vlan %d is out of range %d-%d", vlan, MIN_VLAN_ID, MAX_VLAN_ID),
+			}
+		}
+		if _, ok := seen[vlan]; ok {
+			return &errors.ProjectValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("vlan %d is duplicated", vlan),
+			}
+		}
+		seen[vlan] = struct{}{}
+	}
+	return nil
+}
+
+func validateVlansOwnership(ctx context.Context, repo contracts.NetworkRepository, projectID string, vlans []int) error {
+	if len(vlans) == 0 {
+		return nil
+	}
+
+	owners, err := repo.FindVlanOwners(ctx, vlans)
+	if err != nil {
+		return err
+	}
+
+	sorted := append([]int(nil), vlans...)
+	sort.Ints(sorted)
+	for _, vlan := range sorted {
+		owner, ok := owners[vlan]
+		if ok && owner != projectID {
+			return &errors.ProjectValidationError{
+				Field:   "network.owned_vlans",
+				Message: fmt.Sprintf("vlan %d is already owned by project %s", vlan, owner),
+			}
+		}
+	}
+	return nil
+}
+
+func isValidVlanID(vlan int) bool {
+	return vlan >= MIN_VLAN_ID && vlan <= MAX_VLAN_ID
+}
@@ -0,0 +1,191 @@
+package validators_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	projectErrors "github.com/gwall-e/hosts/internal/domain/projects/errors"
+	. "github.com/gwall-e/hosts/internal/domain/projects/validators"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeNetworkRepository struct {
+	owners map[int]string
+	err    error
+}
+
+func (r *fakeNetworkRepository) FindVlanOwners(ctx context.Context, vlans []int) (map[int]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	result := map[int]string{}
+	for _, vlan := range vlans {
+		if owner, ok := r.owners[vlan]; ok {
+			result[vlan] = owner
+		}
+	}
+	return result, nil
+}
+
+var _ = Describe("ValidateNetwork", func() {
+	var (
+		repo    *fakeNetworkRepository
+		network *entities.Network
+	)
+
+	BeforeEach(func() {
+		repo = &fakeNetworkRepository{owners: map[int]string{}}
+		network = &entities.Network{
+			OwnedVlans: []int{100, 200},
+			VlanScheme: entities.VlanSchemeStatic,
+			NativeVlan: 100,
+			ExtraVlans: []int{300},
+		}
+	})
+
+	expectFieldError := func(err error, field string) {
+		var validationErr *projectErrors.ProjectValidationError
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		Expect(validationErr.Field).To(Equal(field))
+	}
+
+	It("should accept a consistent static network", func() {
+		Expect(ValidateNetwork(context.Background(), repo, "project", network)).To(Succeed())
+	})
+
+	It("should reject nil network", func() {
+		expectFieldError(ValidateNetwork(context.Background(), repo, "project", nil), "network")
+	})
+
+	It("should reject unknown vlan scheme", func() {
+		network.VlanScheme = "UNKNOWN"
+		expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.vlan_scheme")
+	})
+
+	DescribeTable("should reject vlan ids out of range",
+		func(mutate func(n *entities.Network), field string) {
+			mutate(network)
+			expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), field)
+		},
+		Entry("owned vlan 0", func(n *entities.Network) { n.OwnedVlans = []int{0} }, "network.owned_vlans"),
+		Entry("owned vlan 4095", func(n *entities.Network) { n.OwnedVlans = []int{100, 4095} }, "network.owned_vlans"),
+		Entry("extra vlan -1", func(n *entities.Network) { n.ExtraVlans = []int{-1} }, "network.extra_vlans"),
+		Entry("native vlan 5000", func(n *entities.Network) { n.NativeVlan = 5000 }, "network.native_vlan"),
+	)
+
+	It("should reject duplicated vlans", func() {
+		network.OwnedVlans = []int{100, 100}
+		expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.owned_vlans")
+	})
+
+	It("should reject extra vlans intersecting owned vlans", func() {
+		network.ExtraVlans = []int{200}
+		expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.extra_vlans")
+	})
+
+	Context("with STATIC scheme", func() {
+		It("should require native vlan", func() {
+			network.NativeVlan = 0
+			expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.native_vlan")
+		})
+
+		It("should require native vlan to be owned", func() {
+			network.NativeVlan = 300
+			expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.native_vlan")
+		})
+	})
+
+	Context("with MTN schemes", func() {
+		DescribeTable("should require hbf project id",
+			func(scheme entities.VlanScheme) {
+				network.VlanScheme = scheme
+				expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.hbf_project_id")
+
+				network.HbfProjectID = 1234
+				Expect(ValidateNetwork(context.Background(), repo, "project", network)).To(Succeed())
+			},
+			Entry("MTN", entities.VlanSchemeMTN),
+			Entry("MTN_HOSTID", entities.VlanSchemeMTNHostID),
+			Entry("MTN_WITHOUT_FASTBONE", entities.VlanSchemeMTNWithoutFastBone),
+		)
+	})
+
+	Context("with CLOUD scheme", func() {
+		BeforeEach(func() {
+			network.VlanScheme = entities.VlanSchemeCloud
+		})
+
+		It("should require dns zone id", func() {
+			network.YcIAMFolderID = "folder"
+			expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.yc_dns_zone_id")
+		})
+
+		It("should require iam folder id", func() {
+			network.YcDNSZoneID = "zone"
+			expectFieldError(ValidateNetwork(context.Background(), repo, "project", network), "network.yc_iam_folder_id")
+		})
+
+		It("should accept complete configuration", func() {
+			network.YcDNSZoneID = "zone"
+			network.YcIAMFolderID = "folder"
+			Expect(ValidateNetwork(context.Background(), repo, "project", network)).To(Succeed())
+		})
+	})
+
+	Context("with vlans owned by other projects", func() {
+		It("should reject overlapping vlans", func() {
+			repo.owners[200] = "other"
+			err := ValidateNetwork(context.Background(), repo, "project", network)
+			expectFieldError(err, "network.owned_vlans")
+			Expect(err.Error()).To(ContainSubstring("other"))
+		})
+
+		It("should accept vlans already owned by the same project", func() {
+			repo.owners[100] = "project"
+			repo.owners[200] = "project"
+			Expect(ValidateNetwork(context.Background(), repo, "project", network)).To(Succeed())
+		})
+
+		It("should propagate repository errors", func() {
+			repo.err = errors.New("db is down")
+			Expect(ValidateNetwork(context.Background(), repo, "project", network)).To(MatchError("db is down"))
+		})
+	})
+})
+
+var _ = Describe("ValidateNetwork naming", func() {
+	var network *entities.Network
+
+	BeforeEach(func() {
+		network = &entities.Network{
+			VlanScheme: entities.VlanSchemeMock,
+			DNSDomain:  "search.yandex.net",
+		}
+	})
+
+	It("should accept a valid shortname template", func() {
+		network.ShortnameTemplate = "{location}-{index:04d}"
+		Expect(ValidateNetwork(context.Background(), &fakeNetworkRepository{}, "project", network)).To(Succeed())
+	})
+
+	It("should reject an invalid shortname template", func() {
+		network.ShortnameTemplate = "{location}"
+		Expect(ValidateNetwork(context.Background(), &fakeNetworkRepository{}, "project", network)).
+			To(MatchError(ContainSubstring("network.shortname_template")))
+	})
+
+	It("should require dns domain for shortname template", func() {
+		network.DNSDomain = ""
+		network.ShortnameTemplate = "{index}"
+		Expect(ValidateNetwork(context.Background(), &fakeNetworkRepository{}, "project", network)).
+			To(MatchError(ContainSubstring("network.dns_domain")))
+	})
+
+	It("should reject an invalid dns domain", func() {
+		network.DNSDomain = "bad_domain..net"
+		Expect(ValidateNetwork(context.Background(), &fakeNetworkRepository{}, "project", network)).
+			To(MatchError(ContainSubstring("network.dns_domain")))
+	})
+})
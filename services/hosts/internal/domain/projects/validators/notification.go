@@ -0,0 +1,113 @@
+package validators
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+)
+
+func ValidateNotification(notification *entities.Notification) error {
+	if notification == nil {
+		return &errors.ProjectValidationError{
+			Field:   "notification",
+			Message: "notification is required",
+		}
+	}
+
+	channels := make(map[string]struct{}, len(notification.Channels))
+	for _, channel := range notification.Channels {
+		if err := validateNotificationChannel(channel); err != nil {
+			return err
+		}
+		if _, ok := channels[channel.Name]; ok {
+			return &errors.ProjectValidationError{
+				Field:   "notification.channels",
+				Message: fmt.Sprintf("channel %q is duplicated", channel.Name),
+			}
+		}
+		channels[channel.Name] = struct{}{}
+	}
+
+	for _, route := range notification.Routes {
+		if route.EventType == "" {
+			return &errors.ProjectValidationError{
+				Field:   "notification.routes",
+				Message: "event type is required",
+			}
+		}
+		if route.MinSeverity != "" && !route.MinSeverity.IsValid() {
+			return &errors.ProjectValidationError{
+				Field:   "notification.routes",
+				Message: fmt.Sprintf("unknown severity %q", route.MinSeverity),
+			}
+		}
+		if len(route.Channels) == 0 {
+			return &errors.ProjectValidationError{
+				Field:   "notification.routes",
+				Message: fmt.Sprintf("route for %q has no channels", route.EventType),
+			}
+		}
+		for _, name := range route.Channels {
+			if _, ok := channels[name]; !ok {
+				return &errors.ProjectValidationError{
+					Field:   "notification.routes",
+					Message: fmt.Sprintf("route for %q references unknown channel %q", route.EventType, name),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateNotificationChannel(channel entities.NotificationChannel) error {
+	if channel.Name == "" {
+		return &errors.ProjectValidationError{
+			Field:   "notification.channels",
+			Message: "channel name is required",
+		}
+	}
+	if !channel.Type.IsValid() {
+		return &errors.ProjectValidationError{
+			Field:   "notification.channels",
+			Message: fmt.Sprintf("channel %q has unknown type %q", channel.Name, channel.Type),
+		}
+	}
+	if channel.MinSeverity != "" && !channel.MinSeverity.IsValid() {
+		return &errors.ProjectValidationError{
+			Field:   "notification.channels",
+			Message: fmt.Sprintf("channel %q has unknown severity %q", channel.Name, channel.MinSeverity),
+		}
+	}
+	if len(channel.Recipients) == 0 {
+		return &errors.ProjectValidationError{
+			Field:   "notification.channels",
+			Message: fmt.Sprintf("channel %q has no recipients", channel.Name),
+		}
+	}
+
+	for _, recipient := range channel.Recipients {
+		if !isValidRecipient(channel.Type, recipient) {
+			return &errors.ProjectValidationError{
+				Field:   "notification.channels",
+				Message: fmt.Sprintf("channel %q has invalid recipient %q", channel.Name, recipient),
+			}
+		}
+	}
+	return nil
+}
+
+func isValidRecipient(channelType entities.NotificationChannelType, recipient string) bool {
+	switch channelType {
+	case entities.NotificationChannelEmail:
+		address, err := mail.ParseAddress(recipient)
+		return err == nil && address.Address == recipient
+	case entities.NotificationChannelWebhook:
+		u, err := url.Parse(recipient)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	default:
+		return recipient != ""
+	}
+}
@@ -0,0 +1,62 @@
+package validators_test
+
+import (
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	. "github.com/gwall-e/hosts/internal/domain/projects/validators"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateNotification", func() {
+	var notification *entities.Notification
+
+	BeforeEach(func() {
+		notification = &entities.Notification{
+			Channels: []entities.NotificationChannel{
+				{Name: "owners", Type: entities.NotificationChannelEmail, Recipients: []string{"owners@example.com"}},
+				{Name: "hook", Type: entities.NotificationChannelWebhook, Recipients: []string{"https://example.com/hook"}},
+				{Name: "chat", Type: entities.NotificationChannelChat, Recipients: []string{"chat-42"}, MinSeverity: entities.NotificationSeverityError},
+			},
+			Routes: []entities.NotificationRoute{
+				{EventType: entities.NotificationEventAny, Channels: []string{"owners"}},
+				{EventType: "host.state_changed", Channels: []string{"hook", "chat"}, MinSeverity: entities.NotificationSeverityWarning},
+			},
+		}
+	})
+
+	It("should accept valid configuration", func() {
+		Expect(ValidateNotification(notification)).To(Succeed())
+	})
+
+	DescribeTable("should reject invalid configuration",
+		func(mutate func(n *entities.Notification), message string) {
+			mutate(notification)
+			Expect(ValidateNotification(notification)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown channel type", func(n *entities.Notification) { n.Channels[0].Type = "pager" }, "unknown type"),
+		Entry("duplicated channel", func(n *entities.Notification) { n.Channels[1].Name = "owners" }, "duplicated"),
+		Entry("no recipients", func(n *entities.Notification) { n.Channels[0].Recipients = nil }, "no recipients"),
+		Entry("invalid email", func(n *entities.Notification) { n.Channels[0].Recipients = []string{"not-an-email"} }, "invalid recipient"),
+		Entry("invalid webhook url", func(n *entities.Notification) { n.Channels[1].Recipients = []string{"ftp://example.com"} }, "invalid recipient"),
+		Entry("unknown severity", func(n *entities.Notification) { n.Routes[1].MinSeverity = "fatal" }, "unknown severity"),
+		Entry("unknown route channel", func(n *entities.Notification) { n.Routes[0].Channels = []string{"sms"} }, "unknown channel"),
+		Entry("empty event type", func(n *entities.Notification) { n.Routes[0].EventType = "" }, "event type is required"),
+	)
+
+	It("should resolve channels by event type and severity", func() {
+		Expect(channelNames(notification.Resolve("host.state_changed", entities.NotificationSeverityWarning))).
+			To(Equal([]string{"owners", "hook"}))
+		Expect(channelNames(notification.Resolve("host.state_changed", entities.NotificationSeverityCritical))).
+			To(Equal([]string{"owners", "hook", "chat"}))
+		Expect(channelNames(notification.Resolve("project.added", entities.NotificationSeverityCritical))).
+			To(Equal([]string{"owners"}))
+	})
+})
+
+func channelNames(channels []entities.NotificationChannel) []string {
+	names := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		names = append(names, channel.Name)
+	}
+	return names
+}
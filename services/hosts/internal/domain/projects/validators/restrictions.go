@@ -0,0 +1,20 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+	"github.com/gwall-e/pkg/core_entities"
+)
+
+func ValidateRestrictions(restrictions []core_entities.Restriction) error {
+	for _, restriction := range restrictions {
+		if !restriction.IsValid() {
+			return &errors.ProjectValidationError{
+				Field:   "restrictions",
+				Message: fmt.Sprintf("unknown restriction %q", restriction),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package validators_test
+
+import (
+	. "github.com/gwall-e/hosts/internal/domain/projects/validators"
+	"github.com/gwall-e/pkg/core_entities"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateRestrictions", func() {
+	It("should accept known restrictions", func() {
+		Expect(ValidateRestrictions(core_entities.Restrictions())).To(Succeed())
+		Expect(ValidateRestrictions(nil)).To(Succeed())
+	})
+
+	It("should reject unknown restrictions", func() {
+		Expect(ValidateRestrictions([]core_entities.Restriction{core_entities.RestrictionNoReboot, "no-power"})).
+			To(MatchError(ContainSubstring(`unknown restriction "no-power"`)))
+	})
+})
@@ -0,0 +1,13 @@
+package validators_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidatorsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Project Validators Suite")
+}
@@ -0,0 +1,250 @@
+package cmstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Task struct {
+	ID      string
+	Issuer  string
+	Action  string
+	Hosts   []string
+	Status  string
+	Message string
+}
+
+const (
+	StatusInProcess = "in-process"
+	StatusApproved  = "ok"
+	StatusRejected  = "rejected"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	tasks         map[string]*Task
+	authorization string
+	autoApprove   bool
+}
+
+func NewServer() *Server {
+	s := &Server{tasks: map[string]*Task{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *Server) RequireAuthorization(value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorization = value
+}
+
+func (s *Server) SetAutoApprove(autoApprove bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoApprove = autoApprove
+}
+
+func (s *Server) Approve(id string) {
+	s.setStatus(id, StatusApproved, "")
+}
+
+func (s *Server) Reject(id string, message string) {
+	s.setStatus(id, StatusRejected, message)
+}
+
+func (s *Server) AddTask(task Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if task.Status == "" {
+		task.Status = StatusInProcess
+	}
+	s.tasks[task.ID] = &task
+}
+
+func (s *Server) Tasks() []Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		result = append(result, *task)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (s *Server) setStatus(id string, status string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if task, ok := s.tasks[id]; ok {
+		task.Status = status
+		task.Message = message
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	authorization := s.authorization
+	s.mu.Unlock()
+	if authorization != "" && r.Header.Get("Authorization") != authorization {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/api/v2/tasks"):
+		s.route(w, r, strings.TrimPrefix(r.URL.Path, "/api/v2/tasks"), v2Codec{})
+	case strings.HasPrefix(r.URL.Path, "/tasks"):
+		s.route(w, r, strings.TrimPrefix(r.URL.Path, "/tasks"), v1Codec{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, rest string, c codec) {
+	id := strings.Trim(rest, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		task, err := c.decode(r)
+		if err != nil || task.ID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		task.Status = StatusInProcess
+		if s.autoApprove {
+			task.Status = StatusApproved
+		}
+		s.tasks[task.ID] = task
+		writeJSON(w, http.StatusCreated, c.encode(task))
+	case r.Method == http.MethodGet && id == "":
+		tasks := make([]*Task, 0, len(s.tasks))
+		for _, task := range s.tasks {
+			tasks = append(tasks, task)
+		}
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+		writeJSON(w, http.StatusOK, c.encodeList(tasks))
+	case r.Method == http.MethodGet:
+		task, ok := s.tasks[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, c.encode(task))
+	case r.Method == http.MethodDelete:
+		if _, ok := s.tasks[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.tasks, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+type codec interface {
+	decode(r *http.Request) (*Task, error)
+	encode(task *Task) interface{}
+	encodeList(tasks []*Task) interface{}
+}
+
+type v1Codec struct{}
+
+func (v1Codec) decode(r *http.Request) (*Task, error) {
+	var body struct {
+		ID     string   `json:"id"`
+		Issuer string   `json:"issuer"`
+		Action string   `json:"action"`
+		Hosts  []string `json:"hosts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return &Task{ID: body.ID, Issuer: body.Issuer, Action: body.Action, Hosts: body.Hosts}, nil
+}
+
+func (v1Codec) encode(task *Task) interface{} {
+	return map[string]interface{}{
+		"id":      task.ID,
+		"issuer":  task.Issuer,
+		"action":  task.Action,
+		"hosts":   task.Hosts,
+		"status":  task.Status,
+		"message": task.Message,
+	}
+}
+
+func (c v1Codec) encodeList(tasks []*Task) interface{} {
+	result := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, c.encode(task))
+	}
+	return map[string]interface{}{"result": result}
+}
+
+type v2Codec struct{}
+
+var v2States = map[string]string{
+	StatusInProcess: "PENDING",
+	StatusApproved:  "APPROVED",
+	StatusRejected:  "REJECTED",
+}
+
+func (v2Codec) decode(r *http.Request) (*Task, error) {
+	var body struct {
+		ID     string `json:"id"`
+		Issuer string `json:"issuer"`
+		Action string `json:"action"`
+		Hosts  []struct {
+			FQDN string `json:"fqdn"`
+		} `json:"hosts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	task := &Task{ID: body.ID, Issuer: body.Issuer, Action: body.Action}
+	for _, host := range body.Hosts {
+		task.Hosts = append(task.Hosts, host.FQDN)
+	}
+	return task, nil
+}
+
+func (v2Codec) encode(task *Task) interface{} {
+	hosts := make([]map[string]string, 0, len(task.Hosts))
+	for _, host := range task.Hosts {
+		hosts = append(hosts, map[string]string{"fqdn": host})
+	}
+	return map[string]interface{}{
+		"id":     task.ID,
+		"issuer": task.Issuer,
+		"action": task.Action,
+		"hosts":  hosts,
+		"state":  v2States[task.Status],
+		"reason": task.Message,
+	}
+}
+
+func (c v2Codec) encodeList(tasks []*Task) interface{} {
+	result := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, c.encode(task))
+	}
+	return map[string]interface{}{"tasks": result}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
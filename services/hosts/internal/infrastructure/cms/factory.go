@@ -0,0 +1,52 @@
+package cms
+
+import (
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/contracts"
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+	projectEntities "github.com/gwall-e/hosts/internal/domain/projects/entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+const (
+	CMS_VERSION_V1 = "v1"
+	CMS_VERSION_V2 = "v2"
+)
+
+type ClientFactory struct {
+	setup []pkgHttp.SetupFunc
+}
+
+func NewClientFactory(setup ...pkgHttp.SetupFunc) *ClientFactory {
+	return &ClientFactory{setup: setup}
+}
+
+func (f *ClientFactory) NewClient(cms projectEntities.CMS) (contracts.CMSClient, error) {
+	if cms.URL == "" {
+		return nil, fmt.Errorf("cms url is not configured")
+	}
+
+	client := pkgHttp.NewClient(cms.URL, f.setup...)
+	headers := authHeaders(cms.Auth)
+
+	switch cms.Version {
+	case "", CMS_VERSION_V1:
+		return &v1Client{client: client, headers: headers}, nil
+	case CMS_VERSION_V2:
+		return &v2Client{client: client, headers: headers}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", cmsErrors.ErrUnsupportedVersion, cms.Version)
+	}
+}
+
+func authHeaders(auth projectEntities.CMSAuth) map[string]string {
+	headers := map[string]string{"Content-Type": "application/json"}
+	switch auth.Type {
+	case "oauth":
+		headers["Authorization"] = "OAuth " + auth.Value
+	case "bearer", "token":
+		headers["Authorization"] = "Bearer " + auth.Value
+	}
+	return headers
+}
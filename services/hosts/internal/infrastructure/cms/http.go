@@ -0,0 +1,29 @@
+package cms
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	cmsErrors "github.com/gwall-e/hosts/internal/domain/cms/errors"
+)
+
+func decodeResponse(resp *http.Response, err error, target interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return cmsErrors.ErrTaskNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("cms responded with status %d: %s", resp.StatusCode, string(body))
+	}
+	if target == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
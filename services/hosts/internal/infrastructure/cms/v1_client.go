@@ -0,0 +1,99 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+type v1Task struct {
+	ID      string   `json:"id"`
+	Type    string   `json:"type,omitempty"`
+	Issuer  string   `json:"issuer"`
+	Action  string   `json:"action"`
+	Hosts   []string `json:"hosts"`
+	Status  string   `json:"status,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+type v1TaskList struct {
+	Result []v1Task `json:"result"`
+}
+
+type v1Client struct {
+	client  pkgHttp.HTTPClient
+	headers map[string]string
+}
+
+func (c *v1Client) CreateTask(ctx context.Context, task *entities.CMSTask) (*entities.CMSTask, error) {
+	body, err := json.Marshal(v1Task{
+		ID:     task.ID,
+		Type:   "automated",
+		Issuer: task.Issuer,
+		Action: string(task.Action),
+		Hosts:  task.Hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result v1Task
+	resp, err := c.client.Post(ctx, "/tasks", bytes.NewReader(body), c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+	return result.toEntity(), nil
+}
+
+func (c *v1Client) GetTask(ctx context.Context, id string) (*entities.CMSTask, error) {
+	var result v1Task
+	resp, err := c.client.Get(ctx, "/tasks/"+id, nil, c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+	return result.toEntity(), nil
+}
+
+func (c *v1Client) DeleteTask(ctx context.Context, id string) error {
+	resp, err := c.client.Delete(ctx, "/tasks/"+id, c.headers)
+	return decodeResponse(resp, err, nil)
+}
+
+func (c *v1Client) ListTasks(ctx context.Context) ([]entities.CMSTask, error) {
+	var result v1TaskList
+	resp, err := c.client.Get(ctx, "/tasks", nil, c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]entities.CMSTask, 0, len(result.Result))
+	for _, task := range result.Result {
+		tasks = append(tasks, *task.toEntity())
+	}
+	return tasks, nil
+}
+
+func (t v1Task) toEntity() *entities.CMSTask {
+	status := entities.CMSTaskStatus(t.Status)
+	switch status {
+	case entities.CMSTaskApproved, entities.CMSTaskRejected:
+	default:
+		status = entities.CMSTaskInProcess
+	}
+
+	hosts := t.Hosts
+	if hosts == nil {
+		hosts = []string{}
+	}
+	return &entities.CMSTask{
+		ID:      t.ID,
+		Action:  entities.CMSAction(t.Action),
+		Hosts:   hosts,
+		Issuer:  t.Issuer,
+		Status:  status,
+		Message: t.Message,
+	}
+}
@@ -0,0 +1,111 @@
+package cms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/gwall-e/hosts/internal/domain/cms/entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+const (
+	v2StateApproved = "APPROVED"
+	v2StateRejected = "REJECTED"
+)
+
+type v2Host struct {
+	FQDN string `json:"fqdn"`
+}
+
+type v2Task struct {
+	ID     string   `json:"id"`
+	Issuer string   `json:"issuer"`
+	Action string   `json:"action"`
+	Hosts  []v2Host `json:"hosts"`
+	State  string   `json:"state,omitempty"`
+	Reason string   `json:"reason,omitempty"`
+}
+
+type v2TaskList struct {
+	Tasks []v2Task `json:"tasks"`
+}
+
+type v2Client struct {
+	client  pkgHttp.HTTPClient
+	headers map[string]string
+}
+
+func (c *v2Client) CreateTask(ctx context.Context, task *entities.CMSTask) (*entities.CMSTask, error) {
+	hosts := make([]v2Host, 0, len(task.Hosts))
+	for _, host := range task.Hosts {
+		hosts = append(hosts, v2Host{FQDN: host})
+	}
+	body, err := json.Marshal(v2Task{
+		ID:     task.ID,
+		Issuer: task.Issuer,
+		Action: string(task.Action),
+		Hosts:  hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result v2Task
+	resp, err := c.client.Post(ctx, "/api/v2/tasks", bytes.NewReader(body), c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+	return result.toEntity(), nil
+}
+
+func (c *v2Client) GetTask(ctx context.Context, id string) (*entities.CMSTask, error) {
+	var result v2Task
+	resp, err := c.client.Get(ctx, "/api/v2/tasks/"+id, nil, c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+	return result.toEntity(), nil
+}
+
+func (c *v2Client) DeleteTask(ctx context.Context, id string) error {
+	resp, err := c.client.Delete(ctx, "/api/v2/tasks/"+id, c.headers)
+	return decodeResponse(resp, err, nil)
+}
+
+func (c *v2Client) ListTasks(ctx context.Context) ([]entities.CMSTask, error) {
+	var result v2TaskList
+	resp, err := c.client.Get(ctx, "/api/v2/tasks", nil, c.headers)
+	if err := decodeResponse(resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]entities.CMSTask, 0, len(result.Tasks))
+	for _, task := range result.Tasks {
+		tasks = append(tasks, *task.toEntity())
+	}
+	return tasks, nil
+}
+
+func (t v2Task) toEntity() *entities.CMSTask {
+	status := entities.CMSTaskInProcess
+	switch t.State {
+	case v2StateApproved:
+		status = entities.CMSTaskApproved
+	case v2StateRejected:
+		status = entities.CMSTaskRejected
+	}
+
+	hosts := make([]string, 0, len(t.Hosts))
+	for _, host := range t.Hosts {
+		hosts = append(hosts, host.FQDN)
+	}
+	return &entities.CMSTask{
+		ID:      t.ID,
+		Action:  entities.CMSAction(t.Action),
+		Hosts:   hosts,
+		Issuer:  t.Issuer,
+		Status:  status,
+		Message: t.Reason,
+	}
+}
@@ -0,0 +1,13 @@
+package notifications_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNotificationAdaptersSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Notification Adapters Suite")
+}
@@ -0,0 +1,87 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/gwall-e/hosts/internal/application/notifications"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+)
+
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(addr string, from string, auth smtp.Auth) *SMTPSender {
+	return &SMTPSender{addr: addr, from: from, auth: auth}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, channel entities.NotificationChannel, message notifications.Message) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	for _, recipient := range channel.Recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.buildMessage(channel.Recipients, message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (s *SMTPSender) buildMessage(recipients []string, message notifications.Message) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", s.from)
+	fmt.Fprintf(&sb, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\r\n", message.Subject)
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	sb.WriteString("\r\n")
+	return []byte(sb.String())
+}
@@ -0,0 +1,136 @@
+package notifications_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/gwall-e/hosts/internal/application/notifications"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	. "github.com/gwall-e/hosts/internal/infrastructure/notifications"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type smtpServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	from     string
+	rcpt     []string
+	data     string
+}
+
+func newSMTPServer() *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	s := &smtpServer{listener: listener}
+	go s.serve()
+	return s
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		upper := strings.ToUpper(cmd)
+		switch {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<>")
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			s.mu.Lock()
+			s.rcpt = append(s.rcpt, strings.Trim(cmd[len("RCPT TO:"):], "<>"))
+			s.mu.Unlock()
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var sb strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				sb.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.data = sb.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case upper == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+var _ = Describe("SMTPSender", func() {
+	var server *smtpServer
+
+	BeforeEach(func() {
+		server = newSMTPServer()
+	})
+
+	AfterEach(func() {
+		server.listener.Close()
+	})
+
+	It("should deliver message to all recipients", func() {
+		sender := NewSMTPSender(server.listener.Addr().String(), "walle@example.com", nil)
+		channel := entities.NotificationChannel{
+			Name:       "owners",
+			Type:       entities.NotificationChannelEmail,
+			Recipients: []string{"a@example.com", "b@example.com"},
+		}
+
+		err := sender.Send(context.Background(), channel, notifications.Message{
+			Subject: "[warning] search: project.network_changed",
+			Body:    "native vlan changed",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		Expect(server.from).To(Equal("walle@example.com"))
+		Expect(server.rcpt).To(Equal([]string{"a@example.com", "b@example.com"}))
+		Expect(server.data).To(ContainSubstring("Subject: [warning] search: project.network_changed"))
+		Expect(server.data).To(ContainSubstring("native vlan changed"))
+	})
+
+	It("should fail when server is unavailable", func() {
+		addr := server.listener.Addr().String()
+		server.listener.Close()
+
+		sender := NewSMTPSender(addr, "walle@example.com", nil)
+		err := sender.Send(context.Background(), entities.NotificationChannel{Recipients: []string{"a@example.com"}}, notifications.Message{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gwall-e/hosts/internal/application/notifications"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	pkgHttp "github.com/gwall-e/pkg/http"
+)
+
+type webhookPayload struct {
+	ProjectID string `json:"project_id"`
+	EventType string `json:"event_type"`
+	Severity  string `json:"severity"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+}
+
+type WebhookSender struct {
+	client pkgHttp.HTTPClient
+}
+
+func NewWebhookSender(client pkgHttp.HTTPClient) *WebhookSender {
+	return &WebhookSender{client: client}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, channel entities.NotificationChannel, message notifications.Message) error {
+	payload, err := json.Marshal(webhookPayload{
+		ProjectID: message.ProjectID,
+		EventType: message.EventType,
+		Severity:  string(message.Severity),
+		Subject:   message.Subject,
+		Body:      message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, recipient := range channel.Recipients {
+		if err := s.post(ctx, recipient, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *WebhookSender) post(ctx context.Context, recipient string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, recipient, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", recipient, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gwall-e/hosts/internal/application/notifications"
+	"github.com/gwall-e/hosts/internal/domain/projects/entities"
+	. "github.com/gwall-e/hosts/internal/infrastructure/notifications"
+	pkgHttp "github.com/gwall-e/pkg/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookSender", func() {
+	var (
+		server   *httptest.Server
+		received []map[string]string
+		status   int
+	)
+
+	BeforeEach(func() {
+		received = nil
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload := map[string]string{}
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			received = append(received, payload)
+			w.WriteHeader(status)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func() error {
+		sender := NewWebhookSender(pkgHttp.NewClient(""))
+		channel := entities.NotificationChannel{
+			Name:       "alerts",
+			Type:       entities.NotificationChannelWebhook,
+			Recipients: []string{server.URL + "/hook"},
+		}
+		return sender.Send(context.Background(), channel, notifications.Message{
+			ProjectID: "search",
+			EventType: notifications.EventProjectAdded,
+			Severity:  entities.NotificationSeverityInfo,
+			Subject:   "subject",
+			Body:      "body",
+		})
+	}
+
+	It("should post message as json", func() {
+		Expect(send()).To(Succeed())
+		Expect(received).To(HaveLen(1))
+		Expect(received[0]).To(HaveKeyWithValue("project_id", "search"))
+		Expect(received[0]).To(HaveKeyWithValue("event_type", notifications.EventProjectAdded))
+		Expect(received[0]).To(HaveKeyWithValue("severity", "info"))
+	})
+
+	It("should post to remaining recipients when one fails", func() {
+		sender := NewWebhookSender(pkgHttp.NewClient(""))
+		channel := entities.NotificationChannel{
+			Name:       "alerts",
+			Type:       entities.NotificationChannelWebhook,
+			Recipients: []string{"http://127.0.0.1:1/hook", server.URL + "/hook"},
+		}
+		err := sender.Send(context.Background(), channel, notifications.Message{ProjectID: "search"})
+		Expect(err).To(HaveOccurred())
+		Expect(received).To(HaveLen(1))
+	})
+
+	It("should fail on non-successful status", func() {
+		status = http.StatusBadRequest
+		Expect(send()).To(MatchError(ContainSubstring("status 400")))
+	})
+})
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+)
+
+type HostMoveTaskRepository struct {
+	mu    sync.RWMutex
+	tasks map[string]*entities.HostMoveTask
+}
+
+func NewHostMoveTaskRepository() *HostMoveTaskRepository {
+	return &HostMoveTaskRepository{tasks: map[string]*entities.HostMoveTask{}}
+}
+
+func (r *HostMoveTaskRepository) Create(ctx context.Context, task *entities.HostMoveTask) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task.Version = 1
+	r.tasks[task.ID] = cloneHostMoveTask(task)
+	return nil
+}
+
+func (r *HostMoveTaskRepository) Update(ctx context.Context, task *entities.HostMoveTask) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tasks[task.ID]
+	if !ok {
+		return errors.ErrMoveTaskNotFound
+	}
+	if stored.Version != task.Version {
+		return errors.ErrMoveVersionConflict
+	}
+	task.Version++
+	r.tasks[task.ID] = cloneHostMoveTask(task)
+	return nil
+}
+
+func (r *HostMoveTaskRepository) GetByID(ctx context.Context, id string) (*entities.HostMoveTask, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneHostMoveTask(task), nil
+}
+
+func (r *HostMoveTaskRepository) FindActiveByHost(ctx context.Context, hostID string) (*entities.HostMoveTask, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, task := range r.tasks {
+		if task.HostID == hostID && task.IsActive() {
+			return cloneHostMoveTask(task), nil
+		}
+	}
+	return nil, nil
+}
+
+func cloneHostMoveTask(task *entities.HostMoveTask) *entities.HostMoveTask {
+	clone := *task
+	clone.Steps = slices.Clone(task.Steps)
+	if task.LeaseUntil != nil {
+		leaseUntil := *task.LeaseUntil
+		clone.LeaseUntil = &leaseUntil
+	}
+	clone.ClearEvents()
+	return &clone
+}
@@ -0,0 +1,11 @@
+package memory_test
+
+import (
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/memory"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/repotest"
+)
+
+var _ = repotest.DescribeHostMoveTaskRepository(func() contracts.HostMoveTaskRepository {
+	return memory.NewHostMoveTaskRepository()
+})
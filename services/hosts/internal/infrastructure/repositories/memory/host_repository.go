@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/hosts/validators"
+)
+
+type HostRepository struct {
+	mu    sync.RWMutex
+	hosts map[string]*entities.Host
+}
+
+func NewHostRepository() *HostRepository {
+	return &HostRepository{hosts: map[string]*entities.Host{}}
+}
+
+func (r *HostRepository) Create(ctx context.Context, host *entities.Host) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.hosts[host.ID]; ok {
+		return fmt.Errorf("%w: id %s", errors.ErrHostAlreadyExists, host.ID)
+	}
+	if err := r.checkUnique(host); err != nil {
+		return err
+	}
+
+	host.Version = 1
+	r.hosts[host.ID] = cloneHost(host)
+	return nil
+}
+
+func (r *HostRepository) Update(ctx context.Context, host *entities.Host) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.hosts[host.ID]
+	if !ok {
+		return errors.ErrHostNotFound
+	}
+	if stored.Version != host.Version {
+		return errors.ErrHostVersionConflict
+	}
+	if err := r.checkUnique(host); err != nil {
+		return err
+	}
+
+	host.Version++
+	r.hosts[host.ID] = cloneHost(host)
+	return nil
+}
+
+func (r *HostRepository) GetByID(ctx context.Context, id string) (*entities.Host, error) {
+	return r.find(func(host *entities.Host) bool { return host.ID == id }), nil
+}
+
+func (r *HostRepository) GetByInventoryNumber(ctx context.Context, inventoryNumber string) (*entities.Host, error) {
+	return r.find(func(host *entities.Host) bool { return host.InventoryNumber == inventoryNumber }), nil
+}
+
+func (r *HostRepository) GetByFQDN(ctx context.Context, fqdn string) (*entities.Host, error) {
+	if fqdn == "" {
+		return nil, nil
+	}
+	return r.find(func(host *entities.Host) bool { return host.FQDN == fqdn }), nil
+}
+
+func (r *HostRepository) GetByMAC(ctx context.Context, mac string) (*entities.Host, error) {
+	macs, err := validators.NormalizeMACs([]string{mac})
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(host *entities.Host) bool { return slices.Contains(host.MACs, macs[0]) }), nil
+}
+
+func (r *HostRepository) List(ctx context.Context, filter contracts.HostFilter, page contracts.HostPageRequest) (*contracts.HostPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*entities.Host, 0)
+	for _, host := range r.hosts {
+		if host.ID > page.Cursor && matchesFilter(host, filter) {
+			matched = append(matched, host)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	result := &contracts.HostPage{Hosts: make([]*entities.Host, 0)}
+	size := page.PageSize()
+	if len(matched) > size {
+		matched = matched[:size]
+		result.NextCursor = matched[size-1].ID
+	}
+	for _, host := range matched {
+		result.Hosts = append(result.Hosts, cloneHost(host))
+	}
+	return result, nil
+}
+
+func (r *HostRepository) FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	existing := make([]string, 0)
+	for _, host := range r.hosts {
+		if host.FQDN != "" && slices.Contains(fqdns, host.FQDN) {
+			existing = append(existing, host.FQDN)
+		}
+	}
+	sort.Strings(existing)
+	return existing, nil
+}
+
+func (r *HostRepository) find(match func(host *entities.Host) bool) *entities.Host {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, host := range r.hosts {
+		if match(host) {
+			return cloneHost(host)
+		}
+	}
+	return nil
+}
+
+func (r *HostRepository) checkUnique(host *entities.Host) error {
+	for _, stored := range r.hosts {
+		if stored.ID == host.ID {
+			continue
+		}
+		if stored.InventoryNumber == host.InventoryNumber {
+			return fmt.Errorf("%w: inventory number %s", errors.ErrHostAlreadyExists, host.InventoryNumber)
+		}
+		if host.FQDN != "" && stored.FQDN == host.FQDN {
+			return fmt.Errorf("%w: fqdn %s", errors.ErrHostAlreadyExists, host.FQDN)
+		}
+		for _, mac := range host.MACs {
+			if slices.Contains(stored.MACs, mac) {
+				return fmt.Errorf("%w: mac %s", errors.ErrHostAlreadyExists, mac)
+			}
+		}
+	}
+	return nil
+}
+
+func matchesFilter(host *entities.Host, filter contracts.HostFilter) bool {
+	if filter.ProjectID != "" && host.ProjectID != filter.ProjectID {
+		return false
+	}
+	if len(filter.States) > 0 && !slices.Contains(filter.States, host.State) {
+		return false
+	}
+	if filter.Datacenter != "" && host.Location.Datacenter != filter.Datacenter {
+		return false
+	}
+	if filter.Hall != "" && host.Location.Hall != filter.Hall {
+		return false
+	}
+	if filter.Row != "" && host.Location.Row != filter.Row {
+		return false
+	}
+	if filter.Rack != "" && host.Location.Rack != filter.Rack {
+		return false
+	}
+	if filter.Switch != "" && host.Location.Switch != filter.Switch {
+		return false
+	}
+	return host.Matches(filter.Selector)
+}
+
+func cloneHost(host *entities.Host) *entities.Host {
+	clone := *host
+	clone.MACs = slices.Clone(host.MACs)
+	clone.Restrictions = slices.Clone(host.Restrictions)
+	clone.Labels = maps.Clone(host.Labels)
+	clone.ClearEvents()
+	return &clone
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const HOST_MOVES_COLLECTION = "host_moves"
+
+type HostMoveTaskRepository struct {
+	collection *mongo.Collection
+}
+
+func NewHostMoveTaskRepository(db *mongo.Database) *HostMoveTaskRepository {
+	return &HostMoveTaskRepository{collection: db.Collection(HOST_MOVES_COLLECTION)}
+}
+
+func (r *HostMoveTaskRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "status", Value: 1}},
+		Options: options.Index().SetName("host_status"),
+	})
+	return err
+}
+
+func (r *HostMoveTaskRepository) Create(ctx context.Context, task *entities.HostMoveTask) error {
+	version := task.Version
+	task.Version = 1
+
+	if _, err := r.collection.InsertOne(ctx, task); err != nil {
+		task.Version = version
+		return err
+	}
+	return nil
+}
+
+func (r *HostMoveTaskRepository) Update(ctx context.Context, task *entities.HostMoveTask) error {
+	expected := task.Version
+	task.Version++
+
+	result, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: task.ID}, {Key: "version", Value: expected}}, task)
+	if err != nil {
+		task.Version = expected
+		return err
+	}
+	if result.MatchedCount == 1 {
+		return nil
+	}
+
+	task.Version = expected
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "_id", Value: task.ID}})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrMoveTaskNotFound
+	}
+	return errors.ErrMoveVersionConflict
+}
+
+func (r *HostMoveTaskRepository) GetByID(ctx context.Context, id string) (*entities.HostMoveTask, error) {
+	return r.findOne(ctx, bson.D{{Key: "_id", Value: id}})
+}
+
+func (r *HostMoveTaskRepository) FindActiveByHost(ctx context.Context, hostID string) (*entities.HostMoveTask, error) {
+	return r.findOne(ctx, bson.D{
+		{Key: "host_id", Value: hostID},
+		{Key: "status", Value: bson.D{{Key: "$nin", Value: []entities.HostMoveTaskStatus{
+			entities.HostMoveTaskDone,
+			entities.HostMoveTaskCancelled,
+		}}}},
+	})
+}
+
+func (r *HostMoveTaskRepository) findOne(ctx context.Context, filter bson.D) (*entities.HostMoveTask, error) {
+	var task entities.HostMoveTask
+	if err := r.collection.FindOne(ctx, filter).Decode(&task); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
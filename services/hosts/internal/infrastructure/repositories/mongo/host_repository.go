@@ -0,0 +1,214 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/hosts/internal/domain/hosts/validators"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const HOSTS_COLLECTION = "hosts"
+
+type HostRepository struct {
+	collection *mongo.Collection
+}
+
+func NewHostRepository(db *mongo.Database) *HostRepository {
+	return &HostRepository{collection: db.Collection(HOSTS_COLLECTION)}
+}
+
+func (r *HostRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "inventory_number", Value: 1}},
+			Options: options.Index().SetName("inventory_number_unique").SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "fqdn", Value: 1}},
+			Options: options.Index().SetName("fqdn_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "fqdn", Value: bson.D{{Key: "$gt", Value: ""}}}}),
+		},
+		{
+			Keys: bson.D{{Key: "macs", Value: 1}},
+			Options: options.Index().SetName("macs_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "macs.0", Value: bson.D{{Key: "$exists", Value: true}}}}),
+		},
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}, {Key: "state", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("project_state"),
+		},
+		{
+			Keys:    bson.D{{Key: "state", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("state"),
+		},
+		{
+			Keys:    bson.D{{Key: "location.datacenter", Value: 1}, {Key: "location.rack", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("location"),
+		},
+		{
+			Keys:    bson.D{{Key: "location.switch", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("switch"),
+		},
+	})
+	return err
+}
+
+func (r *HostRepository) Create(ctx context.Context, host *entities.Host) error {
+	version := host.Version
+	host.Version = 1
+
+	if _, err := r.collection.InsertOne(ctx, host); err != nil {
+		host.Version = version
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", errors.ErrHostAlreadyExists, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *HostRepository) Update(ctx context.Context, host *entities.Host) error {
+	expected := host.Version
+	host.Version++
+
+	result, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: host.ID}, {Key: "version", Value: expected}}, host)
+	if err != nil {
+		host.Version = expected
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %s", errors.ErrHostAlreadyExists, err)
+		}
+		return err
+	}
+	if result.MatchedCount == 1 {
+		return nil
+	}
+
+	host.Version = expected
+	count, err := r.collection.CountDocuments(ctx, bson.D{{Key: "_id", Value: host.ID}})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrHostNotFound
+	}
+	return errors.ErrHostVersionConflict
+}
+
+func (r *HostRepository) GetByID(ctx context.Context, id string) (*entities.Host, error) {
+	return r.findOne(ctx, bson.D{{Key: "_id", Value: id}})
+}
+
+func (r *HostRepository) GetByInventoryNumber(ctx context.Context, inventoryNumber string) (*entities.Host, error) {
+	return r.findOne(ctx, bson.D{{Key: "inventory_number", Value: inventoryNumber}})
+}
+
+func (r *HostRepository) GetByFQDN(ctx context.Context, fqdn string) (*entities.Host, error) {
+	if fqdn == "" {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.D{{Key: "fqdn", Value: fqdn}})
+}
+
+func (r *HostRepository) GetByMAC(ctx context.Context, mac string) (*entities.Host, error) {
+	macs, err := validators.NormalizeMACs([]string{mac})
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.D{{Key: "macs", Value: macs[0]}})
+}
+
+func (r *HostRepository) List(ctx context.Context, filter contracts.HostFilter, page contracts.HostPageRequest) (*contracts.HostPage, error) {
+	query := hostFilterQuery(filter)
+	if page.Cursor != "" {
+		query = append(query, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: page.Cursor}}})
+	}
+
+	size := page.PageSize()
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(size + 1))
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]*entities.Host, 0)
+	if err := cursor.All(ctx, &hosts); err != nil {
+		return nil, err
+	}
+
+	result := &contracts.HostPage{Hosts: hosts}
+	if len(hosts) > size {
+		result.Hosts = hosts[:size]
+		result.NextCursor = hosts[size-1].ID
+	}
+	return result, nil
+}
+
+func (r *HostRepository) FindExistingFQDNs(ctx context.Context, fqdns []string) ([]string, error) {
+	existing := make([]string, 0)
+	if len(fqdns) == 0 {
+		return existing, nil
+	}
+
+	opts := options.Find().SetProjection(bson.D{{Key: "fqdn", Value: 1}}).SetSort(bson.D{{Key: "fqdn", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "fqdn", Value: bson.D{{Key: "$in", Value: fqdns}}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []struct {
+		FQDN string `bson:"fqdn"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		existing = append(existing, doc.FQDN)
+	}
+	return existing, nil
+}
+
+func (r *HostRepository) findOne(ctx context.Context, filter bson.D) (*entities.Host, error) {
+	var host entities.Host
+	if err := r.collection.FindOne(ctx, filter).Decode(&host); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &host, nil
+}
+
+func hostFilterQuery(filter contracts.HostFilter) bson.D {
+	query := bson.D{}
+	if filter.ProjectID != "" {
+		query = append(query, bson.E{Key: "project_id", Value: filter.ProjectID})
+	}
+	if len(filter.States) > 0 {
+		query = append(query, bson.E{Key: "state", Value: bson.D{{Key: "$in", Value: filter.States}}})
+	}
+	if filter.Datacenter != "" {
+		query = append(query, bson.E{Key: "location.datacenter", Value: filter.Datacenter})
+	}
+	if filter.Hall != "" {
+		query = append(query, bson.E{Key: "location.hall", Value: filter.Hall})
+	}
+	if filter.Row != "" {
+		query = append(query, bson.E{Key: "location.row", Value: filter.Row})
+	}
+	if filter.Rack != "" {
+		query = append(query, bson.E{Key: "location.rack", Value: filter.Rack})
+	}
+	if filter.Switch != "" {
+		query = append(query, bson.E{Key: "location.switch", Value: filter.Switch})
+	}
+	if !filter.Selector.Empty() {
+		query = append(query, bson.E{Key: "$and", Value: selectorQuery(filter.Selector)})
+	}
+	return query
+}
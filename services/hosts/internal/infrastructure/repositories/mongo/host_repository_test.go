@@ -0,0 +1,24 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	repositories "github.com/gwall-e/hosts/internal/infrastructure/repositories/mongo"
+	"github.com/gwall-e/hosts/internal/infrastructure/repositories/repotest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = repotest.DescribeHostRepository(func() contracts.HostRepository {
+	db := client.Database(fmt.Sprintf("hosts_test_%d", time.Now().UnixNano()))
+	DeferCleanup(func() {
+		Expect(db.Drop(context.Background())).To(Succeed())
+	})
+
+	repo := repositories.NewHostRepository(db)
+	Expect(repo.EnsureIndexes(context.Background())).To(Succeed())
+	return repo
+})
@@ -0,0 +1,43 @@
+package mongo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MONGO_TEST_URI_ENV = "MONGO_TEST_URI"
+
+var client *mongo.Client
+
+func TestMongoRepositoriesSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mongo Repositories Suite")
+}
+
+var _ = BeforeSuite(func() {
+	uri := os.Getenv(MONGO_TEST_URI_ENV)
+	if uri == "" {
+		Skip(MONGO_TEST_URI_ENV + " is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var err error
+	client, err = mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(client.Ping(ctx, nil)).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	if client != nil {
+		Expect(client.Disconnect(context.Background())).To(Succeed())
+	}
+})
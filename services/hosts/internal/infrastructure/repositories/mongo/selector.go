@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	"github.com/gwall-e/pkg/selectors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func selectorQuery(selector selectors.Selector) bson.A {
+	clauses := bson.A{}
+	for _, requirement := range selector.Requirements {
+		clauses = append(clauses, requirementQuery(requirement))
+	}
+	return clauses
+}
+
+func requirementQuery(requirement selectors.Requirement) bson.D {
+	path, isField := entities.HOST_SELECTOR_FIELDS[requirement.Key]
+	if !isField {
+		path = "labels." + requirement.Key
+	}
+
+	if isField {
+		return bson.D{{Key: path, Value: fieldCondition(requirement)}}
+	}
+
+	var condition interface{}
+	switch requirement.Operator {
+	case selectors.OperatorEquals:
+		condition = requirement.Values[0]
+	case selectors.OperatorNotEquals:
+		condition = bson.D{{Key: "$ne", Value: requirement.Values[0]}}
+	case selectors.OperatorIn:
+		condition = bson.D{{Key: "$in", Value: requirement.Values}}
+	case selectors.OperatorNotIn:
+		condition = bson.D{{Key: "$nin", Value: requirement.Values}}
+	case selectors.OperatorExists:
+		condition = bson.D{{Key: "$exists", Value: true}}
+	case selectors.OperatorDoesNotExist:
+		condition = bson.D{{Key: "$exists", Value: false}}
+	}
+	return bson.D{{Key: path, Value: condition}}
+}
+
+func fieldCondition(requirement selectors.Requirement) bson.D {
+	values := bson.A{}
+	for _, value := range requirement.Values {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	missing := bson.A{nil, ""}
+
+	switch requirement.Operator {
+	case selectors.OperatorEquals, selectors.OperatorIn:
+		return bson.D{{Key: "$in", Value: values}}
+	case selectors.OperatorNotEquals, selectors.OperatorNotIn:
+		return bson.D{{Key: "$nin", Value: values}}
+	case selectors.OperatorExists:
+		return bson.D{{Key: "$nin", Value: missing}}
+	default:
+		return bson.D{{Key: "$in", Value: missing}}
+	}
+}
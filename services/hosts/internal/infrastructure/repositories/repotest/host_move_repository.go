@@ -0,0 +1,89 @@
+package repotest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeHostMoveTaskRepository(newRepository func() contracts.HostMoveTaskRepository) bool {
+	return Describe("HostMoveTaskRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.HostMoveTaskRepository
+			now  time.Time
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should save and load tasks with step progress", func() {
+			task := entities.NewHostMoveTask("host-1", "search", "ads", entities.HostMoveOptions{EraseDisks: true},
+				[]entities.HostMoveStepType{entities.HostMoveStepEraseDisks, entities.HostMoveStepChangeProject}, now)
+			Expect(task.StartStep(now)).To(Succeed())
+			Expect(repo.Create(ctx, task)).To(Succeed())
+
+			task.CompleteStep(now.Add(time.Minute))
+			Expect(repo.Update(ctx, task)).To(Succeed())
+			Expect(task.Version).To(Equal(int64(2)))
+
+			stored, err := repo.GetByID(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Options).To(Equal(entities.HostMoveOptions{EraseDisks: true}))
+			Expect(stored.Steps).To(HaveLen(2))
+			Expect(stored.Steps[0].Status).To(Equal(entities.HostMoveStepDone))
+			Expect(*stored.Steps[0].FinishedAt).To(BeTemporally("==", now.Add(time.Minute)))
+			Expect(stored.CurrentStep().Type).To(Equal(entities.HostMoveStepChangeProject))
+			Expect(stored.Version).To(Equal(int64(2)))
+		})
+
+		It("should reject updates of a task changed concurrently", func() {
+			task := entities.NewHostMoveTask("host-1", "search", "ads", entities.HostMoveOptions{},
+				[]entities.HostMoveStepType{entities.HostMoveStepChangeProject}, now)
+			Expect(repo.Create(ctx, task)).To(Succeed())
+
+			first, err := repo.GetByID(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := repo.GetByID(ctx, task.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first.Claim(now, now.Add(time.Minute))).To(Succeed())
+			Expect(repo.Update(ctx, first)).To(Succeed())
+			Expect(*first.LeaseUntil).To(BeTemporally("==", now.Add(time.Minute)))
+
+			Expect(second.Claim(now, now.Add(time.Minute))).To(Succeed())
+			Expect(repo.Update(ctx, second)).To(MatchError(hostErrors.ErrMoveVersionConflict))
+			Expect(second.Version).To(Equal(int64(1)))
+
+			unknown := entities.NewHostMoveTask("host-2", "search", "ads", entities.HostMoveOptions{}, nil, now)
+			Expect(repo.Update(ctx, unknown)).To(MatchError(hostErrors.ErrMoveTaskNotFound))
+		})
+
+		It("should return nil for unknown tasks", func() {
+			Expect(repo.GetByID(ctx, "unknown")).To(BeNil())
+		})
+
+		It("should find only active task of the host", func() {
+			done := entities.NewHostMoveTask("host-1", "search", "ads", entities.HostMoveOptions{}, nil, now)
+			Expect(done.Cancel(now)).To(Succeed())
+			Expect(repo.Create(ctx, done)).To(Succeed())
+			Expect(repo.FindActiveByHost(ctx, "host-1")).To(BeNil())
+
+			active := entities.NewHostMoveTask("host-1", "ads", "search", entities.HostMoveOptions{}, nil, now)
+			Expect(repo.Create(ctx, active)).To(Succeed())
+
+			found, err := repo.FindActiveByHost(ctx, "host-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.ID).To(Equal(active.ID))
+			Expect(repo.FindActiveByHost(ctx, "host-2")).To(BeNil())
+		})
+	})
+}
@@ -0,0 +1,263 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwall-e/hosts/internal/domain/hosts/contracts"
+	"github.com/gwall-e/hosts/internal/domain/hosts/entities"
+	hostErrors "github.com/gwall-e/hosts/internal/domain/hosts/errors"
+	"github.com/gwall-e/pkg/core_entities"
+	"github.com/gwall-e/pkg/selectors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeHostRepository(newRepository func() contracts.HostRepository) bool {
+	return Describe("HostRepository contract", func() {
+		var (
+			ctx  context.Context
+			repo contracts.HostRepository
+		)
+
+		newHost := func(n int, macs ...string) *entities.Host {
+			host, err := entities.NewHost(fmt.Sprintf("inv-%04d", n), fmt.Sprintf("host-%04d.example.net", n),
+				core_entities.TypeServer, entities.HostLocation{Datacenter: "sas", Rack: "1a"}, macs)
+			Expect(err).NotTo(HaveOccurred())
+			host.ID = fmt.Sprintf("host-%04d", n)
+			return host
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			repo = newRepository()
+		})
+
+		Describe("lookups", func() {
+			var host *entities.Host
+
+			BeforeEach(func() {
+				host = newHost(1, "aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02")
+				Expect(repo.Create(ctx, host)).To(Succeed())
+				Expect(host.Version).To(Equal(int64(1)))
+			})
+
+			It("should find host by id, inventory number, fqdn and mac", func() {
+				byID, err := repo.GetByID(ctx, host.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(byID).To(Equal(host))
+
+				byInventory, err := repo.GetByInventoryNumber(ctx, "inv-0001")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(byInventory.ID).To(Equal(host.ID))
+
+				byFQDN, err := repo.GetByFQDN(ctx, "host-0001.example.net")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(byFQDN.ID).To(Equal(host.ID))
+
+				byMAC, err := repo.GetByMAC(ctx, "AA-BB-CC-DD-EE-02")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(byMAC.ID).To(Equal(host.ID))
+			})
+
+			It("should return nil for unknown hosts", func() {
+				Expect(repo.GetByID(ctx, "unknown")).To(BeNil())
+				Expect(repo.GetByInventoryNumber(ctx, "unknown")).To(BeNil())
+				Expect(repo.GetByFQDN(ctx, "unknown.example.net")).To(BeNil())
+				Expect(repo.GetByFQDN(ctx, "")).To(BeNil())
+				Expect(repo.GetByMAC(ctx, "00:00:00:00:00:00")).To(BeNil())
+			})
+
+			It("should find existing fqdns", func() {
+				existing, err := repo.FindExistingFQDNs(ctx, []string{"free.example.net", "host-0001.example.net"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(existing).To(Equal([]string{"host-0001.example.net"}))
+
+				existing, err = repo.FindExistingFQDNs(ctx, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(existing).To(BeEmpty())
+			})
+
+			It("should reject invalid mac lookups", func() {
+				_, err := repo.GetByMAC(ctx, "not-a-mac")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("uniqueness", func() {
+			BeforeEach(func() {
+				Expect(repo.Create(ctx, newHost(1, "aa:bb:cc:dd:ee:01"))).To(Succeed())
+			})
+
+			DescribeTable("should reject duplicated hosts",
+				func(mutate func(host *entities.Host)) {
+					host := newHost(2, "aa:bb:cc:dd:ee:02")
+					mutate(host)
+					Expect(repo.Create(ctx, host)).To(MatchError(hostErrors.ErrHostAlreadyExists))
+				},
+				Entry("id", func(host *entities.Host) { host.ID = "host-0001" }),
+				Entry("inventory number", func(host *entities.Host) { host.InventoryNumber = "inv-0001" }),
+				Entry("fqdn", func(host *entities.Host) { host.FQDN = "host-0001.example.net" }),
+				Entry("mac", func(host *entities.Host) { host.MACs = append(host.MACs, "aa:bb:cc:dd:ee:01") }),
+			)
+
+			It("should allow many hosts without fqdn and macs", func() {
+				for n := 2; n <= 3; n++ {
+					host := newHost(n)
+					host.FQDN = ""
+					Expect(repo.Create(ctx, host)).To(Succeed())
+				}
+			})
+
+			It("should reject update taking fqdn of another host", func() {
+				host := newHost(2)
+				Expect(repo.Create(ctx, host)).To(Succeed())
+
+				host.FQDN = "host-0001.example.net"
+				Expect(repo.Update(ctx, host)).To(MatchError(hostErrors.ErrHostAlreadyExists))
+				Expect(host.Version).To(Equal(int64(1)))
+			})
+		})
+
+		Describe("compare-and-set updates", func() {
+			var host *entities.Host
+
+			BeforeEach(func() {
+				host = newHost(1)
+				Expect(repo.Create(ctx, host)).To(Succeed())
+			})
+
+			It("should increment version on update", func() {
+				Expect(host.Assign("search", "")).To(Succeed())
+				Expect(repo.Update(ctx, host)).To(Succeed())
+				Expect(host.Version).To(Equal(int64(2)))
+
+				stored, err := repo.GetByID(ctx, host.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.State).To(Equal(entities.HostStateAssigned))
+				Expect(stored.ProjectID).To(Equal("search"))
+				Expect(stored.Version).To(Equal(int64(2)))
+			})
+
+			It("should reject stale updates", func() {
+				first, err := repo.GetByID(ctx, host.ID)
+				Expect(err).NotTo(HaveOccurred())
+				second, err := repo.GetByID(ctx, host.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(first.Assign("search", "first")).To(Succeed())
+				Expect(repo.Update(ctx, first)).To(Succeed())
+
+				Expect(second.ChangeState(entities.HostStateDecommissioned, "second")).To(Succeed())
+				Expect(repo.Update(ctx, second)).To(MatchError(hostErrors.ErrHostVersionConflict))
+				Expect(second.Version).To(Equal(int64(1)))
+
+				stored, err := repo.GetByID(ctx, host.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.State).To(Equal(entities.HostStateAssigned))
+			})
+
+			It("should report missing hosts", func() {
+				Expect(repo.Update(ctx, newHost(2))).To(MatchError(hostErrors.ErrHostNotFound))
+			})
+		})
+
+		Describe("listing", func() {
+			BeforeEach(func() {
+				for n := 1; n <= 7; n++ {
+					host := newHost(n)
+					if n%2 == 0 {
+						Expect(host.Assign("search", "")).To(Succeed())
+					}
+					if n < 4 {
+						Expect(host.SetLabel("role", "db")).To(Succeed())
+					} else if n == 4 {
+						Expect(host.SetLabel("role", "cache")).To(Succeed())
+					}
+					if n%2 == 1 {
+						Expect(host.SetLabel("gpu", "")).To(Succeed())
+					}
+					if n > 5 {
+						host.Location = entities.HostLocation{Datacenter: "vla", Hall: "1", Row: "a", Rack: "2b", Unit: n, Switch: "vla-s2b"}
+					}
+					Expect(repo.Create(ctx, host)).To(Succeed())
+				}
+			})
+
+			listIDs := func(filter contracts.HostFilter, limit int) [][]string {
+				var pages [][]string
+				request := contracts.HostPageRequest{Limit: limit}
+				for {
+					page, err := repo.List(ctx, filter, request)
+					Expect(err).NotTo(HaveOccurred())
+					ids := make([]string, 0, len(page.Hosts))
+					for _, host := range page.Hosts {
+						ids = append(ids, host.ID)
+					}
+					pages = append(pages, ids)
+					if page.NextCursor == "" {
+						return pages
+					}
+					request.Cursor = page.NextCursor
+				}
+			}
+
+			It("should paginate all hosts ordered by id", func() {
+				Expect(listIDs(contracts.HostFilter{}, 3)).To(Equal([][]string{
+					{"host-0001", "host-0002", "host-0003"},
+					{"host-0004", "host-0005", "host-0006"},
+					{"host-0007"},
+				}))
+			})
+
+			It("should not return an empty trailing page", func() {
+				Expect(listIDs(contracts.HostFilter{ProjectID: "search"}, 3)).To(Equal([][]string{
+					{"host-0002", "host-0004", "host-0006"},
+				}))
+			})
+
+			DescribeTable("should filter hosts",
+				func(filter contracts.HostFilter, expected []string) {
+					Expect(listIDs(filter, 0)).To(Equal([][]string{expected}))
+				},
+				Entry("by project", contracts.HostFilter{ProjectID: "search"},
+					[]string{"host-0002", "host-0004", "host-0006"}),
+				Entry("by state", contracts.HostFilter{States: []entities.HostState{entities.HostStateFree}},
+					[]string{"host-0001", "host-0003", "host-0005", "host-0007"}),
+				Entry("by location", contracts.HostFilter{Datacenter: "vla", Rack: "2b"},
+					[]string{"host-0006", "host-0007"}),
+				Entry("by hall and row", contracts.HostFilter{Datacenter: "vla", Hall: "1", Row: "a"},
+					[]string{"host-0006", "host-0007"}),
+				Entry("by switch", contracts.HostFilter{Switch: "vla-s2b"},
+					[]string{"host-0006", "host-0007"}),
+				Entry("by project and location", contracts.HostFilter{ProjectID: "search", Datacenter: "sas"},
+					[]string{"host-0002", "host-0004"}),
+				Entry("with no matches", contracts.HostFilter{ProjectID: "unknown"}, []string{}),
+				Entry("by label", contracts.HostFilter{Selector: selectors.MustParse("role=db")},
+					[]string{"host-0001", "host-0002", "host-0003"}),
+				Entry("by label inequality", contracts.HostFilter{Selector: selectors.MustParse("role!=db")},
+					[]string{"host-0004", "host-0005", "host-0006", "host-0007"}),
+				Entry("by label set", contracts.HostFilter{Selector: selectors.MustParse("role in (cache, web)")},
+					[]string{"host-0004"}),
+				Entry("by excluded label set", contracts.HostFilter{Selector: selectors.MustParse("role notin (db)")},
+					[]string{"host-0004", "host-0005", "host-0006", "host-0007"}),
+				Entry("by label existence", contracts.HostFilter{Selector: selectors.MustParse("gpu,role")},
+					[]string{"host-0001", "host-0003"}),
+				Entry("by label absence", contracts.HostFilter{Selector: selectors.MustParse("!gpu,!role")},
+					[]string{"host-0006"}),
+				Entry("by project field", contracts.HostFilter{Selector: selectors.MustParse("project=search,role")},
+					[]string{"host-0002", "host-0004"}),
+				Entry("by missing project field", contracts.HostFilter{Selector: selectors.MustParse("!project")},
+					[]string{"host-0001", "host-0003", "host-0005", "host-0007"}),
+				Entry("by excluded project field", contracts.HostFilter{Selector: selectors.MustParse("project!=search")},
+					[]string{"host-0001", "host-0003", "host-0005", "host-0007"}),
+				Entry("by location fields", contracts.HostFilter{Selector: selectors.MustParse("location.datacenter in (vla),location.switch")},
+					[]string{"host-0006", "host-0007"}),
+				Entry("by missing location field", contracts.HostFilter{Selector: selectors.MustParse("location.datacenter=sas,!location.hall")},
+					[]string{"host-0001", "host-0002", "host-0003", "host-0004", "host-0005"}),
+				Entry("by unit type and filter", contracts.HostFilter{ProjectID: "search", Selector: selectors.MustParse("unit_type=server,!gpu")},
+					[]string{"host-0002", "host-0004", "host-0006"}),
+			)
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package vault
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gwall-e/hosts/internal/domain/projects/errors"
+)
+
+type FakeVault struct {
+	mu       sync.RWMutex
+	versions map[string][]string
+}
+
+func NewFakeVault() *FakeVault {
+	return &FakeVault{versions: map[string][]string{}}
+}
+
+func (v *FakeVault) AddVersion(secretID string, version string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.versions[secretID] = append(v.versions[secretID], version)
+}
+
+func (v *FakeVault) ResolveVersion(ctx context.Context, secretID string, version string) (string, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	versions := v.versions[secretID]
+	if len(versions) == 0 {
+		return "", errors.ErrSecretNotFound
+	}
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+	for _, existing := range versions {
+		if existing == version {
+			return version, nil
+		}
+	}
+	return "", errors.ErrSecretNotFound
+}